@@ -1,5 +1,11 @@
 package common
 
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
 // KYCPlatform describes KYC provider platform.
 //
 // * CheckCustomer verifies the given UserData using a specified KYC provider's API.
@@ -8,3 +14,38 @@ type KYCPlatform interface {
 	CheckCustomer(customer *UserData) (KYCResult, error)
 	CheckStatus(referenceID string) (KYCResult, error)
 }
+
+// KYCPlatformContext describes KYC provider platform supporting cancellation and deadlines.
+//
+// * CheckCustomerContext is like CheckCustomer but stops the verification flow when the context is done.
+// * CheckStatusContext is like CheckStatus but stops the status check when the context is done.
+//
+// The context-less methods of KYCPlatform are equivalent to the calls with context.Background().
+type KYCPlatformContext interface {
+	KYCPlatform
+	CheckCustomerContext(ctx context.Context, customer *UserData) (KYCResult, error)
+	CheckStatusContext(ctx context.Context, referenceID string) (KYCResult, error)
+}
+
+// KYCCallbackReceiver describes KYC provider platform delivering verification results using callbacks.
+//
+// * ParseCallback verifies the callback request using the provider's scheme and converts its payload into the result.
+// It returns the reference id of the verification the callback relates to.
+// If the callback isn't authentic it returns ErrCallbackSignature.
+// If the callback payload is malformed it returns the CallbackPayloadError.
+type KYCCallbackReceiver interface {
+	ParseCallback(r *http.Request) (referenceID string, result KYCResult, err error)
+}
+
+// ErrCallbackSignature is returned when the verification of the callback signature fails.
+var ErrCallbackSignature = errors.New("invalid callback signature")
+
+// CallbackPayloadError represents the error of the malformed callback payload.
+type CallbackPayloadError struct {
+	Err error
+}
+
+// Error implements error interface for CallbackPayloadError.
+func (e CallbackPayloadError) Error() string {
+	return "malformed callback payload: " + e.Err.Error()
+}
@@ -0,0 +1,475 @@
+package common
+
+// StrategyV2 represents the strategy of the v2 CheckCustomer request, see Strategy.
+type StrategyV2 struct {
+	Mode      StrategyMode  `json:"mode"`
+	Providers []KYCProvider `json:"providers"`
+	Consensus Consensus     `json:"consensus,omitempty"`
+}
+
+// Strategy converts the strategy of the v2 request or returns nil if it isn't set.
+func (s *StrategyV2) Strategy() *Strategy {
+	if s == nil {
+		return nil
+	}
+
+	return &Strategy{
+		Mode:      s.Mode,
+		Providers: s.Providers,
+		Consensus: s.Consensus,
+	}
+}
+
+// CustomerV2 represents the customer data of the v2 CheckCustomer request, see UserData.
+// The driver license translation uses the DriverLicenseV2 without the version,
+// the debit card uses the CreditCardV2, and the documents consisting of the image only use the ImageDocumentV2.
+type CustomerV2 struct {
+	FirstName                string                   `json:"first_name,omitempty"`
+	LastName                 string                   `json:"last_name,omitempty"`
+	MaternalLastName         string                   `json:"maternal_last_name,omitempty"`
+	MiddleName               string                   `json:"middle_name,omitempty"`
+	FullName                 string                   `json:"full_name,omitempty"`
+	LegalName                string                   `json:"legal_name,omitempty"`
+	LatinISO1Name            string                   `json:"latin_iso1_name,omitempty"`
+	AccountName              string                   `json:"account_name,omitempty"`
+	Email                    string                   `json:"email,omitempty"`
+	IPAddress                string                   `json:"ip_address,omitempty"`
+	Gender                   Gender                   `json:"gender,omitempty"`
+	DateOfBirth              Time                     `json:"date_of_birth"`
+	PlaceOfBirth             string                   `json:"place_of_birth,omitempty"`
+	CountryOfBirthAlpha2     string                   `json:"country_of_birth_alpha2,omitempty"`
+	StateOfBirth             string                   `json:"state_of_birth,omitempty"`
+	CountryAlpha2            string                   `json:"country_alpha2,omitempty"`
+	Nationality              string                   `json:"nationality,omitempty"`
+	Phone                    string                   `json:"phone,omitempty"`
+	MobilePhone              string                   `json:"mobile_phone,omitempty"`
+	BankAccountNumber        string                   `json:"bank_account_number,omitempty"`
+	VehicleRegistrationPlate string                   `json:"vehicle_registration_plate,omitempty"`
+	CurrentAddress           *AddressV2               `json:"current_address,omitempty"`
+	SupplementalAddresses    []AddressV2              `json:"supplemental_addresses,omitempty"`
+	Location                 *LocationV2              `json:"location,omitempty"`
+	Business                 *BusinessV2              `json:"business,omitempty"`
+	Passport                 *PassportV2              `json:"passport,omitempty"`
+	IDCard                   *IDCardV2                `json:"id_card,omitempty"`
+	SNILS                    *SNILSV2                 `json:"snils,omitempty"`
+	HealthID                 *HealthIDV2              `json:"health_id,omitempty"`
+	SocialServiceID          *SocialServiceIDV2       `json:"social_service_id,omitempty"`
+	TaxID                    *TaxIDV2                 `json:"tax_id,omitempty"`
+	DriverLicense            *DriverLicenseV2         `json:"driver_license,omitempty"`
+	DriverLicenseTranslation *DriverLicenseV2         `json:"driver_license_translation,omitempty"`
+	CreditCard               *CreditCardV2            `json:"credit_card,omitempty"`
+	DebitCard                *CreditCardV2            `json:"debit_card,omitempty"`
+	UtilityBill              *UtilityBillV2           `json:"utility_bill,omitempty"`
+	ResidencePermit          *ResidencePermitV2       `json:"residence_permit,omitempty"`
+	Agreement                *ImageDocumentV2         `json:"agreement,omitempty"`
+	EmploymentCertificate    *EmploymentCertificateV2 `json:"employment_certificate,omitempty"`
+	Contract                 *ImageDocumentV2         `json:"contract,omitempty"`
+	DocumentPhoto            *ImageDocumentV2         `json:"document_photo,omitempty"`
+	Selfie                   *ImageDocumentV2         `json:"selfie,omitempty"`
+	Avatar                   *ImageDocumentV2         `json:"avatar,omitempty"`
+	Other                    *OtherV2                 `json:"other,omitempty"`
+	VideoAuth                *DocumentFileV2          `json:"video_auth,omitempty"`
+	Document                 *DocumentV2              `json:"document,omitempty"`
+	CompanyName              string                   `json:"company_name,omitempty"`
+	Website                  string                   `json:"website,omitempty"`
+	CompanyBoard             *DocumentFileV2          `json:"company_board,omitempty"`
+	CompanyRegistration      *DocumentFileV2          `json:"company_registration,omitempty"`
+}
+
+// AddressV2 represents the address of the customer, see Address.
+type AddressV2 struct {
+	CountryAlpha2     string `json:"country_alpha2,omitempty"`
+	County            string `json:"county,omitempty"`
+	State             string `json:"state,omitempty"`
+	Town              string `json:"town,omitempty"`
+	Suburb            string `json:"suburb,omitempty"`
+	Street            string `json:"street,omitempty"`
+	StreetType        string `json:"street_type,omitempty"`
+	SubStreet         string `json:"sub_street,omitempty"`
+	BuildingName      string `json:"building_name,omitempty"`
+	BuildingNumber    string `json:"building_number,omitempty"`
+	FlatNumber        string `json:"flat_number,omitempty"`
+	PostOfficeBox     string `json:"post_office_box,omitempty"`
+	PostCode          string `json:"post_code,omitempty"`
+	StateProvinceCode string `json:"state_province_code,omitempty"`
+	StartDate         Time   `json:"start_date"`
+	EndDate           Time   `json:"end_date"`
+}
+
+// LocationV2 represents the geopositional data, see Location.
+type LocationV2 struct {
+	Latitude  string `json:"latitude"`
+	Longitude string `json:"longitude"`
+}
+
+// BusinessV2 represents the business, see Business.
+type BusinessV2 struct {
+	Name                      string `json:"name,omitempty"`
+	RegistrationNumber        string `json:"registration_number,omitempty"`
+	IncorporationDate         Time   `json:"incorporation_date"`
+	IncorporationJurisdiction string `json:"incorporation_jurisdiction,omitempty"`
+}
+
+// DocumentFileV2 represents the document file containing its original or an image, see DocumentFile.
+type DocumentFileV2 struct {
+	Filename    string `json:"filename,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Data        []byte `json:"data"`
+}
+
+// PassportV2 represents the passport, see Passport.
+type PassportV2 struct {
+	Number        string          `json:"number,omitempty"`
+	Mrz1          string          `json:"mrz1,omitempty"`
+	Mrz2          string          `json:"mrz2,omitempty"`
+	CountryAlpha2 string          `json:"country_alpha2,omitempty"`
+	State         string          `json:"state,omitempty"`
+	IssuedDate    Time            `json:"issued_date"`
+	ValidUntil    Time            `json:"valid_until"`
+	Image         *DocumentFileV2 `json:"image,omitempty"`
+}
+
+// IDCardV2 represents the id card, see IDCard.
+type IDCardV2 struct {
+	Number        string          `json:"number,omitempty"`
+	CountryAlpha2 string          `json:"country_alpha2,omitempty"`
+	IssuedDate    Time            `json:"issued_date"`
+	ValidUntil    Time            `json:"valid_until"`
+	Image         *DocumentFileV2 `json:"image,omitempty"`
+}
+
+// SNILSV2 represents the Russian individual insurance account number, see SNILS.
+type SNILSV2 struct {
+	Number     string          `json:"number,omitempty"`
+	IssuedDate Time            `json:"issued_date"`
+	Image      *DocumentFileV2 `json:"image,omitempty"`
+}
+
+// HealthIDV2 represents National Health Service Identification Information, see HealthID.
+type HealthIDV2 struct {
+	Number string          `json:"number,omitempty"`
+	Image  *DocumentFileV2 `json:"image,omitempty"`
+}
+
+// SocialServiceIDV2 represents National Social Service Identification Information, see SocialServiceID.
+type SocialServiceIDV2 struct {
+	Number     string          `json:"number,omitempty"`
+	IssuedDate Time            `json:"issued_date"`
+	Image      *DocumentFileV2 `json:"image,omitempty"`
+}
+
+// TaxIDV2 represents National Taxpayer Identification Information, see TaxID.
+type TaxIDV2 struct {
+	Number string          `json:"number,omitempty"`
+	Image  *DocumentFileV2 `json:"image,omitempty"`
+}
+
+// DriverLicenseV2 represents the driver license or its translation, see DriverLicense.
+type DriverLicenseV2 struct {
+	Number        string          `json:"number,omitempty"`
+	Version       string          `json:"version,omitempty"`
+	CountryAlpha2 string          `json:"country_alpha2,omitempty"`
+	State         string          `json:"state,omitempty"`
+	IssuedDate    Time            `json:"issued_date"`
+	ValidUntil    Time            `json:"valid_until"`
+	FrontImage    *DocumentFileV2 `json:"front_image,omitempty"`
+	BackImage     *DocumentFileV2 `json:"back_image,omitempty"`
+}
+
+// CreditCardV2 represents the banking credit or debit card, see CreditCard.
+type CreditCardV2 struct {
+	Number     string          `json:"number,omitempty"`
+	ValidUntil Time            `json:"valid_until"`
+	Image      *DocumentFileV2 `json:"image,omitempty"`
+}
+
+// UtilityBillV2 represents the utility bill, see UtilityBill.
+type UtilityBillV2 struct {
+	CountryAlpha2 string          `json:"country_alpha2,omitempty"`
+	Image         *DocumentFileV2 `json:"image,omitempty"`
+}
+
+// ResidencePermitV2 represents the residence permit, see ResidencePermit.
+type ResidencePermitV2 struct {
+	CountryAlpha2 string          `json:"country_alpha2,omitempty"`
+	IssuedDate    Time            `json:"issued_date"`
+	ValidUntil    Time            `json:"valid_until"`
+	Image         *DocumentFileV2 `json:"image,omitempty"`
+}
+
+// EmploymentCertificateV2 represents a document from an employer, see EmploymentCertificate.
+type EmploymentCertificateV2 struct {
+	IssuedDate Time            `json:"issued_date"`
+	Image      *DocumentFileV2 `json:"image,omitempty"`
+}
+
+// ImageDocumentV2 represents the document consisting of the image only,
+// e.g. the agreement, the contract, the selfie, the avatar or the document photo.
+type ImageDocumentV2 struct {
+	Image *DocumentFileV2 `json:"image,omitempty"`
+}
+
+// OtherV2 represents other documents, see Other.
+type OtherV2 struct {
+	Number        string          `json:"number,omitempty"`
+	CountryAlpha2 string          `json:"country_alpha2,omitempty"`
+	State         string          `json:"state,omitempty"`
+	IssuedDate    Time            `json:"issued_date"`
+	ValidUntil    Time            `json:"valid_until"`
+	Image         *DocumentFileV2 `json:"image,omitempty"`
+}
+
+// DocumentV2 represents a document of the type, see Document.
+type DocumentV2 struct {
+	Type          DocumentType    `json:"type"`
+	Number        string          `json:"number,omitempty"`
+	CountryAlpha2 string          `json:"country_alpha2,omitempty"`
+	IssuedDate    Time            `json:"issued_date"`
+	ValidUntil    Time            `json:"valid_until"`
+	Image         *DocumentFileV2 `json:"image,omitempty"`
+}
+
+// UserData converts the customer data of the v2 request or returns nil if it isn't set.
+func (c *CustomerV2) UserData() *UserData {
+	if c == nil {
+		return nil
+	}
+
+	customer := &UserData{
+		FirstName:                c.FirstName,
+		LastName:                 c.LastName,
+		MaternalLastName:         c.MaternalLastName,
+		MiddleName:               c.MiddleName,
+		FullName:                 c.FullName,
+		LegalName:                c.LegalName,
+		LatinISO1Name:            c.LatinISO1Name,
+		AccountName:              c.AccountName,
+		Email:                    c.Email,
+		IPaddress:                c.IPAddress,
+		Gender:                   c.Gender,
+		DateOfBirth:              c.DateOfBirth,
+		PlaceOfBirth:             c.PlaceOfBirth,
+		CountryOfBirthAlpha2:     c.CountryOfBirthAlpha2,
+		StateOfBirth:             c.StateOfBirth,
+		CountryAlpha2:            c.CountryAlpha2,
+		Nationality:              c.Nationality,
+		Phone:                    c.Phone,
+		MobilePhone:              c.MobilePhone,
+		BankAccountNumber:        c.BankAccountNumber,
+		VehicleRegistrationPlate: c.VehicleRegistrationPlate,
+		CompanyName:              c.CompanyName,
+		Website:                  c.Website,
+	}
+
+	if c.CurrentAddress != nil {
+		customer.CurrentAddress = c.CurrentAddress.address()
+	}
+	for _, a := range c.SupplementalAddresses {
+		customer.SupplementalAddresses = append(customer.SupplementalAddresses, a.address())
+	}
+	if l := c.Location; l != nil {
+		customer.Location = &Location{
+			Latitude:  l.Latitude,
+			Longitude: l.Longitude,
+		}
+	}
+	if b := c.Business; b != nil {
+		customer.Business = &Business{
+			Name:                      b.Name,
+			RegistrationNumber:        b.RegistrationNumber,
+			IncorporationDate:         b.IncorporationDate,
+			IncorporationJurisdiction: b.IncorporationJurisdiction,
+		}
+	}
+
+	c.documents(customer)
+
+	return customer
+}
+
+// documents converts the documents of the customer.
+func (c *CustomerV2) documents(customer *UserData) {
+	if d := c.Passport; d != nil {
+		customer.Passport = &Passport{
+			Number:        d.Number,
+			Mrz1:          d.Mrz1,
+			Mrz2:          d.Mrz2,
+			CountryAlpha2: d.CountryAlpha2,
+			State:         d.State,
+			IssuedDate:    d.IssuedDate,
+			ValidUntil:    d.ValidUntil,
+			Image:         d.Image.file(),
+		}
+	}
+	if d := c.IDCard; d != nil {
+		customer.IDCard = &IDCard{
+			Number:        d.Number,
+			CountryAlpha2: d.CountryAlpha2,
+			IssuedDate:    d.IssuedDate,
+			ValidUntil:    d.ValidUntil,
+			Image:         d.Image.file(),
+		}
+	}
+	if d := c.SNILS; d != nil {
+		customer.SNILS = &SNILS{
+			Number:     d.Number,
+			IssuedDate: d.IssuedDate,
+			Image:      d.Image.file(),
+		}
+	}
+	if d := c.HealthID; d != nil {
+		customer.HealthID = &HealthID{
+			Number: d.Number,
+			Image:  d.Image.file(),
+		}
+	}
+	if d := c.SocialServiceID; d != nil {
+		customer.SocialServiceID = &SocialServiceID{
+			Number:     d.Number,
+			IssuedDate: d.IssuedDate,
+			Image:      d.Image.file(),
+		}
+	}
+	if d := c.TaxID; d != nil {
+		customer.TaxID = &TaxID{
+			Number: d.Number,
+			Image:  d.Image.file(),
+		}
+	}
+	if d := c.DriverLicense; d != nil {
+		customer.DriverLicense = &DriverLicense{
+			Number:        d.Number,
+			Version:       d.Version,
+			CountryAlpha2: d.CountryAlpha2,
+			State:         d.State,
+			IssuedDate:    d.IssuedDate,
+			ValidUntil:    d.ValidUntil,
+			FrontImage:    d.FrontImage.file(),
+			BackImage:     d.BackImage.file(),
+		}
+	}
+	if d := c.DriverLicenseTranslation; d != nil {
+		customer.DriverLicenseTranslation = &DriverLicenseTranslation{
+			Number:        d.Number,
+			CountryAlpha2: d.CountryAlpha2,
+			State:         d.State,
+			IssuedDate:    d.IssuedDate,
+			ValidUntil:    d.ValidUntil,
+			FrontImage:    d.FrontImage.file(),
+			BackImage:     d.BackImage.file(),
+		}
+	}
+	if d := c.CreditCard; d != nil {
+		customer.CreditCard = &CreditCard{
+			Number:     d.Number,
+			ValidUntil: d.ValidUntil,
+			Image:      d.Image.file(),
+		}
+	}
+	if d := c.DebitCard; d != nil {
+		customer.DebitCard = &DebitCard{
+			Number:     d.Number,
+			ValidUntil: d.ValidUntil,
+			Image:      d.Image.file(),
+		}
+	}
+	if d := c.UtilityBill; d != nil {
+		customer.UtilityBill = &UtilityBill{
+			CountryAlpha2: d.CountryAlpha2,
+			Image:         d.Image.file(),
+		}
+	}
+	if d := c.ResidencePermit; d != nil {
+		customer.ResidencePermit = &ResidencePermit{
+			CountryAlpha2: d.CountryAlpha2,
+			IssuedDate:    d.IssuedDate,
+			ValidUntil:    d.ValidUntil,
+			Image:         d.Image.file(),
+		}
+	}
+	if d := c.EmploymentCertificate; d != nil {
+		customer.EmploymentCertificate = &EmploymentCertificate{
+			IssuedDate: d.IssuedDate,
+			Image:      d.Image.file(),
+		}
+	}
+	if d := c.Agreement; d != nil {
+		customer.Agreement = &Agreement{Image: d.Image.file()}
+	}
+	if d := c.Contract; d != nil {
+		customer.Contract = &Contract{Image: d.Image.file()}
+	}
+	if d := c.DocumentPhoto; d != nil {
+		customer.DocumentPhoto = &DocumentPhoto{Image: d.Image.file()}
+	}
+	if d := c.Selfie; d != nil {
+		customer.Selfie = &Selfie{Image: d.Image.file()}
+	}
+	if d := c.Avatar; d != nil {
+		customer.Avatar = &Avatar{Image: d.Image.file()}
+	}
+	if d := c.Other; d != nil {
+		customer.Other = &Other{
+			Number:        d.Number,
+			CountryAlpha2: d.CountryAlpha2,
+			State:         d.State,
+			IssuedDate:    d.IssuedDate,
+			ValidUntil:    d.ValidUntil,
+			Image:         d.Image.file(),
+		}
+	}
+	if d := c.Document; d != nil {
+		customer.Document = &Document{
+			Type:          d.Type,
+			Number:        d.Number,
+			CountryAlpha2: d.CountryAlpha2,
+			IssuedDate:    d.IssuedDate,
+			ValidUntil:    d.ValidUntil,
+			Image:         d.Image.file(),
+		}
+	}
+	if f := c.VideoAuth.file(); f != nil {
+		customer.VideoAuth = (*VideoAuth)(f)
+	}
+	if f := c.CompanyBoard.file(); f != nil {
+		customer.CompanyBoard = (*CompanyBoard)(f)
+	}
+	if f := c.CompanyRegistration.file(); f != nil {
+		customer.CompanyRegistration = (*CompanyRegistration)(f)
+	}
+}
+
+// address converts the address of the v2 request.
+func (a AddressV2) address() Address {
+	return Address{
+		CountryAlpha2:     a.CountryAlpha2,
+		County:            a.County,
+		State:             a.State,
+		Town:              a.Town,
+		Suburb:            a.Suburb,
+		Street:            a.Street,
+		StreetType:        a.StreetType,
+		SubStreet:         a.SubStreet,
+		BuildingName:      a.BuildingName,
+		BuildingNumber:    a.BuildingNumber,
+		FlatNumber:        a.FlatNumber,
+		PostOfficeBox:     a.PostOfficeBox,
+		PostCode:          a.PostCode,
+		StateProvinceCode: a.StateProvinceCode,
+		StartDate:         a.StartDate,
+		EndDate:           a.EndDate,
+	}
+}
+
+// file converts the document file of the v2 request or returns nil if it isn't set.
+func (f *DocumentFileV2) file() *DocumentFile {
+	if f == nil {
+		return nil
+	}
+
+	return &DocumentFile{
+		Filename:    f.Filename,
+		ContentType: f.ContentType,
+		Data:        f.Data,
+	}
+}
@@ -82,17 +82,53 @@ type DocumentFile struct {
 }
 
 // KYCDetails defines additional details about the verification result.
+// ReasonCodes holds the machine-readable codes of the Reasons by their indexes if the provider supplies them.
 type KYCDetails struct {
-	Finality KYCFinality
-	Reasons  []string
+	Finality    KYCFinality
+	Reasons     []string
+	ReasonCodes []string
+}
+
+// AddReason appends the reason with its code to the details. The code may be empty.
+func (d *KYCDetails) AddReason(code, reason string) {
+	if len(code) > 0 || len(d.ReasonCodes) > 0 {
+		for len(d.ReasonCodes) < len(d.Reasons) {
+			d.ReasonCodes = append(d.ReasonCodes, "")
+		}
+		d.ReasonCodes = append(d.ReasonCodes, code)
+	}
+	d.Reasons = append(d.Reasons, reason)
+}
+
+// ReasonCode returns the code of the reason by its index or the empty string if the code isn't supplied.
+func (d KYCDetails) ReasonCode(i int) string {
+	if i < len(d.ReasonCodes) {
+		return d.ReasonCodes[i]
+	}
+
+	return ""
 }
 
 // KYCResult represents the verification result.
+// Providers holds the individual results of the providers if the verification used the Strategy.
+// Signals holds the raw provider data the decision rules are evaluated on. It isn't exposed by the API.
+// Reference is the identificator of the verification assigned by the provider if it's returned.
 type KYCResult struct {
 	Status      KYCStatus
 	Details     *KYCDetails
 	ErrorCode   string
 	StatusCheck *KYCStatusCheck
+	Providers   []KYCProviderResult
+	Signals     Signals
+	Reference   string
+}
+
+// Signals holds the raw provider data of the verification result by their names.
+type Signals map[string]interface{}
+
+// IsFinal reports whether the verification result doesn't require subsequent status checks.
+func (r KYCResult) IsFinal() bool {
+	return r.Status != Unclear || r.StatusCheck == nil
 }
 
 // KYCStatusCheck contains data required to do status check requests if needed.
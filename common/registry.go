@@ -0,0 +1,102 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ProviderFactory constructs the KYCPlatformContext object for the provider using its config options.
+type ProviderFactory func(options map[string]string) (KYCPlatformContext, error)
+
+// ProviderCapabilities describes the optional features supported by a KYC provider.
+type ProviderCapabilities struct {
+	StatusPolling bool
+	Callbacks     bool
+	Documents     bool
+	Companies     bool
+}
+
+// ProviderSpec describes the implemented KYC provider.
+//
+// * Name is the identificator of the KYC provider.
+// * Options enumerates the config options required by the provider.
+// * Optional enumerates the config options the provider accepts besides the required ones.
+// * Capabilities describes the features supported by the provider.
+// * Factory constructs the KYCPlatformContext object from the provider config.
+type ProviderSpec struct {
+	Name         KYCProvider
+	Options      []string
+	Optional     []string
+	Capabilities ProviderCapabilities
+	Factory      ProviderFactory
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[KYCProvider]ProviderSpec{}
+)
+
+// RegisterProvider makes the KYC provider available for the service.
+// It's meant to be called from the init function of the integration package.
+// If RegisterProvider is called twice with the same name or if the factory is nil, it panics.
+func RegisterProvider(spec ProviderSpec) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if spec.Factory == nil {
+		panic(fmt.Sprintf("register provider %s: nil factory", spec.Name))
+	}
+	if _, dup := registry[spec.Name]; dup {
+		panic(fmt.Sprintf("register provider %s: called twice", spec.Name))
+	}
+
+	registry[spec.Name] = spec
+}
+
+// LookupProvider returns the spec of the registered KYC provider.
+// The ok result indicates whether the provider was found.
+func LookupProvider(name KYCProvider) (spec ProviderSpec, ok bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	spec, ok = registry[name]
+
+	return
+}
+
+// RegisteredProviders returns the specs of all registered KYC providers sorted by name.
+func RegisteredProviders() (specs []ProviderSpec) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for _, spec := range registry {
+		specs = append(specs, spec)
+	}
+	sort.Slice(specs, func(i, j int) bool { return specs[i].Name < specs[j].Name })
+
+	return
+}
+
+// KnownProvider reports whether the KYC provider is registered or known to the service but not implemented.
+// CipherTrace is the only known provider not implemented as the KYC platform, it's served by its own endpoint.
+func KnownProvider(name KYCProvider) bool {
+	if name == CipherTrace {
+		return true
+	}
+
+	_, ok := LookupProvider(name)
+
+	return ok
+}
+
+// KnownProviders returns the names of the registered KYC providers along with CipherTrace sorted by name.
+func KnownProviders() (names []KYCProvider) {
+	names = []KYCProvider{CipherTrace}
+	for _, spec := range RegisteredProviders() {
+		names = append(names, spec.Name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+
+	return
+}
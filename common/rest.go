@@ -1,12 +1,20 @@
 package common
 
+import "time"
+
 // TooManyRequests defines the error code returned when KYC status check requests send too frequently.
 const TooManyRequests = "429"
 
 // CheckCustomerRequest represents the request for the CheckCustomer handler.
+// Either Provider or Strategy using several providers must be specified.
+// If NotificationURL is set then the final verification result will be posted to it.
+// If Async is set then the verification is run in the background and the handler responds with the JobResponse at once.
 type CheckCustomerRequest struct {
-	Provider KYCProvider
-	UserData *UserData
+	Provider        KYCProvider
+	Strategy        *Strategy
+	UserData        *UserData
+	NotificationURL string
+	Async           bool `json:",omitempty"`
 }
 
 // CheckStatusRequest represents the status check request payload of the CheckStatus handler.
@@ -15,6 +23,84 @@ type CheckStatusRequest struct {
 	ReferenceID string
 }
 
+// TrackedStatusResponse represents the response of the TrackedStatus handler.
+// It holds the latest known status of the verification tracked by the service.
+type TrackedStatusResponse struct {
+	Provider    KYCProvider
+	ReferenceID string
+	Result      *Result
+	Error       string
+	Final       bool
+	Expired     bool
+	Polling     bool
+	Checks      int
+	LastCheck   *time.Time
+	NextCheck   *time.Time
+	Updated     time.Time
+}
+
+// JobResponse represents the response of the Jobs handler and of the CheckCustomer handler in the async mode.
+// Response holds the KYCResponse of the verification once the job is completed.
+// Error holds the error of the job if it has failed.
+// VerificationIDs lists the ids of the verifications recorded in the history.
+type JobResponse struct {
+	ID              string
+	State           string
+	Response        *KYCResponse `json:",omitempty"`
+	Error           string       `json:",omitempty"`
+	VerificationIDs []string     `json:",omitempty"`
+	Created         time.Time
+	Started         *time.Time `json:",omitempty"`
+	Finished        *time.Time `json:",omitempty"`
+}
+
+// BatchRecord represents the record of the batch verification request.
+// ID joins the record to its result. It's the number of the record in the batch if it's empty.
+type BatchRecord struct {
+	ID       string
+	UserData *UserData
+}
+
+// BatchResult represents the line of the results file of the batch verification.
+// Error holds the error of the verification of the record if it has failed.
+// VerificationID is the id of the verification recorded in the history.
+type BatchResult struct {
+	ID             string
+	Result         *Result `json:",omitempty"`
+	Error          string  `json:",omitempty"`
+	VerificationID string  `json:",omitempty"`
+}
+
+// BatchResponse represents the response of the Batches handler.
+// Processed counts the records whose results are checkpointed, Failed counts the ones of them that have failed.
+// Error holds the error of the batch if it has failed as a whole.
+type BatchResponse struct {
+	ID        string
+	State     string
+	Provider  KYCProvider
+	Total     int
+	Processed int
+	Failed    int
+	Error     string `json:",omitempty"`
+	Created   time.Time
+	Started   *time.Time `json:",omitempty"`
+	Finished  *time.Time `json:",omitempty"`
+}
+
+// ConfigResponse represents the response of the AdminConfig handler.
+// It describes the active config snapshot without exposing the config options.
+// Sections lists the names of the config sections. Hash is the SHA256 hash of the config file content.
+// LastReload holds the time of the latest reload of the config file and LastError the reason it's rejected if so.
+type ConfigResponse struct {
+	Version    int64
+	Source     string `json:",omitempty"`
+	Hash       string `json:",omitempty"`
+	Loaded     time.Time
+	Sections   []string
+	LastReload *time.Time `json:",omitempty"`
+	LastError  string     `json:",omitempty"`
+}
+
 // ErrorResponse represents the error response payload from the service.
 type ErrorResponse struct {
 	Error string
@@ -27,11 +113,20 @@ type KYCResponse struct {
 }
 
 // Result represents the verification result for the KYCResponse.
+// Providers holds the individual results of the providers if the verification used the Strategy.
 type Result struct {
 	Status      string
 	Details     *Details
 	ErrorCode   string
 	StatusCheck *KYCStatusCheck
+	Providers   []ProviderResult `json:",omitempty"`
+}
+
+// ProviderResult represents the individual result of the KYC provider for the Result.
+type ProviderResult struct {
+	Provider KYCProvider
+	Result   *Result
+	Error    string `json:",omitempty"`
 }
 
 // Details defines additional details about the verification result.
@@ -53,6 +148,13 @@ func ResultFromKYCResult(kycResult KYCResult) (result *Result) {
 	}
 	result.ErrorCode = kycResult.ErrorCode
 	result.StatusCheck = kycResult.StatusCheck
+	for _, r := range kycResult.Providers {
+		result.Providers = append(result.Providers, ProviderResult{
+			Provider: r.Provider,
+			Result:   ResultFromKYCResult(r.Result),
+			Error:    r.Error,
+		})
+	}
 
 	return
 }
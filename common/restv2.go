@@ -0,0 +1,169 @@
+package common
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ErrorType classifies the errors of the v2 API.
+type ErrorType string
+
+// Possible values of ErrorType.
+const (
+	// ValidationError means the request is malformed or misses a required param.
+	ValidationError ErrorType = "validation"
+	// UnauthorizedError means the client credentials are missing or invalid.
+	UnauthorizedError ErrorType = "unauthorized"
+	// ForbiddenError means the client isn't allowed to request the endpoint or to use the provider.
+	ForbiddenError ErrorType = "forbidden"
+	// NotFoundError means the provider or the verification is unknown.
+	NotFoundError ErrorType = "not_found"
+	// UnsupportedError means the provider doesn't support the requested method or the feature is disabled.
+	UnsupportedError ErrorType = "unsupported"
+	// RateLimitedError means the request exceeded the rate limit or the quota.
+	RateLimitedError ErrorType = "rate_limited"
+	// ConflictError means the Idempotency-Key of the request is in use by another request.
+	ConflictError ErrorType = "conflict"
+	// ConfigError means the service config of the provider is missing or invalid.
+	ConfigError ErrorType = "config"
+	// ProviderUnavailableError means the provider API couldn't be reached or failed with 429 or 5xx.
+	ProviderUnavailableError ErrorType = "provider_unavailable"
+	// ProviderRejectedError means the provider API answered but rejected the verification request.
+	ProviderRejectedError ErrorType = "provider_rejected"
+	// InternalError means something went wrong in the service.
+	InternalError ErrorType = "internal"
+)
+
+// UnspecifiedReasonCode is the code of the reasons the provider doesn't supply the codes for.
+const UnspecifiedReasonCode = "unspecified"
+
+// KYCStatus2StatusV2 maps KYCStatus value to its v2 API representation.
+var KYCStatus2StatusV2 = map[KYCStatus]string{
+	Error:    "error",
+	Approved: "approved",
+	Denied:   "denied",
+	Unclear:  "unclear",
+}
+
+// KYCFinality2FinalityV2 maps KYCFinality value to its v2 API representation.
+var KYCFinality2FinalityV2 = map[KYCFinality]string{
+	Unknown:  "unknown",
+	Final:    "final",
+	NonFinal: "non_final",
+}
+
+// CheckCustomerRequestV2 represents the request for the v2 CheckCustomer handler.
+// Either Provider or Strategy using several providers must be specified.
+// If IncludeRaw is set then the redacted responses of the provider APIs are added to the results.
+type CheckCustomerRequestV2 struct {
+	Provider        KYCProvider `json:"provider,omitempty"`
+	Strategy        *StrategyV2 `json:"strategy,omitempty"`
+	Customer        *CustomerV2 `json:"customer"`
+	NotificationURL string      `json:"notification_url,omitempty"`
+	IncludeRaw      bool        `json:"include_raw,omitempty"`
+}
+
+// CheckStatusRequestV2 represents the request for the v2 CheckStatus handler.
+type CheckStatusRequestV2 struct {
+	Provider    KYCProvider `json:"provider"`
+	ReferenceID string      `json:"reference_id"`
+	IncludeRaw  bool        `json:"include_raw,omitempty"`
+}
+
+// ErrorV2 represents the error of the v2 API.
+// ProviderErrorCode is the error code returned by the provider if any.
+// RetryAfter is the number of seconds to wait before the next request if it's rate limited.
+type ErrorV2 struct {
+	Type              ErrorType `json:"type"`
+	Message           string    `json:"message"`
+	ProviderErrorCode string    `json:"provider_error_code,omitempty"`
+	RetryAfter        int       `json:"retry_after,omitempty"`
+}
+
+// ErrorResponseV2 represents the error response payload of the v2 API.
+type ErrorResponseV2 struct {
+	Error ErrorV2 `json:"error"`
+}
+
+// KYCResponseV2 represents the response of the v2 CheckCustomer and CheckStatus handlers.
+// VerificationID is the id of the verification recorded in the history if the store is enabled.
+type KYCResponseV2 struct {
+	VerificationID string    `json:"verification_id,omitempty"`
+	Result         *ResultV2 `json:"result,omitempty"`
+	Error          *ErrorV2  `json:"error,omitempty"`
+}
+
+// ReasonV2 represents the reason of the verification result with its machine-readable code.
+type ReasonV2 struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ResultV2 represents the verification result of the v2 API.
+//
+// * Reference is the identificator of the verification assigned by the provider if it's known.
+// * Pending reports whether the result isn't final yet and the status checks by the Reference are required.
+// * Raw is the redacted response of the provider API if it was requested.
+// * Providers holds the individual results of the providers if the verification used the Strategy.
+type ResultV2 struct {
+	Status            string             `json:"status"`
+	Finality          string             `json:"finality"`
+	Reasons           []ReasonV2         `json:"reasons"`
+	Provider          KYCProvider        `json:"provider,omitempty"`
+	Reference         string             `json:"reference,omitempty"`
+	Pending           bool               `json:"pending"`
+	LastCheck         *time.Time         `json:"last_check,omitempty"`
+	ProviderErrorCode string             `json:"provider_error_code,omitempty"`
+	Raw               json.RawMessage    `json:"raw,omitempty"`
+	Providers         []ProviderResultV2 `json:"providers,omitempty"`
+}
+
+// ProviderResultV2 represents the individual result of the KYC provider for the ResultV2.
+type ProviderResultV2 struct {
+	Provider       KYCProvider `json:"provider"`
+	VerificationID string      `json:"verification_id,omitempty"`
+	Result         *ResultV2   `json:"result"`
+	Error          *ErrorV2    `json:"error,omitempty"`
+}
+
+// ResultV2FromKYCResult converts KYC verification result of the provider into the v2 API representation.
+// The individual results of the providers are converted without their errors and verification ids.
+func ResultV2FromKYCResult(provider KYCProvider, kycResult KYCResult) (result *ResultV2) {
+	result = &ResultV2{
+		Status:            KYCStatus2StatusV2[kycResult.Status],
+		Finality:          KYCFinality2FinalityV2[Unknown],
+		Reasons:           []ReasonV2{},
+		Provider:          provider,
+		Reference:         kycResult.Reference,
+		Pending:           !kycResult.IsFinal(),
+		ProviderErrorCode: kycResult.ErrorCode,
+	}
+
+	if kycResult.Details != nil {
+		result.Finality = KYCFinality2FinalityV2[kycResult.Details.Finality]
+		for i, reason := range kycResult.Details.Reasons {
+			code := kycResult.Details.ReasonCode(i)
+			if len(code) == 0 {
+				code = UnspecifiedReasonCode
+			}
+			result.Reasons = append(result.Reasons, ReasonV2{Code: code, Message: reason})
+		}
+	}
+
+	if kycResult.StatusCheck != nil {
+		result.Reference = kycResult.StatusCheck.ReferenceID
+		if !kycResult.StatusCheck.LastCheck.IsZero() {
+			lastCheck := kycResult.StatusCheck.LastCheck
+			result.LastCheck = &lastCheck
+		}
+	}
+
+	for _, r := range kycResult.Providers {
+		result.Providers = append(result.Providers, ProviderResultV2{
+			Provider: r.Provider,
+			Result:   ResultV2FromKYCResult(r.Provider, r.Result),
+		})
+	}
+
+	return
+}
@@ -0,0 +1,79 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+)
+
+// StrategyMode defines how the KYC providers of the Strategy are used for the verification.
+type StrategyMode string
+
+// Possible values of StrategyMode.
+const (
+	// Fallback tries the providers in order until one of them returns the result without an error.
+	Fallback StrategyMode = "Fallback"
+	// Parallel checks all the providers at once and merges their results by the consensus rule.
+	Parallel StrategyMode = "Parallel"
+)
+
+// Consensus defines the rule merging the results of the KYC providers checked in parallel.
+type Consensus string
+
+// Possible values of Consensus.
+const (
+	// AllApprove approves if all providers approve and denies if any provider denies.
+	AllApprove Consensus = "AllApprove"
+	// AnyDenial denies if any provider denies and approves if any other provider approves.
+	AnyDenial Consensus = "AnyDenial"
+	// Majority takes the status returned by the most of the providers answered.
+	Majority Consensus = "Majority"
+)
+
+// Strategy represents the verification of the customer using several KYC providers.
+// Consensus is used only for the Parallel mode. The default is AllApprove.
+type Strategy struct {
+	Mode      StrategyMode
+	Providers []KYCProvider
+	Consensus Consensus
+}
+
+// Validate checks the strategy correctness.
+func (s Strategy) Validate() error {
+	switch s.Mode {
+	case Fallback, Parallel:
+	case "":
+		return errors.New("missing strategy mode")
+	default:
+		return fmt.Errorf("unknown strategy mode: %s", s.Mode)
+	}
+
+	if len(s.Providers) == 0 {
+		return errors.New("missing KYC providers of the strategy")
+	}
+
+	seen := map[KYCProvider]bool{}
+	for _, provider := range s.Providers {
+		if len(provider) == 0 {
+			return errors.New("empty KYC provider id in the strategy")
+		}
+		if seen[provider] {
+			return fmt.Errorf("duplicate KYC provider in the strategy: %s", provider)
+		}
+		seen[provider] = true
+	}
+
+	switch s.Consensus {
+	case "", AllApprove, AnyDenial, Majority:
+	default:
+		return fmt.Errorf("unknown consensus rule: %s", s.Consensus)
+	}
+
+	return nil
+}
+
+// KYCProviderResult represents the result of the KYC provider in the multi-provider verification.
+type KYCProviderResult struct {
+	Provider KYCProvider
+	Result   KYCResult
+	Error    string
+}
@@ -0,0 +1,357 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"modulus/kyc/main/option"
+)
+
+// The names of the HTTP client options in the config section of a KYC provider.
+const (
+	TimeoutOption        = "Timeout"
+	ConnectTimeoutOption = "ConnectTimeout"
+	ProxyOption          = "Proxy"
+	CACertOption         = "CACert"
+	ClientCertOption     = "ClientCert"
+	ClientKeyOption      = "ClientKey"
+	MaxRetriesOption     = "MaxRetries"
+	RetryWaitOption      = "RetryWait"
+	RetryMaxWaitOption   = "RetryMaxWait"
+)
+
+// The default values of the retry policy.
+const (
+	defaultRetryWait    = 500 * time.Millisecond
+	defaultRetryMaxWait = 30 * time.Second
+)
+
+// Config holds the settings of the HTTP client.
+//
+// * Timeout limits the overall time of a request including retries. The default timeout is used if it's zero.
+// * ConnectTimeout limits the time of establishing a connection with the server.
+// * Proxy is the url of the HTTP(S) proxy to send requests through.
+// * CACert is the path to the PEM file with the CA certificates used to verify the server.
+// * ClientCert and ClientKey are the paths to the PEM files with the client certificate and its key for mTLS.
+// * MaxRetries is the maximum number of retries of an idempotent request. Retries are disabled if it's zero.
+// * RetryWait is the wait before the first retry. It's doubled for every next retry.
+// * RetryMaxWait limits the wait between retries.
+type Config struct {
+	Timeout        time.Duration
+	ConnectTimeout time.Duration
+	Proxy          string
+	CACert         string
+	ClientCert     string
+	ClientKey      string
+	MaxRetries     int
+	RetryWait      time.Duration
+	RetryMaxWait   time.Duration
+}
+
+// ConfigFromOptions parses the HTTP client options from the config section of a KYC provider.
+// Absent options take their default values.
+func ConfigFromOptions(options map[string]string) (config Config, err error) {
+	durations := []struct {
+		name  string
+		value *time.Duration
+	}{
+		{TimeoutOption, &config.Timeout},
+		{ConnectTimeoutOption, &config.ConnectTimeout},
+		{RetryWaitOption, &config.RetryWait},
+		{RetryMaxWaitOption, &config.RetryMaxWait},
+	}
+
+	for _, d := range durations {
+		if err = option.NonNegativeDuration(options, d.name, d.value); err != nil {
+			return
+		}
+	}
+
+	if err = option.NonNegativeInt(options, MaxRetriesOption, &config.MaxRetries); err != nil {
+		return
+	}
+
+	config.Proxy = options[ProxyOption]
+	config.CACert = options[CACertOption]
+	config.ClientCert = options[ClientCertOption]
+	config.ClientKey = options[ClientKeyOption]
+
+	return
+}
+
+// Client represents the HTTP client used to send requests to a KYC provider API.
+// The nil Client is valid and behaves like the DefaultClient.
+type Client struct {
+	client *http.Client
+	config Config
+}
+
+// DefaultClient is the Client with the default settings.
+// It's used by the package-level functions.
+var DefaultClient = &Client{}
+
+// NewClient constructs a new Client using the config.
+func NewClient(config Config) (client *Client, err error) {
+	client = &Client{
+		config: config,
+	}
+
+	if config.ConnectTimeout == 0 && len(config.Proxy) == 0 && len(config.CACert) == 0 && len(config.ClientCert) == 0 && len(config.ClientKey) == 0 {
+		return
+	}
+
+	transport, err := newTransport(config)
+	if err != nil {
+		client = nil
+		return
+	}
+
+	client.client = &http.Client{
+		Transport: transport,
+	}
+
+	return
+}
+
+// NewClientFromOptions constructs a new Client using the config section of a KYC provider.
+func NewClientFromOptions(options map[string]string) (client *Client, err error) {
+	config, err := ConfigFromOptions(options)
+	if err != nil {
+		return
+	}
+
+	client, err = NewClient(config)
+
+	return
+}
+
+// newTransport constructs the transport with the connect timeout, the proxy and the TLS settings from the config.
+func newTransport(config Config) (transport *http.Transport, err error) {
+	transport = &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   config.ConnectTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	if len(config.Proxy) > 0 {
+		proxy, err1 := url.Parse(config.Proxy)
+		if err1 != nil {
+			err = fmt.Errorf("invalid option '%s': %s", ProxyOption, err1)
+			return
+		}
+		if len(proxy.Host) == 0 {
+			err = fmt.Errorf("invalid option '%s': missing proxy host", ProxyOption)
+			return
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if len(config.CACert) == 0 && len(config.ClientCert) == 0 && len(config.ClientKey) == 0 {
+		return
+	}
+
+	transport.TLSClientConfig = &tls.Config{}
+
+	if len(config.CACert) > 0 {
+		pem, err1 := ioutil.ReadFile(config.CACert)
+		if err1 != nil {
+			err = fmt.Errorf("invalid option '%s': %s", CACertOption, err1)
+			return
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			err = fmt.Errorf("invalid option '%s': no certificates found in %s", CACertOption, config.CACert)
+			return
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+
+	if len(config.ClientCert) > 0 || len(config.ClientKey) > 0 {
+		if len(config.ClientCert) == 0 || len(config.ClientKey) == 0 {
+			err = fmt.Errorf("options '%s' and '%s' must be specified together", ClientCertOption, ClientKeyOption)
+			return
+		}
+		cert, err1 := tls.LoadX509KeyPair(config.ClientCert, config.ClientKey)
+		if err1 != nil {
+			err = fmt.Errorf("invalid client certificate: %s", err1)
+			return
+		}
+		transport.TLSClientConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return
+}
+
+// Post sends a HTTP POST request to the endpoint using the specified headers and body.
+// It returns a HTTP status code or zero in the case of an error, a response body or an error if occurred.
+func (c *Client) Post(endpoint string, headers Headers, body []byte) (int, []byte, error) {
+	return c.RequestContext(context.Background(), http.MethodPost, endpoint, headers, body)
+}
+
+// PostContext is like Post but uses the context to cancel the request.
+func (c *Client) PostContext(ctx context.Context, endpoint string, headers Headers, body []byte) (int, []byte, error) {
+	return c.RequestContext(ctx, http.MethodPost, endpoint, headers, body)
+}
+
+// Get sends a HTTP GET request to the endpoint using the specified headers.
+func (c *Client) Get(endpoint string, headers Headers) (int, []byte, error) {
+	return c.RequestContext(context.Background(), http.MethodGet, endpoint, headers, []byte{})
+}
+
+// GetContext is like Get but uses the context to cancel the request.
+func (c *Client) GetContext(ctx context.Context, endpoint string, headers Headers) (int, []byte, error) {
+	return c.RequestContext(ctx, http.MethodGet, endpoint, headers, []byte{})
+}
+
+// Patch sends a HTTP PATCH request to the endpoint using the specified headers and body.
+// It returns a HTTP status code or zero in the case of an error, a response body or an error if occurred.
+func (c *Client) Patch(endpoint string, headers Headers, body []byte) (int, []byte, error) {
+	return c.RequestContext(context.Background(), http.MethodPatch, endpoint, headers, body)
+}
+
+// PatchContext is like Patch but uses the context to cancel the request.
+func (c *Client) PatchContext(ctx context.Context, endpoint string, headers Headers, body []byte) (int, []byte, error) {
+	return c.RequestContext(ctx, http.MethodPatch, endpoint, headers, body)
+}
+
+// Request sends a HTTP request to the endpoint using the specified method and headers.
+// The body will be used as the request body.
+func (c *Client) Request(method string, endpoint string, headers Headers, body []byte) (int, []byte, error) {
+	return c.RequestContext(context.Background(), method, endpoint, headers, body)
+}
+
+// RequestContext is like Request but uses the context to cancel the request.
+// The request is canceled when the context is done or the client timeout expires, whichever happens first.
+// Idempotent requests are retried with the exponential backoff on 429 and 5xx responses according to the client config.
+// If the context is done while waiting for the retry, the context error is returned instead of the previous response.
+// Every attempt is traced with the client span and its response is recorded if the context has the Recorder.
+func (c *Client) RequestContext(ctx context.Context, method string, endpoint string, headers Headers, body []byte) (int, []byte, error) {
+	if c == nil {
+		c = DefaultClient
+	}
+
+	timeout := c.config.Timeout
+	if timeout == 0 {
+		timeout = defaultHTTPTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for attempt := 0; ; attempt++ {
+		started := time.Now()
+		spanCtx, span := startSpan(ctx, method, endpoint, attempt)
+		code, responseBody, retryAfter, err := c.do(spanCtx, method, endpoint, headers, body)
+		endSpan(span, code, err)
+		observe(ctx, Call{
+			Method:   method,
+			Endpoint: endpoint,
+			Code:     code,
+			Attempt:  attempt,
+			Duration: time.Since(started),
+			Err:      err,
+		})
+		record(ctx, Response{
+			Method:   method,
+			Endpoint: endpoint,
+			Code:     code,
+			Body:     responseBody,
+		})
+		if err != nil || attempt >= c.config.MaxRetries || !isIdempotent(method) || !isRetryable(code) {
+			return code, responseBody, err
+		}
+
+		timer := time.NewTimer(c.backoff(attempt, retryAfter))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return 0, nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// do sends a single HTTP request.
+// It returns a HTTP status code, a response body, the value of the Retry-After header or an error if occurred.
+func (c *Client) do(ctx context.Context, method string, endpoint string, headers Headers, body []byte) (code int, responseBody []byte, retryAfter string, err error) {
+	request, err := http.NewRequest(method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+
+	for header, value := range headers {
+		request.Header.Set(header, value)
+	}
+
+	client := c.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	response, err := client.Do(request.WithContext(ctx))
+	if err != nil {
+		return
+	}
+
+	retryAfter = response.Header.Get("Retry-After")
+	code, responseBody, err = extractCodeAndBodyFromResponse(response)
+
+	return
+}
+
+// backoff returns the wait before the next retry.
+// The Retry-After value in seconds takes precedence over the exponential backoff.
+// The wait never exceeds the maximum wait of the retry policy.
+func (c *Client) backoff(attempt int, retryAfter string) (wait time.Duration) {
+	minWait := c.config.RetryWait
+	if minWait == 0 {
+		minWait = defaultRetryWait
+	}
+	maxWait := c.config.RetryMaxWait
+	if maxWait == 0 {
+		maxWait = defaultRetryMaxWait
+	}
+
+	wait = minWait << uint(attempt)
+	if wait > maxWait || wait < minWait {
+		wait = maxWait
+	}
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		wait = time.Duration(seconds) * time.Second
+		if wait > maxWait {
+			wait = maxWait
+		}
+	}
+
+	return
+}
+
+// isIdempotent reports whether the request with the method is safe to retry.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// isRetryable reports whether the response with the status code is worth to retry.
+func isRetryable(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
@@ -0,0 +1,321 @@
+package http
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigFromOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing the default config.
+	config, err := ConfigFromOptions(map[string]string{
+		"Host": "https://example.com",
+	})
+
+	assert.NoError(err)
+	assert.Equal(Config{}, config)
+
+	// Testing the full config.
+	config, err = ConfigFromOptions(map[string]string{
+		"Timeout":        "2m",
+		"ConnectTimeout": "5s",
+		"Proxy":          "http://proxy.example.com:3128",
+		"CACert":         "ca.pem",
+		"ClientCert":     "client.pem",
+		"ClientKey":      "client.key",
+		"MaxRetries":     "3",
+		"RetryWait":      "250ms",
+		"RetryMaxWait":   "10s",
+	})
+
+	assert.NoError(err)
+	assert.Equal(Config{
+		Timeout:        2 * time.Minute,
+		ConnectTimeout: 5 * time.Second,
+		Proxy:          "http://proxy.example.com:3128",
+		CACert:         "ca.pem",
+		ClientCert:     "client.pem",
+		ClientKey:      "client.key",
+		MaxRetries:     3,
+		RetryWait:      250 * time.Millisecond,
+		RetryMaxWait:   10 * time.Second,
+	}, config)
+
+	// Testing invalid duration.
+	_, err = ConfigFromOptions(map[string]string{
+		"ConnectTimeout": "five seconds",
+	})
+
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "invalid option 'ConnectTimeout'")
+	}
+
+	// Testing negative duration.
+	_, err = ConfigFromOptions(map[string]string{
+		"Timeout": "-1s",
+	})
+
+	if assert.Error(err) {
+		assert.Equal("invalid option 'Timeout': negative duration", err.Error())
+	}
+
+	// Testing invalid number of retries.
+	_, err = ConfigFromOptions(map[string]string{
+		"MaxRetries": "-1",
+	})
+
+	if assert.Error(err) {
+		assert.Equal("invalid option 'MaxRetries': negative number", err.Error())
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing the client with the default transport.
+	client, err := NewClient(Config{MaxRetries: 1})
+
+	assert.NoError(err)
+	assert.NotNil(client)
+	assert.Nil(client.client)
+
+	// Testing the client with the custom transport.
+	client, err = NewClient(Config{ConnectTimeout: time.Second})
+
+	assert.NoError(err)
+	assert.NotNil(client)
+	assert.NotNil(client.client)
+
+	// Testing invalid proxy.
+	client, err = NewClient(Config{Proxy: "proxy.example.com"})
+
+	assert.Nil(client)
+	if assert.Error(err) {
+		assert.Equal("invalid option 'Proxy': missing proxy host", err.Error())
+	}
+
+	// Testing missing CA bundle.
+	client, err = NewClient(Config{CACert: "/nonexistent/ca.pem"})
+
+	assert.Nil(client)
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "invalid option 'CACert'")
+	}
+
+	// Testing client certificate without the key.
+	client, err = NewClient(Config{ClientCert: "client.pem"})
+
+	assert.Nil(client)
+	if assert.Error(err) {
+		assert.Equal("options 'ClientCert' and 'ClientKey' must be specified together", err.Error())
+	}
+}
+
+func TestClientRetry(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, "OK")
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(Config{
+		MaxRetries: 3,
+		RetryWait:  time.Millisecond,
+	})
+
+	assert.NoError(err)
+
+	// Testing retries of an idempotent request.
+	status, responseBody, err := client.Get(ts.URL, Headers{})
+
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, status)
+	assert.Equal([]byte("OK"), responseBody)
+	assert.Equal(int32(3), atomic.LoadInt32(&calls))
+
+	// Testing no retries of a non-idempotent request.
+	atomic.StoreInt32(&calls, 0)
+
+	status, _, err = client.Post(ts.URL, Headers{}, []byte("{}"))
+
+	assert.NoError(err)
+	assert.Equal(http.StatusServiceUnavailable, status)
+	assert.Equal(int32(1), atomic.LoadInt32(&calls))
+
+	// Testing exhausted retries.
+	atomic.StoreInt32(&calls, 0)
+
+	client, err = NewClient(Config{
+		MaxRetries: 1,
+		RetryWait:  time.Millisecond,
+	})
+
+	assert.NoError(err)
+
+	status, _, err = client.Get(ts.URL, Headers{})
+
+	assert.NoError(err)
+	assert.Equal(http.StatusServiceUnavailable, status)
+	assert.Equal(int32(2), atomic.LoadInt32(&calls))
+
+	// Testing the context cancelled while waiting for the retry.
+	atomic.StoreInt32(&calls, 0)
+
+	client, err = NewClient(Config{
+		MaxRetries: 3,
+		RetryWait:  time.Hour,
+	})
+
+	assert.NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	status, responseBody, err = client.GetContext(ctx, ts.URL, Headers{})
+
+	assert.Equal(context.DeadlineExceeded, err)
+	assert.Equal(0, status)
+	assert.Nil(responseBody)
+	assert.Equal(int32(1), atomic.LoadInt32(&calls))
+
+	// Testing no retries by default.
+	atomic.StoreInt32(&calls, 0)
+
+	status, _, err = Get(ts.URL, Headers{})
+
+	assert.NoError(err)
+	assert.Equal(http.StatusServiceUnavailable, status)
+	assert.Equal(int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestClientBackoff(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &Client{
+		config: Config{
+			RetryWait:    100 * time.Millisecond,
+			RetryMaxWait: time.Second,
+		},
+	}
+
+	assert.Equal(100*time.Millisecond, client.backoff(0, ""))
+	assert.Equal(400*time.Millisecond, client.backoff(2, ""))
+	assert.Equal(time.Second, client.backoff(5, ""))
+	assert.Equal(time.Second, client.backoff(64, ""))
+	assert.Equal(0*time.Second, client.backoff(3, "0"))
+	assert.Equal(time.Second, client.backoff(0, "120"))
+	assert.Equal(800*time.Millisecond, client.backoff(3, "Wed, 21 Oct 2015 07:28:00 GMT"))
+}
+
+func TestClientTimeout(t *testing.T) {
+	release := make(chan struct{})
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	client, err := NewClient(Config{Timeout: 100 * time.Millisecond})
+
+	assert.NoError(t, err)
+
+	_, _, err = client.GetContext(context.Background(), ts.URL, Headers{})
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), context.DeadlineExceeded.Error())
+	}
+}
+
+func TestClientProxy(t *testing.T) {
+	assert := assert.New(t)
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "proxied "+r.URL.String())
+	}))
+	defer proxy.Close()
+
+	client, err := NewClient(Config{Proxy: proxy.URL})
+
+	assert.NoError(err)
+
+	status, responseBody, err := client.Get("http://kyc.example.com/ping", Headers{})
+
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, status)
+	assert.Equal([]byte("proxied http://kyc.example.com/ping"), responseBody)
+}
+
+func TestClientCACert(t *testing.T) {
+	assert := assert.New(t)
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "OK")
+	}))
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "kyc")
+	if !assert.NoError(err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	cacert := filepath.Join(dir, "ca.pem")
+	err = ioutil.WriteFile(cacert, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: ts.Certificate().Raw,
+	}), 0600)
+	if !assert.NoError(err) {
+		return
+	}
+
+	// Testing the server certificate signed by the unknown authority.
+	_, _, err = Get(ts.URL, Headers{})
+
+	assert.Error(err)
+
+	// Testing the custom CA bundle.
+	client, err := NewClient(Config{CACert: cacert})
+
+	assert.NoError(err)
+
+	status, responseBody, err := client.Get(ts.URL, Headers{})
+
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, status)
+	assert.Equal([]byte("OK"), responseBody)
+}
+
+func TestNilClient(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "OK")
+	}))
+	defer ts.Close()
+
+	var client *Client
+
+	status, responseBody, err := client.Get(ts.URL, Headers{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []byte("OK"), responseBody)
+}
@@ -1,7 +1,6 @@
 package http
 
 import (
-	"bytes"
 	"context"
 	"io/ioutil"
 	"net/http"
@@ -9,7 +8,8 @@ import (
 )
 
 // defaultHTTPTimeout holds the default value for a HTTP request timeout.
-// Currently, the timeout value for requests isn't configurable.
+// It's used unless the client config specifies the timeout.
+// A caller may shorten it using the context deadline.
 var defaultHTTPTimeout = time.Minute * 5
 
 // Headers represents a HTTP request headers.
@@ -18,43 +18,45 @@ type Headers map[string]string
 // Post sends a HTTP POST request to the endpoint using the specified headers and body.
 // It returns a HTTP status code or zero in the case of an error, a response body or an error if occurred.
 func Post(endpoint string, headers Headers, body []byte) (int, []byte, error) {
-	return Request(http.MethodPost, endpoint, headers, body)
+	return DefaultClient.RequestContext(context.Background(), http.MethodPost, endpoint, headers, body)
+}
+
+// PostContext is like Post but uses the context to cancel the request.
+func PostContext(ctx context.Context, endpoint string, headers Headers, body []byte) (int, []byte, error) {
+	return DefaultClient.RequestContext(ctx, http.MethodPost, endpoint, headers, body)
 }
 
 // Get sends a HTTP GET request to the endpoint using the specified headers.
 func Get(endpoint string, headers Headers) (int, []byte, error) {
-	return Request(http.MethodGet, endpoint, headers, []byte{})
+	return DefaultClient.RequestContext(context.Background(), http.MethodGet, endpoint, headers, []byte{})
+}
+
+// GetContext is like Get but uses the context to cancel the request.
+func GetContext(ctx context.Context, endpoint string, headers Headers) (int, []byte, error) {
+	return DefaultClient.RequestContext(ctx, http.MethodGet, endpoint, headers, []byte{})
 }
 
 // Patch sends a HTTP PATCH request to the endpoint using the specified headers and body.
 // It returns a HTTP status code or zero in the case of an error, a response body or an error if occurred.
 func Patch(endpoint string, headers Headers, body []byte) (int, []byte, error) {
-	return Request(http.MethodPatch, endpoint, headers, body)
+	return DefaultClient.RequestContext(context.Background(), http.MethodPatch, endpoint, headers, body)
+}
+
+// PatchContext is like Patch but uses the context to cancel the request.
+func PatchContext(ctx context.Context, endpoint string, headers Headers, body []byte) (int, []byte, error) {
+	return DefaultClient.RequestContext(ctx, http.MethodPatch, endpoint, headers, body)
 }
 
 // Request sends a HTTP request to the endpoint using the specified method and headers.
 // The body will be used as the request body.
 func Request(method string, endpoint string, headers Headers, body []byte) (int, []byte, error) {
-	request, err := http.NewRequest(method, endpoint, bytes.NewReader(body))
-
-	if err != nil {
-		return 0, nil, err
-	}
-
-	for header, value := range headers {
-		request.Header.Set(header, value)
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), defaultHTTPTimeout)
-	defer cancel()
-
-	response, err := http.DefaultClient.Do(request.WithContext(ctx))
-
-	if err != nil {
-		return 0, nil, err
-	}
+	return DefaultClient.RequestContext(context.Background(), method, endpoint, headers, body)
+}
 
-	return extractCodeAndBodyFromResponse(response)
+// RequestContext is like Request but uses the context to cancel the request.
+// The request is canceled when the context is done or the default timeout expires, whichever happens first.
+func RequestContext(ctx context.Context, method string, endpoint string, headers Headers, body []byte) (int, []byte, error) {
+	return DefaultClient.RequestContext(ctx, method, endpoint, headers, body)
 }
 
 // extractCodeAndBodyFromResponse extracts the content from a HTTP response.
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -110,3 +111,27 @@ func TestTimeout(t *testing.T) {
 
 	assert.Error(t, err)
 }
+
+func TestRequestContext(t *testing.T) {
+	release := make(chan struct{})
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	status, responseBody, err := GetContext(ctx, ts.URL, Headers{})
+
+	if assert.Error(t, err) {
+		assert.Equal(t, 0, status)
+		assert.Nil(t, responseBody)
+		assert.Contains(t, err.Error(), context.DeadlineExceeded.Error())
+		assert.True(t, time.Since(start) < defaultHTTPTimeout)
+	}
+}
@@ -0,0 +1,23 @@
+package http
+
+import "context"
+
+// Labels describe the verification operation the requests are sent on behalf of.
+// They're used by the observers and added to the request spans.
+type Labels struct {
+	Provider  string
+	Operation string
+}
+
+type labelsKey struct{}
+
+// WithLabels returns the copy of the context labelling the requests sent with it.
+func WithLabels(ctx context.Context, labels Labels) context.Context {
+	return context.WithValue(ctx, labelsKey{}, labels)
+}
+
+// LabelsFromContext returns the labels of the requests from the context if any.
+func LabelsFromContext(ctx context.Context) (labels Labels, ok bool) {
+	labels, ok = ctx.Value(labelsKey{}).(Labels)
+	return
+}
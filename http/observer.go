@@ -0,0 +1,40 @@
+package http
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Call describes a single HTTP request sent to a KYC provider API.
+//
+// * Code is the HTTP status code of the response or zero in the case of an error.
+// * Attempt is the number of the attempt starting from zero. Retries have positive numbers.
+// * Duration is the time elapsed until the response body was read or the error occurred.
+type Call struct {
+	Method   string
+	Endpoint string
+	Code     int
+	Attempt  int
+	Duration time.Duration
+	Err      error
+}
+
+// Observer receives every HTTP request sent by the clients of the package.
+// The context is the one the request was sent with, so the observer may take the request labels from it.
+type Observer func(ctx context.Context, call Call)
+
+var observer atomic.Value
+
+// SetObserver sets the observer of the HTTP requests. The nil observer disables the observation.
+// It must not block since it's called synchronously for every request.
+func SetObserver(o Observer) {
+	observer.Store(o)
+}
+
+// observe passes the call to the observer if it's set.
+func observe(ctx context.Context, call Call) {
+	if o, _ := observer.Load().(Observer); o != nil {
+		o(ctx, call)
+	}
+}
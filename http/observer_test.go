@@ -0,0 +1,82 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type observerKey struct{}
+
+func TestObserver(t *testing.T) {
+	assert := assert.New(t)
+
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	var calls []Call
+
+	SetObserver(func(ctx context.Context, call Call) {
+		assert.Equal("label", ctx.Value(observerKey{}))
+		calls = append(calls, call)
+	})
+	defer SetObserver(nil)
+
+	client, err := NewClient(Config{MaxRetries: 1, RetryWait: time.Millisecond})
+	if !assert.NoError(err) {
+		return
+	}
+
+	ctx := context.WithValue(context.Background(), observerKey{}, "label")
+
+	// Testing the observed retries.
+	code, _, err := client.GetContext(ctx, server.URL, nil)
+
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, code)
+	if assert.Len(calls, 2) {
+		assert.Equal(http.MethodGet, calls[0].Method)
+		assert.Equal(server.URL, calls[0].Endpoint)
+		assert.Equal(http.StatusServiceUnavailable, calls[0].Code)
+		assert.Equal(0, calls[0].Attempt)
+		assert.Equal(http.StatusOK, calls[1].Code)
+		assert.Equal(1, calls[1].Attempt)
+		assert.True(calls[1].Duration > 0)
+		assert.NoError(calls[1].Err)
+	}
+
+	// Testing the observed error.
+	calls = nil
+
+	code, _, err = client.PostContext(ctx, "http://127.0.0.1:0", nil, nil)
+
+	assert.Error(err)
+	assert.Zero(code)
+	if assert.Len(calls, 1) {
+		assert.Equal(http.MethodPost, calls[0].Method)
+		assert.Zero(calls[0].Code)
+		assert.Error(calls[0].Err)
+	}
+
+	// Testing the disabled observer.
+	SetObserver(nil)
+	calls = nil
+
+	_, _, err = client.GetContext(ctx, server.URL, nil)
+
+	assert.NoError(err)
+	assert.Empty(calls)
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"context"
+	"sync"
+)
+
+// Response describes the response of a KYC provider API recorded by the Recorder.
+type Response struct {
+	Method   string
+	Endpoint string
+	Code     int
+	Body     []byte
+}
+
+// Recorder records the responses of the KYC provider APIs received with the context it's attached to.
+// It's safe for concurrent use.
+type Recorder struct {
+	mu        sync.Mutex
+	responses []Response
+}
+
+// Responses returns the recorded responses in the order they were received.
+func (r *Recorder) Responses() []Response {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Response{}, r.responses...)
+}
+
+// Last returns the last recorded response if any.
+func (r *Recorder) Last() (response Response, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.responses) == 0 {
+		return
+	}
+
+	return r.responses[len(r.responses)-1], true
+}
+
+type recorderKey struct{}
+
+// WithRecorder returns the copy of the context recording the responses to the requests sent with it.
+func WithRecorder(ctx context.Context, recorder *Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, recorder)
+}
+
+// record passes the response to the recorder of the context if any.
+// The failed requests aren't recorded.
+func record(ctx context.Context, response Response) {
+	recorder, ok := ctx.Value(recorderKey{}).(*Recorder)
+	if !ok || response.Code == 0 {
+		return
+	}
+
+	recorder.mu.Lock()
+	recorder.responses = append(recorder.responses, response)
+	recorder.mu.Unlock()
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"status":"ok"}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(Config{
+		MaxRetries: 1,
+		RetryWait:  time.Millisecond,
+	})
+
+	assert.NoError(err)
+
+	recorder := &Recorder{}
+
+	_, ok := recorder.Last()
+
+	assert.False(ok)
+
+	// Testing every attempt is recorded.
+	_, _, err = client.GetContext(WithRecorder(context.Background(), recorder), ts.URL, Headers{})
+
+	assert.NoError(err)
+	assert.Equal([]Response{
+		{Method: http.MethodGet, Endpoint: ts.URL, Code: http.StatusServiceUnavailable, Body: []byte{}},
+		{Method: http.MethodGet, Endpoint: ts.URL, Code: http.StatusOK, Body: []byte(`{"status":"ok"}`)},
+	}, recorder.Responses())
+
+	last, ok := recorder.Last()
+
+	assert.True(ok)
+	assert.Equal(http.StatusOK, last.Code)
+
+	// Testing the requests without the recorder and the failed requests aren't recorded.
+	_, _, err = client.GetContext(context.Background(), ts.URL, Headers{})
+
+	assert.NoError(err)
+
+	_, _, err = client.PostContext(WithRecorder(context.Background(), recorder), "http://127.0.0.1:0", Headers{}, nil)
+
+	assert.Error(err)
+	assert.Len(recorder.Responses(), 2)
+}
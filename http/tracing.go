@@ -0,0 +1,71 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation name of the request spans.
+const tracerName = "modulus/kyc/http"
+
+// The attributes of the request spans.
+const (
+	ProviderAttribute    = attribute.Key("kyc.provider")
+	OperationAttribute   = attribute.Key("kyc.operation")
+	methodAttribute      = attribute.Key("http.request.method")
+	urlAttribute         = attribute.Key("url.full")
+	serverAttribute      = attribute.Key("server.address")
+	statusCodeAttribute  = attribute.Key("http.response.status_code")
+	resendCountAttribute = attribute.Key("http.request.resend_count")
+)
+
+// startSpan starts the client span of the request attempt using the global tracer provider.
+// The span is a child of the span from the context if any.
+func startSpan(ctx context.Context, method, endpoint string, attempt int) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		methodAttribute.String(method),
+	}
+	if u, err := url.Parse(endpoint); err == nil {
+		attrs = append(attrs, urlAttribute.String(redactURL(u)), serverAttribute.String(u.Hostname()))
+	}
+	if attempt > 0 {
+		attrs = append(attrs, resendCountAttribute.Int(attempt))
+	}
+	if labels, ok := LabelsFromContext(ctx); ok {
+		attrs = append(attrs, ProviderAttribute.String(labels.Provider), OperationAttribute.String(labels.Operation))
+	}
+
+	return otel.Tracer(tracerName).Start(ctx, "HTTP "+method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// endSpan records the outcome of the request attempt and ends the span.
+func endSpan(span trace.Span, code int, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetAttributes(statusCodeAttribute.Int(code))
+		if code >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(code))
+		}
+	}
+
+	span.End()
+}
+
+// redactURL returns the url without the user info and the query since they may hold credentials.
+func redactURL(u *url.URL) string {
+	r := *u
+	r.User = nil
+	r.RawQuery = ""
+	r.ForceQuery = false
+	r.Fragment = ""
+
+	return r.String()
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanAttributes returns the attributes of the span as a map.
+func spanAttributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, attr := range span.Attributes {
+		attrs[attr.Key] = attr.Value
+	}
+
+	return attrs
+}
+
+func TestTracing(t *testing.T) {
+	assert := assert.New(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	defaultProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	defer otel.SetTracerProvider(defaultProvider)
+
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{MaxRetries: 1, RetryWait: time.Millisecond})
+	if !assert.NoError(err) {
+		return
+	}
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "CheckStatus")
+	ctx = WithLabels(ctx, Labels{Provider: "Jumio", Operation: "CheckStatus"})
+
+	// Testing the spans of the retried request.
+	code, _, err := client.GetContext(ctx, "http://user:secret@"+server.Listener.Addr().String()+"/status/42?token=secret", nil)
+	parent.End()
+
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, code)
+
+	spans := exporter.GetSpans()
+	if !assert.Len(spans, 3) {
+		return
+	}
+
+	assert.Equal("CheckStatus", spans[2].Name)
+
+	for i, span := range spans[:2] {
+		attrs := spanAttributes(span)
+
+		assert.Equal("HTTP GET", span.Name)
+		assert.Equal(trace.SpanKindClient, span.SpanKind)
+		assert.Equal(parent.SpanContext().TraceID(), span.SpanContext.TraceID())
+		assert.Equal(parent.SpanContext().SpanID(), span.Parent.SpanID())
+		assert.Equal("Jumio", attrs["kyc.provider"].AsString())
+		assert.Equal("CheckStatus", attrs["kyc.operation"].AsString())
+		assert.Equal("GET", attrs["http.request.method"].AsString())
+		assert.Equal(server.URL+"/status/42", attrs["url.full"].AsString())
+		assert.Equal("127.0.0.1", attrs["server.address"].AsString())
+
+		if i == 0 {
+			assert.Equal(int64(http.StatusBadGateway), attrs["http.response.status_code"].AsInt64())
+			assert.Equal(codes.Error, span.Status.Code)
+			_, resent := attrs["http.request.resend_count"]
+			assert.False(resent)
+		} else {
+			assert.Equal(int64(http.StatusOK), attrs["http.response.status_code"].AsInt64())
+			assert.Equal(int64(1), attrs["http.request.resend_count"].AsInt64())
+			assert.Equal(codes.Unset, span.Status.Code)
+		}
+	}
+
+	// Testing the span of the failed request.
+	exporter.Reset()
+
+	_, _, err = client.PostContext(context.Background(), "http://127.0.0.1:0", nil, nil)
+
+	assert.Error(err)
+
+	spans = exporter.GetSpans()
+	if assert.Len(spans, 1) {
+		assert.Equal("HTTP POST", spans[0].Name)
+		assert.Equal(codes.Error, spans[0].Status.Code)
+		assert.Len(spans[0].Events, 1)
+		assert.False(spans[0].Parent.IsValid())
+	}
+}
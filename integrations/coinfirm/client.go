@@ -1,6 +1,7 @@
 package coinfirm
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	stdhttp "net/http"
@@ -10,7 +11,7 @@ import (
 )
 
 // newAuthToken requests the API for a new user token required to access nearly all endpoints.
-func (c Coinfirm) newAuthToken(headers http.Headers) (token model.AuthResponse, status *int, err error) {
+func (c Coinfirm) newAuthToken(ctx context.Context, headers http.Headers) (token model.AuthResponse, status *int, err error) {
 	authreq := model.AuthRequest{
 		Email:    c.config.Email,
 		Password: c.config.Password,
@@ -21,7 +22,7 @@ func (c Coinfirm) newAuthToken(headers http.Headers) (token model.AuthResponse,
 		return
 	}
 
-	code, resp, err := http.Post(c.config.Host+"/auth/login", headers, body)
+	code, resp, err := c.config.HTTPClient.PostContext(ctx, c.config.Host+"/auth/login", headers, body)
 	if err != nil {
 		return
 	}
@@ -43,13 +44,13 @@ func (c Coinfirm) newAuthToken(headers http.Headers) (token model.AuthResponse,
 }
 
 // newParticipant requests the API to add new participant without data.
-func (c Coinfirm) newParticipant(headers http.Headers, nParticipant model.NewParticipant) (participant model.NewParticipantResponse, status *int, err error) {
+func (c Coinfirm) newParticipant(ctx context.Context, headers http.Headers, nParticipant model.NewParticipant) (participant model.NewParticipantResponse, status *int, err error) {
 	body, err := json.Marshal(nParticipant)
 	if err != nil {
 		return
 	}
 
-	code, resp, err := http.Request(stdhttp.MethodPut, c.config.Host+"/kyc/customers/"+c.config.Company, headers, body)
+	code, resp, err := c.config.HTTPClient.RequestContext(ctx, stdhttp.MethodPut, c.config.Host+"/kyc/customers/"+c.config.Company, headers, body)
 	if err != nil {
 		return
 	}
@@ -71,13 +72,13 @@ func (c Coinfirm) newParticipant(headers http.Headers, nParticipant model.NewPar
 }
 
 // sendParticipantDetails sends individual participant data to the API.
-func (c Coinfirm) sendParticipantDetails(headers http.Headers, pID string, details model.ParticipantDetails) (status *int, err error) {
+func (c Coinfirm) sendParticipantDetails(ctx context.Context, headers http.Headers, pID string, details model.ParticipantDetails) (status *int, err error) {
 	body, err := json.Marshal(details)
 	if err != nil {
 		return
 	}
 
-	code, resp, err := http.Request(stdhttp.MethodPut, c.config.Host+"/kyc/forms/"+c.config.Company+"/"+pID, headers, body)
+	code, resp, err := c.config.HTTPClient.RequestContext(ctx, stdhttp.MethodPut, c.config.Host+"/kyc/forms/"+c.config.Company+"/"+pID, headers, body)
 	if err != nil {
 		return
 	}
@@ -96,13 +97,13 @@ func (c Coinfirm) sendParticipantDetails(headers http.Headers, pID string, detai
 }
 
 // sendDocFile sends a document file to the API to add it to KYC process.
-func (c Coinfirm) sendDocFile(headers http.Headers, pID string, docfile *model.File) (status *int, err error) {
+func (c Coinfirm) sendDocFile(ctx context.Context, headers http.Headers, pID string, docfile *model.File) (status *int, err error) {
 	body, err := json.Marshal(docfile)
 	if err != nil {
 		return
 	}
 
-	code, resp, err := http.Post(c.config.Host+"/kyc/files/"+c.config.Company+"/"+pID, headers, body)
+	code, resp, err := c.config.HTTPClient.PostContext(ctx, c.config.Host+"/kyc/files/"+c.config.Company+"/"+pID, headers, body)
 	if err != nil {
 		return
 	}
@@ -121,8 +122,8 @@ func (c Coinfirm) sendDocFile(headers http.Headers, pID string, docfile *model.F
 }
 
 // getParticipantCurrentStatus requests the current participant status in KYC flow from the API.
-func (c Coinfirm) getParticipantCurrentStatus(headers http.Headers, pID string) (status model.StatusResponse, code *int, err error) {
-	rcode, resp, err := http.Get(c.config.Host+"/kyc/status/"+c.config.Company+"/"+pID, headers)
+func (c Coinfirm) getParticipantCurrentStatus(ctx context.Context, headers http.Headers, pID string) (status model.StatusResponse, code *int, err error) {
+	rcode, resp, err := c.config.HTTPClient.GetContext(ctx, c.config.Host+"/kyc/status/"+c.config.Company+"/"+pID, headers)
 	if err != nil {
 		return
 	}
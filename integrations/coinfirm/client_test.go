@@ -1,6 +1,7 @@
 package coinfirm
 
 import (
+	"context"
 	"encoding/base64"
 	"io/ioutil"
 	"net/http"
@@ -41,7 +42,7 @@ func TestNewAuthTokenSuccess(t *testing.T) {
 
 	httpmock.RegisterResponder(http.MethodPost, c.config.Host+"/auth/login", httpmock.NewStringResponder(http.StatusOK, tokenResp))
 
-	token, status, err := c.newAuthToken(hdrs)
+	token, status, err := c.newAuthToken(context.Background(), hdrs)
 
 	assert.NoError(err)
 	assert.Nil(status)
@@ -56,7 +57,7 @@ func TestNewAuthTokenUnexpectedResp(t *testing.T) {
 
 	httpmock.RegisterResponder(http.MethodPost, c.config.Host+"/auth/login", httpmock.NewStringResponder(http.StatusOK, malformedResp))
 
-	token, status, err := c.newAuthToken(hdrs)
+	token, status, err := c.newAuthToken(context.Background(), hdrs)
 
 	assert.Error(err)
 	assert.Equal("invalid character 'h' in literal true (expecting 'r')", err.Error())
@@ -70,7 +71,7 @@ func TestNewAuthTokenSendError(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
 
-	token, status, err := c.newAuthToken(hdrs)
+	token, status, err := c.newAuthToken(context.Background(), hdrs)
 
 	assert.Error(err)
 	assert.Equal("Post https://api.coinfirm.io/v2/auth/login: no responder found", err.Error())
@@ -86,7 +87,7 @@ func TestNewAuthTokenStatus400(t *testing.T) {
 
 	httpmock.RegisterResponder(http.MethodPost, c.config.Host+"/auth/login", httpmock.NewStringResponder(http.StatusBadRequest, error400Resp))
 
-	token, status, err := c.newAuthToken(hdrs)
+	token, status, err := c.newAuthToken(context.Background(), hdrs)
 
 	assert.Error(err)
 	assert.Equal("Invalid email or password", err.Error())
@@ -103,7 +104,7 @@ func TestNewAuthTokenStatus400UnexpectedResp(t *testing.T) {
 
 	httpmock.RegisterResponder(http.MethodPost, c.config.Host+"/auth/login", httpmock.NewStringResponder(http.StatusBadRequest, malformedResp))
 
-	token, status, err := c.newAuthToken(hdrs)
+	token, status, err := c.newAuthToken(context.Background(), hdrs)
 
 	assert.Error(err)
 	assert.Equal("http error", err.Error())
@@ -128,7 +129,7 @@ func TestNewParticipantSuccess(t *testing.T) {
 		Email: "sarbash.s@ya.ru",
 	}
 
-	participant, status, err := c.newParticipant(hdrs, nParticipant)
+	participant, status, err := c.newParticipant(context.Background(), hdrs, nParticipant)
 
 	assert.NoError(err)
 	assert.Nil(status)
@@ -141,7 +142,7 @@ func TestNewParticipantSendError(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
 
-	participant, status, err := c.newParticipant(hdrs, model.NewParticipant{})
+	participant, status, err := c.newParticipant(context.Background(), hdrs, model.NewParticipant{})
 
 	assert.Error(err)
 	assert.Equal("Put https://api.coinfirm.io/v2/kyc/customers/Fuzion: no responder found", err.Error())
@@ -161,7 +162,7 @@ func TestNewParticipantStatus400(t *testing.T) {
 		Email: "sarbash.s@ya.ru",
 	}
 
-	participant, status, err := c.newParticipant(hdrs, nParticipant)
+	participant, status, err := c.newParticipant(context.Background(), hdrs, nParticipant)
 
 	assert.Error(err)
 	assert.Equal("Request body validation errors", err.Error())
@@ -182,7 +183,7 @@ func TestNewParticipantStatus400UnexpectedResp(t *testing.T) {
 		Email: "sarbash.s@ya.ru",
 	}
 
-	participant, status, err := c.newParticipant(hdrs, nParticipant)
+	participant, status, err := c.newParticipant(context.Background(), hdrs, nParticipant)
 
 	assert.Error(err)
 	assert.Equal("http error", err.Error())
@@ -218,7 +219,7 @@ func TestSendParticipantDetailsSuccess(t *testing.T) {
 		BirthDate:     "1960-08-15",
 	}
 
-	status, err := c.sendParticipantDetails(hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc", participant)
+	status, err := c.sendParticipantDetails(context.Background(), hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc", participant)
 
 	assert.NoError(err)
 	assert.Nil(status)
@@ -229,7 +230,7 @@ func TestSendParticipantDetailsSendError(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
 
-	status, err := c.sendParticipantDetails(hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc", model.ParticipantDetails{})
+	status, err := c.sendParticipantDetails(context.Background(), hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc", model.ParticipantDetails{})
 
 	assert.Error(err)
 	assert.Equal("Put https://api.coinfirm.io/v2/kyc/forms/Fuzion/33611d6d-2826-4c3e-a777-3f0397e283fc: no responder found", err.Error())
@@ -255,7 +256,7 @@ func TestSendParticipantDetailsStatus400(t *testing.T) {
 		Street:        "Gifford St",
 	}
 
-	status, err := c.sendParticipantDetails(hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc", participant)
+	status, err := c.sendParticipantDetails(context.Background(), hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc", participant)
 
 	assert.Error(err)
 	assert.Equal("Request body validation errors", err.Error())
@@ -271,7 +272,7 @@ func TestSendParticipantDetailsStatus400UnexpectedResp(t *testing.T) {
 
 	httpmock.RegisterResponder(http.MethodPut, c.config.Host+"/kyc/forms/Fuzion/33611d6d-2826-4c3e-a777-3f0397e283fc", httpmock.NewStringResponder(http.StatusBadRequest, malformedResp))
 
-	status, err := c.sendParticipantDetails(hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc", model.ParticipantDetails{})
+	status, err := c.sendParticipantDetails(context.Background(), hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc", model.ParticipantDetails{})
 
 	assert.Error(err)
 	assert.Equal("http error", err.Error())
@@ -299,7 +300,7 @@ func TestSendDocFileSuccess(t *testing.T) {
 		DataBase64: base64.StdEncoding.EncodeToString(data),
 	}
 
-	status, err := c.sendDocFile(hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc", docfile)
+	status, err := c.sendDocFile(context.Background(), hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc", docfile)
 
 	assert.NoError(err)
 	assert.Nil(status)
@@ -311,7 +312,7 @@ func TestSendDocFileSendError(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
 
-	status, err := c.sendDocFile(hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc", &model.File{})
+	status, err := c.sendDocFile(context.Background(), hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc", &model.File{})
 
 	assert.Error(err)
 	assert.Equal("Post https://api.coinfirm.io/v2/kyc/files/Fuzion/33611d6d-2826-4c3e-a777-3f0397e283fc: no responder found", err.Error())
@@ -326,7 +327,7 @@ func TestSendDocFileStatus400(t *testing.T) {
 
 	httpmock.RegisterResponder(http.MethodPost, c.config.Host+"/kyc/files/Fuzion/33611d6d-2826-4c3e-a777-3f0397e283fc", httpmock.NewStringResponder(http.StatusBadRequest, `{"error":"Request body validation errors"}`))
 
-	status, err := c.sendDocFile(hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc", &model.File{})
+	status, err := c.sendDocFile(context.Background(), hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc", &model.File{})
 
 	assert.Error(err)
 	assert.Equal("Request body validation errors", err.Error())
@@ -342,7 +343,7 @@ func TestSendDocFileStatus400UnexpectedResp(t *testing.T) {
 
 	httpmock.RegisterResponder(http.MethodPost, c.config.Host+"/kyc/files/Fuzion/33611d6d-2826-4c3e-a777-3f0397e283fc", httpmock.NewStringResponder(http.StatusBadRequest, malformedResp))
 
-	status, err := c.sendDocFile(hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc", &model.File{})
+	status, err := c.sendDocFile(context.Background(), hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc", &model.File{})
 
 	assert.Error(err)
 	assert.Equal("http error", err.Error())
@@ -362,7 +363,7 @@ func TestGetParticipantCurrentStatusSuccess(t *testing.T) {
 
 	httpmock.RegisterResponder(http.MethodGet, c.config.Host+"/kyc/status/Fuzion/33611d6d-2826-4c3e-a777-3f0397e283fc", httpmock.NewStringResponder(http.StatusOK, statusInprogressResp))
 
-	status, code, err := c.getParticipantCurrentStatus(hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc")
+	status, code, err := c.getParticipantCurrentStatus(context.Background(), hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc")
 
 	assert.NoError(err)
 	assert.Nil(code)
@@ -376,7 +377,7 @@ func TestGetParticipantCurrentStatusSendError(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
 
-	status, code, err := c.getParticipantCurrentStatus(hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc")
+	status, code, err := c.getParticipantCurrentStatus(context.Background(), hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc")
 
 	assert.Error(err)
 	assert.Equal("Get https://api.coinfirm.io/v2/kyc/status/Fuzion/33611d6d-2826-4c3e-a777-3f0397e283fc: no responder found", err.Error())
@@ -392,7 +393,7 @@ func TestGetParticipantCurrentStatusStatus404(t *testing.T) {
 
 	httpmock.RegisterResponder(http.MethodGet, c.config.Host+"/kyc/status/Fuzion/33611d6d-2826-4c3e-a777-3f0397e283fc", httpmock.NewStringResponder(http.StatusNotFound, `{"error":"Resource not found"}`))
 
-	status, code, err := c.getParticipantCurrentStatus(hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc")
+	status, code, err := c.getParticipantCurrentStatus(context.Background(), hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc")
 
 	assert.Error(err)
 	assert.Equal("Resource not found", err.Error())
@@ -409,7 +410,7 @@ func TestGetParticipantCurrentStatusStatus404UnexpectedResp(t *testing.T) {
 
 	httpmock.RegisterResponder(http.MethodGet, c.config.Host+"/kyc/status/Fuzion/33611d6d-2826-4c3e-a777-3f0397e283fc", httpmock.NewStringResponder(http.StatusNotFound, malformedResp))
 
-	status, code, err := c.getParticipantCurrentStatus(hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc")
+	status, code, err := c.getParticipantCurrentStatus(context.Background(), hdrs, "33611d6d-2826-4c3e-a777-3f0397e283fc")
 
 	assert.Error(err)
 	assert.Equal("http error", err.Error())
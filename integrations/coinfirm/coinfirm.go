@@ -1,6 +1,7 @@
 package coinfirm
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
@@ -10,7 +11,7 @@ import (
 	"modulus/kyc/integrations/coinfirm/model"
 )
 
-var _ common.KYCPlatform = Coinfirm{}
+var _ common.KYCPlatformContext = Coinfirm{}
 
 // Coinfirm represents the Coinfirm API client.
 type Coinfirm struct {
@@ -27,6 +28,11 @@ func New(config Config) Coinfirm {
 
 // CheckCustomer implements KYCPlatform interface for the Coinfirm.
 func (c Coinfirm) CheckCustomer(customer *common.UserData) (res common.KYCResult, err error) {
+	return c.CheckCustomerContext(context.Background(), customer)
+}
+
+// CheckCustomerContext implements KYCPlatformContext interface for the Coinfirm.
+func (c Coinfirm) CheckCustomerContext(ctx context.Context, customer *common.UserData) (res common.KYCResult, err error) {
 	if customer == nil {
 		err = errors.New("customer is absent or no data received")
 		return
@@ -36,7 +42,7 @@ func (c Coinfirm) CheckCustomer(customer *common.UserData) (res common.KYCResult
 
 	headers := headers()
 
-	token, code, err := c.newAuthToken(headers)
+	token, code, err := c.newAuthToken(ctx, headers)
 	if err != nil {
 		if code != nil {
 			res.ErrorCode = strconv.Itoa(*code)
@@ -51,7 +57,7 @@ func (c Coinfirm) CheckCustomer(customer *common.UserData) (res common.KYCResult
 		Email: customer.Email,
 	}
 
-	participant, code, err := c.newParticipant(headers, newParticipant)
+	participant, code, err := c.newParticipant(ctx, headers, newParticipant)
 	if err != nil {
 		if code != nil {
 			res.ErrorCode = strconv.Itoa(*code)
@@ -60,7 +66,7 @@ func (c Coinfirm) CheckCustomer(customer *common.UserData) (res common.KYCResult
 		return
 	}
 
-	code, err = c.sendParticipantDetails(headers, participant.UUID, details)
+	code, err = c.sendParticipantDetails(ctx, headers, participant.UUID, details)
 	if err != nil {
 		if code != nil {
 			res.ErrorCode = strconv.Itoa(*code)
@@ -71,7 +77,7 @@ func (c Coinfirm) CheckCustomer(customer *common.UserData) (res common.KYCResult
 
 	// We wouldn't use parallel upload due to possible throttling as we upload from the same IP.
 	for _, docfile := range docfiles {
-		code, err = c.sendDocFile(headers, participant.UUID, &docfile)
+		code, err = c.sendDocFile(ctx, headers, participant.UUID, &docfile)
 		if err != nil {
 			if code != nil {
 				res.ErrorCode = strconv.Itoa(*code)
@@ -81,7 +87,7 @@ func (c Coinfirm) CheckCustomer(customer *common.UserData) (res common.KYCResult
 		}
 	}
 
-	status, code, err := c.getParticipantCurrentStatus(headers, participant.UUID)
+	status, code, err := c.getParticipantCurrentStatus(ctx, headers, participant.UUID)
 	if err != nil {
 		if code != nil {
 			res.ErrorCode = strconv.Itoa(*code)
@@ -97,9 +103,14 @@ func (c Coinfirm) CheckCustomer(customer *common.UserData) (res common.KYCResult
 
 // CheckStatus implements KYCPlatform interface for the Coinfirm.
 func (c Coinfirm) CheckStatus(pID string) (res common.KYCResult, err error) {
+	return c.CheckStatusContext(context.Background(), pID)
+}
+
+// CheckStatusContext implements KYCPlatformContext interface for the Coinfirm.
+func (c Coinfirm) CheckStatusContext(ctx context.Context, pID string) (res common.KYCResult, err error) {
 	headers := headers()
 
-	token, code, err := c.newAuthToken(headers)
+	token, code, err := c.newAuthToken(ctx, headers)
 	if err != nil {
 		if code != nil {
 			res.ErrorCode = strconv.Itoa(*code)
@@ -110,7 +121,7 @@ func (c Coinfirm) CheckStatus(pID string) (res common.KYCResult, err error) {
 
 	headers["Authorization"] = "Bearer " + token.Token
 
-	status, code, err := c.getParticipantCurrentStatus(headers, pID)
+	status, code, err := c.getParticipantCurrentStatus(ctx, headers, pID)
 	if err != nil {
 		if code != nil {
 			res.ErrorCode = strconv.Itoa(*code)
@@ -1,9 +1,12 @@
 package coinfirm
 
+import "modulus/kyc/http"
+
 // Config represents the Coinfirm API client config.
 type Config struct {
-	Host     string
-	Email    string
-	Password string
-	Company  string
+	Host       string
+	Email      string
+	Password   string
+	Company    string
+	HTTPClient *http.Client
 }
@@ -0,0 +1,31 @@
+package coinfirm
+
+import (
+	"modulus/kyc/common"
+	"modulus/kyc/http"
+)
+
+func init() {
+	common.RegisterProvider(common.ProviderSpec{
+		Name:    common.Coinfirm,
+		Options: []string{"Host", "Email", "Password", "Company"},
+		Capabilities: common.ProviderCapabilities{
+			StatusPolling: true,
+			Documents:     true,
+			Companies:     true,
+		},
+		Factory: func(options map[string]string) (common.KYCPlatformContext, error) {
+			client, err := http.NewClientFromOptions(options)
+			if err != nil {
+				return nil, err
+			}
+			return New(Config{
+				Host:       options["Host"],
+				Email:      options["Email"],
+				Password:   options["Password"],
+				Company:    options["Company"],
+				HTTPClient: client,
+			}), nil
+		},
+	})
+}
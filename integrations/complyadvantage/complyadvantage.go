@@ -1,6 +1,7 @@
 package complyadvantage
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,7 +11,7 @@ import (
 	"modulus/kyc/http"
 )
 
-var _ common.KYCPlatform = ComplyAdvantage{}
+var _ common.KYCPlatformContext = ComplyAdvantage{}
 
 // ComplyAdvantage represents the ComplyAdvantage KYC service.
 type ComplyAdvantage struct {
@@ -26,8 +27,13 @@ func New(config Config) ComplyAdvantage {
 
 // CheckCustomer implements KYCPlatform interface for the ComplyAdvantage.
 func (c ComplyAdvantage) CheckCustomer(customer *common.UserData) (result common.KYCResult, err error) {
+	return c.CheckCustomerContext(context.Background(), customer)
+}
+
+// CheckCustomerContext implements KYCPlatformContext interface for the ComplyAdvantage.
+func (c ComplyAdvantage) CheckCustomerContext(ctx context.Context, customer *common.UserData) (result common.KYCResult, err error) {
 	r := c.newRequest(customer)
-	resp, status, err := c.performSearch(r)
+	resp, status, err := c.performSearch(ctx, r)
 	if err != nil {
 		if status != nil {
 			result.ErrorCode = fmt.Sprintf("%d", *status)
@@ -41,7 +47,7 @@ func (c ComplyAdvantage) CheckCustomer(customer *common.UserData) (result common
 }
 
 // performSearch performs a search request to the ComplyAdvantage API.
-func (c ComplyAdvantage) performSearch(r Request) (response Response, status *int, err error) {
+func (c ComplyAdvantage) performSearch(ctx context.Context, r Request) (response Response, status *int, err error) {
 	body, err := json.Marshal(r)
 	if err != nil {
 		return
@@ -52,7 +58,7 @@ func (c ComplyAdvantage) performSearch(r Request) (response Response, status *in
 		"Authorization": "Token " + c.config.APIkey,
 	}
 
-	code, resp, err := http.Post(c.config.Host+"/searches", headers, body)
+	code, resp, err := c.config.HTTPClient.PostContext(ctx, c.config.Host+"/searches", headers, body)
 	if err != nil {
 		return
 	}
@@ -79,6 +85,11 @@ func (c ComplyAdvantage) performSearch(r Request) (response Response, status *in
 
 // CheckStatus implements KYCPlatform interface for the ComplyAdvantage.
 func (c ComplyAdvantage) CheckStatus(referenceID string) (res common.KYCResult, err error) {
+	return c.CheckStatusContext(context.Background(), referenceID)
+}
+
+// CheckStatusContext implements KYCPlatformContext interface for the ComplyAdvantage.
+func (c ComplyAdvantage) CheckStatusContext(ctx context.Context, referenceID string) (res common.KYCResult, err error) {
 	err = errors.New("ComplyAdvantage doesn't support a verification status check")
 	return
 }
@@ -1,8 +1,11 @@
 package complyadvantage
 
+import "modulus/kyc/http"
+
 // Config represents the service config.
 type Config struct {
-	Host      string
-	APIkey    string
-	Fuzziness float32
+	Host       string
+	APIkey     string
+	Fuzziness  float32
+	HTTPClient *http.Client
 }
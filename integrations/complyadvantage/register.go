@@ -0,0 +1,32 @@
+package complyadvantage
+
+import (
+	"fmt"
+	"strconv"
+
+	"modulus/kyc/common"
+	"modulus/kyc/http"
+)
+
+func init() {
+	common.RegisterProvider(common.ProviderSpec{
+		Name:    common.ComplyAdvantage,
+		Options: []string{"Host", "APIkey", "Fuzziness"},
+		Factory: func(options map[string]string) (common.KYCPlatformContext, error) {
+			client, err := http.NewClientFromOptions(options)
+			if err != nil {
+				return nil, err
+			}
+			fuzziness, err := strconv.ParseFloat(options["Fuzziness"], 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid option 'Fuzziness': %s", err)
+			}
+			return New(Config{
+				Host:       options["Host"],
+				APIkey:     options["APIkey"],
+				Fuzziness:  float32(fuzziness),
+				HTTPClient: client,
+			}), nil
+		},
+	})
+}
@@ -140,29 +140,83 @@ type MatchTypesDetails struct {
 
 // toResult processes the response and generates the verification result.
 func (r Response) toResult() (result common.KYCResult, err error) {
+	result.Signals = r.signals()
+	result.Reference = r.Content.Data.Ref
+
 	if r.Content.Data.TotalHits == 0 {
 		result.Status = common.Approved
 		return
 	}
 
-	reasons := []string{fmt.Sprintf("Search ID: %d", r.Content.Data.ID)}
+	details := &common.KYCDetails{}
+	details.AddReason("search_id", fmt.Sprintf("Search ID: %d", r.Content.Data.ID))
 
 	for _, h := range r.Content.Data.Hits {
 		if h.Doc.EntityType != "person" {
 			continue
 		}
 
-		reasons = append(reasons, "[Name: "+h.Doc.Name+"] Match types: "+strings.Join(h.MatchTypes, "|"))
+		details.AddReason("hit", "[Name: "+h.Doc.Name+"] Match types: "+strings.Join(h.MatchTypes, "|"))
 	}
 
-	if len(reasons) == 1 {
-		reasons = append(reasons, "Possible false positive. Please, inspect case details on the ComplyAdvantage site.")
+	if len(details.Reasons) == 1 {
+		details.AddReason("possible_false_positive", "Possible false positive. Please, inspect case details on the ComplyAdvantage site.")
 	}
 
 	result.Status = common.Denied
-	result.Details = &common.KYCDetails{
-		Reasons: reasons,
-	}
+	result.Details = details
 
 	return
 }
+
+// signals returns the search data the decision rules are evaluated on.
+//
+// * hits is the total number of the hits;
+// * hitTypes lists the unique types of the person hits, e.g. "sanction", "pep", "adverse-media";
+// * matchTypes lists the unique match types of the person hits, e.g. "name_exact", "year_of_birth";
+// * sanctionsMatch reports whether any person hit is a sanction;
+// * dobMatch reports whether the year of birth matched in any person hit;
+// * riskLevel is the risk level of the search.
+func (r Response) signals() common.Signals {
+	hitTypes := []string{}
+	matchTypes := []string{}
+
+	for _, h := range r.Content.Data.Hits {
+		if h.Doc.EntityType != "person" {
+			continue
+		}
+		hitTypes = appendUnique(hitTypes, h.Doc.Types...)
+		matchTypes = appendUnique(matchTypes, h.MatchTypes...)
+	}
+
+	return common.Signals{
+		"hits":           r.Content.Data.TotalHits,
+		"hitTypes":       hitTypes,
+		"matchTypes":     matchTypes,
+		"sanctionsMatch": contains(hitTypes, "sanction"),
+		"dobMatch":       contains(matchTypes, "year_of_birth"),
+		"riskLevel":      r.Content.Data.RiskLevel,
+	}
+}
+
+// appendUnique appends the values missing in the list.
+func appendUnique(list []string, values ...string) []string {
+	for _, v := range values {
+		if !contains(list, v) {
+			list = append(list, v)
+		}
+	}
+
+	return list
+}
+
+// contains reports whether the list contains the value.
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -40,3 +40,54 @@ func TestToResult(t *testing.T) {
 	assert.Nil(res.StatusCheck)
 
 }
+
+func TestToResultSignals(t *testing.T) {
+	r := Response{
+		Content: &Content{
+			Data: Data{
+				ID:        123,
+				TotalHits: 3,
+				RiskLevel: "high",
+				Hits: []Hit{
+					Hit{
+						Doc:        Doc{EntityType: "person", Name: "John Doe", Types: []string{"sanction", "pep"}},
+						MatchTypes: []string{"name_exact", "year_of_birth"},
+					},
+					Hit{
+						Doc:        Doc{EntityType: "person", Name: "Jon Doe", Types: []string{"adverse-media", "pep"}},
+						MatchTypes: []string{"name_fuzzy"},
+					},
+					Hit{
+						Doc:        Doc{EntityType: "organisation", Types: []string{"warning"}},
+						MatchTypes: []string{"name_exact"},
+					},
+				},
+			},
+		},
+	}
+
+	res, err := r.toResult()
+
+	assert := assert.New(t)
+
+	assert.Nil(err)
+	assert.Equal(common.Denied, res.Status)
+	assert.Equal(common.Signals{
+		"hits":           3,
+		"hitTypes":       []string{"sanction", "pep", "adverse-media"},
+		"matchTypes":     []string{"name_exact", "year_of_birth", "name_fuzzy"},
+		"sanctionsMatch": true,
+		"dobMatch":       true,
+		"riskLevel":      "high",
+	}, res.Signals)
+
+	// Testing no hits.
+	r.Content.Data = Data{}
+
+	res, err = r.toResult()
+
+	assert.Nil(err)
+	assert.Equal(common.Approved, res.Status)
+	assert.Equal(0, res.Signals["hits"])
+	assert.Equal(false, res.Signals["sanctionsMatch"])
+}
@@ -1,13 +1,14 @@
 package example
 
 import (
+	"context"
 	"errors"
 	"time"
 
 	"modulus/kyc/common"
 )
 
-var _ common.KYCPlatform = Example{}
+var _ common.KYCPlatformContext = Example{}
 
 // Example represents the example KYC provider.
 type Example struct{}
@@ -43,6 +44,15 @@ func (ex Example) CheckCustomer(customer *common.UserData) (res common.KYCResult
 	return
 }
 
+// CheckCustomerContext implements KYCPlatformContext interface for the example KYC provider.
+func (ex Example) CheckCustomerContext(ctx context.Context, customer *common.UserData) (res common.KYCResult, err error) {
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	return ex.CheckCustomer(customer)
+}
+
 // CheckStatus implements KYCPlatform interface for the example KYC provider.
 func (ex Example) CheckStatus(referenceID string) (res common.KYCResult, err error) {
 	switch referenceID {
@@ -62,6 +72,15 @@ func (ex Example) CheckStatus(referenceID string) (res common.KYCResult, err err
 	return
 }
 
+// CheckStatusContext implements KYCPlatformContext interface for the example KYC provider.
+func (ex Example) CheckStatusContext(ctx context.Context, referenceID string) (res common.KYCResult, err error) {
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	return ex.CheckStatus(referenceID)
+}
+
 func errorResult() (res common.KYCResult) {
 	res.Details = &common.KYCDetails{
 		Reasons: []string{
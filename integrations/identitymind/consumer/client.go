@@ -1,6 +1,7 @@
 package consumer
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -24,6 +25,7 @@ const (
 type Client struct {
 	host        string
 	credentials string
+	client      *http.Client
 }
 
 // NewClient constructs new client object.
@@ -31,11 +33,17 @@ func NewClient(config Config) Client {
 	return Client{
 		host:        config.Host,
 		credentials: "Basic " + base64.StdEncoding.EncodeToString([]byte(config.Username+":"+config.Password)),
+		client:      config.HTTPClient,
 	}
 }
 
 // CheckCustomer implements customer verification using IdentityMind API.
 func (c Client) CheckCustomer(customer *common.UserData) (result common.KYCResult, err error) {
+	return c.CheckCustomerContext(context.Background(), customer)
+}
+
+// CheckCustomerContext is like CheckCustomer but uses the context to cancel the verification.
+func (c Client) CheckCustomerContext(ctx context.Context, customer *common.UserData) (result common.KYCResult, err error) {
 	if customer == nil {
 		err = errors.New("no customer supplied")
 		return
@@ -53,7 +61,7 @@ func (c Client) CheckCustomer(customer *common.UserData) (result common.KYCResul
 		return
 	}
 
-	response, errorCode, err := c.sendRequest(body)
+	response, errorCode, err := c.sendRequest(ctx, body)
 	if err != nil {
 		if errorCode != nil {
 			result.ErrorCode = fmt.Sprintf("%d", *errorCode)
@@ -69,13 +77,13 @@ func (c Client) CheckCustomer(customer *common.UserData) (result common.KYCResul
 
 // sendRequest sends a vefirication request into the API.
 // It returns a response from the API or the error if occured.
-func (c Client) sendRequest(body []byte) (response *ApplicationResponseData, errorCode *int, err error) {
+func (c Client) sendRequest(ctx context.Context, body []byte) (response *ApplicationResponseData, errorCode *int, err error) {
 	headers := http.Headers{
 		"Content-Type":  contentType,
 		"Authorization": c.credentials,
 	}
 
-	status, resp, err := http.Post(c.host+consumerEndpoint, headers, body)
+	status, resp, err := c.client.PostContext(ctx, c.host+consumerEndpoint, headers, body)
 	if err != nil {
 		return
 	}
@@ -97,11 +105,16 @@ func (c Client) sendRequest(body []byte) (response *ApplicationResponseData, err
 // CheckStatus queries IDM API for the current state of a consumer KYC.
 // If the application is not found then an error message is provided in the response.
 func (c Client) CheckStatus(referenceID string) (result common.KYCResult, err error) {
+	return c.CheckStatusContext(context.Background(), referenceID)
+}
+
+// CheckStatusContext is like CheckStatus but uses the context to cancel the status check.
+func (c Client) CheckStatusContext(ctx context.Context, referenceID string) (result common.KYCResult, err error) {
 	headers := http.Headers{
 		"Authorization": c.credentials,
 	}
 
-	status, resp, err := http.Get(c.host+stateRetrievalEndpoint+referenceID, headers)
+	status, resp, err := c.client.GetContext(ctx, c.host+stateRetrievalEndpoint+referenceID, headers)
 	if err != nil {
 		err = fmt.Errorf("during sending request: %s", err)
 		return
@@ -1,6 +1,7 @@
 package consumer
 
 import (
+	"context"
 	"encoding/base64"
 	"net/http"
 
@@ -648,7 +649,7 @@ var _ = Describe("Client", func() {
 
 			httpmock.RegisterResponder(http.MethodPost, client.host+consumerEndpoint, httpmock.NewStringResponder(http.StatusOK, malformedResponse))
 
-			resp, errorCode, err := client.sendRequest([]byte{})
+			resp, errorCode, err := client.sendRequest(context.Background(), []byte{})
 
 			Expect(resp).ToNot(BeNil())
 			Expect(errorCode).To(BeNil())
@@ -660,7 +661,7 @@ var _ = Describe("Client", func() {
 
 			httpmock.RegisterResponder(http.MethodPost, client.host+consumerEndpoint, httpmock.NewStringResponder(http.StatusOK, acceptedResponse))
 
-			resp, errorCode, err := client.sendRequest([]byte{})
+			resp, errorCode, err := client.sendRequest(context.Background(), []byte{})
 
 			Expect(resp).ToNot(BeNil())
 			Expect(errorCode).To(BeNil())
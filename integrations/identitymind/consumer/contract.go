@@ -1,8 +1,11 @@
 package consumer
 
+import "modulus/kyc/http"
+
 // Config holds configuration settings for the service.
 type Config struct {
-	Host     string
-	Username string
-	Password string
+	Host       string
+	Username   string
+	Password   string
+	HTTPClient *http.Client
 }
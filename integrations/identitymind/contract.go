@@ -1,6 +1,9 @@
 package identitymind
 
-import "modulus/kyc/integrations/identitymind/consumer"
+import (
+	"modulus/kyc/http"
+	"modulus/kyc/integrations/identitymind/consumer"
+)
 
 // IdentityMind API urls for the convenience.
 const (
@@ -11,9 +14,10 @@ const (
 
 // Config holds configuration settings for the service.
 type Config struct {
-	Host     string
-	Username string
-	Password string
+	Host       string
+	Username   string
+	Password   string
+	HTTPClient *http.Client
 }
 
 var _ *Config = (*Config)(&consumer.Config{})
@@ -1,12 +1,14 @@
 package identitymind
 
 import (
+	"context"
+
 	"modulus/kyc/common"
 	"modulus/kyc/integrations/identitymind/consumer"
 )
 
-// Assert that IdentityMind implements the KYCPlatform interface.
-var _ common.KYCPlatform = IdentityMind{}
+// Assert that IdentityMind implements the KYCPlatformContext interface.
+var _ common.KYCPlatformContext = IdentityMind{}
 
 // IdentityMind defines the model for the IdentityMind services.
 // It shouldn't be instantiated directly.
@@ -28,8 +30,20 @@ func (i IdentityMind) CheckCustomer(customer *common.UserData) (res common.KYCRe
 	return
 }
 
+// CheckCustomerContext implements KYCPlatformContext interface for the IdentityMind.
+func (i IdentityMind) CheckCustomerContext(ctx context.Context, customer *common.UserData) (res common.KYCResult, err error) {
+	res, err = i.consumer.CheckCustomerContext(ctx, customer)
+	return
+}
+
 // CheckStatus implements KYCPlatform interface for the IdentityMind.
 func (i IdentityMind) CheckStatus(referenceID string) (res common.KYCResult, err error) {
 	res, err = i.consumer.CheckStatus(referenceID)
 	return
 }
+
+// CheckStatusContext implements KYCPlatformContext interface for the IdentityMind.
+func (i IdentityMind) CheckStatusContext(ctx context.Context, referenceID string) (res common.KYCResult, err error) {
+	res, err = i.consumer.CheckStatusContext(ctx, referenceID)
+	return
+}
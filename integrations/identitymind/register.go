@@ -0,0 +1,29 @@
+package identitymind
+
+import (
+	"modulus/kyc/common"
+	"modulus/kyc/http"
+)
+
+func init() {
+	common.RegisterProvider(common.ProviderSpec{
+		Name:    common.IdentityMind,
+		Options: []string{"Host", "Username", "Password"},
+		Capabilities: common.ProviderCapabilities{
+			StatusPolling: true,
+			Documents:     true,
+		},
+		Factory: func(options map[string]string) (common.KYCPlatformContext, error) {
+			client, err := http.NewClientFromOptions(options)
+			if err != nil {
+				return nil, err
+			}
+			return New(Config{
+				Host:       options["Host"],
+				Username:   options["Username"],
+				Password:   options["Password"],
+				HTTPClient: client,
+			}), nil
+		},
+	})
+}
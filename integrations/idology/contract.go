@@ -1,6 +1,7 @@
 package idology
 
 import (
+	"modulus/kyc/http"
 	"modulus/kyc/integrations/idology/expectid"
 )
 
@@ -15,6 +16,7 @@ type Config struct {
 	Username         string
 	Password         string
 	UseSummaryResult bool
+	HTTPClient       *http.Client
 }
 
 var _ *Config = (*Config)(&expectid.Config{})
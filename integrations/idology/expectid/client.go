@@ -1,6 +1,7 @@
 package expectid
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -23,6 +24,11 @@ func NewClient(config Config) Client {
 
 // CheckCustomer implements customer verification using IDology API.
 func (c Client) CheckCustomer(customer *common.UserData) (result common.KYCResult, err error) {
+	return c.CheckCustomerContext(context.Background(), customer)
+}
+
+// CheckCustomerContext is like CheckCustomer but uses the context to cancel the verification.
+func (c Client) CheckCustomerContext(ctx context.Context, customer *common.UserData) (result common.KYCResult, err error) {
 	if customer == nil {
 		err = errors.New("no customer supplied")
 		return
@@ -30,7 +36,7 @@ func (c Client) CheckCustomer(customer *common.UserData) (result common.KYCResul
 
 	requestBody := c.makeRequestBody(customer)
 
-	response, err := c.sendRequest(requestBody)
+	response, err := c.sendRequest(ctx, requestBody)
 	if err != nil {
 		return
 	}
@@ -1,9 +1,12 @@
 package expectid
 
+import "modulus/kyc/http"
+
 // Config holds configuration settings for the IDology ExpectID® API client.
 type Config struct {
 	Host             string
 	Username         string
 	Password         string
 	UseSummaryResult bool
+	HTTPClient       *http.Client
 }
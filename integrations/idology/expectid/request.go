@@ -1,6 +1,7 @@
 package expectid
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 	"net/url"
@@ -13,12 +14,12 @@ import (
 // sendRequest sends a vefirication request into the API.
 // It expects an url-encoded request body as the param.
 // It returns a response from the API or the error if occured.
-func (c Client) sendRequest(requestBody string) (resp *Response, err error) {
+func (c Client) sendRequest(ctx context.Context, requestBody string) (resp *Response, err error) {
 	headers := http.Headers{
 		"Content-Type": "application/x-www-form-urlencoded",
 	}
 
-	_, response, err := http.Post(c.config.Host, headers, []byte(requestBody))
+	_, response, err := c.config.HTTPClient.PostContext(ctx, c.config.Host, headers, []byte(requestBody))
 	if err != nil {
 		return
 	}
@@ -3,6 +3,7 @@ package expectid
 import (
 	"encoding/xml"
 	"fmt"
+	"strconv"
 
 	"modulus/kyc/common"
 )
@@ -90,19 +91,55 @@ func (r *Response) toResult(useSummaryResult bool) (result common.KYCResult, err
 
 	if r.Restriction != nil {
 		detailsCreateIfNil(&result.Details)
-		result.Details.Reasons = []string{
-			r.Restriction.Message,
-			r.Restriction.PatriotAct.List,
-			fmt.Sprintf("Patriot Act score: %d", r.Restriction.PatriotAct.Score),
-		}
+		result.Details.AddReason(r.Restriction.Key, r.Restriction.Message)
+		result.Details.AddReason("patriot_act.list", r.Restriction.PatriotAct.List)
+		result.Details.AddReason("patriot_act.score", fmt.Sprintf("Patriot Act score: %d", r.Restriction.PatriotAct.Score))
 	}
 
 	if r.Qualifiers != nil {
 		detailsCreateIfNil(&result.Details)
 		for _, q := range r.Qualifiers.Qualifiers {
-			result.Details.Reasons = append(result.Details.Reasons, q.Message)
+			result.Details.AddReason(q.Key, q.Message)
 		}
 	}
 
+	result.Signals = r.signals()
+	if r.IDNumber != 0 {
+		result.Reference = strconv.Itoa(r.IDNumber)
+	}
+
 	return
 }
+
+// signals returns the response data the decision rules are evaluated on.
+//
+// * summaryResult is the key of the summary result, e.g. "id.success";
+// * results is the key of the results, e.g. "result.match";
+// * qualifiers lists the keys of the qualifiers;
+// * restriction is the key of the restriction or empty;
+// * patriotActList is the name of the Patriot Act list matched or empty;
+// * patriotActScore is the Patriot Act score.
+func (r *Response) signals() common.Signals {
+	qualifiers := []string{}
+	if r.Qualifiers != nil {
+		for _, q := range r.Qualifiers.Qualifiers {
+			qualifiers = append(qualifiers, q.Key)
+		}
+	}
+
+	signals := common.Signals{
+		"summaryResult":   string(r.SummaryResult.Key),
+		"results":         string(r.Results.Key),
+		"qualifiers":      qualifiers,
+		"restriction":     "",
+		"patriotActList":  "",
+		"patriotActScore": 0,
+	}
+	if r.Restriction != nil {
+		signals["restriction"] = r.Restriction.Key
+		signals["patriotActList"] = r.Restriction.PatriotAct.List
+		signals["patriotActScore"] = r.Restriction.PatriotAct.Score
+	}
+
+	return signals
+}
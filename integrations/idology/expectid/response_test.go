@@ -116,6 +116,14 @@ var _ = Describe("Response", func() {
 				Expect(result.Details.Finality).To(Equal(common.Unknown))
 				Expect(result.Details.Reasons).To(HaveLen(1))
 				Expect(result.Details.Reasons[0]).To(Equal("COPPA Alert"))
+				Expect(result.Signals).To(Equal(common.Signals{
+					"summaryResult":   "id.failure",
+					"results":         "result.match.restricted",
+					"qualifiers":      []string{"resultcode.coppa.alert"},
+					"restriction":     "",
+					"patriotActList":  "",
+					"patriotActScore": 0,
+				}))
 				Expect(err).NotTo(HaveOccurred())
 			})
 
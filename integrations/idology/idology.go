@@ -1,13 +1,15 @@
 package idology
 
 import (
+	"context"
 	"errors"
+
 	"modulus/kyc/common"
 	"modulus/kyc/integrations/idology/expectid"
 )
 
 // Assert that Service implements the CustomerChecker interface.
-var _ common.KYCPlatform = IDology{}
+var _ common.KYCPlatformContext = IDology{}
 
 // IDology represents the IDology API services.
 // It shouldn't be instantiated directly.
@@ -29,8 +31,19 @@ func (i IDology) CheckCustomer(customer *common.UserData) (res common.KYCResult,
 	return
 }
 
+// CheckCustomerContext implements KYCPlatformContext interface for the IDology.
+func (i IDology) CheckCustomerContext(ctx context.Context, customer *common.UserData) (res common.KYCResult, err error) {
+	res, err = i.expectID.CheckCustomerContext(ctx, customer)
+	return
+}
+
 // CheckStatus implements KYCPlatform interface for the IDology.
 func (i IDology) CheckStatus(referenceID string) (res common.KYCResult, err error) {
+	return i.CheckStatusContext(context.Background(), referenceID)
+}
+
+// CheckStatusContext implements KYCPlatformContext interface for the IDology.
+func (i IDology) CheckStatusContext(ctx context.Context, referenceID string) (res common.KYCResult, err error) {
 	err = errors.New("IDology doesn't support a verification status check")
 	return
 }
@@ -0,0 +1,33 @@
+package idology
+
+import (
+	"fmt"
+	"strconv"
+
+	"modulus/kyc/common"
+	"modulus/kyc/http"
+)
+
+func init() {
+	common.RegisterProvider(common.ProviderSpec{
+		Name:    common.IDology,
+		Options: []string{"Host", "Username", "Password", "UseSummaryResult"},
+		Factory: func(options map[string]string) (common.KYCPlatformContext, error) {
+			client, err := http.NewClientFromOptions(options)
+			if err != nil {
+				return nil, err
+			}
+			useSummaryResult, err := strconv.ParseBool(options["UseSummaryResult"])
+			if err != nil {
+				return nil, fmt.Errorf("invalid option 'UseSummaryResult': %s", err)
+			}
+			return New(Config{
+				Host:             options["Host"],
+				Username:         options["Username"],
+				Password:         options["Password"],
+				UseSummaryResult: useSummaryResult,
+				HTTPClient:       client,
+			}), nil
+		},
+	})
+}
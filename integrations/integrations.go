@@ -0,0 +1,18 @@
+// Package integrations links all implemented KYC providers into the service.
+// Every provider registers itself in the common provider registry upon the package initialization.
+// Import this package for side effects to make the providers available.
+package integrations
+
+import (
+	// Add the import of a new integration here to make it available for the service.
+	_ "modulus/kyc/integrations/coinfirm"
+	_ "modulus/kyc/integrations/complyadvantage"
+	_ "modulus/kyc/integrations/identitymind"
+	_ "modulus/kyc/integrations/idology"
+	_ "modulus/kyc/integrations/jumio"
+	_ "modulus/kyc/integrations/shuftipro"
+	_ "modulus/kyc/integrations/sumsub"
+	_ "modulus/kyc/integrations/synapsefi"
+	_ "modulus/kyc/integrations/thomsonreuters"
+	_ "modulus/kyc/integrations/trulioo"
+)
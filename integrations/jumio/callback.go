@@ -0,0 +1,63 @@
+package jumio
+
+import (
+	"crypto/subtle"
+	"errors"
+	stdhttp "net/http"
+
+	"modulus/kyc/common"
+)
+
+// CallbackTokenParam is the query parameter of the callback url holding the callback token,
+// e.g. /Callback/Jumio?token=<CallbackToken>.
+const CallbackTokenParam = "token"
+
+var _ common.KYCCallbackReceiver = Jumio{}
+
+// Callback represents the fields of the Netverify callback used by the service.
+// The callback is sent as the HTML form.
+type Callback struct {
+	// Jumio's reference number of the scan.
+	JumioIDScanReference string
+	// Verification status of the scan, e.g. APPROVED_VERIFIED.
+	VerificationStatus DocumentStatus
+	// Status of the scan: SUCCESS or ERROR.
+	IDScanStatus string
+}
+
+// ParseCallback implements KYCCallbackReceiver interface for Jumio.
+// Jumio doesn't sign callbacks, so the callback url holds the secret callback token and the payload isn't trusted.
+// The scan details are retrieved from the API instead to make sure the result is genuine.
+// The API isn't called for the callback without the valid token.
+func (j Jumio) ParseCallback(r *stdhttp.Request) (referenceID string, result common.KYCResult, err error) {
+	if len(j.callbackToken) == 0 {
+		err = errors.New("the callback token isn't configured")
+		return
+	}
+
+	token := r.URL.Query().Get(CallbackTokenParam)
+	if subtle.ConstantTimeCompare([]byte(token), []byte(j.callbackToken)) != 1 {
+		err = common.ErrCallbackSignature
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		err = common.CallbackPayloadError{Err: err}
+		return
+	}
+
+	callback := Callback{
+		JumioIDScanReference: r.PostForm.Get("jumioIdScanReference"),
+		VerificationStatus:   DocumentStatus(r.PostForm.Get("verificationStatus")),
+		IDScanStatus:         r.PostForm.Get("idScanStatus"),
+	}
+	if len(callback.JumioIDScanReference) == 0 {
+		err = common.CallbackPayloadError{Err: errors.New("missing jumioIdScanReference")}
+		return
+	}
+
+	referenceID = callback.JumioIDScanReference
+	result, err = j.CheckStatusContext(r.Context(), callback.JumioIDScanReference)
+
+	return
+}
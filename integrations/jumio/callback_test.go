@@ -0,0 +1,108 @@
+package jumio
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	"modulus/kyc/common"
+
+	"gopkg.in/jarcoal/httpmock.v1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseCallback", func() {
+	var service = New(Config{
+		BaseURL:       USbaseURL,
+		Token:         "test_token",
+		Secret:        "test_secret",
+		CallbackToken: "callback_token",
+	})
+
+	var (
+		referenceID            = "jumioID"
+		retrieveScanStatusURL  = USbaseURL + scanStatusEndpoint + referenceID
+		retrieveScanDetailsURL = fmt.Sprintf(USbaseURL+scanDetailsEndpoint, referenceID)
+	)
+
+	newCallback := func(form url.Values) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/Callback/Jumio?token=callback_token", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		return r
+	}
+
+	BeforeEach(func() {
+		httpmock.Activate()
+	})
+
+	AfterEach(func() {
+		httpmock.DeactivateAndReset()
+	})
+
+	It("should reject the callback without the valid token", func() {
+		for _, target := range []string{"/Callback/Jumio", "/Callback/Jumio?token=forged"} {
+			r := httptest.NewRequest(http.MethodPost, target, strings.NewReader(url.Values{"jumioIdScanReference": {referenceID}}.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			ref, _, err := service.ParseCallback(r)
+
+			Expect(err).To(Equal(common.ErrCallbackSignature))
+			Expect(ref).To(BeEmpty())
+		}
+
+		Expect(httpmock.GetTotalCallCount()).To(BeZero())
+	})
+
+	It("should reject the callbacks if the token isn't configured", func() {
+		service := New(Config{BaseURL: USbaseURL})
+
+		_, _, err := service.ParseCallback(newCallback(url.Values{"jumioIdScanReference": {referenceID}}))
+
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("the callback token isn't configured"))
+		Expect(httpmock.GetTotalCallCount()).To(BeZero())
+	})
+
+	It("should fail with missing scan reference", func() {
+		_, _, err := service.ParseCallback(newCallback(url.Values{
+			"verificationStatus": {"APPROVED_VERIFIED"},
+		}))
+
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(BeAssignableToTypeOf(common.CallbackPayloadError{}))
+		Expect(err.Error()).To(Equal("malformed callback payload: missing jumioIdScanReference"))
+	})
+
+	It("should take the result from the API instead of the payload", func() {
+		httpmock.RegisterResponder(http.MethodGet, retrieveScanStatusURL, httpmock.NewBytesResponder(http.StatusOK, []byte(`{"status":"DONE"}`)))
+		httpmock.RegisterResponder(http.MethodGet, retrieveScanDetailsURL, httpmock.NewBytesResponder(http.StatusOK, approvedResponse))
+
+		ref, result, err := service.ParseCallback(newCallback(url.Values{
+			"jumioIdScanReference": {referenceID},
+			"verificationStatus":   {"DENIED_FRAUD"},
+			"idScanStatus":         {"ERROR"},
+		}))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ref).To(Equal(referenceID))
+		Expect(result.Status).To(Equal(common.Approved))
+	})
+
+	It("should fail when the API call fails", func() {
+		httpmock.RegisterResponder(http.MethodGet, retrieveScanStatusURL, httpmock.NewBytesResponder(http.StatusNotFound, nil))
+
+		ref, result, err := service.ParseCallback(newCallback(url.Values{
+			"jumioIdScanReference": {referenceID},
+		}))
+
+		Expect(err).To(HaveOccurred())
+		Expect(err).NotTo(BeAssignableToTypeOf(common.CallbackPayloadError{}))
+		Expect(ref).To(Equal(referenceID))
+		Expect(result.ErrorCode).To(Equal("404"))
+	})
+})
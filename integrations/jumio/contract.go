@@ -1,5 +1,7 @@
 package jumio
 
+import "modulus/kyc/http"
+
 // Jumio performNetverify API endpoints.
 const (
 	USbaseURL = "https://netverify.com/api/netverify/v2"
@@ -8,7 +10,9 @@ const (
 
 // Config holds configuration settings for the service.
 type Config struct {
-	BaseURL string
-	Token   string
-	Secret  string
+	BaseURL       string
+	Token         string
+	Secret        string
+	CallbackToken string
+	HTTPClient    *http.Client
 }
@@ -1,6 +1,7 @@
 package jumio
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -12,24 +13,33 @@ import (
 	"modulus/kyc/http"
 )
 
-var _ common.KYCPlatform = Jumio{}
+var _ common.KYCPlatformContext = Jumio{}
 
 // Jumio defines the model for the Jumio performNetverify API.
 type Jumio struct {
-	baseURL     string
-	credentials string
+	baseURL       string
+	credentials   string
+	callbackToken string
+	client        *http.Client
 }
 
 // New constructs new service object to use with the Jumio performNetverify API.
 func New(config Config) Jumio {
 	return Jumio{
-		baseURL:     config.BaseURL,
-		credentials: "Basic " + base64.StdEncoding.EncodeToString([]byte(config.Token+":"+config.Secret)),
+		baseURL:       config.BaseURL,
+		credentials:   "Basic " + base64.StdEncoding.EncodeToString([]byte(config.Token+":"+config.Secret)),
+		callbackToken: config.CallbackToken,
+		client:        config.HTTPClient,
 	}
 }
 
 // CheckCustomer implements customer verification using the Jumio performNetverify API.
 func (j Jumio) CheckCustomer(customer *common.UserData) (result common.KYCResult, err error) {
+	return j.CheckCustomerContext(context.Background(), customer)
+}
+
+// CheckCustomerContext implements the KYCPlatformContext interface for Jumio.
+func (j Jumio) CheckCustomerContext(ctx context.Context, customer *common.UserData) (result common.KYCResult, err error) {
 	if customer == nil {
 		err = errors.New("no customer supplied")
 		return
@@ -41,7 +51,7 @@ func (j Jumio) CheckCustomer(customer *common.UserData) (result common.KYCResult
 		return
 	}
 
-	response, errorCode, err := j.sendRequest(req)
+	response, errorCode, err := j.sendRequest(ctx, req)
 	if err != nil {
 		if errorCode != nil {
 			result.ErrorCode = fmt.Sprintf("%d", *errorCode)
@@ -62,7 +72,7 @@ func (j Jumio) CheckCustomer(customer *common.UserData) (result common.KYCResult
 
 // sendRequest sends a vefirication request into the API.
 // It returns a response from the API or the error if occured.
-func (j Jumio) sendRequest(request *Request) (response *Response, errorCode *int, err error) {
+func (j Jumio) sendRequest(ctx context.Context, request *Request) (response *Response, errorCode *int, err error) {
 	body, err := json.Marshal(request)
 	if err != nil {
 		return
@@ -72,7 +82,7 @@ func (j Jumio) sendRequest(request *Request) (response *Response, errorCode *int
 	headers["Content-Type"] = contentType
 	headers["Content-Length"] = fmt.Sprintf("%d", len(body))
 
-	statusCode, resp, err := http.Post(j.baseURL+performNetverifyEndpoint, headers, body)
+	statusCode, resp, err := j.client.PostContext(ctx, j.baseURL+performNetverifyEndpoint, headers, body)
 	if err != nil {
 		return
 	}
@@ -95,12 +105,17 @@ func (j Jumio) sendRequest(request *Request) (response *Response, errorCode *int
 
 // CheckStatus implements the KYCPlatform interface for Jumio.
 func (j Jumio) CheckStatus(referenceID string) (result common.KYCResult, err error) {
+	return j.CheckStatusContext(context.Background(), referenceID)
+}
+
+// CheckStatusContext implements the KYCPlatformContext interface for Jumio.
+func (j Jumio) CheckStatusContext(ctx context.Context, referenceID string) (result common.KYCResult, err error) {
 	if len(referenceID) == 0 {
 		err = errors.New("empty Jumio’s reference number of an existing scan")
 		return
 	}
 
-	status, errorCode, err := j.retrieveScanStatus(referenceID)
+	status, errorCode, err := j.retrieveScanStatus(ctx, referenceID)
 	if err != nil {
 		if errorCode != nil {
 			result.ErrorCode = fmt.Sprintf("%d", *errorCode)
@@ -119,7 +134,7 @@ func (j Jumio) CheckStatus(referenceID string) (result common.KYCResult, err err
 		}
 	case DoneStatus, FailedStatus:
 		scanDetails := &DetailsResponse{}
-		scanDetails, errorCode, err = j.retrieveScanDetails(referenceID)
+		scanDetails, errorCode, err = j.retrieveScanDetails(ctx, referenceID)
 		if err != nil {
 			if errorCode != nil {
 				result.ErrorCode = fmt.Sprintf("%d", *errorCode)
@@ -136,8 +151,8 @@ func (j Jumio) CheckStatus(referenceID string) (result common.KYCResult, err err
 }
 
 // retrieveScanStatus retrieves the status of an Jumio scan.
-func (j Jumio) retrieveScanStatus(referenceID string) (status ScanStatus, errorCode *int, err error) {
-	statusCode, resp, err := http.Get(j.baseURL+scanStatusEndpoint+referenceID, j.headers())
+func (j Jumio) retrieveScanStatus(ctx context.Context, referenceID string) (status ScanStatus, errorCode *int, err error) {
+	statusCode, resp, err := j.client.GetContext(ctx, j.baseURL+scanStatusEndpoint+referenceID, j.headers())
 	if err != nil {
 		return
 	}
@@ -164,8 +179,8 @@ func (j Jumio) retrieveScanStatus(referenceID string) (status ScanStatus, errorC
 }
 
 // retrieveScanDetails retrieves details of an Jumio scan.
-func (j Jumio) retrieveScanDetails(referenceID string) (response *DetailsResponse, errorCode *int, err error) {
-	statusCode, resp, err := http.Get(fmt.Sprintf(j.baseURL+scanDetailsEndpoint, referenceID), j.headers())
+func (j Jumio) retrieveScanDetails(ctx context.Context, referenceID string) (response *DetailsResponse, errorCode *int, err error) {
+	statusCode, resp, err := j.client.GetContext(ctx, fmt.Sprintf(j.baseURL+scanDetailsEndpoint, referenceID), j.headers())
 	if err != nil {
 		return
 	}
@@ -0,0 +1,32 @@
+package jumio
+
+import (
+	"modulus/kyc/common"
+	"modulus/kyc/http"
+)
+
+func init() {
+	common.RegisterProvider(common.ProviderSpec{
+		Name:     common.Jumio,
+		Options:  []string{"BaseURL", "Token", "Secret"},
+		Optional: []string{"CallbackToken"},
+		Capabilities: common.ProviderCapabilities{
+			StatusPolling: true,
+			Callbacks:     true,
+			Documents:     true,
+		},
+		Factory: func(options map[string]string) (common.KYCPlatformContext, error) {
+			client, err := http.NewClientFromOptions(options)
+			if err != nil {
+				return nil, err
+			}
+			return New(Config{
+				BaseURL:       options["BaseURL"],
+				Token:         options["Token"],
+				Secret:        options["Secret"],
+				CallbackToken: options["CallbackToken"],
+				HTTPClient:    client,
+			}), nil
+		},
+	})
+}
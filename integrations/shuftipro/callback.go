@@ -0,0 +1,71 @@
+package shuftipro
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	stdhttp "net/http"
+	"time"
+
+	"modulus/kyc/common"
+)
+
+// signatureHeader is the header of the callback request holding the signature.
+const signatureHeader = "Signature"
+
+var _ common.KYCCallbackReceiver = ShuftiPro{}
+
+// ParseCallback implements KYCCallbackReceiver interface for the ShuftiPro.
+// The callback is signed with the SHA256 hash of the body concatenated with the secret key.
+func (s ShuftiPro) ParseCallback(r *stdhttp.Request) (referenceID string, result common.KYCResult, err error) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return
+	}
+
+	if !s.client.validSignature(body, r.Header.Get(signatureHeader)) {
+		err = common.ErrCallbackSignature
+		return
+	}
+
+	response := Response{}
+	if err = json.Unmarshal(body, &response); err != nil {
+		err = common.CallbackPayloadError{Err: err}
+		return
+	}
+	if len(response.Reference) == 0 {
+		err = common.CallbackPayloadError{Err: errors.New("missing reference")}
+		return
+	}
+
+	referenceID = response.Reference
+
+	switch response.Event {
+	case ReqPending, StatusChanged:
+		result.Status = common.Unclear
+		result.StatusCheck = &common.KYCStatusCheck{
+			Provider:    common.ShuftiPro,
+			ReferenceID: response.Reference,
+			LastCheck:   time.Now(),
+		}
+	default:
+		result = response.ToKYCResult()
+	}
+
+	return
+}
+
+// validSignature checks the signature of the callback body.
+func (c Client) validSignature(body []byte, signature string) bool {
+	if len(signature) == 0 {
+		return false
+	}
+
+	hash := sha256.Sum256(append(body, c.secretKey...))
+	expected := hex.EncodeToString(hash[:])
+
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(signature)) == 1
+}
@@ -0,0 +1,86 @@
+package shuftipro
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"modulus/kyc/common"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func signedCallback(body, secret string) *stdhttp.Request {
+	hash := sha256.Sum256([]byte(body + secret))
+
+	r := httptest.NewRequest(stdhttp.MethodPost, "/Callback/ShuftiPro", bytes.NewReader([]byte(body)))
+	r.Header.Set(signatureHeader, hex.EncodeToString(hash[:]))
+
+	return r
+}
+
+func TestParseCallback(t *testing.T) {
+	assert := assert.New(t)
+
+	s := New(Config{
+		Host:      "https://shuftipro.com/api/",
+		ClientID:  "client_id",
+		SecretKey: "secret_key",
+	})
+
+	// Testing accepted verification.
+	referenceID, result, err := s.ParseCallback(signedCallback(`{"reference":"ref1","event":"verification.accepted"}`, "secret_key"))
+
+	assert.NoError(err)
+	assert.Equal("ref1", referenceID)
+	assert.Equal(common.Approved, result.Status)
+	assert.Nil(result.StatusCheck)
+
+	// Testing declined verification.
+	referenceID, result, err = s.ParseCallback(signedCallback(`{"reference":"ref2","event":"verification.declined","declined_reason":"Face is not verified"}`, "secret_key"))
+
+	assert.NoError(err)
+	assert.Equal("ref2", referenceID)
+	assert.Equal(common.Denied, result.Status)
+	if assert.NotNil(result.Details) {
+		assert.Equal([]string{"Face is not verified"}, result.Details.Reasons)
+	}
+
+	// Testing pending verification.
+	referenceID, result, err = s.ParseCallback(signedCallback(`{"reference":"ref3","event":"request.pending"}`, "secret_key"))
+
+	assert.NoError(err)
+	assert.Equal("ref3", referenceID)
+	assert.Equal(common.Unclear, result.Status)
+	if assert.NotNil(result.StatusCheck) {
+		assert.Equal(common.ShuftiPro, result.StatusCheck.Provider)
+		assert.Equal("ref3", result.StatusCheck.ReferenceID)
+	}
+
+	// Testing invalid signature.
+	_, _, err = s.ParseCallback(signedCallback(`{"reference":"ref1","event":"verification.accepted"}`, "wrong_key"))
+
+	assert.Equal(common.ErrCallbackSignature, err)
+
+	// Testing missing signature.
+	r := httptest.NewRequest(stdhttp.MethodPost, "/Callback/ShuftiPro", bytes.NewReader([]byte(`{"reference":"ref1","event":"verification.accepted"}`)))
+
+	_, _, err = s.ParseCallback(r)
+
+	assert.Equal(common.ErrCallbackSignature, err)
+
+	// Testing malformed payload.
+	_, _, err = s.ParseCallback(signedCallback(`{"reference":`, "secret_key"))
+
+	assert.IsType(common.CallbackPayloadError{}, err)
+
+	// Testing missing reference.
+	_, _, err = s.ParseCallback(signedCallback(`{"event":"verification.accepted"}`, "secret_key"))
+
+	if assert.IsType(common.CallbackPayloadError{}, err) {
+		assert.Equal("malformed callback payload: missing reference", err.Error())
+	}
+}
@@ -1,6 +1,7 @@
 package shuftipro
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -21,6 +22,8 @@ type Client struct {
 	host        string
 	headers     http.Headers
 	callbackURL string
+	secretKey   string
+	client      *http.Client
 }
 
 // NewClient constructs new Client object.
@@ -32,11 +35,26 @@ func NewClient(config Config) Client {
 			"Authorization": "Basic " + base64.StdEncoding.EncodeToString([]byte(config.ClientID+":"+config.SecretKey)),
 		},
 		callbackURL: config.CallbackURL,
+		secretKey:   config.SecretKey,
+		client:      config.HTTPClient,
 	}
 }
 
 // CheckCustomer implements the KYCPlatform interface for the Client.
 func (c Client) CheckCustomer(customer *common.UserData) (res common.KYCResult, err error) {
+	return c.CheckCustomerContext(context.Background(), customer)
+}
+
+// verificationResult holds the outcome of the verification request.
+type verificationResult struct {
+	res common.KYCResult
+	err error
+}
+
+// CheckCustomerContext implements the KYCPlatformContext interface for the Client.
+// If the API doesn't respond within a minute, the request keeps running in the background
+// and the Unclear result is returned with the data required for the status check.
+func (c Client) CheckCustomerContext(ctx context.Context, customer *common.UserData) (res common.KYCResult, err error) {
 	req, err := c.NewRequest(customer)
 	if err != nil {
 		return
@@ -46,42 +64,24 @@ func (c Client) CheckCustomer(customer *common.UserData) (res common.KYCResult,
 		return
 	}
 
+	// The request must outlive the caller's context when the result is postponed by the timer.
+	// Otherwise, it's canceled along with the caller's context.
+	reqCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
 	timer := time.NewTimer(time.Minute)
-	done := make(chan struct{})
+	done := make(chan verificationResult, 1)
 
 	go func() {
-		defer close(done)
-
-		code, resp, err1 := http.Post(c.host, c.headers, body)
-		if err1 != nil {
-			err = err1
-			return
-		}
-		if code != stdhttp.StatusOK {
-			res.ErrorCode = strconv.Itoa(code)
-		}
-
-		response := Response{}
-		err = json.Unmarshal(resp, &response)
-		if err != nil {
-			return
-		}
+		defer cancel()
 
-		if code != stdhttp.StatusOK {
-			if _, ok := response.Error.(map[string]interface{}); !ok {
-				err = fmt.Errorf("%scheck the error code in the result", event2description[response.Event])
-				return
-			}
-			err = errorFromResponse(resp)
-			return
-		}
-
-		res = response.ToKYCResult()
+		res, err := c.verify(reqCtx, body)
+		done <- verificationResult{res: res, err: err}
 	}()
 
 	select {
-	case <-done:
+	case result := <-done:
 		timer.Stop()
+		res, err = result.res, result.err
 	case <-timer.C:
 		res.Status = common.Unclear
 		res.StatusCheck = &common.KYCStatusCheck{
@@ -89,13 +89,52 @@ func (c Client) CheckCustomer(customer *common.UserData) (res common.KYCResult,
 			ReferenceID: req.Reference,
 			LastCheck:   time.Now(),
 		}
+	case <-ctx.Done():
+		timer.Stop()
+		cancel()
+		err = ctx.Err()
 	}
 
 	return
 }
 
+// verify sends the verification request to the API and converts the response into the result.
+func (c Client) verify(ctx context.Context, body []byte) (res common.KYCResult, err error) {
+	code, resp, err := c.client.PostContext(ctx, c.host, c.headers, body)
+	if err != nil {
+		return
+	}
+	if code != stdhttp.StatusOK {
+		res.ErrorCode = strconv.Itoa(code)
+	}
+
+	response := Response{}
+	err = json.Unmarshal(resp, &response)
+	if err != nil {
+		return
+	}
+
+	if code != stdhttp.StatusOK {
+		if _, ok := response.Error.(map[string]interface{}); !ok {
+			err = fmt.Errorf("%scheck the error code in the result", event2description[response.Event])
+			return
+		}
+		err = errorFromResponse(resp)
+		return
+	}
+
+	res = response.ToKYCResult()
+
+	return
+}
+
 // CheckStatus implements the KYCPlatform interface for the Client.
 func (c Client) CheckStatus(referenceID string) (res common.KYCResult, err error) {
+	return c.CheckStatusContext(context.Background(), referenceID)
+}
+
+// CheckStatusContext implements the KYCPlatformContext interface for the Client.
+func (c Client) CheckStatusContext(ctx context.Context, referenceID string) (res common.KYCResult, err error) {
 	req := StatusRequest{
 		Reference: referenceID,
 	}
@@ -104,7 +143,7 @@ func (c Client) CheckStatus(referenceID string) (res common.KYCResult, err error
 		return
 	}
 
-	code, resp, err := http.Post(c.host+statusEndpoint, c.headers, body)
+	code, resp, err := c.client.PostContext(ctx, c.host+statusEndpoint, c.headers, body)
 	if err != nil {
 		return
 	}
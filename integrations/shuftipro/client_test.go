@@ -199,6 +199,7 @@ func TestNewClient(t *testing.T) {
 			"Authorization": "Basic " + base64.StdEncoding.EncodeToString([]byte(config.ClientID+":"+config.SecretKey)),
 		},
 		callbackURL: config.CallbackURL,
+		secretKey:   config.SecretKey,
 	}
 
 	client2 := NewClient(config)
@@ -1,9 +1,12 @@
 package shuftipro
 
+import "modulus/kyc/http"
+
 // Config represents the configuration for the service.
 type Config struct {
 	Host        string
 	ClientID    string
 	SecretKey   string
 	CallbackURL string
+	HTTPClient  *http.Client
 }
@@ -0,0 +1,31 @@
+package shuftipro
+
+import (
+	"modulus/kyc/common"
+	"modulus/kyc/http"
+)
+
+func init() {
+	common.RegisterProvider(common.ProviderSpec{
+		Name:    common.ShuftiPro,
+		Options: []string{"Host", "SecretKey", "ClientID", "CallbackURL"},
+		Capabilities: common.ProviderCapabilities{
+			StatusPolling: true,
+			Callbacks:     true,
+			Documents:     true,
+		},
+		Factory: func(options map[string]string) (common.KYCPlatformContext, error) {
+			client, err := http.NewClientFromOptions(options)
+			if err != nil {
+				return nil, err
+			}
+			return New(Config{
+				Host:        options["Host"],
+				SecretKey:   options["SecretKey"],
+				ClientID:    options["ClientID"],
+				CallbackURL: options["CallbackURL"],
+				HTTPClient:  client,
+			}), nil
+		},
+	})
+}
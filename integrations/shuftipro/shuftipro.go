@@ -1,12 +1,13 @@
 package shuftipro
 
 import (
+	"context"
 	"errors"
 
 	"modulus/kyc/common"
 )
 
-var _ common.KYCPlatform = ShuftiPro{}
+var _ common.KYCPlatformContext = ShuftiPro{}
 
 // ShuftiPro represents the verification service.
 type ShuftiPro struct {
@@ -22,24 +23,34 @@ func New(config Config) ShuftiPro {
 
 // CheckCustomer implements KYCPlatform interface for ShuftiPro.
 func (s ShuftiPro) CheckCustomer(customer *common.UserData) (result common.KYCResult, err error) {
+	return s.CheckCustomerContext(context.Background(), customer)
+}
+
+// CheckCustomerContext implements KYCPlatformContext interface for ShuftiPro.
+func (s ShuftiPro) CheckCustomerContext(ctx context.Context, customer *common.UserData) (result common.KYCResult, err error) {
 	if customer == nil {
 		err = errors.New("no customer supplied")
 		return
 	}
 
-	result, err = s.client.CheckCustomer(customer)
+	result, err = s.client.CheckCustomerContext(ctx, customer)
 
 	return
 }
 
 // CheckStatus implements KYCPlatform interface for the ShuftiPro.
 func (s ShuftiPro) CheckStatus(referenceID string) (result common.KYCResult, err error) {
+	return s.CheckStatusContext(context.Background(), referenceID)
+}
+
+// CheckStatusContext implements KYCPlatformContext interface for the ShuftiPro.
+func (s ShuftiPro) CheckStatusContext(ctx context.Context, referenceID string) (result common.KYCResult, err error) {
 	if len(referenceID) == 0 {
 		err = errors.New("no referenceID supplied")
 		return
 	}
 
-	result, err = s.client.CheckStatus(referenceID)
+	result, err = s.client.CheckStatusContext(ctx, referenceID)
 
 	return
 }
@@ -1,18 +1,25 @@
 package applicants
 
+import (
+	"context"
+
+	"modulus/kyc/http"
+)
+
 type Config struct {
-	Host   string
-	APIKey string
+	Host       string
+	APIKey     string
+	HTTPClient *http.Client
 }
 
 type Applicants interface {
-	CreateApplicant(email string, applicant ApplicantInfo) (*CreateApplicantResponse, error)
+	CreateApplicant(ctx context.Context, email string, applicant ApplicantInfo) (*CreateApplicantResponse, error)
 }
 
 type Mock struct {
 	CreateApplicantFn func(email string, applicant ApplicantInfo) (*CreateApplicantResponse, error)
 }
 
-func (mock Mock) CreateApplicant(email string, applicant ApplicantInfo) (*CreateApplicantResponse, error) {
+func (mock Mock) CreateApplicant(ctx context.Context, email string, applicant ApplicantInfo) (*CreateApplicantResponse, error) {
 	return mock.CreateApplicantFn(email, applicant)
 }
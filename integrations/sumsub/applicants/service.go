@@ -1,6 +1,7 @@
 package applicants
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"modulus/kyc/http"
@@ -11,16 +12,18 @@ import (
 type service struct {
 	host   string
 	apiKey string
+	client *http.Client
 }
 
 func NewService(config Config) Applicants {
 	return service{
 		host:   config.Host,
 		apiKey: config.APIKey,
+		client: config.HTTPClient,
 	}
 }
 
-func (service service) CreateApplicant(email string, applicant ApplicantInfo) (*CreateApplicantResponse, error) {
+func (service service) CreateApplicant(ctx context.Context, email string, applicant ApplicantInfo) (*CreateApplicantResponse, error) {
 	requestBytes, err := json.Marshal(CreateApplicantRequest{
 		Email: email,
 		Info:  applicant,
@@ -29,7 +32,8 @@ func (service service) CreateApplicant(email string, applicant ApplicantInfo) (*
 		return nil, err
 	}
 
-	_, responseBytes, err := http.Post(
+	_, responseBytes, err := service.client.PostContext(
+		ctx,
 		fmt.Sprintf("%s/resources/applicants?key=%s",
 			service.host,
 			service.apiKey,
@@ -1,6 +1,7 @@
 package applicants
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
@@ -58,7 +59,7 @@ func Test_service_CreateApplicantSuccess(t *testing.T) {
 		},
 	)
 
-	response, err := applicantsService.CreateApplicant("", ApplicantInfo{})
+	response, err := applicantsService.CreateApplicant(context.Background(), "", ApplicantInfo{})
 	if assert.NoError(t, err) && assert.NotNil(t, response) {
 		assert.Equal(t, "596eb3c93a0eb985b8ade34d", response.ID)
 		assert.Equal(t, "2017-07-19 03:20:09", response.CreatedAt)
@@ -98,7 +99,7 @@ func Test_service_CreateApplicantError(t *testing.T) {
 		},
 	)
 
-	response, err := applicantsService.CreateApplicant("", ApplicantInfo{})
+	response, err := applicantsService.CreateApplicant(context.Background(), "", ApplicantInfo{})
 	if assert.Error(t, err) && assert.NotNil(t, response) {
 		assert.Equal(t, 400, *response.Code)
 		assert.Equal(t, "Null applicant was provided", err.Error())
@@ -113,7 +114,7 @@ func Test_service_CreateApplicantError(t *testing.T) {
 		},
 	)
 
-	response, err = applicantsService.CreateApplicant("", ApplicantInfo{})
+	response, err = applicantsService.CreateApplicant(context.Background(), "", ApplicantInfo{})
 	assert.Error(t, err)
 	assert.Nil(t, response)
 
@@ -126,7 +127,7 @@ func Test_service_CreateApplicantError(t *testing.T) {
 		},
 	)
 
-	response, err = applicantsService.CreateApplicant("", ApplicantInfo{})
+	response, err = applicantsService.CreateApplicant(context.Background(), "", ApplicantInfo{})
 	if assert.Error(t, err) && assert.Nil(t, response) {
 		assert.Equal(t, "Post https://test_host.subsub.com/resources/applicants?key=api_key: test_error", err.Error())
 	}
@@ -0,0 +1,66 @@
+package sumsub
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	stdhttp "net/http"
+
+	"modulus/kyc/common"
+	"modulus/kyc/integrations/sumsub/verification"
+)
+
+// digestHeader is the header of the webhook request holding the payload digest.
+const digestHeader = "X-Payload-Digest"
+
+var _ common.KYCCallbackReceiver = SumSub{}
+
+// ParseCallback implements KYCCallbackReceiver interface for Sum&Substance KYC provider.
+// The webhook payload is signed with HMAC-SHA1 using the webhook secret key.
+func (service SumSub) ParseCallback(r *stdhttp.Request) (referenceID string, result common.KYCResult, err error) {
+	if len(service.webhookSecret) == 0 {
+		err = errors.New("the webhook secret isn't configured")
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return
+	}
+
+	if !service.validDigest(body, r.Header.Get(digestHeader)) {
+		err = common.ErrCallbackSignature
+		return
+	}
+
+	event := verification.ApplicantReviewedEvent{}
+	if err = json.Unmarshal(body, &event); err != nil {
+		err = common.CallbackPayloadError{Err: err}
+		return
+	}
+	if len(event.ApplicantID) == 0 {
+		err = common.CallbackPayloadError{Err: errors.New("missing applicant id")}
+		return
+	}
+
+	referenceID = event.ApplicantID
+	result, err = reviewToResult(event.ApplicantID, event.ReviewStatus, &event.ReviewResult)
+
+	return
+}
+
+// validDigest checks the digest of the webhook payload.
+func (service SumSub) validDigest(body []byte, digest string) bool {
+	expected, err := hex.DecodeString(digest)
+	if err != nil || len(expected) == 0 {
+		return false
+	}
+
+	mac := hmac.New(sha1.New, []byte(service.webhookSecret))
+	mac.Write(body)
+
+	return hmac.Equal(mac.Sum(nil), expected)
+}
@@ -0,0 +1,91 @@
+package sumsub
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"modulus/kyc/common"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func signedWebhook(body, secret string) *stdhttp.Request {
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write([]byte(body))
+
+	r := httptest.NewRequest(stdhttp.MethodPost, "/Callback/Sum&Substance", bytes.NewReader([]byte(body)))
+	r.Header.Set(digestHeader, hex.EncodeToString(mac.Sum(nil)))
+
+	return r
+}
+
+func TestParseCallback(t *testing.T) {
+	assert := assert.New(t)
+
+	service := New(Config{
+		Host:          "https://test-api.sumsub.com",
+		APIKey:        "api_key",
+		WebhookSecret: "webhook_secret",
+	})
+
+	// Testing approved applicant.
+	referenceID, result, err := service.ParseCallback(signedWebhook(`{
+		"applicantId": "5cb56e8e0a975a35f333cb83",
+		"inspectionId": "5cb56e8e0a975a35f333cb84",
+		"type": "applicantReviewed",
+		"reviewStatus": "completed",
+		"reviewResult": {"reviewAnswer": "GREEN"}
+	}`, "webhook_secret"))
+
+	assert.NoError(err)
+	assert.Equal("5cb56e8e0a975a35f333cb83", referenceID)
+	assert.Equal(common.Approved, result.Status)
+	assert.Nil(result.Details)
+
+	// Testing rejected applicant.
+	referenceID, result, err = service.ParseCallback(signedWebhook(`{
+		"applicantId": "5cb56e8e0a975a35f333cb83",
+		"type": "applicantReviewed",
+		"reviewStatus": "completed",
+		"reviewResult": {"reviewAnswer": "RED", "rejectLabels": ["FORGERY"], "reviewRejectType": "FINAL"}
+	}`, "webhook_secret"))
+
+	assert.NoError(err)
+	assert.Equal("5cb56e8e0a975a35f333cb83", referenceID)
+	assert.Equal(common.Denied, result.Status)
+	if assert.NotNil(result.Details) {
+		assert.Equal(common.Final, result.Details.Finality)
+		assert.Equal([]string{"FORGERY"}, result.Details.Reasons)
+	}
+
+	// Testing pending applicant.
+	_, result, err = service.ParseCallback(signedWebhook(`{"applicantId": "5cb56e8e0a975a35f333cb83", "type": "applicantPending", "reviewStatus": "pending"}`, "webhook_secret"))
+
+	assert.NoError(err)
+	assert.Equal(common.Unclear, result.Status)
+	assert.NotNil(result.StatusCheck)
+
+	// Testing invalid digest.
+	_, _, err = service.ParseCallback(signedWebhook(`{"applicantId": "5cb56e8e0a975a35f333cb83"}`, "wrong_secret"))
+
+	assert.Equal(common.ErrCallbackSignature, err)
+
+	// Testing malformed payload.
+	_, _, err = service.ParseCallback(signedWebhook(`{"applicantId": 42}`, "webhook_secret"))
+
+	assert.IsType(common.CallbackPayloadError{}, err)
+
+	// Testing missing webhook secret.
+	service.webhookSecret = ""
+
+	_, _, err = service.ParseCallback(signedWebhook(`{"applicantId": "5cb56e8e0a975a35f333cb83"}`, ""))
+
+	if assert.Error(err) {
+		assert.Equal("the webhook secret isn't configured", err.Error())
+	}
+}
@@ -1,9 +1,13 @@
 package sumsub
 
+import "modulus/kyc/http"
+
 // Config defines configuration for the service.
 type Config struct {
-	Host   string
-	APIKey string
+	Host          string
+	APIKey        string
+	WebhookSecret string
+	HTTPClient    *http.Client
 }
 
 // Different values of a verification result.
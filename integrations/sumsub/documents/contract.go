@@ -1,9 +1,16 @@
 package documents
 
+import (
+	"context"
+
+	"modulus/kyc/http"
+)
+
 // Config represents the configuration of the service.
 type Config struct {
-	Host   string
-	APIKey string
+	Host       string
+	APIKey     string
+	HTTPClient *http.Client
 }
 
 // The document subtype values.
@@ -15,6 +22,7 @@ const (
 // Documents represents the documents uploading interface.
 type Documents interface {
 	UploadDocument(
+		ctx context.Context,
 		applicantID string,
 		document Document,
 	) (*Metadata, *int, error)
@@ -30,6 +38,7 @@ type Mock struct {
 
 // UploadDocument implements the Documents interface for Mock.
 func (mock Mock) UploadDocument(
+	ctx context.Context,
 	applicantID string,
 	document Document,
 ) (*Metadata, *int, error) {
@@ -2,6 +2,7 @@ package documents
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"mime/multipart"
@@ -13,6 +14,7 @@ import (
 type service struct {
 	host   string
 	apiKey string
+	client *http.Client
 }
 
 // NewService constructs a new documents verification service object.
@@ -20,10 +22,12 @@ func NewService(config Config) Documents {
 	return service{
 		host:   config.Host,
 		apiKey: config.APIKey,
+		client: config.HTTPClient,
 	}
 }
 
 func (service service) UploadDocument(
+	ctx context.Context,
 	applicantID string,
 	document Document,
 ) (*Metadata, *int, error) {
@@ -51,7 +55,7 @@ func (service service) UploadDocument(
 		return nil, nil, err
 	}
 
-	_, responseBytes, err := http.Post(fmt.Sprintf("%s/resources/applicants/%s/info/idDoc?key=%s",
+	_, responseBytes, err := service.client.PostContext(ctx, fmt.Sprintf("%s/resources/applicants/%s/info/idDoc?key=%s",
 		service.host,
 		applicantID,
 		service.apiKey,
@@ -1,6 +1,7 @@
 package documents
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
@@ -49,6 +50,7 @@ func Test_service_UploadDocument(t *testing.T) {
 	}
 
 	response, errorCode, err := documentsService.UploadDocument(
+		context.Background(),
 		"test_applicant_id",
 		Document{
 			Metadata: testMetadata,
@@ -76,6 +78,7 @@ func Test_service_UploadDocument(t *testing.T) {
 	)
 
 	response, errorCode, err = documentsService.UploadDocument(
+		context.Background(),
 		"test_applicant_id",
 		Document{
 			Metadata: testMetadata,
@@ -113,7 +116,7 @@ func Test_service_UploadDocumentError(t *testing.T) {
 		},
 	)
 
-	response, errorCode, err := documentsService.UploadDocument("test_applicant_id", Document{})
+	response, errorCode, err := documentsService.UploadDocument(context.Background(), "test_applicant_id", Document{})
 	if assert.Error(t, err) && assert.Nil(t, response) {
 		assert.Equal(t, 400, *errorCode)
 		assert.Equal(t, "Cannot read a metadata object from the body", err.Error())
@@ -131,7 +134,7 @@ func Test_service_UploadDocumentError(t *testing.T) {
 		},
 	)
 
-	response, errorCode, err = documentsService.UploadDocument("test_applicant_id", Document{})
+	response, errorCode, err = documentsService.UploadDocument(context.Background(), "test_applicant_id", Document{})
 	assert.Error(t, err)
 	assert.Nil(t, errorCode)
 	assert.Nil(t, response)
@@ -145,7 +148,7 @@ func Test_service_UploadDocumentError(t *testing.T) {
 		},
 	)
 
-	response, errorCode, err = documentsService.UploadDocument("test_applicant_id", Document{})
+	response, errorCode, err = documentsService.UploadDocument(context.Background(), "test_applicant_id", Document{})
 	assert.Error(t, err)
 	assert.Nil(t, errorCode)
 	assert.Nil(t, response)
@@ -0,0 +1,31 @@
+package sumsub
+
+import (
+	"modulus/kyc/common"
+	"modulus/kyc/http"
+)
+
+func init() {
+	common.RegisterProvider(common.ProviderSpec{
+		Name:     common.SumSub,
+		Options:  []string{"Host", "APIKey"},
+		Optional: []string{"WebhookSecret"},
+		Capabilities: common.ProviderCapabilities{
+			StatusPolling: true,
+			Callbacks:     true,
+			Documents:     true,
+		},
+		Factory: func(options map[string]string) (common.KYCPlatformContext, error) {
+			client, err := http.NewClientFromOptions(options)
+			if err != nil {
+				return nil, err
+			}
+			return New(Config{
+				Host:          options["Host"],
+				APIKey:        options["APIKey"],
+				WebhookSecret: options["WebhookSecret"],
+				HTTPClient:    client,
+			}), nil
+		},
+	})
+}
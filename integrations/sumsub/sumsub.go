@@ -1,6 +1,7 @@
 package sumsub
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -12,35 +13,45 @@ import (
 	"github.com/pkg/errors"
 )
 
-var _ common.KYCPlatform = SumSub{}
+var _ common.KYCPlatformContext = SumSub{}
 
 // SumSub defines the verification service.
 type SumSub struct {
-	applicants   applicants.Applicants
-	documents    documents.Documents
-	verification verification.Verification
+	applicants    applicants.Applicants
+	documents     documents.Documents
+	verification  verification.Verification
+	webhookSecret string
 }
 
 // New constructs new verification service object.
 func New(config Config) SumSub {
 	return SumSub{
 		applicants: applicants.NewService(applicants.Config{
-			Host:   config.Host,
-			APIKey: config.APIKey,
+			Host:       config.Host,
+			APIKey:     config.APIKey,
+			HTTPClient: config.HTTPClient,
 		}),
 		documents: documents.NewService(documents.Config{
-			Host:   config.Host,
-			APIKey: config.APIKey,
+			Host:       config.Host,
+			APIKey:     config.APIKey,
+			HTTPClient: config.HTTPClient,
 		}),
 		verification: verification.NewService(verification.Config{
-			Host:   config.Host,
-			APIKey: config.APIKey,
+			Host:       config.Host,
+			APIKey:     config.APIKey,
+			HTTPClient: config.HTTPClient,
 		}),
+		webhookSecret: config.WebhookSecret,
 	}
 }
 
 // CheckCustomer implements KYCPlatform interface for Sum&Substance KYC provider.
 func (service SumSub) CheckCustomer(customer *common.UserData) (res common.KYCResult, err error) {
+	return service.CheckCustomerContext(context.Background(), customer)
+}
+
+// CheckCustomerContext implements KYCPlatformContext interface for Sum&Substance KYC provider.
+func (service SumSub) CheckCustomerContext(ctx context.Context, customer *common.UserData) (res common.KYCResult, err error) {
 	if customer == nil {
 		err = errors.New("no customer supplied")
 		return
@@ -55,6 +66,7 @@ func (service SumSub) CheckCustomer(customer *common.UserData) (res common.KYCRe
 
 	// Create an applicant.
 	applicantResponse, err := service.applicants.CreateApplicant(
+		ctx,
 		customer.Email,
 		applicants.MapCommonCustomerToApplicant(*customer),
 	)
@@ -72,7 +84,7 @@ func (service SumSub) CheckCustomer(customer *common.UserData) (res common.KYCRe
 
 	// Upload applicant's documents.
 	for _, document := range mappedDocuments {
-		_, errorCode, err1 := service.documents.UploadDocument(applicantResponse.ID, document)
+		_, errorCode, err1 := service.documents.UploadDocument(ctx, applicantResponse.ID, document)
 		if err1 != nil {
 			if errorCode != nil {
 				res.ErrorCode = fmt.Sprintf("%d", *errorCode)
@@ -89,7 +101,7 @@ func (service SumSub) CheckCustomer(customer *common.UserData) (res common.KYCRe
 	}
 
 	// Request applicant check.
-	if err = service.verification.RequestApplicantCheck(applicantResponse.ID); err != nil {
+	if err = service.verification.RequestApplicantCheck(ctx, applicantResponse.ID); err != nil {
 		err = fmt.Errorf("during requesting applicant check: %s", err)
 		return
 	}
@@ -107,7 +119,12 @@ func (service SumSub) CheckCustomer(customer *common.UserData) (res common.KYCRe
 
 // CheckStatus implements KYCPlatform interface for Sum&Substance KYC provider.
 func (service SumSub) CheckStatus(refID string) (res common.KYCResult, err error) {
-	status, result, err := service.verification.CheckApplicantStatus(refID)
+	return service.CheckStatusContext(context.Background(), refID)
+}
+
+// CheckStatusContext implements KYCPlatformContext interface for Sum&Substance KYC provider.
+func (service SumSub) CheckStatusContext(ctx context.Context, refID string) (res common.KYCResult, err error) {
+	status, result, err := service.verification.CheckApplicantStatus(ctx, refID)
 	if err != nil {
 		if result != nil && result.ErrorCode != 0 {
 			res.ErrorCode = fmt.Sprintf("%d", result.ErrorCode)
@@ -115,6 +132,13 @@ func (service SumSub) CheckStatus(refID string) (res common.KYCResult, err error
 		return
 	}
 
+	res, err = reviewToResult(refID, status, result)
+
+	return
+}
+
+// reviewToResult converts the applicant review status and result into the verification result.
+func reviewToResult(refID string, status string, result *verification.ReviewResult) (res common.KYCResult, err error) {
 	switch status {
 	case "completed", "completedSent", "completedSentFailure":
 		var detailedResult *common.KYCDetails
@@ -1,15 +1,22 @@
 package verification
 
+import (
+	"context"
+
+	"modulus/kyc/http"
+)
+
 // Config represents service configuration.
 type Config struct {
-	Host   string
-	APIKey string
+	Host       string
+	APIKey     string
+	HTTPClient *http.Client
 }
 
 // Verification represents KYC verification interface.
 type Verification interface {
-	CheckApplicantStatus(applicantID string) (string, *ReviewResult, error)
-	RequestApplicantCheck(applicantID string) error
+	CheckApplicantStatus(ctx context.Context, applicantID string) (string, *ReviewResult, error)
+	RequestApplicantCheck(ctx context.Context, applicantID string) error
 }
 
 // Mock represents the service mock.
@@ -19,11 +26,11 @@ type Mock struct {
 }
 
 // CheckApplicantStatus implements Verification interface for the Mock.
-func (mock Mock) CheckApplicantStatus(applicantID string) (string, *ReviewResult, error) {
+func (mock Mock) CheckApplicantStatus(ctx context.Context, applicantID string) (string, *ReviewResult, error) {
 	return mock.CheckApplicantStatusFn(applicantID)
 }
 
 // RequestApplicantCheck implements Verification interface for the Mock.
-func (mock Mock) RequestApplicantCheck(applicantID string) error {
+func (mock Mock) RequestApplicantCheck(ctx context.Context, applicantID string) error {
 	return nil
 }
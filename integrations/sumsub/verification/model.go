@@ -34,3 +34,14 @@ type ReviewResult struct {
 	ReviewRejectType string   `json:"reviewRejectType"`
 	ErrorCode        int      `json:"-"`
 }
+
+// ApplicantReviewedEvent represents the webhook payload sent when the applicant review is completed or changed.
+type ApplicantReviewedEvent struct {
+	ApplicantID    string       `json:"applicantId"`
+	InspectionID   string       `json:"inspectionId"`
+	CorrelationID  string       `json:"correlationId"`
+	ExternalUserID string       `json:"externalUserId"`
+	Type           string       `json:"type"`
+	ReviewStatus   string       `json:"reviewStatus"`
+	ReviewResult   ReviewResult `json:"reviewResult"`
+}
@@ -1,6 +1,7 @@
 package verification
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,6 +13,7 @@ import (
 type service struct {
 	host   string
 	apiKey string
+	client *http.Client
 }
 
 // NewService constructs a new verification service object.
@@ -19,11 +21,12 @@ func NewService(config Config) Verification {
 	return service{
 		host:   config.Host,
 		apiKey: config.APIKey,
+		client: config.HTTPClient,
 	}
 }
 
-func (service service) CheckApplicantStatus(applicantID string) (string, *ReviewResult, error) {
-	_, responseBytes, err := http.Get(fmt.Sprintf("%s/resources/applicants/%s/status?key=%s",
+func (service service) CheckApplicantStatus(ctx context.Context, applicantID string) (string, *ReviewResult, error) {
+	_, responseBytes, err := service.client.GetContext(ctx, fmt.Sprintf("%s/resources/applicants/%s/status?key=%s",
 		service.host,
 		applicantID,
 		service.apiKey,
@@ -45,8 +48,8 @@ func (service service) CheckApplicantStatus(applicantID string) (string, *Review
 	return response.ReviewStatus, &response.ReviewResult, nil
 }
 
-func (service service) RequestApplicantCheck(applicantID string) (err error) {
-	code, responseBytes, err := http.Post(fmt.Sprintf("%s/resources/applicants/%s/status/pending?reason=docs_sent&key=%s",
+func (service service) RequestApplicantCheck(ctx context.Context, applicantID string) (err error) {
+	code, responseBytes, err := service.client.PostContext(ctx, fmt.Sprintf("%s/resources/applicants/%s/status/pending?reason=docs_sent&key=%s",
 		service.host, applicantID, service.apiKey), http.Headers{}, nil)
 	if err != nil {
 		return
@@ -1,6 +1,7 @@
 package verification
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
@@ -60,7 +61,7 @@ func Test_service_CheckApplicantStatus(t *testing.T) {
 		},
 	)
 
-	status, result, err := service.CheckApplicantStatus("test_applicant_id")
+	status, result, err := service.CheckApplicantStatus(context.Background(), "test_applicant_id")
 	if assert.NoError(t, err) && assert.Equal(t, "completed", status) && assert.NotNil(t, result) {
 		assert.Equal(t, "RED", result.ReviewAnswer)
 		assert.Equal(t, "OTHER", result.Label)
@@ -89,7 +90,7 @@ func Test_service_CheckApplicantStatusError(t *testing.T) {
 		},
 	)
 
-	_, response, err := service.CheckApplicantStatus("test_applicant_id")
+	_, response, err := service.CheckApplicantStatus(context.Background(), "test_applicant_id")
 	if assert.Error(t, err) {
 		assert.Equal(t, 400, response.ErrorCode)
 	}
@@ -106,7 +107,7 @@ func Test_service_CheckApplicantStatusError(t *testing.T) {
 		},
 	)
 
-	_, response, err = service.CheckApplicantStatus("test_applicant_id")
+	_, response, err = service.CheckApplicantStatus(context.Background(), "test_applicant_id")
 	if assert.Error(t, err) {
 		assert.Nil(t, response)
 	}
@@ -120,7 +121,7 @@ func Test_service_CheckApplicantStatusError(t *testing.T) {
 		},
 	)
 
-	_, response, err = service.CheckApplicantStatus("test_applicant_id")
+	_, response, err = service.CheckApplicantStatus(context.Background(), "test_applicant_id")
 	if assert.Error(t, err) {
 		assert.Nil(t, response)
 	}
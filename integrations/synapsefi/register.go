@@ -0,0 +1,29 @@
+package synapsefi
+
+import (
+	"modulus/kyc/common"
+	"modulus/kyc/http"
+)
+
+func init() {
+	common.RegisterProvider(common.ProviderSpec{
+		Name:    common.SynapseFI,
+		Options: []string{"Host", "ClientID", "ClientSecret"},
+		Capabilities: common.ProviderCapabilities{
+			StatusPolling: true,
+			Documents:     true,
+		},
+		Factory: func(options map[string]string) (common.KYCPlatformContext, error) {
+			client, err := http.NewClientFromOptions(options)
+			if err != nil {
+				return nil, err
+			}
+			return New(Config{
+				Host:         options["Host"],
+				ClientID:     options["ClientID"],
+				ClientSecret: options["ClientSecret"],
+				HTTPClient:   client,
+			}), nil
+		},
+	})
+}
@@ -1,6 +1,7 @@
 package synapsefi
 
 import (
+	"context"
 	"time"
 
 	"modulus/kyc/common"
@@ -9,7 +10,7 @@ import (
 	"github.com/pkg/errors"
 )
 
-var _ common.KYCPlatform = SynapseFI{}
+var _ common.KYCPlatformContext = SynapseFI{}
 
 // SynapseFI represents the verification service.
 type SynapseFI struct {
@@ -25,6 +26,11 @@ func New(config Config) SynapseFI {
 
 // CheckCustomer implements KYCPlatform interface for the SynapseFI.
 func (service SynapseFI) CheckCustomer(customer *common.UserData) (result common.KYCResult, err error) {
+	return service.CheckCustomerContext(context.Background(), customer)
+}
+
+// CheckCustomerContext implements KYCPlatformContext interface for the SynapseFI.
+func (service SynapseFI) CheckCustomerContext(ctx context.Context, customer *common.UserData) (result common.KYCResult, err error) {
 	if customer == nil {
 		err = errors.New("no customer supplied")
 		return
@@ -44,7 +50,7 @@ func (service SynapseFI) CheckCustomer(customer *common.UserData) (result common
 		return
 	}
 
-	response, code, err := service.verification.CreateUser(user)
+	response, code, err := service.verification.CreateUser(ctx, user)
 	if err != nil {
 		if code != nil {
 			result.ErrorCode = *code
@@ -57,7 +63,7 @@ func (service SynapseFI) CheckCustomer(customer *common.UserData) (result common
 		return
 	}
 
-	code, err = service.verification.AddPhysicalDocs(response.ID, response.RefreshToken, response.Documents[0].ID, physDocs)
+	code, err = service.verification.AddPhysicalDocs(ctx, response.ID, response.RefreshToken, response.Documents[0].ID, physDocs)
 	if err != nil {
 		if code != nil {
 			result.ErrorCode = *code
@@ -77,7 +83,12 @@ func (service SynapseFI) CheckCustomer(customer *common.UserData) (result common
 
 // CheckStatus implements KYCPlatform interface for the SynapseFI.
 func (service SynapseFI) CheckStatus(refID string) (result common.KYCResult, err error) {
-	resp, code, err := service.verification.GetUser(refID)
+	return service.CheckStatusContext(context.Background(), refID)
+}
+
+// CheckStatusContext implements KYCPlatformContext interface for the SynapseFI.
+func (service SynapseFI) CheckStatusContext(ctx context.Context, refID string) (result common.KYCResult, err error) {
+	resp, code, err := service.verification.GetUser(ctx, refID)
 	if err != nil {
 		if code != nil {
 			result.ErrorCode = *code
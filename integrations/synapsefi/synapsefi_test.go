@@ -1,6 +1,7 @@
 package synapsefi
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"testing"
@@ -23,15 +24,15 @@ type Mock struct {
 	GetUserFn         func(string) (*verification.Response, *string, error)
 }
 
-func (m Mock) CreateUser(user verification.User) (*verification.Response, *string, error) {
+func (m Mock) CreateUser(ctx context.Context, user verification.User) (*verification.Response, *string, error) {
 	return m.CreateUserFn(user)
 }
 
-func (m Mock) AddPhysicalDocs(userID string, rtoken string, docsID string, physdocs []verification.SubDocument) (*string, error) {
+func (m Mock) AddPhysicalDocs(ctx context.Context, userID string, rtoken string, docsID string, physdocs []verification.SubDocument) (*string, error) {
 	return m.AddPhysicalDocsFn(userID, rtoken, docsID, physdocs)
 }
 
-func (m Mock) GetUser(refID string) (*verification.Response, *string, error) {
+func (m Mock) GetUser(ctx context.Context, refID string) (*verification.Response, *string, error) {
 	return m.GetUserFn(refID)
 }
 
@@ -1,8 +1,11 @@
 package verification
 
 import (
+	"context"
 	"crypto/sha256"
 	"fmt"
+
+	"modulus/kyc/http"
 )
 
 // Config represents service config.
@@ -10,14 +13,15 @@ type Config struct {
 	Host         string
 	ClientID     string
 	ClientSecret string
+	HTTPClient   *http.Client
 	fingerprint  string
 }
 
 // Verification describes the verification interface.
 type Verification interface {
-	CreateUser(user User) (*Response, *string, error)
-	AddPhysicalDocs(userID, rtoken, docsID string, docs []SubDocument) (*string, error)
-	GetUser(userID string) (*Response, *string, error)
+	CreateUser(ctx context.Context, user User) (*Response, *string, error)
+	AddPhysicalDocs(ctx context.Context, userID, rtoken, docsID string, docs []SubDocument) (*string, error)
+	GetUser(ctx context.Context, userID string) (*Response, *string, error)
 }
 
 func (c Config) calcFingerprint() string {
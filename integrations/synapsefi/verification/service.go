@@ -1,6 +1,7 @@
 package verification
 
 import (
+	"context"
 	"encoding/json"
 	stdhttp "net/http"
 
@@ -29,7 +30,7 @@ func NewService(config Config) Verification {
 }
 
 // TODO: resend on fail, process errors, PROCESS RESPONSE PERMISSIONS!!!
-func (service service) CreateUser(user User) (resp *Response, code *string, err error) {
+func (service service) CreateUser(ctx context.Context, user User) (resp *Response, code *string, err error) {
 	body, err := json.Marshal(user)
 	if err != nil {
 		return
@@ -38,7 +39,7 @@ func (service service) CreateUser(user User) (resp *Response, code *string, err
 	headers := service.composeHeaders(true, "")
 	endpoint := service.config.Host + endpointUsers
 
-	status, response, err := http.Post(endpoint, headers, body)
+	status, response, err := service.config.HTTPClient.PostContext(ctx, endpoint, headers, body)
 	if err != nil {
 		return
 	}
@@ -56,8 +57,8 @@ func (service service) CreateUser(user User) (resp *Response, code *string, err
 	return
 }
 
-func (service service) AddPhysicalDocs(userID, rtoken, docsID string, docs []SubDocument) (code *string, err error) {
-	key, err := service.getOAuthKey(userID, rtoken)
+func (service service) AddPhysicalDocs(ctx context.Context, userID, rtoken, docsID string, docs []SubDocument) (code *string, err error) {
+	key, err := service.getOAuthKey(ctx, userID, rtoken)
 	if err != nil {
 		return
 	}
@@ -82,7 +83,7 @@ func (service service) AddPhysicalDocs(userID, rtoken, docsID string, docs []Sub
 			return nil, err1
 		}
 
-		status, response, err1 := http.Patch(endpoint, headers, body)
+		status, response, err1 := service.config.HTTPClient.PatchContext(ctx, endpoint, headers, body)
 		if err1 != nil {
 			return nil, err1
 		}
@@ -95,11 +96,11 @@ func (service service) AddPhysicalDocs(userID, rtoken, docsID string, docs []Sub
 	return
 }
 
-func (service service) GetUser(userID string) (resp *Response, code *string, err error) {
+func (service service) GetUser(ctx context.Context, userID string) (resp *Response, code *string, err error) {
 	headers := service.composeHeaders(true, "")
 	endpoint := service.config.Host + endpointUsers + "/" + userID
 
-	status, response, err := http.Get(endpoint, headers)
+	status, response, err := service.config.HTTPClient.GetContext(ctx, endpoint, headers)
 	if err != nil {
 		return
 	}
@@ -117,7 +118,7 @@ func (service service) GetUser(userID string) (resp *Response, code *string, err
 	return
 }
 
-func (service service) getOAuthKey(userID, rtoken string) (key string, err error) {
+func (service service) getOAuthKey(ctx context.Context, userID, rtoken string) (key string, err error) {
 	req := OAuthRequest{
 		RefreshToken: rtoken,
 	}
@@ -130,7 +131,7 @@ func (service service) getOAuthKey(userID, rtoken string) (key string, err error
 	headers := service.composeHeaders(false, "")
 	endpoint := service.config.Host + endpointOAuth + "/" + userID
 
-	status, resp, err := http.Post(endpoint, headers, body)
+	status, resp, err := service.config.HTTPClient.PostContext(ctx, endpoint, headers, body)
 	if err != nil {
 		return
 	}
@@ -1,6 +1,7 @@
 package verification
 
 import (
+	"context"
 	"crypto/sha256"
 	"fmt"
 	"net/http"
@@ -134,7 +135,7 @@ func Test_service_CreateUser(t *testing.T) {
 		},
 	)
 
-	response, code, err := service.CreateUser(User{})
+	response, code, err := service.CreateUser(context.Background(), User{})
 	if assert.NoError(t, err) {
 		assert.Nil(t, code)
 		assert.Equal(t, "594e0fa2838454002ea317a0", response.ID)
@@ -168,7 +169,7 @@ func Test_service_CreateUserError(t *testing.T) {
 		},
 	)
 
-	response, code, err := service.CreateUser(User{})
+	response, code, err := service.CreateUser(context.Background(), User{})
 	assert.Error(t, err)
 	assert.Nil(t, code)
 	assert.Nil(t, response)
@@ -182,7 +183,7 @@ func Test_service_CreateUserError(t *testing.T) {
 		},
 	)
 
-	response, code, err = service.CreateUser(User{})
+	response, code, err = service.CreateUser(context.Background(), User{})
 	assert.Error(t, err)
 	assert.Nil(t, code)
 	assert.Nil(t, response)
@@ -271,7 +272,7 @@ func Test_service_GetUser(t *testing.T) {
 		},
 	)
 
-	response, code, err := service.GetUser("id")
+	response, code, err := service.GetUser(context.Background(), "id")
 	if assert.NoError(t, err) {
 		assert.Nil(t, code)
 		assert.Equal(t, "594e0fa2838454002ea317a0", response.ID)
@@ -308,7 +309,7 @@ func Test_service_GetUserError(t *testing.T) {
 		},
 	)
 
-	response, code, err := service.GetUser("id")
+	response, code, err := service.GetUser(context.Background(), "id")
 	assert.Error(t, err)
 	assert.Nil(t, code)
 	assert.Nil(t, response)
@@ -322,7 +323,7 @@ func Test_service_GetUserError(t *testing.T) {
 		},
 	)
 
-	response, code, err = service.GetUser("id")
+	response, code, err = service.GetUser(context.Background(), "id")
 	assert.Error(t, err)
 	assert.Nil(t, response)
 }
@@ -349,7 +350,7 @@ func Test_service_getOAuthKey(t *testing.T) {
 		},
 	)
 
-	key, err := svc.(service).getOAuthKey(userID, "")
+	key, err := svc.(service).getOAuthKey(context.Background(), userID, "")
 	if assert.NoError(t, err) {
 		assert.Equal(t, "oauth_bo4WXMIT5V0zKSRLYcqNwGtHZEDaA1k3pBv7r20s", key)
 	}
@@ -376,7 +377,7 @@ func Test_service_getOAuthKeyError(t *testing.T) {
 		},
 	)
 
-	key, err := svc.(service).getOAuthKey(userID, "")
+	key, err := svc.(service).getOAuthKey(context.Background(), userID, "")
 	assert.Error(t, err)
 	assert.Empty(t, key)
 
@@ -389,7 +390,7 @@ func Test_service_getOAuthKeyError(t *testing.T) {
 		},
 	)
 
-	key, err = svc.(service).getOAuthKey(userID, "")
+	key, err = svc.(service).getOAuthKey(context.Background(), userID, "")
 	assert.Error(t, err)
 	assert.Empty(t, key)
 }
@@ -491,7 +492,7 @@ func Test_service_AddPhysicalDocs(t *testing.T) {
 		},
 	)
 
-	code, err := service.AddPhysicalDocs(userID, userOAuth, docsID, []SubDocument{})
+	code, err := service.AddPhysicalDocs(context.Background(), userID, userOAuth, docsID, []SubDocument{})
 	if assert.NoError(t, err) {
 		assert.Nil(t, code)
 	}
@@ -520,7 +521,7 @@ func Test_service_AddPhysicalDocsError(t *testing.T) {
 		},
 	)
 
-	code, err := service.AddPhysicalDocs(userID, userOAuth, docsID, []SubDocument{})
+	code, err := service.AddPhysicalDocs(context.Background(), userID, userOAuth, docsID, []SubDocument{})
 	assert.Error(t, err)
 	assert.Nil(t, code)
 
@@ -533,7 +534,7 @@ func Test_service_AddPhysicalDocsError(t *testing.T) {
 		},
 	)
 
-	code, err = service.AddPhysicalDocs(userID, userOAuth, docsID, []SubDocument{})
+	code, err = service.AddPhysicalDocs(context.Background(), userID, userOAuth, docsID, []SubDocument{})
 	assert.Error(t, err)
 	assert.Nil(t, code)
 }
@@ -1,21 +1,21 @@
 package thomsonreuters
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	stdhttp "net/http"
 
-	"modulus/kyc/http"
 	"modulus/kyc/integrations/thomsonreuters/model"
 )
 
 // getRootGroups retrieves all the top-level groups with their immediate descendants.
-func (tr ThomsonReuters) getRootGroups() (groups model.Groups, code *int, err error) {
+func (tr ThomsonReuters) getRootGroups(ctx context.Context) (groups model.Groups, code *int, err error) {
 	path := "groups"
 
 	headers := tr.createHeaders(mGET, path, nil)
 
-	status, resp, err := http.Get(tr.scheme+"://"+tr.host+tr.path+path, headers)
+	status, resp, err := tr.client.GetContext(ctx, tr.scheme+"://"+tr.host+tr.path+path, headers)
 	if err != nil {
 		err = fmt.Errorf("during fetching top level groups: %s", err)
 		return
@@ -40,12 +40,12 @@ func (tr ThomsonReuters) getRootGroups() (groups model.Groups, code *int, err er
 }
 
 // getGroup retrieves a specified group including its immediate descendants.
-func (tr ThomsonReuters) getGroup(groupID string) (group model.Group, code *int, err error) {
+func (tr ThomsonReuters) getGroup(ctx context.Context, groupID string) (group model.Group, code *int, err error) {
 	path := "groups/" + groupID
 
 	headers := tr.createHeaders(mGET, path, nil)
 
-	status, resp, err := http.Get(tr.scheme+"://"+tr.host+tr.path+path, headers)
+	status, resp, err := tr.client.GetContext(ctx, tr.scheme+"://"+tr.host+tr.path+path, headers)
 	if err != nil {
 		err = fmt.Errorf("during fetching the group with id %s: %s", groupID, err)
 		return
@@ -70,12 +70,12 @@ func (tr ThomsonReuters) getGroup(groupID string) (group model.Group, code *int,
 }
 
 // getCaseTemplate retrieves the CaseTemplate for the given Group.
-func (tr ThomsonReuters) getCaseTemplate(groupID string) (caseTemplate model.CaseTemplateResponse, code *int, err error) {
+func (tr ThomsonReuters) getCaseTemplate(ctx context.Context, groupID string) (caseTemplate model.CaseTemplateResponse, code *int, err error) {
 	path := "groups/" + groupID + "/caseTemplate"
 
 	headers := tr.createHeaders(mGET, path, nil)
 
-	status, resp, err := http.Get(tr.scheme+"://"+tr.host+tr.path+path, headers)
+	status, resp, err := tr.client.GetContext(ctx, tr.scheme+"://"+tr.host+tr.path+path, headers)
 	if err != nil {
 		err = fmt.Errorf("during fetching a case template for the group with id %s: %s", groupID, err)
 		return
@@ -99,109 +99,9 @@ func (tr ThomsonReuters) getCaseTemplate(groupID string) (caseTemplate model.Cas
 	return
 }
 
-/*
-This method will likely return a significant amount of data (more than 1 Mb), well, I guess we ain't gonna need it.
-
-// getProviders retrieves a list of all available providers and their sources.
-func (tr ThomsonReuters) getProviders() (providers model.ProviderDetails, code *int, err error) {
-	path := "reference/providers"
-
-	headers := tr.createHeaders(mGET, path, nil)
-
-	status, resp, err := http.Get(tr.scheme+"://"+tr.host+tr.path+path, headers)
-	if err != nil {
-		return
-	}
-
-	if status != stdhttp.StatusOK {
-		code = &status
-		errs := model.Errors{}
-		err = json.Unmarshal(resp, &errs)
-		if err != nil {
-			err = errors.New("http error")
-			return
-		}
-		err = errs
-
-		return
-	}
-
-	err = json.Unmarshal(resp, &providers)
-
-	return
-}
-*/
-
-/*
-Currently, we don't use this.
-
-// getResolutionToolkits retrieves the ResolutionToolkits for the given Group for all enabled provider types,
-// used to construct a valid resolution request(tr) on the results for a Case belonging to the given Group groupId.
-func (tr ThomsonReuters) getResolutionToolkits(groupID string) (resToolkits model.ResolutionToolkits, code *int, err error) {
-	path := "groups/" + groupID + "/resolutionToolkits"
-
-	headers := tr.createHeaders(mGET, path, nil)
-
-	status, resp, err := http.Get(tr.scheme+"://"+tr.host+tr.path+path, headers)
-	if err != nil {
-		return
-	}
-
-	if status != stdhttp.StatusOK {
-		code = &status
-		errs := model.Errors{}
-		err = json.Unmarshal(resp, &errs)
-		if err != nil {
-			err = errors.New("http error")
-			return
-		}
-		err = errs
-
-		return
-	}
-
-	err = json.Unmarshal(resp, &resToolkits)
-
-	return
-}
-*/
-
-/*
-Currently, we don't use this.
-
-// getActiveUsers retrieves a list of active users (customers) in the Thomson Reuters API client’s account.
-func (tr ThomsonReuters) getActiveUsers() (users model.Users, code *int, err error) {
-	path := "users"
-
-	headers := tr.createHeaders(mGET, path, nil)
-
-	status, resp, err := http.Get(tr.scheme+"://"+tr.host+tr.path+path, headers)
-	if err != nil {
-		return
-	}
-
-	if status != stdhttp.StatusOK {
-		code = &status
-		errs := model.Errors{}
-		err = json.Unmarshal(resp, &errs)
-		if err != nil {
-			err = errors.New("http error")
-			return
-		}
-		err = errs
-
-		return
-	}
-
-	err = json.Unmarshal(resp, &users)
-
-	return
-}
-*/
-
 // performSynchronousScreening performs a synchronous screening for a given case.
 // The returned result collection contains the regular case result details plus identity documents and important events.
-func (tr ThomsonReuters) performSynchronousScreening(newcase model.NewCase) (rescol model.ScreeningResultCollection, code *int, err error) {
+func (tr ThomsonReuters) performSynchronousScreening(ctx context.Context, newcase model.NewCase) (rescol model.ScreeningResultCollection, code *int, err error) {
 	path := "cases/screeningRequest"
 
 	payload, err := json.Marshal(newcase)
@@ -211,7 +111,7 @@ func (tr ThomsonReuters) performSynchronousScreening(newcase model.NewCase) (res
 
 	headers := tr.createHeaders(mPOST, path, payload)
 
-	status, resp, err := http.Post(tr.scheme+"://"+tr.host+tr.path+path, headers, payload)
+	status, resp, err := tr.client.PostContext(ctx, tr.scheme+"://"+tr.host+tr.path+path, headers, payload)
 	if err != nil {
 		err = fmt.Errorf("during performing synchronous screening: %s", err)
 		return
@@ -1,6 +1,7 @@
 package thomsonreuters
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
@@ -1309,7 +1310,7 @@ func TestGetRootGroups(t *testing.T) {
 
 	httpmock.RegisterResponder(http.MethodGet, tr.scheme+"://"+tr.host+tr.path+"groups", httpmock.NewStringResponder(http.StatusOK, groupsResponse))
 
-	groups, status, err := tr.getRootGroups()
+	groups, status, err := tr.getRootGroups(context.Background())
 
 	assert.NoError(err)
 	assert.Nil(status)
@@ -1333,7 +1334,7 @@ func TestGetGroup(t *testing.T) {
 	httpmock.RegisterResponder(http.MethodGet, tr.scheme+"://"+tr.host+tr.path+"groups", httpmock.NewStringResponder(http.StatusOK, groupsResponse))
 	httpmock.RegisterResponder(http.MethodGet, tr.scheme+"://"+tr.host+tr.path+"groups/0a3687cf-65b4-1aaa-9975-f229000006ba", httpmock.NewStringResponder(http.StatusOK, groupResponse))
 
-	groups, status, err := tr.getRootGroups()
+	groups, status, err := tr.getRootGroups(context.Background())
 
 	assert.NoError(err)
 	assert.Nil(status)
@@ -1350,7 +1351,7 @@ func TestGetGroup(t *testing.T) {
 
 	assert.NotEmpty(gID)
 
-	group, status, err := tr.getGroup(gID)
+	group, status, err := tr.getGroup(context.Background(), gID)
 
 	assert.NoError(err)
 	assert.Nil(status)
@@ -1372,7 +1373,7 @@ func TestGetCaseTemplate(t *testing.T) {
 	httpmock.RegisterResponder(http.MethodGet, tr.scheme+"://"+tr.host+tr.path+"groups", httpmock.NewStringResponder(http.StatusOK, groupsResponse))
 	httpmock.RegisterResponder(http.MethodGet, tr.scheme+"://"+tr.host+tr.path+"groups/0a3687d0-65b4-1cc3-9975-f20b0000066f/caseTemplate", httpmock.NewStringResponder(http.StatusOK, caseTemplateResponse))
 
-	groups, status, err := tr.getRootGroups()
+	groups, status, err := tr.getRootGroups(context.Background())
 
 	assert.NoError(err)
 	assert.Nil(status)
@@ -1390,7 +1391,7 @@ func TestGetCaseTemplate(t *testing.T) {
 
 	assert.NotEmpty(gID)
 
-	ctr, status, err := tr.getCaseTemplate(gID)
+	ctr, status, err := tr.getCaseTemplate(context.Background(), gID)
 
 	// FIXME: perhaps, can add more checks.
 
@@ -1437,7 +1438,7 @@ func TestPerformSynchronousScreening(t *testing.T) {
 	httpmock.RegisterResponder(http.MethodGet, tr.scheme+"://"+tr.host+tr.path+"groups", httpmock.NewStringResponder(http.StatusOK, groupsResponse))
 	httpmock.RegisterResponder(http.MethodPost, tr.scheme+"://"+tr.host+tr.path+"cases/screeningRequest", httpmock.NewStringResponder(http.StatusOK, syncScreeningResponseDenied))
 
-	groups, status, err := tr.getRootGroups()
+	groups, status, err := tr.getRootGroups(context.Background())
 
 	assert.NoError(err)
 	assert.Nil(status)
@@ -1486,7 +1487,7 @@ func TestPerformSynchronousScreening(t *testing.T) {
 		},
 	}
 
-	src, status, err := tr.performSynchronousScreening(newcase)
+	src, status, err := tr.performSynchronousScreening(context.Background(), newcase)
 	if status != nil {
 		fmt.Println(*status)
 	}
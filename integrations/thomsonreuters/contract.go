@@ -1,8 +1,11 @@
 package thomsonreuters
 
+import "modulus/kyc/http"
+
 // Config represents the service config.
 type Config struct {
-	Host      string
-	APIkey    string
-	APIsecret string
+	Host       string
+	APIkey     string
+	APIsecret  string
+	HTTPClient *http.Client
 }
@@ -1,12 +1,13 @@
 package thomsonreuters
 
 import (
+	"context"
 	"errors"
 	"modulus/kyc/integrations/thomsonreuters/model"
 )
 
 // getGroupID returns group id.
-func (tr ThomsonReuters) getGroupID() (groupID string, code *int, err error) {
+func (tr ThomsonReuters) getGroupID(ctx context.Context) (groupID string, code *int, err error) {
 	/*
 	 * It's hard to determine what group we require for verification because
 	 * there's no "standard" classification of groups by a usage purpose or anything else.
@@ -14,7 +15,7 @@ func (tr ThomsonReuters) getGroupID() (groupID string, code *int, err error) {
 	 * As I see, the solution for this problem is to introduce other select criteria
 	 * related on some attribute's unique value or their combination (group name, id, etc).
 	 */
-	groups, code, err := tr.getRootGroups()
+	groups, code, err := tr.getRootGroups(ctx)
 	if err != nil {
 		return
 	}
@@ -1,6 +1,7 @@
 package thomsonreuters
 
 import (
+	"context"
 	"net/http"
 	"testing"
 
@@ -24,7 +25,7 @@ func TestGetGroupID(t *testing.T) {
 
 	httpmock.RegisterResponder(http.MethodGet, tr.scheme+"://"+tr.host+tr.path+"groups", httpmock.NewStringResponder(http.StatusOK, groupsResponse))
 
-	groups, status, err := tr.getRootGroups()
+	groups, status, err := tr.getRootGroups(context.Background())
 
 	assert.NoError(err)
 	assert.Nil(status)
@@ -0,0 +1,31 @@
+package thomsonreuters
+
+import (
+	"errors"
+	"net/url"
+
+	"modulus/kyc/common"
+	"modulus/kyc/http"
+)
+
+func init() {
+	common.RegisterProvider(common.ProviderSpec{
+		Name:    common.ThomsonReuters,
+		Options: []string{"Host", "APIkey", "APIsecret"},
+		Factory: func(options map[string]string) (common.KYCPlatformContext, error) {
+			client, err := http.NewClientFromOptions(options)
+			if err != nil {
+				return nil, err
+			}
+			if u, err := url.Parse(options["Host"]); err != nil || len(u.Scheme) == 0 || len(u.Host) == 0 {
+				return nil, errors.New("invalid option 'Host': malformed URL")
+			}
+			return New(Config{
+				Host:       options["Host"],
+				APIkey:     options["APIkey"],
+				APIsecret:  options["APIsecret"],
+				HTTPClient: client,
+			}), nil
+		},
+	})
+}
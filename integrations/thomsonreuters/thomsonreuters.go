@@ -1,6 +1,7 @@
 package thomsonreuters
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -8,9 +9,10 @@ import (
 	"strings"
 
 	"modulus/kyc/common"
+	"modulus/kyc/http"
 )
 
-var _ common.KYCPlatform = ThomsonReuters{}
+var _ common.KYCPlatformContext = ThomsonReuters{}
 
 // ThomsonReuters represents the Thomson Reuters API client.
 type ThomsonReuters struct {
@@ -19,6 +21,7 @@ type ThomsonReuters struct {
 	path   string
 	key    string
 	secret string
+	client *http.Client
 }
 
 // New constructs a new ThomsonReuters client.
@@ -43,17 +46,23 @@ func New(c Config) ThomsonReuters {
 		path:   u.Path,
 		key:    c.APIkey,
 		secret: c.APIsecret,
+		client: c.HTTPClient,
 	}
 }
 
 // CheckCustomer implements KYCPlatform interface for Thomson Reuters.
 func (tr ThomsonReuters) CheckCustomer(customer *common.UserData) (result common.KYCResult, err error) {
+	return tr.CheckCustomerContext(context.Background(), customer)
+}
+
+// CheckCustomerContext implements KYCPlatformContext interface for Thomson Reuters.
+func (tr ThomsonReuters) CheckCustomerContext(ctx context.Context, customer *common.UserData) (result common.KYCResult, err error) {
 	if customer == nil {
 		err = errors.New("customer data is nil")
 		return
 	}
 
-	gID, code, err := tr.getGroupID()
+	gID, code, err := tr.getGroupID(ctx)
 	if err != nil {
 		if code != nil {
 			result.ErrorCode = fmt.Sprintf("%d", *code)
@@ -61,7 +70,7 @@ func (tr ThomsonReuters) CheckCustomer(customer *common.UserData) (result common
 		return
 	}
 
-	template, code, err := tr.getCaseTemplate(gID)
+	template, code, err := tr.getCaseTemplate(ctx, gID)
 	if err != nil {
 		if code != nil {
 			result.ErrorCode = fmt.Sprintf("%d", *code)
@@ -71,7 +80,7 @@ func (tr ThomsonReuters) CheckCustomer(customer *common.UserData) (result common
 
 	newcase := newCase(template, customer)
 
-	src, code, err := tr.performSynchronousScreening(newcase)
+	src, code, err := tr.performSynchronousScreening(ctx, newcase)
 	if err != nil {
 		if code != nil {
 			result.ErrorCode = fmt.Sprintf("%d", *code)
@@ -86,6 +95,11 @@ func (tr ThomsonReuters) CheckCustomer(customer *common.UserData) (result common
 
 // CheckStatus implements KYCPlatform interface for Thomson Reuters.
 func (tr ThomsonReuters) CheckStatus(referenceID string) (res common.KYCResult, err error) {
+	return tr.CheckStatusContext(context.Background(), referenceID)
+}
+
+// CheckStatusContext implements KYCPlatformContext interface for Thomson Reuters.
+func (tr ThomsonReuters) CheckStatusContext(ctx context.Context, referenceID string) (res common.KYCResult, err error) {
 	err = errors.New("Thomson Reuters doesn't support a verification status check")
 	return
 }
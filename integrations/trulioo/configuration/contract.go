@@ -1,14 +1,21 @@
 package configuration
 
+import (
+	"context"
+
+	"modulus/kyc/http"
+)
+
 // Config represents the configuration for the configuration provider.
 type Config struct {
-	Host  string
-	Token string
+	Host       string
+	Token      string
+	HTTPClient *http.Client
 }
 
 // Configuration represents the configuration interface.
 type Configuration interface {
-	Consents(countryAlpha2 string) (Consents, *int, error)
+	Consents(ctx context.Context, countryAlpha2 string) (Consents, *int, error)
 }
 
 // Mock represents the mock for the configuration provider.
@@ -17,6 +24,6 @@ type Mock struct {
 }
 
 // Consents implements the Configuration interface for the Mock.
-func (mock Mock) Consents(countryAlpha2 string) (Consents, *int, error) {
+func (mock Mock) Consents(ctx context.Context, countryAlpha2 string) (Consents, *int, error) {
 	return mock.ConsentsFn(countryAlpha2)
 }
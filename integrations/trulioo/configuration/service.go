@@ -1,6 +1,7 @@
 package configuration
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"modulus/kyc/http"
@@ -18,11 +19,12 @@ func NewService(config Config) Configuration {
 	}
 }
 
-func (service service) Consents(countryAlpha2 string) (Consents, *int, error) {
+func (service service) Consents(ctx context.Context, countryAlpha2 string) (Consents, *int, error) {
 	if countryAlpha2 == "" {
 		return nil, nil, errors.New("No country code provided")
 	}
-	code, responseBytes, err := http.Get(
+	code, responseBytes, err := service.config.HTTPClient.GetContext(
+		ctx,
 		service.config.Host+"/consents/Identity Verification/"+countryAlpha2,
 		http.Headers{
 			"Authorization": "Basic " + service.config.Token,
@@ -1,6 +1,7 @@
 package configuration
 
 import (
+	"context"
 	"net/http"
 	"testing"
 
@@ -58,7 +59,7 @@ func Test_service_Consents(t *testing.T) {
 		},
 	)
 
-	consents, errorCode, err := service.Consents("AU")
+	consents, errorCode, err := service.Consents(context.Background(), "AU")
 	if assert.NoError(t, err) {
 		assert.Equal(t, Consents{
 			"Australia Driver Licence",
@@ -100,7 +101,7 @@ func Test_service_Consents_Error(t *testing.T) {
 		},
 	)
 
-	consents, errorCode, err := service.Consents("AU")
+	consents, errorCode, err := service.Consents(context.Background(), "AU")
 	assert.Nil(t, consents)
 	assert.NotNil(t, errorCode)
 	assert.Equal(t, 400, *errorCode)
@@ -118,7 +119,7 @@ func Test_service_Consents_Error(t *testing.T) {
 		},
 	)
 
-	consents, errorCode, err = service.Consents("AU")
+	consents, errorCode, err = service.Consents(context.Background(), "AU")
 	assert.Nil(t, consents)
 	assert.NotNil(t, errorCode)
 	assert.Equal(t, 400, *errorCode)
@@ -137,7 +138,7 @@ func Test_service_Consents_Error(t *testing.T) {
 		},
 	)
 
-	consents, errorCode, err = service.Consents("AU")
+	consents, errorCode, err = service.Consents(context.Background(), "AU")
 	assert.Nil(t, consents)
 	assert.NotNil(t, errorCode)
 	assert.Equal(t, 400, *errorCode)
@@ -152,11 +153,11 @@ func Test_service_Consents_Error(t *testing.T) {
 		},
 	)
 
-	consents, errorCode, err = service.Consents("AU")
+	consents, errorCode, err = service.Consents(context.Background(), "AU")
 	assert.Nil(t, consents)
 	assert.Error(t, err)
 
-	consents, errorCode, err = service.Consents("")
+	consents, errorCode, err = service.Consents(context.Background(), "")
 	assert.Nil(t, consents)
 	assert.Nil(t, errorCode)
 	assert.Error(t, err)
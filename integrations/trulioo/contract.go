@@ -2,6 +2,7 @@ package trulioo
 
 import (
 	"encoding/base64"
+	"modulus/kyc/http"
 	"modulus/kyc/integrations/trulioo/configuration"
 	"modulus/kyc/integrations/trulioo/verification"
 )
@@ -11,6 +12,7 @@ type Config struct {
 	Host         string
 	NAPILogin    string
 	NAPIPassword string
+	HTTPClient   *http.Client
 }
 
 func (config Config) createToken() string {
@@ -22,16 +24,18 @@ func (config Config) createToken() string {
 // ToConfigurationConfig converts the service config to the specific config required to use for certain requests.
 func (config Config) ToConfigurationConfig() configuration.Config {
 	return configuration.Config{
-		Host:  config.Host + "/configuration/v1",
-		Token: config.createToken(),
+		Host:       config.Host + "/configuration/v1",
+		Token:      config.createToken(),
+		HTTPClient: config.HTTPClient,
 	}
 }
 
 // ToVerificationConfig converts the service config to the specific config required to use for certain requests.
 func (config Config) ToVerificationConfig() verification.Config {
 	return verification.Config{
-		Host:  config.Host + "/verifications/v1",
-		Token: config.createToken(),
+		Host:       config.Host + "/verifications/v1",
+		Token:      config.createToken(),
+		HTTPClient: config.HTTPClient,
 	}
 }
 
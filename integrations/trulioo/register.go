@@ -0,0 +1,29 @@
+package trulioo
+
+import (
+	"modulus/kyc/common"
+	"modulus/kyc/http"
+)
+
+func init() {
+	common.RegisterProvider(common.ProviderSpec{
+		Name:    common.Trulioo,
+		Options: []string{"Host", "NAPILogin", "NAPIPassword"},
+		Capabilities: common.ProviderCapabilities{
+			Documents: true,
+			Companies: true,
+		},
+		Factory: func(options map[string]string) (common.KYCPlatformContext, error) {
+			client, err := http.NewClientFromOptions(options)
+			if err != nil {
+				return nil, err
+			}
+			return New(Config{
+				Host:         options["Host"],
+				NAPILogin:    options["NAPILogin"],
+				NAPIPassword: options["NAPIPassword"],
+				HTTPClient:   client,
+			}), nil
+		},
+	})
+}
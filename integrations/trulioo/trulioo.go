@@ -1,6 +1,7 @@
 package trulioo
 
 import (
+	"context"
 	"fmt"
 	"modulus/kyc/common"
 	"modulus/kyc/integrations/trulioo/configuration"
@@ -9,7 +10,7 @@ import (
 	"github.com/pkg/errors"
 )
 
-var _ common.KYCPlatform = Trulioo{}
+var _ common.KYCPlatformContext = Trulioo{}
 
 // Trulioo defines the verification service.
 type Trulioo struct {
@@ -28,12 +29,17 @@ func New(config Config) Trulioo {
 
 // CheckCustomer implements KYCPlatform interface for Trulioo.
 func (service Trulioo) CheckCustomer(customer *common.UserData) (res common.KYCResult, err error) {
+	return service.CheckCustomerContext(context.Background(), customer)
+}
+
+// CheckCustomerContext implements KYCPlatformContext interface for Trulioo.
+func (service Trulioo) CheckCustomerContext(ctx context.Context, customer *common.UserData) (res common.KYCResult, err error) {
 	if customer == nil {
 		err = errors.New("No customer supplied")
 		return
 	}
 
-	consents, errorCode, err := service.configuration.Consents(customer.CountryAlpha2)
+	consents, errorCode, err := service.configuration.Consents(ctx, customer.CountryAlpha2)
 	if err != nil {
 		if errorCode != nil {
 			res.ErrorCode = fmt.Sprintf("%d", *errorCode)
@@ -43,7 +49,7 @@ func (service Trulioo) CheckCustomer(customer *common.UserData) (res common.KYCR
 
 	dataFields := verification.MapCustomerToDataFields(customer)
 
-	response, err := service.verification.Verify(customer.CountryAlpha2, consents, dataFields)
+	response, err := service.verification.Verify(ctx, customer.CountryAlpha2, consents, dataFields)
 	if response != nil && response.ErrorCode != nil {
 		res.ErrorCode = fmt.Sprintf("%d", *response.ErrorCode)
 	}
@@ -118,6 +124,11 @@ func (service Trulioo) CheckCustomer(customer *common.UserData) (res common.KYCR
 
 // CheckStatus implements KYCPlatform interface for Trulioo.
 func (service Trulioo) CheckStatus(referenceID string) (res common.KYCResult, err error) {
+	return service.CheckStatusContext(context.Background(), referenceID)
+}
+
+// CheckStatusContext implements KYCPlatformContext interface for Trulioo.
+func (service Trulioo) CheckStatusContext(ctx context.Context, referenceID string) (res common.KYCResult, err error) {
 	err = errors.New("Trulioo doesn't support a verification status check")
 	return
 }
@@ -1,16 +1,22 @@
 package verification
 
-import "modulus/kyc/integrations/trulioo/configuration"
+import (
+	"context"
+
+	"modulus/kyc/http"
+	"modulus/kyc/integrations/trulioo/configuration"
+)
 
 // Config represents the configuration for the service.
 type Config struct {
-	Host  string
-	Token string
+	Host       string
+	Token      string
+	HTTPClient *http.Client
 }
 
 // Verification defines the interface for the verification services.
 type Verification interface {
-	Verify(countryAlpha2 string, consents configuration.Consents, fields DataFields) (*Response, error)
+	Verify(ctx context.Context, countryAlpha2 string, consents configuration.Consents, fields DataFields) (*Response, error)
 }
 
 // Mock represents the mock of the service for tests.
@@ -19,6 +25,6 @@ type Mock struct {
 }
 
 // Verify implements Verification interface for Mock.
-func (mock Mock) Verify(countryAlpha2 string, consents configuration.Consents, fields DataFields) (*Response, error) {
+func (mock Mock) Verify(ctx context.Context, countryAlpha2 string, consents configuration.Consents, fields DataFields) (*Response, error) {
 	return mock.VerifyFn(countryAlpha2, consents, fields)
 }
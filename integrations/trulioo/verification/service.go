@@ -1,6 +1,7 @@
 package verification
 
 import (
+	"context"
 	"encoding/json"
 	"modulus/kyc/http"
 	"modulus/kyc/integrations/trulioo/configuration"
@@ -18,7 +19,7 @@ func NewService(config Config) Verification {
 	}
 }
 
-func (service service) Verify(countryAlpha2 string, consents configuration.Consents, fields DataFields) (*Response, error) {
+func (service service) Verify(ctx context.Context, countryAlpha2 string, consents configuration.Consents, fields DataFields) (*Response, error) {
 	request := StartVerificationRequest{
 		AcceptTruliooTermsAndConditions: true,
 		ConfigurationName:               "Identity Verification",
@@ -32,7 +33,8 @@ func (service service) Verify(countryAlpha2 string, consents configuration.Conse
 		return nil, err
 	}
 
-	code, responseBytes, err := http.Post(
+	code, responseBytes, err := service.config.HTTPClient.PostContext(
+		ctx,
 		service.config.Host+"/verify",
 		http.Headers{
 			"Authorization": "Basic " + service.config.Token,
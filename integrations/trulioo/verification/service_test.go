@@ -1,6 +1,7 @@
 package verification
 
 import (
+	"context"
 	"testing"
 
 	"errors"
@@ -65,7 +66,7 @@ func Test_service_Verify(t *testing.T) {
 		},
 	)
 
-	response, err := service.Verify("US", configuration.Consents{}, DataFields{})
+	response, err := service.Verify(context.Background(), "US", configuration.Consents{}, DataFields{})
 	if assert.NoError(t, err) && assert.NotNil(t, response) {
 		assert.Equal(t, "US", response.CountryCode)
 		assert.Equal(t, "02b39dac-55f2-4019-8cac-5de931669191", response.Record.TransactionRecordID)
@@ -128,7 +129,7 @@ func Test_service_Verify_Error(t *testing.T) {
 		},
 	)
 
-	response, err := service.Verify("", configuration.Consents{}, DataFields{})
+	response, err := service.Verify(context.Background(), "", configuration.Consents{}, DataFields{})
 	assert.Error(t, err)
 	assert.NotNil(t, response)
 	assert.Nil(t, response.ErrorCode)
@@ -142,7 +143,7 @@ func Test_service_Verify_Error(t *testing.T) {
 		},
 	)
 
-	response, err = service.Verify("", configuration.Consents{}, DataFields{})
+	response, err = service.Verify(context.Background(), "", configuration.Consents{}, DataFields{})
 	assert.Error(t, err)
 	assert.Nil(t, response)
 }
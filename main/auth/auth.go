@@ -0,0 +1,271 @@
+// Package auth authenticates the API clients of the service and authorizes their requests.
+// The clients are defined in the config. A client authenticates using the API key from the X-API-Key header
+// or the HS256/RS256 JWT token from the Authorization header whose subject is the client id.
+// Every client may be restricted to the subset of the KYC providers and the endpoints.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"modulus/kyc/common"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// The headers holding the client credentials.
+const (
+	APIKeyHeader        = "X-API-Key"
+	AuthorizationHeader = "Authorization"
+)
+
+// bearerPrefix is the prefix of the JWT token in the Authorization header.
+const bearerPrefix = "Bearer "
+
+// The authentication errors.
+var (
+	ErrMissingCredentials = errors.New("missing API key or token in the request")
+	ErrInvalidAPIKey      = errors.New("invalid API key")
+)
+
+// Error represents the authentication or the authorization error.
+// Status is the HTTP status code the request is responded with.
+type Error struct {
+	Status int
+	Err    error
+}
+
+// Error implements error interface for the Error.
+func (e Error) Error() string {
+	return e.Err.Error()
+}
+
+// Authenticator authenticates the API clients using the config.
+type Authenticator struct {
+	config  Config
+	methods []string
+}
+
+// New constructs the Authenticator using the config.
+func New(config Config) *Authenticator {
+	a := &Authenticator{
+		config: config,
+	}
+
+	if len(config.JWTSecret) > 0 {
+		a.methods = append(a.methods, jwt.SigningMethodHS256.Alg())
+	}
+	if config.JWTPublicKey != nil {
+		a.methods = append(a.methods, jwt.SigningMethodRS256.Alg())
+	}
+
+	return a
+}
+
+// Authenticate returns the client the request is sent by.
+func (a *Authenticator) Authenticate(r *http.Request) (client Client, err error) {
+	return a.AuthenticateCredentials(r.Header.Get(APIKeyHeader), r.Header.Get(AuthorizationHeader))
+}
+
+// AuthenticateCredentials returns the client owning the API key or the token of the authorization value.
+// The authorization value has the same form as the Authorization header, i.e. "Bearer <token>".
+func (a *Authenticator) AuthenticateCredentials(apiKey, authorization string) (client Client, err error) {
+	if len(apiKey) > 0 {
+		return a.authenticateAPIKey(apiKey)
+	}
+
+	if strings.HasPrefix(authorization, bearerPrefix) && a.config.JWTEnabled() {
+		return a.authenticateToken(strings.TrimPrefix(authorization, bearerPrefix))
+	}
+
+	err = Error{Status: http.StatusUnauthorized, Err: ErrMissingCredentials}
+
+	return
+}
+
+// authenticateAPIKey returns the client owning the API key.
+func (a *Authenticator) authenticateAPIKey(key string) (client Client, err error) {
+	hash := sha256.Sum256([]byte(key))
+
+	found := false
+	for _, c := range a.config.Clients {
+		for _, k := range c.apiKeys {
+			if subtle.ConstantTimeCompare(hash[:], k[:]) == 1 {
+				client, found = c, true
+			}
+		}
+	}
+	if !found {
+		err = Error{Status: http.StatusUnauthorized, Err: ErrInvalidAPIKey}
+	}
+
+	return
+}
+
+// authenticateToken returns the client the JWT token is issued to.
+func (a *Authenticator) authenticateToken(token string) (client Client, err error) {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(a.methods),
+		jwt.WithExpirationRequired(),
+	}
+	if len(a.config.JWTIssuer) > 0 {
+		options = append(options, jwt.WithIssuer(a.config.JWTIssuer))
+	}
+	if len(a.config.JWTAudience) > 0 {
+		options = append(options, jwt.WithAudience(a.config.JWTAudience))
+	}
+
+	claims := jwt.RegisteredClaims{}
+
+	_, err = jwt.ParseWithClaims(token, &claims, a.key, options...)
+	if err != nil {
+		err = Error{Status: http.StatusUnauthorized, Err: fmt.Errorf("invalid token: %s", err)}
+		return
+	}
+
+	client, ok := a.config.Clients[claims.Subject]
+	if !ok {
+		err = Error{Status: http.StatusUnauthorized, Err: fmt.Errorf("invalid token: unknown client %s", claims.Subject)}
+	}
+
+	return
+}
+
+// key returns the key verifying the token according to its signing method.
+func (a *Authenticator) key(token *jwt.Token) (interface{}, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return a.config.JWTSecret, nil
+	case jwt.SigningMethodRS256.Alg():
+		return a.config.JWTPublicKey, nil
+	}
+
+	return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+}
+
+type clientKey struct{}
+
+// WithClient returns the copy of the context holding the authenticated client.
+func WithClient(ctx context.Context, client Client) context.Context {
+	return context.WithValue(ctx, clientKey{}, client)
+}
+
+// ClientFromContext returns the authenticated client from the context if any.
+func ClientFromContext(ctx context.Context) (client Client, ok bool) {
+	client, ok = ctx.Value(clientKey{}).(Client)
+	return
+}
+
+// ClientID returns the id of the authenticated client from the context or the empty string if there is none.
+func ClientID(ctx context.Context) string {
+	client, _ := ClientFromContext(ctx)
+	return client.ID
+}
+
+// AuthorizeProvider checks whether the authenticated client from the context may use the provider.
+// The requests without the authenticated client are authorized since the authentication is disabled for them.
+func AuthorizeProvider(ctx context.Context, provider common.KYCProvider) error {
+	client, ok := ClientFromContext(ctx)
+	if !ok || client.AllowsProvider(provider) {
+		return nil
+	}
+
+	return Error{Status: http.StatusForbidden, Err: fmt.Errorf("client %s isn't allowed to use %s", client.ID, provider)}
+}
+
+var (
+	mu      sync.RWMutex
+	current *Authenticator
+)
+
+// Setup sets up the authentication of the service using the config.
+// The authentication is disabled if the config doesn't define clients.
+func Setup(config Config) {
+	var a *Authenticator
+	if config.Enabled() {
+		a = New(config)
+	}
+
+	mu.Lock()
+	current = a
+	mu.Unlock()
+}
+
+// Enabled reports whether the authentication of the service is enabled.
+func Enabled() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return current != nil
+}
+
+// ErrorWriter writes the response for the authentication or the authorization error.
+type ErrorWriter func(w http.ResponseWriter, err error)
+
+// Middleware authenticates the requests to the endpoint and checks the client may request it.
+// The authenticated client is passed to the handler in the request context.
+// The requests pass through if the authentication is disabled.
+func Middleware(endpoint string, next http.Handler) http.Handler {
+	return MiddlewareWithErrorWriter(endpoint, next, WriteError)
+}
+
+// MiddlewareWithErrorWriter is like Middleware but writes the errors using the writeError.
+func MiddlewareWithErrorWriter(endpoint string, next http.Handler, writeError ErrorWriter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := Authorize(r.Context(), endpoint, r.Header.Get(APIKeyHeader), r.Header.Get(AuthorizationHeader))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Authorize authenticates the client by the credentials and checks it may request the endpoint.
+// The returned context holds the authenticated client.
+// The context is returned as is if the authentication is disabled.
+// It serves the APIs other than REST, e.g. gRPC, that get the credentials from their own headers.
+func Authorize(ctx context.Context, endpoint, apiKey, authorization string) (context.Context, error) {
+	mu.RLock()
+	a := current
+	mu.RUnlock()
+
+	if a == nil {
+		return ctx, nil
+	}
+
+	client, err := a.AuthenticateCredentials(apiKey, authorization)
+	if err == nil && !client.AllowsEndpoint(endpoint) {
+		err = Error{Status: http.StatusForbidden, Err: fmt.Errorf("client %s isn't allowed to request %s", client.ID, endpoint)}
+	}
+	if err != nil {
+		return ctx, err
+	}
+
+	return WithClient(ctx, client), nil
+}
+
+// WriteError writes the error response for the authentication or the authorization error.
+func WriteError(w http.ResponseWriter, err error) {
+	status := http.StatusUnauthorized
+	if e, ok := err.(Error); ok {
+		status = e.Status
+	}
+	if status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="kyc"`)
+	}
+
+	resp, _ := json.Marshal(common.ErrorResponse{Error: err.Error()})
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(resp)
+}
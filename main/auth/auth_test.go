@@ -0,0 +1,386 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"modulus/kyc/common"
+	// Make the registered KYC providers known to the tests.
+	_ "modulus/kyc/integrations"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// signToken returns the token signed by the key using the method.
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, claims jwt.RegisteredClaims) string {
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
+
+// requestWith returns the request having the header set.
+func requestWith(header, value string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/CheckCustomer", nil)
+	if len(header) > 0 {
+		req.Header.Set(header, value)
+	}
+
+	return req
+}
+
+func TestConfigFromOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing the disabled authentication.
+	config, err := ConfigFromOptions(map[string]string{"Port": "8080"}, nil)
+
+	assert.NoError(err)
+	assert.False(config.Enabled())
+	assert.False(config.JWTEnabled())
+
+	// Testing the clients.
+	config, err = ConfigFromOptions(map[string]string{
+		"AuthJWTSecret":   "secret",
+		"AuthJWTIssuer":   "https://auth.example.com",
+		"AuthJWTAudience": "kyc",
+	}, map[string]map[string]string{
+		"backoffice": {
+			"APIKey":    "key1, key2",
+			"Providers": "IDology,Jumio",
+			"Endpoints": "CheckCustomer",
+		},
+		"mobile": {},
+	})
+
+	assert.NoError(err)
+	assert.True(config.Enabled())
+	assert.True(config.JWTEnabled())
+	assert.Equal([]byte("secret"), config.JWTSecret)
+	assert.Equal("https://auth.example.com", config.JWTIssuer)
+	assert.Equal("kyc", config.JWTAudience)
+	assert.Len(config.Clients, 2)
+
+	backoffice := config.Clients["backoffice"]
+
+	assert.Equal("backoffice", backoffice.ID)
+	assert.Equal([]common.KYCProvider{common.IDology, common.Jumio}, backoffice.Providers)
+	assert.Equal([]string{"CheckCustomer"}, backoffice.Endpoints)
+	assert.Len(backoffice.apiKeys, 2)
+	assert.True(backoffice.AllowsProvider(common.Jumio))
+	assert.False(backoffice.AllowsProvider(common.Trulioo))
+	assert.True(backoffice.AllowsEndpoint("CheckCustomer"))
+	assert.False(backoffice.AllowsEndpoint("CheckStatus"))
+
+	mobile := config.Clients["mobile"]
+
+	assert.True(mobile.AllowsProvider(common.Trulioo))
+	assert.True(mobile.AllowsEndpoint("CheckStatus"))
+
+	// Testing the client without the API key.
+	_, err = ConfigFromOptions(nil, map[string]map[string]string{
+		"mobile": {},
+	})
+
+	if assert.Error(err) {
+		assert.Equal("client mobile: missing option 'APIKey'", err.Error())
+	}
+
+	// Testing the unknown provider.
+	_, err = ConfigFromOptions(nil, map[string]map[string]string{
+		"backoffice": {
+			"APIKey":    "key",
+			"Providers": "Foobar",
+		},
+	})
+
+	if assert.Error(err) {
+		assert.Equal("client backoffice: invalid option 'Providers': unknown provider Foobar", err.Error())
+	}
+
+	// Testing the JWT authentication without clients.
+	_, err = ConfigFromOptions(map[string]string{"AuthJWTSecret": "secret"}, nil)
+
+	if assert.Error(err) {
+		assert.Equal("the JWT authentication requires the clients to be defined", err.Error())
+	}
+
+	// Testing the missing public key file.
+	_, err = ConfigFromOptions(map[string]string{"AuthJWTPublicKey": "nonexistent.pem"}, nil)
+
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "invalid option 'AuthJWTPublicKey': ")
+	}
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	assert := assert.New(t)
+
+	config, err := ConfigFromOptions(nil, map[string]map[string]string{
+		"backoffice": {"APIKey": "key1,key2"},
+		"mobile":     {"APIKey": "key3"},
+	})
+	if !assert.NoError(err) {
+		return
+	}
+
+	a := New(config)
+
+	client, err := a.Authenticate(requestWith(APIKeyHeader, "key2"))
+
+	assert.NoError(err)
+	assert.Equal("backoffice", client.ID)
+
+	client, err = a.Authenticate(requestWith(APIKeyHeader, "key3"))
+
+	assert.NoError(err)
+	assert.Equal("mobile", client.ID)
+
+	// Testing the invalid API key.
+	_, err = a.Authenticate(requestWith(APIKeyHeader, "key4"))
+
+	assert.Equal(Error{Status: http.StatusUnauthorized, Err: ErrInvalidAPIKey}, err)
+
+	// Testing the missing credentials.
+	_, err = a.Authenticate(requestWith("", ""))
+
+	assert.Equal(Error{Status: http.StatusUnauthorized, Err: ErrMissingCredentials}, err)
+
+	// Testing the token while the JWT authentication is disabled.
+	_, err = a.Authenticate(requestWith(AuthorizationHeader, "Bearer token"))
+
+	assert.Equal(Error{Status: http.StatusUnauthorized, Err: ErrMissingCredentials}, err)
+}
+
+func TestAuthenticateHS256(t *testing.T) {
+	assert := assert.New(t)
+
+	config, err := ConfigFromOptions(map[string]string{
+		"AuthJWTSecret":   "secret",
+		"AuthJWTIssuer":   "https://auth.example.com",
+		"AuthJWTAudience": "kyc",
+	}, map[string]map[string]string{
+		"backoffice": {},
+	})
+	if !assert.NoError(err) {
+		return
+	}
+
+	a := New(config)
+
+	claims := jwt.RegisteredClaims{
+		Subject:   "backoffice",
+		Issuer:    "https://auth.example.com",
+		Audience:  jwt.ClaimStrings{"kyc"},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+
+	client, err := a.Authenticate(requestWith(AuthorizationHeader, "Bearer "+signToken(t, jwt.SigningMethodHS256, []byte("secret"), claims)))
+
+	assert.NoError(err)
+	assert.Equal("backoffice", client.ID)
+
+	// Testing the wrong secret.
+	_, err = a.Authenticate(requestWith(AuthorizationHeader, "Bearer "+signToken(t, jwt.SigningMethodHS256, []byte("wrong"), claims)))
+
+	if assert.Error(err) {
+		assert.Equal(http.StatusUnauthorized, err.(Error).Status)
+		assert.Contains(err.Error(), "invalid token: ")
+	}
+
+	// Testing the expired token.
+	expired := claims
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+
+	_, err = a.Authenticate(requestWith(AuthorizationHeader, "Bearer "+signToken(t, jwt.SigningMethodHS256, []byte("secret"), expired)))
+
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "token is expired")
+	}
+
+	// Testing the token without expiration.
+	eternal := claims
+	eternal.ExpiresAt = nil
+
+	_, err = a.Authenticate(requestWith(AuthorizationHeader, "Bearer "+signToken(t, jwt.SigningMethodHS256, []byte("secret"), eternal)))
+
+	assert.Error(err)
+
+	// Testing the wrong audience.
+	foreign := claims
+	foreign.Audience = jwt.ClaimStrings{"billing"}
+
+	_, err = a.Authenticate(requestWith(AuthorizationHeader, "Bearer "+signToken(t, jwt.SigningMethodHS256, []byte("secret"), foreign)))
+
+	assert.Error(err)
+
+	// Testing the unknown client.
+	unknown := claims
+	unknown.Subject = "mobile"
+
+	_, err = a.Authenticate(requestWith(AuthorizationHeader, "Bearer "+signToken(t, jwt.SigningMethodHS256, []byte("secret"), unknown)))
+
+	if assert.Error(err) {
+		assert.Equal("invalid token: unknown client mobile", err.Error())
+	}
+}
+
+func TestAuthenticateRS256(t *testing.T) {
+	assert := assert.New(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if !assert.NoError(err) {
+		return
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if !assert.NoError(err) {
+		return
+	}
+
+	dir, err := ioutil.TempDir("", "auth")
+	if !assert.NoError(err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "public.pem")
+
+	err = ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600)
+	if !assert.NoError(err) {
+		return
+	}
+
+	config, err := ConfigFromOptions(map[string]string{
+		"AuthJWTPublicKey": path,
+	}, map[string]map[string]string{
+		"backoffice": {},
+	})
+	if !assert.NoError(err) {
+		return
+	}
+
+	a := New(config)
+
+	claims := jwt.RegisteredClaims{
+		Subject:   "backoffice",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+
+	client, err := a.Authenticate(requestWith(AuthorizationHeader, "Bearer "+signToken(t, jwt.SigningMethodRS256, key, claims)))
+
+	assert.NoError(err)
+	assert.Equal("backoffice", client.ID)
+
+	// Testing the HS256 token while only RS256 is configured.
+	_, err = a.Authenticate(requestWith(AuthorizationHeader, "Bearer "+signToken(t, jwt.SigningMethodHS256, der, claims)))
+
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "invalid token: ")
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	assert := assert.New(t)
+
+	defer Setup(Config{})
+
+	var clientID string
+	handler := Middleware("CheckCustomer", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID = ClientID(r.Context())
+	}))
+
+	// Testing the disabled authentication.
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, requestWith("", ""))
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.False(Enabled())
+	assert.Empty(clientID)
+
+	config, err := ConfigFromOptions(nil, map[string]map[string]string{
+		"backoffice": {"APIKey": "key1", "Endpoints": "CheckCustomer"},
+		"mobile":     {"APIKey": "key2", "Endpoints": "CheckStatus"},
+	})
+	if !assert.NoError(err) {
+		return
+	}
+
+	Setup(config)
+
+	assert.True(Enabled())
+
+	// Testing the authenticated client.
+	w = httptest.NewRecorder()
+
+	handler.ServeHTTP(w, requestWith(APIKeyHeader, "key1"))
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("backoffice", clientID)
+
+	// Testing the missing credentials.
+	w = httptest.NewRecorder()
+
+	handler.ServeHTTP(w, requestWith("", ""))
+
+	assert.Equal(http.StatusUnauthorized, w.Code)
+	assert.Equal(`Bearer realm="kyc"`, w.Header().Get("WWW-Authenticate"))
+	assert.Equal(`{"Error":"missing API key or token in the request"}`, w.Body.String())
+
+	// Testing the disallowed endpoint.
+	w = httptest.NewRecorder()
+
+	handler.ServeHTTP(w, requestWith(APIKeyHeader, "key2"))
+
+	assert.Equal(http.StatusForbidden, w.Code)
+	assert.Empty(w.Header().Get("WWW-Authenticate"))
+	assert.Equal(`{"Error":"client mobile isn't allowed to request CheckCustomer"}`, w.Body.String())
+
+	// Testing the custom error writer.
+	var writtenErr error
+	handler = MiddlewareWithErrorWriter("CheckCustomer", handler, func(w http.ResponseWriter, err error) {
+		writtenErr = err
+		w.WriteHeader(http.StatusTeapot)
+	})
+	w = httptest.NewRecorder()
+
+	handler.ServeHTTP(w, requestWith(APIKeyHeader, "key2"))
+
+	assert.Equal(http.StatusTeapot, w.Code)
+	if assert.Error(writtenErr) {
+		assert.Equal(http.StatusForbidden, writtenErr.(Error).Status)
+	}
+}
+
+func TestAuthorizeProvider(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := httptest.NewRequest(http.MethodGet, "/", nil).Context()
+
+	// Testing the request without the authenticated client.
+	assert.NoError(AuthorizeProvider(ctx, common.Jumio))
+	assert.Empty(ClientID(ctx))
+
+	ctx = WithClient(ctx, Client{ID: "backoffice", Providers: []common.KYCProvider{common.IDology}})
+
+	assert.Equal("backoffice", ClientID(ctx))
+	assert.NoError(AuthorizeProvider(ctx, common.IDology))
+
+	err := AuthorizeProvider(ctx, common.Jumio)
+
+	assert.Equal(Error{Status: http.StatusForbidden, Err: err.(Error).Err}, err)
+	assert.Equal("client backoffice isn't allowed to use Jumio", err.Error())
+}
@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"modulus/kyc/common"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ClientSectionPrefix is the prefix of the config sections defining the API clients.
+// The rest of the section name is the client id, e.g. [Client:backoffice].
+const ClientSectionPrefix = "Client:"
+
+// The names of the client options in the client config section.
+const (
+	APIKeyOption    = "APIKey"
+	ProvidersOption = "Providers"
+	EndpointsOption = "Endpoints"
+)
+
+// The names of the authentication options in the service config section.
+const (
+	JWTSecretOption    = "AuthJWTSecret"
+	JWTPublicKeyOption = "AuthJWTPublicKey"
+	JWTIssuerOption    = "AuthJWTIssuer"
+	JWTAudienceOption  = "AuthJWTAudience"
+)
+
+// Client represents the API client of the service.
+//
+// * ID is the identificator of the client. It's the subject of the client's JWT tokens.
+// * Providers lists the KYC providers the client may use. Any provider may be used if it's empty.
+// * Endpoints lists the endpoints the client may request, e.g. CheckCustomer. Any endpoint may be requested if it's empty.
+type Client struct {
+	ID        string
+	Providers []common.KYCProvider
+	Endpoints []string
+	apiKeys   [][sha256.Size]byte
+}
+
+// AllowsProvider reports whether the client may use the provider.
+func (c Client) AllowsProvider(provider common.KYCProvider) bool {
+	if len(c.Providers) == 0 {
+		return true
+	}
+	for _, p := range c.Providers {
+		if p == provider {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllowsEndpoint reports whether the client may request the endpoint.
+func (c Client) AllowsEndpoint(endpoint string) bool {
+	if len(c.Endpoints) == 0 {
+		return true
+	}
+	for _, e := range c.Endpoints {
+		if e == endpoint {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Config holds the settings of the authentication.
+//
+// * Clients are the API clients keyed by their ids. The authentication is disabled if there are no clients.
+// * JWTSecret is the key of the HS256 tokens.
+// * JWTPublicKey is the key of the RS256 tokens.
+// * JWTIssuer and JWTAudience are the required issuer and audience of the tokens if specified.
+type Config struct {
+	Clients      map[string]Client
+	JWTSecret    []byte
+	JWTPublicKey *rsa.PublicKey
+	JWTIssuer    string
+	JWTAudience  string
+}
+
+// Enabled reports whether the requests must be authenticated.
+func (c Config) Enabled() bool {
+	return len(c.Clients) > 0
+}
+
+// JWTEnabled reports whether the clients may authenticate using the JWT tokens.
+func (c Config) JWTEnabled() bool {
+	return len(c.JWTSecret) > 0 || c.JWTPublicKey != nil
+}
+
+// ConfigFromOptions parses the authentication options from the service config section
+// and the client config sections keyed by the client ids.
+func ConfigFromOptions(options map[string]string, clients map[string]map[string]string) (config Config, err error) {
+	config = Config{
+		JWTSecret:   []byte(options[JWTSecretOption]),
+		JWTIssuer:   options[JWTIssuerOption],
+		JWTAudience: options[JWTAudienceOption],
+	}
+
+	if path := options[JWTPublicKeyOption]; len(path) > 0 {
+		pem, err1 := ioutil.ReadFile(path)
+		if err1 != nil {
+			err = fmt.Errorf("invalid option '%s': %s", JWTPublicKeyOption, err1)
+			return
+		}
+		if config.JWTPublicKey, err = jwt.ParseRSAPublicKeyFromPEM(pem); err != nil {
+			err = fmt.Errorf("invalid option '%s': %s", JWTPublicKeyOption, err)
+			return
+		}
+	}
+
+	if len(clients) == 0 {
+		if config.JWTEnabled() {
+			err = errors.New("the JWT authentication requires the clients to be defined")
+		}
+		return
+	}
+
+	config.Clients = make(map[string]Client, len(clients))
+	for id, clientOptions := range clients {
+		client, err1 := ClientFromOptions(id, clientOptions)
+		if err1 == nil && len(client.apiKeys) == 0 && !config.JWTEnabled() {
+			err1 = fmt.Errorf("missing option '%s'", APIKeyOption)
+		}
+		if err1 != nil {
+			err = fmt.Errorf("client %s: %s", id, err1)
+			return
+		}
+		config.Clients[id] = client
+	}
+
+	return
+}
+
+// ClientFromOptions parses the client options from the client config section.
+func ClientFromOptions(id string, options map[string]string) (client Client, err error) {
+	if len(id) == 0 {
+		err = errors.New("empty client id")
+		return
+	}
+
+	client = Client{
+		ID:        id,
+		Endpoints: splitList(options[EndpointsOption]),
+	}
+
+	for _, key := range splitList(options[APIKeyOption]) {
+		client.apiKeys = append(client.apiKeys, sha256.Sum256([]byte(key)))
+	}
+
+	for _, name := range splitList(options[ProvidersOption]) {
+		provider := common.KYCProvider(name)
+		if !common.KnownProvider(provider) && provider != common.Example {
+			err = fmt.Errorf("invalid option '%s': unknown provider %s", ProvidersOption, name)
+			return
+		}
+		client.Providers = append(client.Providers, provider)
+	}
+
+	return
+}
+
+// splitList splits the comma-separated list of values skipping the empty ones.
+func splitList(list string) (values []string) {
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); len(value) > 0 {
+			values = append(values, value)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,759 @@
+// Package batch verifies the customers in bulk, e.g. to re-screen them after the compliance policy has changed.
+// The batch of the customer records in NDJSON or CSV is verified by the KYC provider in the background.
+// The records are verified with the concurrency limited per provider and the requests exceeding
+// the rate limits are retried after the wait. The progress is checkpointed after every record, so the batch
+// interrupted by the restart or the crash of the service is resumed from the last checkpoint.
+// The results joined to the record ids are written to the NDJSON file that is downloaded by the client.
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/logging"
+
+	"github.com/google/uuid"
+)
+
+// The states of the batch.
+const (
+	Queued    = "queued"
+	Running   = "running"
+	Completed = "completed"
+	Failed    = "failed"
+)
+
+// The names of the files of the batch in its directory.
+const (
+	stateFile   = "batch.json"
+	inputFile   = "input.ndjson"
+	resultsFile = "results.ndjson"
+)
+
+// The retries of the record verification exceeding the rate limits.
+// The record isn't retried if the wait is longer than maxRetryWait, e.g. the quota is exhausted.
+const (
+	maxRetries   = 10
+	maxRetryWait = 5 * time.Minute
+)
+
+// The errors of the batches.
+var (
+	ErrDisabled = errors.New("batch verifications aren't configured")
+	ErrNotFound = errors.New("batch not found")
+)
+
+// errDrained stops the verification of the batch drained before the stop.
+var errDrained = errors.New("batch verification drained")
+
+// Runner verifies the customer according to the CheckCustomer request of the client.
+// It returns the response and the ids of the verifications recorded in the history.
+// The error is returned if the request itself has failed. The request exceeding the rate limits is retried
+// if the error has the RetryAfter method returning the wait.
+type Runner func(ctx context.Context, clientID string, req common.CheckCustomerRequest) (response common.KYCResponse, ids []string, err error)
+
+// retryable represents the error of the request that may be retried after the wait.
+type retryable interface {
+	RetryAfter() time.Duration
+}
+
+// Batch represents the state of the batch verification.
+// Processed and ResultsSize are the checkpoint: the number of the records whose results are written
+// and the size of the results file holding them.
+type Batch struct {
+	ID          string
+	ClientID    string
+	Provider    common.KYCProvider
+	State       string
+	Total       int
+	Processed   int
+	Failed      int
+	ResultsSize int64
+	Error       string `json:",omitempty"`
+	Created     time.Time
+	Started     *time.Time `json:",omitempty"`
+	Finished    *time.Time `json:",omitempty"`
+}
+
+// Done reports whether the batch is completed or failed.
+func (b Batch) Done() bool {
+	return b.State == Completed || b.State == Failed
+}
+
+// BatchResponse returns the representation of the batch for the API.
+func (b Batch) BatchResponse() common.BatchResponse {
+	return common.BatchResponse{
+		ID:        b.ID,
+		State:     b.State,
+		Provider:  b.Provider,
+		Total:     b.Total,
+		Processed: b.Processed,
+		Failed:    b.Failed,
+		Error:     b.Error,
+		Created:   b.Created,
+		Started:   b.Started,
+		Finished:  b.Finished,
+	}
+}
+
+// Processor stores the batches and verifies their records in the background.
+type Processor struct {
+	config      Config
+	runner      Runner
+	concurrency ConcurrencyFunc
+	mu          sync.Mutex
+	batches     map[string]Batch
+	slots       map[common.KYCProvider]chan struct{}
+	ctx         context.Context
+	cancel      context.CancelFunc
+	idle        context.Context
+	drain       context.CancelFunc
+	done        sync.WaitGroup
+}
+
+// New constructs a new Processor using the config and loads the stored batches.
+// The runner verifies the records and the concurrency limits the number of the records verified by a provider at once.
+func New(config Config, runner Runner, concurrency ConcurrencyFunc) (p *Processor, err error) {
+	if err = os.MkdirAll(config.Dir, 0700); err != nil {
+		err = fmt.Errorf("opening the batches directory %s: %s", config.Dir, err)
+		return
+	}
+
+	dirs, err := ioutil.ReadDir(config.Dir)
+	if err != nil {
+		err = fmt.Errorf("opening the batches directory %s: %s", config.Dir, err)
+		return
+	}
+
+	p = &Processor{
+		config:      config,
+		runner:      runner,
+		concurrency: concurrency,
+		batches:     map[string]Batch{},
+		slots:       map[common.KYCProvider]chan struct{}{},
+	}
+
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+
+		data, err1 := ioutil.ReadFile(filepath.Join(config.Dir, dir.Name(), stateFile))
+		if os.IsNotExist(err1) {
+			// The batch hasn't been accepted before the stop.
+			os.RemoveAll(filepath.Join(config.Dir, dir.Name()))
+			continue
+		}
+
+		b := Batch{}
+		if err1 == nil {
+			err1 = json.Unmarshal(data, &b)
+		}
+		if err1 != nil {
+			err = fmt.Errorf("loading the batch %s: %s", dir.Name(), err1)
+			p = nil
+			return
+		}
+
+		p.batches[b.ID] = b
+	}
+
+	return
+}
+
+// Start resumes the unfinished batches from their checkpoints and launches the cleanup of the finished ones.
+// The records being verified are cancelled by the context, while the next records aren't taken once the idle context is done.
+func (p *Processor) Start() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+	p.idle, p.drain = context.WithCancel(p.ctx)
+
+	unfinished := []Batch{}
+	for _, b := range p.batches {
+		if !b.Done() {
+			unfinished = append(unfinished, b)
+		}
+	}
+	sort.Slice(unfinished, func(i, j int) bool { return unfinished[i].Created.Before(unfinished[j].Created) })
+
+	for _, b := range unfinished {
+		p.launch(b)
+	}
+
+	p.done.Add(1)
+	go p.clean(p.idle)
+}
+
+// Stop stops the verification of the batches and waits for it to finish.
+// The batches are resumed from their checkpoints after the next start.
+func (p *Processor) Stop() {
+	p.mu.Lock()
+	cancel := p.cancel
+	p.cancel = nil
+	p.drain = nil
+	p.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+	p.done.Wait()
+}
+
+// Drain stops taking the next records of the batches and waits for the records being verified until the context is done.
+// The verification still running then is cancelled like by Stop. The batches are resumed from their checkpoints after the next start.
+func (p *Processor) Drain(ctx context.Context) {
+	p.mu.Lock()
+	drain := p.drain
+	p.mu.Unlock()
+
+	if drain == nil {
+		return
+	}
+
+	drain()
+
+	finished := make(chan struct{})
+	go func() {
+		p.done.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+	case <-ctx.Done():
+	}
+
+	p.Stop()
+}
+
+// Submit reads the records of the client from the input in the format of the media type
+// and queues their verification by the provider.
+// The malformed input is rejected with the InputError.
+func (p *Processor) Submit(clientID string, provider common.KYCProvider, mediaType string, input io.Reader) (b Batch, err error) {
+	b = Batch{
+		ID:       uuid.New().String(),
+		ClientID: clientID,
+		Provider: provider,
+		State:    Queued,
+		Created:  time.Now(),
+	}
+
+	dir := p.dir(b.ID)
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return
+	}
+
+	b.Total, err = p.writeInput(filepath.Join(dir, inputFile), newRecordReader(input, mediaType))
+	if err == nil {
+		err = ioutil.WriteFile(filepath.Join(dir, resultsFile), nil, 0600)
+	}
+	if err == nil {
+		err = p.put(b)
+	}
+	if err != nil {
+		os.RemoveAll(dir)
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cancel != nil {
+		p.launch(b)
+	}
+
+	return
+}
+
+// Get returns the batch by its id.
+func (p *Processor) Get(id string) (b Batch, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	b, ok := p.batches[id]
+	if !ok {
+		err = ErrNotFound
+	}
+
+	return
+}
+
+// Results returns the reader of the checkpointed results of the batch and its size.
+// The reader must be closed after use.
+func (p *Processor) Results(id string) (results io.ReadCloser, size int64, err error) {
+	b, err := p.Get(id)
+	if err != nil {
+		return
+	}
+
+	file, err := os.Open(filepath.Join(p.dir(id), resultsFile))
+	if err != nil {
+		return
+	}
+
+	results = struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(file, b.ResultsSize), file}
+	size = b.ResultsSize
+
+	return
+}
+
+// writeInput writes the records read by the reader to the input file as NDJSON and returns their number.
+// The records without the ids get their numbers in the batch as the ids.
+func (p *Processor) writeInput(filename string, reader recordReader) (total int, err error) {
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	ids := map[string]bool{}
+
+	for {
+		record, err1 := reader.read()
+		if err1 == io.EOF {
+			break
+		}
+		if err1 != nil {
+			err = err1
+			return
+		}
+
+		total++
+		if total > p.config.MaxRecords {
+			err = InputError{Err: fmt.Errorf("too many records, the maximum is %d", p.config.MaxRecords)}
+			return
+		}
+
+		if len(record.ID) == 0 {
+			record.ID = strconv.Itoa(total)
+		}
+		if ids[record.ID] {
+			err = InputError{Err: fmt.Errorf("duplicate record id: %s", record.ID)}
+			return
+		}
+		ids[record.ID] = true
+
+		if err = encoder.Encode(record); err != nil {
+			return
+		}
+	}
+
+	if total == 0 {
+		err = InputError{Err: errors.New("empty batch")}
+		return
+	}
+
+	err = file.Sync()
+
+	return
+}
+
+// launch starts the verification of the batch in the background.
+// It must be called with the lock held.
+func (p *Processor) launch(b Batch) {
+	ctx, idle := p.ctx, p.idle
+
+	p.done.Add(1)
+	go func() {
+		defer p.done.Done()
+		p.process(ctx, idle, b)
+	}()
+}
+
+// process verifies the records of the batch from its checkpoint until the context or the idle context is done.
+// The batch interrupted either way is left running, so it's resumed after the next start.
+func (p *Processor) process(ctx, idle context.Context, b Batch) {
+	if b.Started == nil {
+		now := time.Now()
+		b.Started = &now
+	}
+	b.State = Running
+
+	err := p.put(b)
+	if err == nil {
+		err = p.verify(ctx, idle, &b)
+	}
+	if ctx.Err() != nil || err == errDrained {
+		return
+	}
+
+	now := time.Now()
+	b.Finished = &now
+	if err != nil {
+		b.State = Failed
+		b.Error = err.Error()
+		slog.Error("Batch verification error", "batchID", b.ID, logging.ErrorKey, err)
+	} else {
+		b.State = Completed
+	}
+
+	// The customer data isn't kept after the batch is finished.
+	if err := os.Remove(filepath.Join(p.dir(b.ID), inputFile)); err != nil && !os.IsNotExist(err) {
+		slog.Error("Batch input removal error", "batchID", b.ID, logging.ErrorKey, err)
+	}
+
+	if err := p.put(b); err != nil {
+		slog.Error("Batch state store error", "batchID", b.ID, logging.ErrorKey, err)
+	}
+}
+
+// verify verifies the records of the batch from the checkpoint with the window of the records verified at once.
+// The results are written in the order of the records and the checkpoint is advanced after every record,
+// so only the records being verified are verified again after the restart. The results file is truncated
+// to the checkpoint to drop the result written halfway. The next records aren't taken once the idle context
+// is done, and errDrained is returned after the records being verified are written.
+func (p *Processor) verify(ctx, idle context.Context, b *Batch) (err error) {
+	input, err := os.Open(filepath.Join(p.dir(b.ID), inputFile))
+	if err != nil {
+		return
+	}
+	defer input.Close()
+
+	results, err := os.OpenFile(filepath.Join(p.dir(b.ID), resultsFile), os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer results.Close()
+
+	if err = results.Truncate(b.ResultsSize); err != nil {
+		return
+	}
+	if _, err = results.Seek(b.ResultsSize, io.SeekStart); err != nil {
+		return
+	}
+
+	reader := newRecordReader(input, NDJSON)
+	for i := 0; i < b.Processed; i++ {
+		if _, err = reader.read(); err != nil {
+			return
+		}
+	}
+
+	window := p.concurrency(b.Provider)
+	slots := p.providerSlots(b.Provider, window)
+
+	// The results of the records being verified in the order of the records.
+	pending := []chan common.BatchResult{}
+	defer func() {
+		// The records being verified don't outlive the batch verification.
+		for _, result := range pending {
+			<-result
+		}
+	}()
+
+	eof := false
+	for {
+		if !eof && idle.Err() == nil && len(pending) < window {
+			record, err1 := reader.read()
+			if err1 == io.EOF {
+				eof = true
+				continue
+			}
+			if err1 != nil {
+				return err1
+			}
+
+			result := make(chan common.BatchResult, 1)
+			pending = append(pending, result)
+
+			go func(record common.BatchRecord) {
+				select {
+				case slots <- struct{}{}:
+				case <-ctx.Done():
+					result <- common.BatchResult{}
+					return
+				}
+				line := p.verifyRecord(ctx, b.ClientID, b.Provider, record)
+				<-slots
+				result <- line
+			}(record)
+
+			continue
+		}
+
+		if len(pending) == 0 {
+			if !eof {
+				err = errDrained
+			}
+			return
+		}
+
+		line := <-pending[0]
+		pending = pending[1:]
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err = p.checkpoint(results, b, line); err != nil {
+			return
+		}
+	}
+}
+
+// checkpoint writes the result of the next record of the batch to the results file and stores the progress.
+func (p *Processor) checkpoint(results *os.File, b *Batch, result common.BatchResult) (err error) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return
+	}
+	data = append(data, '\n')
+
+	if _, err = results.Write(data); err != nil {
+		return
+	}
+	if err = results.Sync(); err != nil {
+		return
+	}
+
+	b.Processed++
+	b.ResultsSize += int64(len(data))
+	if len(result.Error) > 0 {
+		b.Failed++
+	}
+
+	err = p.put(*b)
+
+	return
+}
+
+// verifyRecord verifies the customer of the record by the provider.
+// The request exceeding the rate limits is retried after the wait unless it's too long.
+func (p *Processor) verifyRecord(ctx context.Context, clientID string, provider common.KYCProvider, record common.BatchRecord) (result common.BatchResult) {
+	req := common.CheckCustomerRequest{
+		Provider: provider,
+		UserData: record.UserData,
+	}
+
+	var (
+		response common.KYCResponse
+		ids      []string
+		err      error
+	)
+
+	for attempt := 0; ; attempt++ {
+		response, ids, err = p.runner(ctx, clientID, req)
+
+		e, ok := err.(retryable)
+		if !ok || e.RetryAfter() <= 0 || e.RetryAfter() > maxRetryWait || attempt >= maxRetries {
+			break
+		}
+
+		timer := time.NewTimer(e.RetryAfter())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+
+	result = common.BatchResult{
+		ID:     record.ID,
+		Result: response.Result,
+		Error:  response.Error,
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	if len(ids) > 0 {
+		result.VerificationID = ids[0]
+	}
+
+	return
+}
+
+// providerSlots returns the slots limiting the number of the records verified by the provider at once across the batches.
+func (p *Processor) providerSlots(provider common.KYCProvider, concurrency int) chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	slots, ok := p.slots[provider]
+	if !ok || cap(slots) != concurrency {
+		slots = make(chan struct{}, concurrency)
+		p.slots[provider] = slots
+	}
+
+	return slots
+}
+
+// clean removes the expired batches periodically until the context is done.
+func (p *Processor) clean(ctx context.Context) {
+	defer p.done.Done()
+
+	interval := p.config.Retention
+	if interval > time.Hour {
+		interval = time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			p.cleanup(now)
+		}
+	}
+}
+
+// cleanup removes the batches finished longer than the retention ago by the time.
+func (p *Processor) cleanup(now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for id, b := range p.batches {
+		if !b.Done() || b.Finished == nil || now.Sub(*b.Finished) <= p.config.Retention {
+			continue
+		}
+		if err := os.RemoveAll(p.dir(id)); err != nil {
+			slog.Error("Batch removal error", "batchID", id, logging.ErrorKey, err)
+			continue
+		}
+		delete(p.batches, id)
+	}
+}
+
+// put stores the state of the batch.
+// The file is replaced atomically so the checkpoint is never lost halfway.
+func (p *Processor) put(b Batch) (err error) {
+	data, err := json.Marshal(b)
+	if err != nil {
+		return
+	}
+
+	filename := filepath.Join(p.dir(b.ID), stateFile)
+	tmp := filename + ".tmp"
+
+	if err = ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return
+	}
+	if err = os.Rename(tmp, filename); err != nil {
+		os.Remove(tmp)
+		return
+	}
+
+	p.mu.Lock()
+	p.batches[b.ID] = b
+	p.mu.Unlock()
+
+	return
+}
+
+// dir returns the directory of the batch.
+func (p *Processor) dir(id string) string {
+	return filepath.Join(p.config.Dir, id)
+}
+
+var (
+	mu      sync.RWMutex
+	current *Processor
+)
+
+// Start constructs the service Processor using the config and launches it.
+func Start(config Config, runner Runner, concurrency ConcurrencyFunc) (err error) {
+	p, err := New(config, runner, concurrency)
+	if err != nil {
+		return
+	}
+
+	p.Start()
+
+	if previous := swap(p); previous != nil {
+		previous.Stop()
+	}
+
+	return
+}
+
+// Stop stops the service Processor.
+func Stop() {
+	if previous := swap(nil); previous != nil {
+		previous.Stop()
+	}
+}
+
+// Drain drains the service Processor, see Processor.Drain.
+func Drain(ctx context.Context) {
+	if previous := swap(nil); previous != nil {
+		previous.Drain(ctx)
+	}
+}
+
+// swap replaces the service Processor and returns the previous one.
+func swap(p *Processor) (previous *Processor) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	previous, current = current, p
+
+	return
+}
+
+// Enabled reports whether the service Processor is running.
+func Enabled() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return current != nil
+}
+
+// Submit queues the batch of the client with the service Processor.
+func Submit(clientID string, provider common.KYCProvider, mediaType string, input io.Reader) (b Batch, err error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current == nil {
+		err = ErrDisabled
+		return
+	}
+
+	return current.Submit(clientID, provider, mediaType, input)
+}
+
+// Get returns the batch by its id from the service Processor.
+func Get(id string) (b Batch, err error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current == nil {
+		err = ErrDisabled
+		return
+	}
+
+	return current.Get(id)
+}
+
+// Results returns the reader of the checkpointed results of the batch from the service Processor.
+func Results(id string) (results io.ReadCloser, size int64, err error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current == nil {
+		err = ErrDisabled
+		return
+	}
+
+	return current.Results(id)
+}
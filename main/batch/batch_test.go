@@ -0,0 +1,494 @@
+package batch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"modulus/kyc/common"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testConfig returns the config of the Processor with the batches in the temporary directory.
+func testConfig(t *testing.T) Config {
+	dir, err := ioutil.TempDir("", "batches")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	return Config{
+		Dir:        dir,
+		MaxRecords: 100,
+		Retention:  time.Hour,
+	}
+}
+
+// concurrency is the ConcurrencyFunc of the tests.
+func concurrency(provider common.KYCProvider) int {
+	return 2
+}
+
+// limitError is the error of the request exceeding the rate limit in tests.
+type limitError time.Duration
+
+func (e limitError) Error() string {
+	return "rate limit exceeded"
+}
+
+func (e limitError) RetryAfter() time.Duration {
+	return time.Duration(e)
+}
+
+// approve is the runner approving every customer but Delilah and failing the request for Erika.
+func approve(ctx context.Context, clientID string, req common.CheckCustomerRequest) (response common.KYCResponse, ids []string, err error) {
+	switch req.UserData.FirstName {
+	case "Delilah":
+		response.Result = &common.Result{Status: "Denied"}
+	case "Erika":
+		err = limitError(time.Hour)
+	default:
+		response.Result = &common.Result{Status: "Approved"}
+	}
+	ids = []string{"v-" + req.UserData.FirstName}
+
+	return
+}
+
+// waitBatch waits until the batch gets the state.
+func waitBatch(t *testing.T, p *Processor, id, state string) (b Batch) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		b, err := p.Get(id)
+		if err == nil && b.State == state {
+			return b
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("batch %s didn't get %s", id, state)
+
+	return
+}
+
+// readResults reads the results of the batch.
+func readResults(t *testing.T, p *Processor, id string) (results []common.BatchResult) {
+	reader, _, err := p.Results(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		result := common.BatchResult{}
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, result)
+	}
+
+	return
+}
+
+func TestConfigFromOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	config, err := ConfigFromOptions(map[string]string{})
+	assert.NoError(err)
+	assert.Equal(Config{Dir: DefaultDir, MaxRecords: DefaultMaxRecords, Retention: DefaultRetention}, config)
+
+	config, err = ConfigFromOptions(map[string]string{
+		DirOption:        "/var/lib/kyc/batches",
+		MaxRecordsOption: "5000",
+		RetentionOption:  "24h",
+	})
+	assert.NoError(err)
+	assert.Equal(Config{Dir: "/var/lib/kyc/batches", MaxRecords: 5000, Retention: 24 * time.Hour}, config)
+
+	_, err = ConfigFromOptions(map[string]string{MaxRecordsOption: "0"})
+	assert.EqualError(err, "invalid option 'BatchMaxRecords': non-positive number")
+
+	_, err = ConfigFromOptions(map[string]string{RetentionOption: "forever"})
+	assert.Error(err)
+
+	// Testing the concurrency of a provider.
+	n, err := ConcurrencyFromOptions(map[string]string{})
+	assert.NoError(err)
+	assert.Equal(DefaultConcurrency, n)
+
+	n, err = ConcurrencyFromOptions(map[string]string{ConcurrencyOption: "16"})
+	assert.NoError(err)
+	assert.Equal(16, n)
+
+	_, err = ConcurrencyFromOptions(map[string]string{ConcurrencyOption: "-1"})
+	assert.EqualError(err, "invalid option 'BatchConcurrency': non-positive number")
+}
+
+func TestRecordReader(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing NDJSON input.
+	reader := newRecordReader(strings.NewReader(`{"ID":"a","UserData":{"FirstName":"Abby"}}
+
+{"UserData":{"FirstName":"Delilah","CurrentAddress":{"Town":"Austin"}}}`), NDJSON)
+
+	record, err := reader.read()
+	assert.NoError(err)
+	assert.Equal("a", record.ID)
+	assert.Equal("Abby", record.UserData.FirstName)
+
+	record, err = reader.read()
+	assert.NoError(err)
+	assert.Empty(record.ID)
+	assert.Equal("Austin", record.UserData.CurrentAddress.Town)
+
+	_, err = reader.read()
+	assert.Equal(io.EOF, err)
+
+	// Testing CSV input.
+	reader = newRecordReader(strings.NewReader("ID,FirstName,LastName,Gender,DateOfBirth,CurrentAddress.Town,Passport.Number\n"+
+		"c-1,Abby,Smith,F,1990-02-03,Austin,1234\n"+
+		"c-2,Delilah,,male,1985-01-02T00:00:00Z,,\n"), CSV)
+
+	record, err = reader.read()
+	assert.NoError(err)
+	assert.Equal("c-1", record.ID)
+	assert.Equal("Abby", record.UserData.FirstName)
+	assert.Equal("Smith", record.UserData.LastName)
+	assert.Equal(common.Female, record.UserData.Gender)
+	assert.Equal(time.Date(1990, 2, 3, 0, 0, 0, 0, time.UTC), time.Time(record.UserData.DateOfBirth).UTC())
+	assert.Equal("Austin", record.UserData.CurrentAddress.Town)
+	if assert.NotNil(record.UserData.Passport) {
+		assert.Equal("1234", record.UserData.Passport.Number)
+	}
+
+	record, err = reader.read()
+	assert.NoError(err)
+	assert.Equal(common.Male, record.UserData.Gender)
+	assert.Nil(record.UserData.Passport)
+
+	_, err = reader.read()
+	assert.Equal(io.EOF, err)
+
+	// Testing malformed input.
+	testCases := []struct {
+		input     string
+		mediaType string
+		err       string
+	}{
+		{`{"ID":"a"}`, NDJSON, "line 1: missing UserData in the record"},
+		{"{\"UserData\":{}}\n{\"UserData\":{\"Unknown\":1}}", NDJSON, `line 2: json: unknown field "Unknown"`},
+		{"", CSV, "missing CSV header"},
+		{"FirstName,Nickname\nAbby,Ab\n", CSV, `line 2: json: unknown field "Nickname"`},
+		{"FirstName,Gender\nAbby,X\n", CSV, "line 2: invalid Gender: X"},
+		{"FirstName,DateOfBirth\nAbby,02/03/1990\n", CSV, "line 2: invalid DateOfBirth: 02/03/1990"},
+		{"FirstName,LastName\nAbby\n", CSV, "line 2: wrong number of fields"},
+	}
+
+	for _, tc := range testCases {
+		reader := newRecordReader(strings.NewReader(tc.input), tc.mediaType)
+
+		var err error
+		for err == nil {
+			_, err = reader.read()
+		}
+		if assert.NotEqual(io.EOF, err, tc.input) {
+			assert.Equal(tc.err, err.Error(), tc.input)
+			assert.IsType(InputError{}, err, tc.input)
+		}
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	assert := assert.New(t)
+
+	config := testConfig(t)
+	config.MaxRecords = 3
+
+	p, err := New(config, approve, concurrency)
+	if !assert.NoError(err) {
+		return
+	}
+	p.Start()
+	defer p.Stop()
+
+	b, err := p.Submit("backoffice", common.ComplyAdvantage, CSV, strings.NewReader("ID,FirstName\nc-1,Abby\nc-2,Delilah\n,Erika\n"))
+	assert.NoError(err)
+	assert.Equal(3, b.Total)
+	assert.Equal(common.ComplyAdvantage, b.Provider)
+
+	b = waitBatch(t, p, b.ID, Completed)
+	assert.Equal(3, b.Processed)
+	assert.Equal(1, b.Failed)
+	assert.NotNil(b.Finished)
+
+	assert.Equal([]common.BatchResult{
+		{ID: "c-1", Result: &common.Result{Status: "Approved"}, VerificationID: "v-Abby"},
+		{ID: "c-2", Result: &common.Result{Status: "Denied"}, VerificationID: "v-Delilah"},
+		{ID: "3", Error: "rate limit exceeded", VerificationID: "v-Erika"},
+	}, readResults(t, p, b.ID))
+
+	// The customer data isn't kept after the batch is finished.
+	_, err = os.Stat(filepath.Join(config.Dir, b.ID, inputFile))
+	assert.True(os.IsNotExist(err))
+
+	// Testing the rejected input.
+	testCases := []struct {
+		input string
+		err   string
+	}{
+		{"", "empty batch"},
+		{"{\"ID\":\"a\",\"UserData\":{}}\n{\"ID\":\"a\",\"UserData\":{}}\n", "duplicate record id: a"},
+		{strings.Repeat("{\"UserData\":{}}\n", 4), "too many records, the maximum is 3"},
+	}
+
+	for _, tc := range testCases {
+		_, err = p.Submit("", common.ComplyAdvantage, NDJSON, strings.NewReader(tc.input))
+		assert.EqualError(err, tc.err)
+		assert.IsType(InputError{}, err)
+	}
+
+	dirs, err := ioutil.ReadDir(config.Dir)
+	assert.NoError(err)
+	assert.Len(dirs, 1)
+
+	_, err = p.Get("unknown")
+	assert.Equal(ErrNotFound, err)
+}
+
+func TestConcurrencyAndRetries(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		running    int32
+		maxRunning int32
+		calls      sync.Map
+	)
+
+	runner := func(ctx context.Context, clientID string, req common.CheckCustomerRequest) (response common.KYCResponse, ids []string, err error) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		// The first request of every customer exceeds the rate limit.
+		if _, retried := calls.LoadOrStore(req.UserData.FirstName, true); !retried {
+			err = limitError(time.Millisecond)
+			return
+		}
+		response.Result = &common.Result{Status: "Approved"}
+
+		return
+	}
+
+	p, err := New(testConfig(t), runner, concurrency)
+	if !assert.NoError(err) {
+		return
+	}
+	p.Start()
+	defer p.Stop()
+
+	// Testing the concurrency of the provider is shared by the batches.
+	input := "FirstName\nA\nB\nC\nD\nE\n"
+
+	b1, err := p.Submit("", common.ComplyAdvantage, CSV, strings.NewReader(input))
+	assert.NoError(err)
+	b2, err := p.Submit("", common.ComplyAdvantage, CSV, strings.NewReader(strings.ToLower(input)))
+	assert.NoError(err)
+
+	for _, id := range []string{b1.ID, b2.ID} {
+		b := waitBatch(t, p, id, Completed)
+		assert.Equal(5, b.Processed)
+		assert.Zero(b.Failed)
+	}
+
+	assert.True(atomic.LoadInt32(&maxRunning) <= 2)
+}
+
+func TestResume(t *testing.T) {
+	assert := assert.New(t)
+
+	config := testConfig(t)
+	stopped := make(chan struct{})
+
+	// Testing the batch interrupted by the stop is resumed from the checkpoint.
+	// The records C and D are being verified when the batch is stopped.
+	p, err := New(config, func(ctx context.Context, clientID string, req common.CheckCustomerRequest) (common.KYCResponse, []string, error) {
+		switch req.UserData.FirstName {
+		case "C":
+			<-ctx.Done()
+		case "D":
+			close(stopped)
+		}
+		return approve(ctx, clientID, req)
+	}, concurrency)
+	if !assert.NoError(err) {
+		return
+	}
+	p.Start()
+
+	b, err := p.Submit("", common.ComplyAdvantage, CSV, strings.NewReader("FirstName\nA\nB\nC\nD\nE\n"))
+	assert.NoError(err)
+
+	<-stopped
+	p.Stop()
+
+	// The result of the interrupted record might be written after the checkpoint.
+	results, err := os.OpenFile(filepath.Join(config.Dir, b.ID, resultsFile), os.O_APPEND|os.O_WRONLY, 0600)
+	if assert.NoError(err) {
+		results.WriteString(`{"ID":"3","Result":{"Status":"Approved"}}` + "\n")
+		results.Close()
+	}
+
+	p, err = New(config, approve, concurrency)
+	if !assert.NoError(err) {
+		return
+	}
+
+	b, err = p.Get(b.ID)
+	assert.NoError(err)
+	assert.Equal(Running, b.State)
+	assert.Equal(2, b.Processed)
+
+	p.Start()
+	defer p.Stop()
+
+	b = waitBatch(t, p, b.ID, Completed)
+	assert.Equal(5, b.Processed)
+
+	results1 := readResults(t, p, b.ID)
+	if assert.Len(results1, 5) {
+		for i, result := range results1 {
+			assert.Equal(string(rune('1'+i)), result.ID)
+		}
+	}
+}
+
+func TestDrain(t *testing.T) {
+	assert := assert.New(t)
+
+	config := testConfig(t)
+	started, release := make(chan struct{}), make(chan struct{})
+
+	// Testing the records being verified are completed and checkpointed, while the next ones are left for the next start.
+	// The records C and D are being verified when the batch is drained.
+	p, err := New(config, func(ctx context.Context, clientID string, req common.CheckCustomerRequest) (common.KYCResponse, []string, error) {
+		switch req.UserData.FirstName {
+		case "C":
+			<-release
+		case "D":
+			close(started)
+		}
+		return approve(ctx, clientID, req)
+	}, concurrency)
+	if !assert.NoError(err) {
+		return
+	}
+	p.Start()
+
+	b, err := p.Submit("", common.ComplyAdvantage, CSV, strings.NewReader("FirstName\nA\nB\nC\nD\nE\n"))
+	assert.NoError(err)
+
+	<-started
+
+	drained := make(chan struct{})
+	go func() {
+		p.Drain(context.Background())
+		close(drained)
+	}()
+
+	// Let the drain begin before the records are completed.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	<-drained
+
+	p, err = New(config, approve, concurrency)
+	if !assert.NoError(err) {
+		return
+	}
+
+	b, err = p.Get(b.ID)
+	assert.NoError(err)
+	assert.Equal(Running, b.State)
+	assert.Equal(4, b.Processed)
+
+	p.Start()
+	defer p.Stop()
+
+	b = waitBatch(t, p, b.ID, Completed)
+	assert.Equal(5, b.Processed)
+	assert.Len(readResults(t, p, b.ID), 5)
+}
+
+func TestCleanup(t *testing.T) {
+	assert := assert.New(t)
+
+	config := testConfig(t)
+
+	p, err := New(config, approve, concurrency)
+	if !assert.NoError(err) {
+		return
+	}
+	p.Start()
+	defer p.Stop()
+
+	b, err := p.Submit("", common.ComplyAdvantage, NDJSON, strings.NewReader(`{"UserData":{"FirstName":"Abby"}}`))
+	assert.NoError(err)
+
+	b = waitBatch(t, p, b.ID, Completed)
+
+	p.cleanup(time.Now())
+	_, err = p.Get(b.ID)
+	assert.NoError(err)
+
+	p.cleanup(b.Finished.Add(2 * time.Hour))
+	_, err = p.Get(b.ID)
+	assert.Equal(ErrNotFound, err)
+
+	_, err = os.Stat(filepath.Join(config.Dir, b.ID))
+	assert.True(os.IsNotExist(err))
+}
+
+func TestServiceProcessor(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.False(Enabled())
+
+	_, err := Submit("", common.ComplyAdvantage, NDJSON, strings.NewReader(""))
+	assert.Equal(ErrDisabled, err)
+
+	_, err = Get("id")
+	assert.Equal(ErrDisabled, err)
+
+	_, _, err = Results("id")
+	assert.Equal(ErrDisabled, err)
+
+	assert.NoError(Start(testConfig(t), approve, concurrency))
+	defer Stop()
+
+	assert.True(Enabled())
+
+	b, err := Submit("", common.ComplyAdvantage, NDJSON, strings.NewReader(`{"UserData":{"FirstName":"Abby"}}`))
+	assert.NoError(err)
+
+	_, err = Get(b.ID)
+	assert.NoError(err)
+
+	Stop()
+	assert.False(Enabled())
+}
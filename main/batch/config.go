@@ -0,0 +1,72 @@
+package batch
+
+import (
+	"time"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/option"
+)
+
+// The names of the batch options in the service config section.
+const (
+	DirOption        = "BatchDir"
+	MaxRecordsOption = "BatchMaxRecords"
+	RetentionOption  = "BatchRetention"
+)
+
+// ConcurrencyOption is the name of the option in the config section of a KYC provider
+// limiting the number of the batch records verified by the provider at once.
+const ConcurrencyOption = "BatchConcurrency"
+
+// The default values of the batch options.
+const (
+	DefaultDir         = "batches"
+	DefaultMaxRecords  = 100000
+	DefaultRetention   = 72 * time.Hour
+	DefaultConcurrency = 4
+)
+
+// Config holds the settings of the Processor.
+//
+// * Dir is the directory where the batches are stored.
+// * MaxRecords limits the number of the records in a batch.
+// * Retention is how long the finished batches and their results are kept.
+type Config struct {
+	Dir        string
+	MaxRecords int
+	Retention  time.Duration
+}
+
+// ConcurrencyFunc returns the number of the batch records verified by the provider at once.
+type ConcurrencyFunc func(provider common.KYCProvider) int
+
+// ConfigFromOptions parses the batch options from the service config section.
+// Absent options take their default values.
+func ConfigFromOptions(options map[string]string) (config Config, err error) {
+	config = Config{
+		Dir:        options[DirOption],
+		MaxRecords: DefaultMaxRecords,
+		Retention:  DefaultRetention,
+	}
+
+	if len(config.Dir) == 0 {
+		config.Dir = DefaultDir
+	}
+
+	if err = option.Int(options, MaxRecordsOption, &config.MaxRecords); err != nil {
+		return
+	}
+	err = option.Duration(options, RetentionOption, &config.Retention)
+
+	return
+}
+
+// ConcurrencyFromOptions parses the batch concurrency option from the config section of a KYC provider.
+// The default value is returned if the option is absent.
+func ConcurrencyFromOptions(options map[string]string) (concurrency int, err error) {
+	concurrency = DefaultConcurrency
+
+	err = option.Int(options, ConcurrencyOption, &concurrency)
+
+	return
+}
@@ -0,0 +1,207 @@
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"modulus/kyc/common"
+)
+
+// The media types of the batch input.
+const (
+	NDJSON = "application/x-ndjson"
+	CSV    = "text/csv"
+)
+
+// dateLayout is the layout of the dates in the CSV input. The dates in RFC3339 format are accepted as well.
+const dateLayout = "2006-01-02"
+
+// InputError represents the malformed input of the batch.
+// Line is the number of the line of the input the error occurred at if it's known.
+type InputError struct {
+	Line int
+	Err  error
+}
+
+// Error implements error interface for the InputError.
+func (e InputError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+	}
+	return e.Err.Error()
+}
+
+// recordReader reads the records of the batch input one by one.
+// The io.EOF is returned after the last record.
+type recordReader interface {
+	read() (record common.BatchRecord, err error)
+}
+
+// newRecordReader returns the reader of the input in the format of the media type.
+// The input is read as NDJSON unless the media type is CSV.
+func newRecordReader(r io.Reader, mediaType string) recordReader {
+	if mediaType == CSV {
+		reader := csv.NewReader(r)
+		reader.TrimLeadingSpace = true
+		return &csvReader{reader: reader}
+	}
+
+	return &ndjsonReader{reader: bufio.NewReader(r)}
+}
+
+// ndjsonReader reads the records from the JSON objects separated by the new lines.
+type ndjsonReader struct {
+	reader *bufio.Reader
+	line   int
+}
+
+// read implements recordReader interface for the ndjsonReader.
+func (r *ndjsonReader) read() (record common.BatchRecord, err error) {
+	for {
+		data, err1 := r.reader.ReadBytes('\n')
+		if err1 != nil && err1 != io.EOF {
+			err = err1
+			return
+		}
+		r.line++
+
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 {
+			if err1 == io.EOF {
+				err = io.EOF
+				return
+			}
+			continue
+		}
+
+		if err = decode(data, &record); err == nil && record.UserData == nil {
+			err = errors.New("missing UserData in the record")
+		}
+		if err != nil {
+			err = InputError{Line: r.line, Err: err}
+		}
+
+		return
+	}
+}
+
+// csvReader reads the records from the CSV rows.
+// The first row holds the names of the columns. The ID column holds the record id and the other columns hold the UserData fields.
+// The names of the nested fields are joined with the dots, e.g. CurrentAddress.Town.
+type csvReader struct {
+	reader *csv.Reader
+	header []string
+}
+
+// read implements recordReader interface for the csvReader.
+func (r *csvReader) read() (record common.BatchRecord, err error) {
+	if r.header == nil {
+		if r.header, err = r.reader.Read(); err != nil {
+			if err == io.EOF {
+				err = InputError{Err: errors.New("missing CSV header")}
+			}
+			return
+		}
+		r.reader.FieldsPerRecord = len(r.header)
+	}
+
+	row, err := r.reader.Read()
+	if err != nil {
+		if e, ok := err.(*csv.ParseError); ok {
+			err = InputError{Line: e.Line, Err: e.Err}
+		}
+		return
+	}
+
+	line, _ := r.reader.FieldPos(0)
+
+	fields := map[string]interface{}{}
+	for i, name := range r.header {
+		value := strings.TrimSpace(row[i])
+		if len(value) == 0 {
+			continue
+		}
+		if name == "ID" {
+			record.ID = value
+			continue
+		}
+
+		v, err1 := csvValue(name, value)
+		if err1 != nil {
+			err = InputError{Line: line, Err: err1}
+			return
+		}
+		setField(fields, strings.Split(name, "."), v)
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return
+	}
+
+	record.UserData = &common.UserData{}
+	if err = decode(data, record.UserData); err != nil {
+		err = InputError{Line: line, Err: err}
+	}
+
+	return
+}
+
+// csvValue converts the value of the CSV column to the value of the UserData field.
+// The Gender is either the number or the name of the gender, the dates are either in YYYY-MM-DD or in RFC3339 format
+// and the other fields are the strings.
+func csvValue(name, value string) (v interface{}, err error) {
+	field := name[strings.LastIndex(name, ".")+1:]
+
+	switch {
+	case field == "Gender":
+		switch strings.ToLower(value) {
+		case "1", "m", "male":
+			v = common.Male
+		case "2", "f", "female":
+			v = common.Female
+		default:
+			err = fmt.Errorf("invalid %s: %s", name, value)
+		}
+	case field == "DateOfBirth" || field == "ValidUntil" || strings.HasSuffix(field, "Date"):
+		if t, err1 := time.Parse(dateLayout, value); err1 == nil {
+			v = t.Format(time.RFC3339)
+		} else if _, err1 = time.Parse(time.RFC3339, value); err1 == nil {
+			v = value
+		} else {
+			err = fmt.Errorf("invalid %s: %s", name, value)
+		}
+	default:
+		v = value
+	}
+
+	return
+}
+
+// setField sets the value at the path of the nested fields.
+func setField(fields map[string]interface{}, path []string, value interface{}) {
+	for _, name := range path[:len(path)-1] {
+		nested, ok := fields[name].(map[string]interface{})
+		if !ok {
+			nested = map[string]interface{}{}
+			fields[name] = nested
+		}
+		fields = nested
+	}
+	fields[path[len(path)-1]] = value
+}
+
+// decode decodes the JSON data into the value rejecting the unknown fields.
+func decode(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	return decoder.Decode(v)
+}
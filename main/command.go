@@ -0,0 +1,119 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"modulus/kyc/main/config"
+	"modulus/kyc/main/secrets"
+)
+
+// configUsage describes the config subcommands.
+const configUsage = `Usage: kyc config <command> [options]
+
+Commands:
+  encrypt [-keyfile path] [value]  Prints the encrypted value for the config file.
+                                   The value is read from the standard input if it's omitted.
+  check [-strict] <file>           Reports all the problems of the config file.
+                                   Exits with 1 if errors are found, or warnings with -strict.
+`
+
+// runConfig runs the config subcommand with the args and returns the exit code of the process.
+func runConfig(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, configUsage)
+		return 2
+	}
+
+	switch args[0] {
+	case "encrypt":
+		return encryptValue(args[1:], stdin, stdout, stderr)
+	case "check":
+		return checkConfig(args[1:], stdout, stderr)
+	}
+
+	fmt.Fprintf(stderr, "Unknown config command: %s\n\n%s", args[0], configUsage)
+
+	return 2
+}
+
+// encryptValue prints the encrypted value using the key from the key file or from the environment variables.
+func encryptValue(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("config encrypt", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	keyFile := flags.String("keyfile", "", "Read the base64-encoded AES-256 key from the file instead of the environment variables")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	var keys secrets.KeyProvider = secrets.EnvKey{}
+	if len(*keyFile) > 0 {
+		keys = secrets.KeyFile(*keyFile)
+	}
+
+	key, err := keys.Key()
+	if err != nil {
+		fmt.Fprintf(stderr, "Encrypting value: %s\n", err)
+		return 1
+	}
+
+	value := flags.Arg(0)
+	if flags.NArg() == 0 {
+		data, err := ioutil.ReadAll(stdin)
+		if err != nil {
+			fmt.Fprintf(stderr, "Reading value: %s\n", err)
+			return 1
+		}
+		value = strings.TrimRight(string(data), "\r\n")
+	}
+
+	encrypted, err := secrets.Encrypt(key, value)
+	if err != nil {
+		fmt.Fprintf(stderr, "Encrypting value: %s\n", err)
+		return 1
+	}
+
+	fmt.Fprintln(stdout, encrypted)
+
+	return 0
+}
+
+// checkConfig prints the problems of the config file and returns 1 if the file isn't fit for the service.
+// The warnings fail the check only in the strict mode.
+func checkConfig(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("config check", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	strict := flags.Bool("strict", false, "Treat the warnings as the errors")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprint(stderr, configUsage)
+		return 2
+	}
+
+	filename := flags.Arg(0)
+
+	errors, warnings := 0, 0
+	for _, problem := range config.Check(filename) {
+		fmt.Fprintln(stdout, problem)
+		if problem.Warning {
+			warnings++
+		} else {
+			errors++
+		}
+	}
+
+	fmt.Fprintf(stdout, "%s: %d error(s), %d warning(s)\n", filename, errors, warnings)
+
+	if errors > 0 || (*strict && warnings > 0) {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"modulus/kyc/main/secrets"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunConfigEncrypt(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "kyc")
+	if !assert.NoError(err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	key := []byte(strings.Repeat("k", secrets.KeySize))
+	keyFile := filepath.Join(dir, "kyc.key")
+	ioutil.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(key)), 0600)
+
+	run := func(stdin string, args ...string) (code int, stdout, stderr string) {
+		out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+		code = runConfig(args, strings.NewReader(stdin), out, errOut)
+		return code, out.String(), errOut.String()
+	}
+
+	// Testing the value from the args.
+	code, stdout, _ := run("", "encrypt", "-keyfile", keyFile, "password")
+
+	assert.Equal(0, code)
+
+	value, err := secrets.Decrypt(key, strings.TrimSpace(stdout))
+
+	assert.NoError(err)
+	assert.Equal("password", value)
+
+	// Testing the value from the standard input.
+	code, stdout, _ = run("password\n", "encrypt", "-keyfile", keyFile)
+
+	assert.Equal(0, code)
+
+	value, err = secrets.Decrypt(key, strings.TrimSpace(stdout))
+
+	assert.NoError(err)
+	assert.Equal("password", value)
+
+	// Testing the missing key.
+	t.Setenv(secrets.KeyEnv, "")
+	t.Setenv(secrets.KeyFileEnv, "")
+
+	code, stdout, stderr := run("", "encrypt", "password")
+
+	assert.Equal(1, code)
+	assert.Empty(stdout)
+	assert.Contains(stderr, secrets.ErrNoKey.Error())
+
+	// Testing the unknown commands.
+	code, _, stderr = run("", "decrypt")
+
+	assert.Equal(2, code)
+	assert.Contains(stderr, "Unknown config command: decrypt")
+
+	code, _, _ = run("")
+
+	assert.Equal(2, code)
+}
+
+func TestRunConfigCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "kyc")
+	if !assert.NoError(err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	run := func(args ...string) (code int, stdout string) {
+		out := &bytes.Buffer{}
+		code = runConfig(args, strings.NewReader(""), out, ioutil.Discard)
+		return code, out.String()
+	}
+
+	// Testing the valid config.
+	code, stdout := run("check", "kyc_dev.cfg")
+
+	assert.Equal(0, code)
+	assert.Equal("kyc_dev.cfg: 0 error(s), 0 warning(s)\n", stdout)
+
+	// Testing the warnings.
+	filename := filepath.Join(dir, "kyc.cfg")
+	ioutil.WriteFile(filename, []byte("[Config]\nPort=8080\nPrt=8081\n"), 0600)
+
+	code, stdout = run("check", filename)
+
+	assert.Equal(0, code)
+	assert.Equal("warning: Config configuration error: unknown option 'Prt', did you mean 'Port'?\n"+filename+": 0 error(s), 1 warning(s)\n", stdout)
+
+	code, _ = run("check", "-strict", filename)
+
+	assert.Equal(1, code)
+
+	// Testing the errors.
+	ioutil.WriteFile(filename, []byte("[Trulioo]\nHost=localhost\n"), 0600)
+
+	code, stdout = run("check", filename)
+
+	assert.Equal(1, code)
+	assert.Contains(stdout, "error: Trulioo configuration error: missing or empty option 'NAPILogin'\n")
+	assert.Contains(stdout, "error: Trulioo configuration error: invalid option 'Host': malformed URL 'localhost'\n")
+
+	// Testing the missing file argument.
+	code, _ = run("check")
+
+	assert.Equal(2, code)
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/auth"
+)
+
+// maxTypos is the maximum edit distance between the unknown option and the known one suggested instead.
+const maxTypos = 2
+
+// Problem represents an issue found in the config by Check.
+//
+// * Err describes the issue.
+// * Warning marks the issue that doesn't prevent the service from starting, e.g. the unknown option.
+type Problem struct {
+	Err     error
+	Warning bool
+}
+
+// String implements fmt.Stringer interface for Problem.
+func (p Problem) String() string {
+	if p.Warning {
+		return "warning: " + p.Err.Error()
+	}
+
+	return "error: " + p.Err.Error()
+}
+
+// Check parses the configuration from the specified file like Load but goes on after the errors and reports all of them.
+// Besides the errors Load stops at, the unknown options are reported as the warnings.
+// No requests to the providers are made.
+func Check(filename string) (problems []Problem) {
+	report := func(errs []error, warning bool) {
+		for _, err := range errs {
+			problems = append(problems, Problem{Err: err, Warning: warning})
+		}
+	}
+
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		report([]error{err}, false)
+		return
+	}
+	if len(data) == 0 {
+		report([]error{fmt.Errorf("empty %s", filename)}, false)
+		return
+	}
+
+	config, errs := scan(FormatOf(filename), data)
+	report(errs, false)
+	if config == nil {
+		return
+	}
+
+	applyEnv(config, os.Environ())
+
+	report(resolveFiles(config), false)
+	report(decrypt(config), false)
+
+	// The values left encrypted are already reported by decrypt.
+	for _, err := range validationErrors(config) {
+		if _, ok := err.(ErrUndecryptable); !ok {
+			report([]error{err}, false)
+		}
+	}
+
+	for _, section := range sortedSections(config) {
+		options := config[section]
+
+		spec, ok := common.LookupProvider(common.KYCProvider(section))
+		names := append(append([]string{}, spec.Options...), spec.Optional...)
+
+		var known []string
+		switch {
+		case section == ServiceSection:
+			known = serviceOptions
+		case strings.HasPrefix(section, auth.ClientSectionPrefix):
+			known = clientOptions
+		case ok:
+			known = append(names, providerOptions...)
+		default:
+			// The sections of the services without the registered provider, e.g. CipherTrace, are read as is.
+			continue
+		}
+		report(unknownOptions(section, options, known), true)
+	}
+
+	return
+}
+
+// unknownOptions returns the errors of the options of the section missing from the known ones.
+// The closest known option whose name differs only by the case or by a couple of letters is suggested.
+func unknownOptions(section string, options Options, known []string) (errs []error) {
+	for _, option := range sortedOptions(options) {
+		name := strings.TrimSuffix(option, FileSuffix)
+
+		suggestion, closest, found := "", maxTypos+1, false
+		for _, k := range known {
+			if k == name {
+				found = true
+				break
+			}
+			if d := distance(strings.ToLower(k), strings.ToLower(name)); d < closest {
+				suggestion, closest = k, d
+			}
+		}
+		if !found {
+			errs = append(errs, ErrUnknownOption{provider: section, option: option, suggestion: suggestion})
+		}
+	}
+
+	return
+}
+
+// distance returns the Levenshtein distance between the strings.
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j-1] + cost
+			if prev[j]+1 < curr[j] {
+				curr[j] = prev[j] + 1
+			}
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,90 @@
+package config_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"modulus/kyc/main/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var rawBrokenConfig = `
+[Config]
+Port=8080
+LogLevl=debug
+
+[Foobar]
+Host=localhost
+
+[ComplyAdvantage]
+Host=https://api.complyadvantage.com
+APIkey=key
+Fuzziness=high
+
+[IDology]
+Host=https://web.idologylive.com/api/idiq.svc
+Username=user
+Password=
+UseSummaryResult=maybe
+
+[ThomsonReuters]
+Host=rms-world-check-one-api-pilot.thomsonreuters.com
+APIkey=key
+APIsecret=secret
+APISecret=secret
+Timeout=5
+`
+
+func TestCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "kyc")
+	if !assert.NoError(err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	messages := func(problems []config.Problem) (lines []string) {
+		for _, problem := range problems {
+			lines = append(lines, problem.String())
+		}
+		return
+	}
+
+	// Testing the valid config.
+	assert.Empty(config.Check("../kyc_dev.cfg"))
+
+	// Testing all the problems reported at once.
+	filename := filepath.Join(dir, "kyc.cfg")
+	ioutil.WriteFile(filename, []byte(rawBrokenConfig), 0600)
+
+	problems := config.Check(filename)
+
+	assert.Equal([]string{
+		"error: parsing failed at line 6 '[Foobar]': unknown KYC provider name in the config",
+		"error: ComplyAdvantage configuration error: invalid option 'Fuzziness': strconv.ParseFloat: parsing \"high\": invalid syntax",
+		"error: IDology configuration error: missing or empty option 'Password'",
+		"error: IDology configuration error: invalid option 'UseSummaryResult': strconv.ParseBool: parsing \"maybe\": invalid syntax",
+		"error: ThomsonReuters configuration error: invalid option 'Timeout': time: missing unit in duration \"5\"",
+		"error: ThomsonReuters configuration error: invalid option 'Host': malformed URL 'rms-world-check-one-api-pilot.thomsonreuters.com'",
+		"warning: Config configuration error: unknown option 'LogLevl', did you mean 'LogLevel'?",
+		"warning: ThomsonReuters configuration error: unknown option 'APISecret', did you mean 'APIsecret'?",
+	}, messages(problems))
+	assert.True(problems[6].Warning)
+	assert.False(problems[0].Warning)
+
+	// Testing the config that can't be parsed.
+	filename = filepath.Join(dir, "kyc.yaml")
+	ioutil.WriteFile(filename, []byte("Trulioo: [\n"), 0600)
+
+	problems = config.Check(filename)
+
+	assert.Len(problems, 1)
+	assert.Contains(problems[0].String(), "error: parsing YAML failed")
+
+	// Testing the missing file.
+	assert.Equal([]string{"error: open missing.cfg: no such file or directory"}, messages(config.Check("missing.cfg")))
+}
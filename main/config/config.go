@@ -1,5 +1,11 @@
 package config
 
+import (
+	"strings"
+
+	"modulus/kyc/main/auth"
+)
+
 const (
 	// ServiceSection is the hardcoded value of the KYC service config section name.
 	ServiceSection = "Config"
@@ -8,10 +14,6 @@ const (
 	DefaultPort = "8080"
 )
 
-// Cfg holds the current config for the KYC service.
-// Beware that it isn't concurrent writes safe.
-var Cfg Config
-
 // Options represents the configuration options for the KYC provider.
 type Options map[string]string
 
@@ -31,6 +33,19 @@ func (c Config) Option(section, option string) (opt string) {
 	return
 }
 
+// Clients returns the options of the API clients from the client sections keyed by the client ids.
+func (c Config) Clients() (clients map[string]map[string]string) {
+	clients = map[string]map[string]string{}
+
+	for name, options := range c {
+		if strings.HasPrefix(name, auth.ClientSectionPrefix) {
+			clients[strings.TrimPrefix(name, auth.ClientSectionPrefix)] = options
+		}
+	}
+
+	return
+}
+
 // ServicePort returns the KYC service port.
 func (c Config) ServicePort() (port string) {
 	if port = c.Option(ServiceSection, "Port"); len(port) == 0 {
@@ -0,0 +1,207 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"modulus/kyc/common"
+	"modulus/kyc/http"
+	"modulus/kyc/main/auth"
+	"modulus/kyc/main/batch"
+	grpcconfig "modulus/kyc/main/grpcapi/config"
+	"modulus/kyc/main/idempotency"
+	"modulus/kyc/main/jobs"
+	"modulus/kyc/main/limits"
+	"modulus/kyc/main/logging"
+	"modulus/kyc/main/notify"
+	"modulus/kyc/main/poller"
+	"modulus/kyc/main/rules"
+	"modulus/kyc/main/server"
+	"modulus/kyc/main/store"
+	"modulus/kyc/main/tracing"
+)
+
+// EnvPrefix is the prefix of the environment variables overriding the config options.
+// The variable KYC_<SECTION>_<OPTION> overrides the option of the section, e.g. KYC_TRULIOO_NAPIPASSWORD.
+// The names of the section and the option are upper-cased and stripped of anything but the letters and the digits,
+// e.g. KYC_SUMSUBSTANCE_APIKEY for the APIKey of the Sum&Substance and KYC_CLIENTBACKOFFICE_APIKEY for the client backoffice.
+const EnvPrefix = "KYC_"
+
+// FileSuffix is the suffix of the options holding the paths to the files with their values,
+// e.g. NAPIPassword_FILE=/run/secrets/trulioo for the secrets mounted by Kubernetes.
+// The environment variables with the suffix, e.g. KYC_TRULIOO_NAPIPASSWORD_FILE, point to the files as well.
+const FileSuffix = "_FILE"
+
+// serviceOptions lists the options of the service section.
+var serviceOptions = []string{
+	"Port",
+	auth.JWTSecretOption, auth.JWTPublicKeyOption, auth.JWTIssuerOption, auth.JWTAudienceOption,
+	logging.LevelOption, logging.FormatOption,
+	tracing.EndpointOption, tracing.SampleRatioOption, tracing.ServiceNameOption,
+	notify.SecretOption, notify.OutboxOption, notify.MaxAttemptsOption, notify.RetryWaitOption, notify.RetryMaxWaitOption,
+	jobs.DirOption, jobs.WorkersOption, jobs.MaxQueuedOption, jobs.RetentionOption,
+	batch.DirOption, batch.MaxRecordsOption, batch.RetentionOption,
+	store.DriverOption, store.DSNOption,
+	idempotency.TTLOption,
+	rules.FileOption,
+	server.ReadTimeoutOption, server.WriteTimeoutOption, server.IdleTimeoutOption, server.MaxBodySizeOption,
+	server.ShutdownTimeoutOption, server.TLSCertOption, server.TLSKeyOption,
+	grpcconfig.PortOption, grpcconfig.UploadTTLOption, grpcconfig.MaxUploadSizeOption,
+	grpcconfig.MaxUploadsSizeOption, grpcconfig.MaxClientUploadsSizeOption,
+}
+
+// clientOptions lists the options of the API client sections.
+var clientOptions = []string{
+	auth.APIKeyOption, auth.ProvidersOption, auth.EndpointsOption,
+	limits.RateLimitOption, limits.RateBurstOption, limits.DailyQuotaOption, limits.MonthlyQuotaOption,
+}
+
+// providerOptions lists the options shared by the provider sections.
+var providerOptions = []string{
+	http.TimeoutOption, http.ConnectTimeoutOption, http.ProxyOption, http.CACertOption, http.ClientCertOption,
+	http.ClientKeyOption, http.MaxRetriesOption, http.RetryWaitOption, http.RetryMaxWaitOption,
+	poller.IntervalOption, poller.MaxIntervalOption, poller.RateLimitOption, poller.MaxAgeOption,
+	limits.RateLimitOption, limits.RateBurstOption, limits.DailyQuotaOption, limits.MonthlyQuotaOption,
+	batch.ConcurrencyOption,
+}
+
+// applyEnv overrides the config options by the environment variables with the EnvPrefix.
+// The variable may set the option present in the config or the known option of the service, the API client
+// or the provider section. The other variables are ignored since the original case of their names can't be restored.
+// The option overridden by the variable with the FileSuffix is read from the file and vice versa.
+func applyEnv(cfg Config, environ []string) {
+	sort.Strings(environ)
+
+	for _, variable := range environ {
+		i := strings.IndexByte(variable, '=')
+		if i < 0 || !strings.HasPrefix(variable, EnvPrefix) {
+			continue
+		}
+		key, value := variable[len(EnvPrefix):i], variable[i+1:]
+
+		fromFile := strings.HasSuffix(key, FileSuffix)
+		key = strings.TrimSuffix(key, FileSuffix)
+
+		j := strings.IndexByte(key, '_')
+		if j < 0 {
+			continue
+		}
+
+		section, ok := lookupName(key[:j], sectionNames(cfg))
+		if !ok {
+			continue
+		}
+		option, ok := lookupName(key[j+1:], optionNames(section, cfg[section]))
+		if !ok {
+			continue
+		}
+
+		options := cfg[section]
+		if options == nil {
+			options = Options{}
+			cfg[section] = options
+		}
+		if fromFile {
+			delete(options, option)
+			options[option+FileSuffix] = value
+		} else {
+			delete(options, option+FileSuffix)
+			options[option] = value
+		}
+	}
+}
+
+// resolveFiles replaces the options with the FileSuffix by the options holding the contents of the files
+// and returns the errors of the options that can't be resolved. The trailing line breaks of the files are trimmed.
+func resolveFiles(cfg Config) (errs []error) {
+	for _, section := range sortedSections(cfg) {
+		options := cfg[section]
+		names := []string{}
+		for name := range options {
+			if strings.HasSuffix(name, FileSuffix) && len(name) > len(FileSuffix) {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			option := strings.TrimSuffix(name, FileSuffix)
+			if _, ok := options[option]; ok {
+				errs = append(errs, ErrInvalidOption{provider: section, err: fmt.Sprintf("both options '%s' and '%s' are set", option, name)})
+				continue
+			}
+
+			data, err := ioutil.ReadFile(options[name])
+			if err != nil {
+				errs = append(errs, ErrInvalidOption{provider: section, err: fmt.Sprintf("invalid option '%s': %s", name, err)})
+				continue
+			}
+
+			delete(options, name)
+			options[option] = strings.TrimRight(string(data), "\r\n")
+		}
+	}
+
+	return
+}
+
+// sectionNames returns the names of the sections the environment variables may override.
+func sectionNames(cfg Config) (names []string) {
+	names = []string{ServiceSection}
+	for name := range cfg {
+		names = append(names, name)
+	}
+	for _, provider := range common.KnownProviders() {
+		names = append(names, string(provider))
+	}
+
+	return
+}
+
+// optionNames returns the names of the options of the section the environment variables may override.
+func optionNames(section string, options Options) (names []string) {
+	for name := range options {
+		names = append(names, strings.TrimSuffix(name, FileSuffix))
+	}
+
+	switch {
+	case section == ServiceSection:
+		names = append(names, serviceOptions...)
+	case strings.HasPrefix(section, auth.ClientSectionPrefix):
+		names = append(names, clientOptions...)
+	default:
+		if spec, ok := common.LookupProvider(common.KYCProvider(section)); ok {
+			names = append(names, spec.Options...)
+			names = append(names, spec.Optional...)
+		}
+		names = append(names, providerOptions...)
+	}
+
+	return
+}
+
+// lookupName returns the name whose environment variable form is the key.
+func lookupName(key string, names []string) (string, bool) {
+	for _, name := range names {
+		if envName(name) == key {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+// envName returns the name upper-cased and stripped of anything but the letters and the digits.
+func envName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return -1
+	}, name)
+}
@@ -15,6 +15,46 @@ func (e ErrMissingOption) Error() string {
 	return fmt.Sprintf("%s configuration error: missing or empty option '%s'", e.provider, e.option)
 }
 
+// ErrInvalidOption defines an error of the invalid config option.
+type ErrInvalidOption struct {
+	provider string
+	err      string
+}
+
+// Error implements error interface for ErrInvalidOption.
+func (e ErrInvalidOption) Error() string {
+	return fmt.Sprintf("%s configuration error: %s", e.provider, e.err)
+}
+
+// ErrUndecryptable defines an error of the encrypted config option that can't be decrypted.
+type ErrUndecryptable struct {
+	provider string
+	option   string
+	err      string
+}
+
+// Error implements error interface for ErrUndecryptable.
+func (e ErrUndecryptable) Error() string {
+	return fmt.Sprintf("%s configuration error: can't decrypt option '%s': %s", e.provider, e.option, e.err)
+}
+
+// ErrUnknownOption defines a warning of the config option no one reads.
+// The suggestion is the known option whose name is close to the unknown one, if any.
+type ErrUnknownOption struct {
+	provider   string
+	option     string
+	suggestion string
+}
+
+// Error implements error interface for ErrUnknownOption.
+func (e ErrUnknownOption) Error() string {
+	if len(e.suggestion) > 0 {
+		return fmt.Sprintf("%s configuration error: unknown option '%s', did you mean '%s'?", e.provider, e.option, e.suggestion)
+	}
+
+	return fmt.Sprintf("%s configuration error: unknown option '%s'", e.provider, e.option)
+}
+
 // ParseError represents a config parser error.
 type ParseError struct {
 	strnum  int
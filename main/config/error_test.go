@@ -17,6 +17,17 @@ func TestErrMissingOption(t *testing.T) {
 	assert.Equal(t, text, err.Error())
 }
 
+func TestErrInvalidOption(t *testing.T) {
+	err := ErrInvalidOption{
+		provider: "Foobar",
+		err:      "invalid option 'Timeout': time: invalid duration \"1\"",
+	}
+
+	text := "Foobar configuration error: invalid option 'Timeout': time: invalid duration \"1\""
+
+	assert.Equal(t, text, err.Error())
+}
+
 func TestParseError(t *testing.T) {
 	err := ParseError{
 		strnum:  17,
@@ -28,3 +39,33 @@ func TestParseError(t *testing.T) {
 
 	assert.Equal(t, text, err.Error())
 }
+
+func TestErrUndecryptable(t *testing.T) {
+	err := ErrUndecryptable{
+		provider: "Foobar",
+		option:   "Password",
+		err:      "wrong key or corrupted value",
+	}
+
+	text := "Foobar configuration error: can't decrypt option 'Password': wrong key or corrupted value"
+
+	assert.Equal(t, text, err.Error())
+}
+
+func TestErrUnknownOption(t *testing.T) {
+	err := ErrUnknownOption{
+		provider:   "Foobar",
+		option:     "Hots",
+		suggestion: "Host",
+	}
+
+	text := "Foobar configuration error: unknown option 'Hots', did you mean 'Host'?"
+
+	assert.Equal(t, text, err.Error())
+
+	err.suggestion = ""
+
+	text = "Foobar configuration error: unknown option 'Hots'"
+
+	assert.Equal(t, text, err.Error())
+}
@@ -1,33 +1,96 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"time"
 )
 
-// FromFile loads the configuration from the specified file.
+// FromFile loads the configuration from the specified file and makes it the active snapshot.
+// The active snapshot is left intact if the config is invalid.
 func FromFile(filename string) (err error) {
-	file, err := os.Open(filename)
+	config, hash, err := Load(filename)
 	if err != nil {
 		return
 	}
-	defer file.Close()
 
-	info, err := file.Stat()
+	swap(config, filename, hash)
+
+	return
+}
+
+// Load parses the configuration from the specified file and validates it in full without applying it.
+// The format of the file is chosen by its extension, see FormatOf.
+// The options are overridden by the environment variables, and the options with the FileSuffix are read from the files.
+// The encrypted option values are decrypted using the key provider, see SetKeyProvider.
+// The hex-encoded SHA256 hash of the file content is returned along with the config.
+func Load(filename string) (config Config, hash string, err error) {
+	data, err := ioutil.ReadFile(filename)
 	if err != nil {
 		return
 	}
-	if info.Size() == 0 {
+	if len(data) == 0 {
 		err = fmt.Errorf("empty %s", filename)
 		return
 	}
 
-	Cfg, err = parseConfig(file)
+	config, err = parse(FormatOf(filename), data)
 	if err != nil {
 		return
 	}
 
-	err = validate(Cfg)
+	applyEnv(config, os.Environ())
+
+	if err = resolve(config); err != nil {
+		config = nil
+		return
+	}
+
+	sum := sha256.Sum256(data)
+	hash = hex.EncodeToString(sum[:])
+
+	return
+}
+
+// resolve reads the options from the files and decrypts the encrypted ones, then validates the config.
+func resolve(config Config) error {
+	if errs := resolveFiles(config); len(errs) > 0 {
+		return errs[0]
+	}
+	if errs := decrypt(config); len(errs) > 0 {
+		return errs[0]
+	}
+
+	return validate(config)
+}
+
+// Reload loads the configuration from the specified file and swaps it in if it's valid.
+// The changed sections are returned along with the new snapshot.
+// Nothing is swapped in if no section is changed.
+// The active snapshot is left intact if the config is invalid, and the error is reported by LastReload.
+func Reload(filename string) (snapshot *Snapshot, changed []string, err error) {
+	config, hash, err := Load(filename)
+
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	lastReload = ReloadStatus{Time: time.Now()}
+	if err != nil {
+		lastReload.Error = err.Error()
+		return
+	}
+
+	snapshot = Current()
+
+	changed = Changed(snapshot.Config, config)
+	if len(changed) == 0 && snapshot.Source == filename {
+		return
+	}
+
+	snapshot = swap(config, filename, hash)
 
 	return
 }
@@ -3,6 +3,7 @@ package config_test
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"modulus/kyc/main/config"
@@ -16,7 +17,7 @@ func TestFromFile(t *testing.T) {
 	err := config.FromFile("../kyc_dev.cfg")
 
 	assert.NoError(err)
-	assert.NotEmpty(config.Cfg)
+	assert.NotEmpty(config.Get())
 
 	err = config.FromFile("fake")
 
@@ -41,3 +42,31 @@ func TestFromFile(t *testing.T) {
 	assert.Error(err)
 	assert.Equal("parsing failed at line 1 'package config_test': not proper config string", err.Error())
 }
+
+func TestFromFileLayers(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "kyc")
+	if !assert.NoError(err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	secret := filepath.Join(dir, "trulioo")
+	ioutil.WriteFile(secret, []byte("password\n"), 0600)
+
+	filename := filepath.Join(dir, "kyc.yaml")
+	ioutil.WriteFile(filename, []byte("Trulioo:\n  Host: https://api.globaldatacompany.com\n  NAPILogin: login\n  NAPIPassword:\n"), 0600)
+
+	t.Setenv("KYC_TRULIOO_NAPILOGIN", "admin")
+	t.Setenv("KYC_TRULIOO_NAPIPASSWORD_FILE", secret)
+
+	err = config.FromFile(filename)
+
+	assert.NoError(err)
+	assert.Equal(config.Options{
+		"Host":         "https://api.globaldatacompany.com",
+		"NAPILogin":    "admin",
+		"NAPIPassword": "password",
+	}, config.Get()["Trulioo"])
+}
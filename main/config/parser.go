@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"modulus/kyc/common"
+	"modulus/kyc/main/auth"
 )
 
 // These are config keywords.
@@ -32,15 +33,28 @@ type kind int
 // parseConfig reads string by string from the input and parses it
 // into valid Config or returns an error if occured.
 func parseConfig(r io.Reader) (Config, error) {
+	cfg, errs := scanConfig(r)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	return cfg, nil
+}
+
+// scanConfig parses the config like parseConfig but goes on after the errors and returns all of them.
+// The malformed strings and the sections with the invalid names are skipped.
+func scanConfig(r io.Reader) (cfg Config, errs []error) {
 	if r == nil {
-		return nil, errors.New("the config source is nil")
+		errs = append(errs, errors.New("the config source is nil"))
+		return
 	}
 
 	scanner := bufio.NewScanner(r)
 
-	cfg := Config{}
+	cfg = Config{}
 	opts := Options{}
 	name := ""
+	skip := false
 	s := ""
 	count := 0
 	for scanner.Scan() {
@@ -59,22 +73,27 @@ func parseConfig(r io.Reader) (Config, error) {
 				opts = Options{}
 			}
 			name = s[1 : len(s)-1]
+			skip = false
 			if err := validateName(name); err != nil {
-				err := ParseError{
+				errs = append(errs, ParseError{
 					strnum:  count,
 					content: scanner.Text(),
 					err:     err.Error(),
-				}
-				return nil, err
+				})
+				name = ""
+				skip = true
 			}
 		case isopt:
+			if skip {
+				continue
+			}
 			if len(name) == 0 {
-				err := ParseError{
+				errs = append(errs, ParseError{
 					strnum:  count,
 					content: scanner.Text(),
 					err:     "standalone option string",
-				}
-				return nil, err
+				})
+				continue
 			}
 			i := bytes.IndexByte([]byte(s), sep)
 			key := s[:i]
@@ -84,33 +103,33 @@ func parseConfig(r io.Reader) (Config, error) {
 			}
 			opts[key] = val
 		case iserror:
-			err := ParseError{
+			errs = append(errs, ParseError{
 				strnum:  count,
 				content: scanner.Text(),
 				err:     "not proper config string",
-			}
-			return nil, err
+			})
 		}
 	}
 	if err := scanner.Err(); err != nil {
-		err := ParseError{
+		errs = append(errs, ParseError{
 			strnum:  count,
 			content: scanner.Text(),
 			err:     err.Error(),
-		}
-		return nil, err
+		})
+		return
 	}
-	if len(name) == 0 {
-		err := ParseError{
+	if len(name) > 0 {
+		cfg[name] = opts
+	}
+	if len(cfg) == 0 && len(errs) == 0 {
+		errs = append(errs, ParseError{
 			strnum:  count,
 			content: scanner.Text(),
 			err:     "config is empty",
-		}
-		return nil, err
+		})
 	}
-	cfg[name] = opts
 
-	return cfg, nil
+	return
 }
 
 func kindOf(s string) kind {
@@ -136,7 +155,13 @@ func validateName(name string) (err error) {
 		err = errors.New("empty section name")
 		return err
 	}
-	if name != ServiceSection && !common.KYCProviders[common.KYCProvider(name)] {
+	if strings.HasPrefix(name, auth.ClientSectionPrefix) {
+		if len(name) == len(auth.ClientSectionPrefix) {
+			err = errors.New("empty client id")
+		}
+		return err
+	}
+	if name != ServiceSection && !common.KnownProvider(common.KYCProvider(name)) {
 		err = errors.New("unknown KYC provider name in the config")
 		return err
 	}
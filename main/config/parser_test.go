@@ -83,6 +83,20 @@ Host=https://sandbox.identitymind.com/im
 Username=modulusglobal
 Password=64117e699462ce859d970648461a625bc6a6f3cb`
 
+var rawConfigWithClients = `
+[IDology]
+Host=https://web.idologylive.com/api/idiq.svc
+Username=modulus.dev2
+Password=}$tRPfT1sZQmU@uh8@
+
+[Client:backoffice]
+APIKey=bBF7TsnK2xCIMMNNbgUNcDwvSRSIbLT9
+Providers=IDology`
+
+var rawConfigWithEmptyClientID = `
+[Client:]
+APIKey=bBF7TsnK2xCIMMNNbgUNcDwvSRSIbLT9`
+
 var emptyRawConfig = ``
 
 func TestParseConfig(t *testing.T) {
@@ -131,6 +145,26 @@ func TestParseConfig(t *testing.T) {
 	assert.Equal("parsing failed at line 2 '[IdentityMind': not proper config string", err.Error())
 	assert.Nil(cfg)
 
+	reader = strings.NewReader(rawConfigWithClients)
+
+	cfg, err = parseConfig(reader)
+
+	assert.NoError(err)
+	assert.Equal(map[string]map[string]string{
+		"backoffice": {
+			"APIKey":    "bBF7TsnK2xCIMMNNbgUNcDwvSRSIbLT9",
+			"Providers": "IDology",
+		},
+	}, cfg.Clients())
+
+	reader = strings.NewReader(rawConfigWithEmptyClientID)
+
+	cfg, err = parseConfig(reader)
+
+	assert.Error(err)
+	assert.Equal("parsing failed at line 2 '[Client:]': empty client id", err.Error())
+	assert.Nil(cfg)
+
 	reader = strings.NewReader(emptyRawConfig)
 
 	cfg, err = parseConfig(reader)
@@ -154,3 +188,29 @@ func TestParseConfig(t *testing.T) {
 	assert.Equal("parsing failed at line 0 '': bufio.Scanner: token too long", err.Error())
 	assert.Nil(cfg)
 }
+
+func TestScanConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg, errs := scanConfig(strings.NewReader(rawConfigWithUnknownName + "\n[Trulioo\nHost=https://api.globaldatacompany.com"))
+
+	assert.Equal([]string{
+		"parsing failed at line 9 '[Foobar]': unknown KYC provider name in the config",
+		"parsing failed at line 14 '[Trulioo': not proper config string",
+	}, errorStrings(errs))
+	assert.Equal(Config{
+		"IdentityMind": Options{
+			"Host":     "https://sandbox.identitymind.com/im",
+			"Username": "modulusglobal",
+			"Password": "64117e699462ce859d970648461a625bc6a6f3cb",
+		},
+	}, cfg)
+}
+
+func errorStrings(errs []error) (strs []string) {
+	for _, err := range errs {
+		strs = append(strs, err.Error())
+	}
+
+	return
+}
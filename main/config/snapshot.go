@@ -0,0 +1,119 @@
+package config
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Snapshot represents the immutable version of the service config.
+// The snapshots are swapped atomically, so the readers never see the config half-updated.
+//
+// * Config is the config itself. It must not be modified, the modified copy is swapped in using Set instead.
+// * Version is the sequence number of the snapshot. It grows with every swapped in config.
+// * Source is the file the config is loaded from if any.
+// * Hash is the hex-encoded SHA256 hash of the source file content.
+// * Loaded is the time the snapshot is swapped in.
+type Snapshot struct {
+	Config  Config
+	Version int64
+	Source  string
+	Hash    string
+	Loaded  time.Time
+}
+
+// ReloadStatus represents the outcome of the latest config reload.
+//
+// * Time is the time of the reload attempt.
+// * Error is the reason the reloaded config is rejected. It's empty if the reload succeeded.
+type ReloadStatus struct {
+	Time  time.Time
+	Error string
+}
+
+var (
+	current atomic.Pointer[Snapshot]
+	version atomic.Int64
+
+	reloadMu   sync.Mutex
+	lastReload ReloadStatus
+)
+
+// Current returns the active snapshot of the service config.
+// The empty snapshot of version 0 is returned if no config is loaded yet.
+func Current() *Snapshot {
+	if snapshot := current.Load(); snapshot != nil {
+		return snapshot
+	}
+
+	return &Snapshot{Config: Config{}}
+}
+
+// Get returns the active service config. It must not be modified.
+func Get() Config {
+	return Current().Config
+}
+
+// Set swaps in the config as the new snapshot without validating it and returns the snapshot.
+func Set(config Config) *Snapshot {
+	return swap(config, "", "")
+}
+
+// LastReload returns the outcome of the latest config reload.
+func LastReload() ReloadStatus {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	return lastReload
+}
+
+// swap makes the config the active snapshot.
+func swap(config Config, source, hash string) (snapshot *Snapshot) {
+	snapshot = &Snapshot{
+		Config:  config,
+		Version: version.Add(1),
+		Source:  source,
+		Hash:    hash,
+		Loaded:  time.Now(),
+	}
+	current.Store(snapshot)
+
+	return
+}
+
+// With returns the copy of the config with the section replaced by the options.
+// The section is removed if the options are nil.
+func (c Config) With(section string, options Options) (config Config) {
+	config = make(Config, len(c)+1)
+	for name, opts := range c {
+		config[name] = opts
+	}
+
+	if options == nil {
+		delete(config, section)
+	} else {
+		config[section] = options
+	}
+
+	return
+}
+
+// Changed returns the sorted names of the sections added, removed or modified in the next config.
+func Changed(prev, next Config) (sections []string) {
+	for name, options := range next {
+		if opts, ok := prev[name]; !ok || !reflect.DeepEqual(opts, options) {
+			sections = append(sections, name)
+		}
+	}
+	for name := range prev {
+		if _, ok := next[name]; !ok {
+			sections = append(sections, name)
+		}
+	}
+
+	sort.Strings(sections)
+
+	return
+}
@@ -0,0 +1,106 @@
+package config_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"modulus/kyc/main/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := config.Config{
+		"Foo": config.Options{"Bar": "bar option"},
+	}
+
+	previous := config.Current()
+
+	snapshot := config.Set(cfg)
+
+	assert.True(snapshot.Version > previous.Version)
+	assert.Equal(cfg, snapshot.Config)
+	assert.Equal(snapshot, config.Current())
+	assert.Equal(cfg, config.Get())
+
+	// Testing the modified copy of the active config.
+	modified := config.Get().With("Qux", config.Options{"Quux": "quux option"})
+
+	assert.Len(modified, 2)
+	assert.Len(config.Get(), 1)
+	assert.Equal([]string{"Qux"}, config.Changed(config.Get(), modified))
+	assert.Equal([]string{"Foo"}, config.Changed(config.Get(), config.Get().With("Foo", nil)))
+	assert.Empty(config.Changed(config.Get(), cfg))
+}
+
+func TestReload(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpfile, err := ioutil.TempFile("", "kyc")
+	if !assert.NoError(err) {
+		return
+	}
+	defer os.Remove(tmpfile.Name())
+
+	tmpfile.WriteString("[Config]\nPort=8080\n\n[IDology]\nHost=https://idology.example.com\nUsername=user\nPassword=password\nUseSummaryResult=false\n")
+	tmpfile.Close()
+
+	err = config.FromFile(tmpfile.Name())
+
+	assert.NoError(err)
+
+	loaded := config.Current()
+
+	assert.Equal(tmpfile.Name(), loaded.Source)
+	assert.NotEmpty(loaded.Hash)
+
+	// Testing the reload of the same content.
+	snapshot, changed, err := config.Reload(tmpfile.Name())
+
+	assert.NoError(err)
+	assert.Empty(changed)
+	assert.Equal(loaded, snapshot)
+	assert.Empty(config.LastReload().Error)
+
+	// Testing the changed sections.
+	ioutil.WriteFile(tmpfile.Name(), []byte("[Config]\nPort=8081\n\n[IDology]\nHost=https://idology.example.com\nUsername=user\nPassword=password\nUseSummaryResult=false\n"), 0600)
+
+	snapshot, changed, err = config.Reload(tmpfile.Name())
+
+	assert.NoError(err)
+	assert.Equal([]string{config.ServiceSection}, changed)
+	assert.True(snapshot.Version > loaded.Version)
+	assert.Equal("8081", config.Get().ServicePort())
+
+	// Testing the invalid config is rejected in full.
+	ioutil.WriteFile(tmpfile.Name(), []byte("[Config]\nPort=8082\nLogLevel=loud\n"), 0600)
+
+	_, _, err = config.Reload(tmpfile.Name())
+
+	assert.Error(err)
+	assert.Equal(snapshot, config.Current())
+	assert.Equal("8081", config.Get().ServicePort())
+	assert.Equal(err.Error(), config.LastReload().Error)
+
+	// Testing the provider option only the provider factory rejects.
+	ioutil.WriteFile(tmpfile.Name(), []byte("[Config]\nPort=8083\n\n[IDology]\nHost=https://idology.example.com\nUsername=user\nPassword=password\nUseSummaryResult=maybe\n"), 0600)
+
+	_, _, err = config.Reload(tmpfile.Name())
+
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "UseSummaryResult")
+	}
+	assert.Equal(snapshot, config.Current())
+	assert.Equal("8081", config.Get().ServicePort())
+
+	// Testing the half-saved file.
+	ioutil.WriteFile(tmpfile.Name(), []byte{}, 0600)
+
+	_, _, err = config.Reload(tmpfile.Name())
+
+	assert.EqualError(err, "empty "+tmpfile.Name())
+	assert.Equal(snapshot, config.Current())
+}
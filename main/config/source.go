@@ -0,0 +1,152 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Supported formats of the config file.
+const (
+	INIFormat  = "ini"
+	YAMLFormat = "yaml"
+	TOMLFormat = "toml"
+)
+
+// FormatOf returns the format of the config file by its extension.
+// The files with other extensions, e.g. kyc.cfg, are in the INI-like format.
+func FormatOf(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		return YAMLFormat
+	case ".toml":
+		return TOMLFormat
+	}
+
+	return INIFormat
+}
+
+// parse parses the config in the format.
+func parse(format string, data []byte) (Config, error) {
+	cfg, errs := scan(format, data)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	return cfg, nil
+}
+
+// scan parses the config in the format like parse but goes on after the errors and returns all of them.
+// The invalid sections and options are skipped.
+func scan(format string, data []byte) (Config, []error) {
+	sections := map[string]map[string]interface{}{}
+
+	switch format {
+	case YAMLFormat:
+		if err := yaml.Unmarshal(data, &sections); err != nil {
+			return nil, []error{fmt.Errorf("parsing YAML failed: %s", err)}
+		}
+	case TOMLFormat:
+		// The section names with the spaces or the special characters are quoted, e.g. ["Client:backoffice"].
+		if _, err := toml.Decode(string(data), &sections); err != nil {
+			return nil, []error{fmt.Errorf("parsing TOML failed: %s", err)}
+		}
+	default:
+		return scanConfig(bytes.NewReader(data))
+	}
+
+	return fromSections(sections)
+}
+
+// fromSections converts the YAML mappings or the TOML tables of the sections into the config.
+// The section names are validated like the ones of the INI-like format and the empty sections are omitted.
+func fromSections(sections map[string]map[string]interface{}) (cfg Config, errs []error) {
+	if len(sections) == 0 {
+		errs = append(errs, errors.New("config is empty"))
+		return
+	}
+
+	names := []string{}
+	for name := range sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	cfg = Config{}
+	for _, name := range names {
+		if err := validateName(name); err != nil {
+			errs = append(errs, fmt.Errorf("parsing failed at section '%s': %s", name, err))
+			continue
+		}
+		if len(sections[name]) == 0 {
+			continue
+		}
+
+		options := Options{}
+		for _, option := range sortedKeys(sections[name]) {
+			value, err := optionValue(sections[name][option])
+			if err != nil {
+				errs = append(errs, fmt.Errorf("parsing failed at option '%s' of section '%s': %s", option, name, err))
+				continue
+			}
+			options[option] = value
+		}
+		cfg[name] = options
+	}
+
+	return
+}
+
+// sortedKeys returns the keys of the decoded section sorted.
+func sortedKeys(section map[string]interface{}) (keys []string) {
+	for key := range section {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return
+}
+
+// optionValue converts the decoded value into the option value.
+// The lists are joined with commas, e.g. the Endpoints of the API client.
+func optionValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case time.Time:
+		return v.Format(time.RFC3339), nil
+	case []interface{}:
+		values := make([]string, len(v))
+		for i, item := range v {
+			if _, ok := item.([]interface{}); ok {
+				return "", errors.New("nested list")
+			}
+			s, err := optionValue(item)
+			if err != nil {
+				return "", err
+			}
+			values[i] = s
+		}
+		return strings.Join(values, ","), nil
+	}
+
+	return "", fmt.Errorf("unsupported value of type %T", value)
+}
@@ -0,0 +1,197 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var rawYAMLConfig = []byte(`
+Config:
+  Port: 8081
+  TracingSampleRatio: 0.5
+
+Trulioo:
+  Host: https://api.globaldatacompany.com
+  NAPILogin: login
+  NAPIPassword: password
+
+Client:backoffice:
+  APIKey: secret
+  Endpoints: [CheckCustomer, CheckStatus]
+`)
+
+var rawTOMLConfig = []byte(`
+[Config]
+Port = 8081
+TracingSampleRatio = 0.5
+
+[Trulioo]
+Host = "https://api.globaldatacompany.com"
+NAPILogin = "login"
+NAPIPassword = "password"
+
+["Client:backoffice"]
+APIKey = "secret"
+Endpoints = ["CheckCustomer", "CheckStatus"]
+`)
+
+var parsedConfig = Config{
+	"Config": Options{
+		"Port":               "8081",
+		"TracingSampleRatio": "0.5",
+	},
+	"Trulioo": Options{
+		"Host":         "https://api.globaldatacompany.com",
+		"NAPILogin":    "login",
+		"NAPIPassword": "password",
+	},
+	"Client:backoffice": Options{
+		"APIKey":    "secret",
+		"Endpoints": "CheckCustomer,CheckStatus",
+	},
+}
+
+func TestFormatOf(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(INIFormat, FormatOf("kyc.cfg"))
+	assert.Equal(YAMLFormat, FormatOf("/etc/kyc/kyc.yaml"))
+	assert.Equal(YAMLFormat, FormatOf("kyc.YML"))
+	assert.Equal(TOMLFormat, FormatOf("kyc.toml"))
+}
+
+func TestParse(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing the YAML config.
+	cfg, err := parse(YAMLFormat, rawYAMLConfig)
+
+	assert.NoError(err)
+	assert.Equal(parsedConfig, cfg)
+
+	// Testing the TOML config.
+	cfg, err = parse(TOMLFormat, rawTOMLConfig)
+
+	assert.NoError(err)
+	assert.Equal(parsedConfig, cfg)
+
+	// Testing the INI-like config.
+	cfg, err = parse(INIFormat, []byte(rawConfig))
+
+	assert.NoError(err)
+	assert.NotEmpty(cfg)
+
+	// Testing the invalid configs.
+	_, err = parse(YAMLFormat, []byte("Foobar:\n  Host: localhost\n"))
+
+	assert.EqualError(err, "parsing failed at section 'Foobar': unknown KYC provider name in the config")
+
+	_, err = parse(YAMLFormat, []byte("Trulioo:\n  Host:\n    URL: localhost\n"))
+
+	assert.EqualError(err, "parsing failed at option 'Host' of section 'Trulioo': unsupported value of type map[string]interface {}")
+
+	_, err = parse(YAMLFormat, []byte("Port: 8080\n"))
+
+	assert.Error(err)
+
+	_, err = parse(TOMLFormat, []byte("[Trulioo\n"))
+
+	assert.Error(err)
+}
+
+func TestApplyEnv(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := Config{
+		"Config": Options{"Port": "8080"},
+		"Trulioo": Options{
+			"Host":         "https://api.globaldatacompany.com",
+			"NAPILogin":    "login",
+			"NAPIPassword": "",
+		},
+		"Client:backoffice": Options{"APIKey": "secret"},
+	}
+
+	applyEnv(cfg, []string{
+		"KYC_TRULIOO_NAPIPASSWORD=password",
+		"KYC_CONFIG_PORT=8081",
+		"KYC_CONFIG_STOREDSN=postgres://localhost/kyc",
+		"KYC_SUMSUBSTANCE_APIKEY=sumsub",
+		"KYC_SUMSUBSTANCE_WEBHOOKSECRET_FILE=/run/secrets/sumsub",
+		"KYC_JUMIO_CALLBACKTOKEN=token",
+		"KYC_CLIENTBACKOFFICE_APIKEY_FILE=/run/secrets/backoffice",
+		"KYC_CLIENTMOBILE_APIKEY=mobile",
+		"KYC_TRULIOO_UNKNOWN=unknown",
+		"KYC_VERSION=1",
+		"HOME=/root",
+	})
+
+	assert.Equal(Config{
+		"Config": Options{
+			"Port":     "8081",
+			"StoreDSN": "postgres://localhost/kyc",
+		},
+		"Trulioo": Options{
+			"Host":         "https://api.globaldatacompany.com",
+			"NAPILogin":    "login",
+			"NAPIPassword": "password",
+		},
+		"Sum&Substance":     Options{"APIKey": "sumsub", "WebhookSecret_FILE": "/run/secrets/sumsub"},
+		"Jumio":             Options{"CallbackToken": "token"},
+		"Client:backoffice": Options{"APIKey_FILE": "/run/secrets/backoffice"},
+	}, cfg)
+
+	// Testing the optional option of the provider.
+	cfg = Config{}
+
+	applyEnv(cfg, []string{"KYC_SUMSUBSTANCE_WEBHOOKSECRET=secret"})
+
+	assert.Equal(Config{"Sum&Substance": Options{"WebhookSecret": "secret"}}, cfg)
+}
+
+func TestResolveFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "secrets")
+	if !assert.NoError(err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	secret := filepath.Join(dir, "trulioo")
+	ioutil.WriteFile(secret, []byte("password\n"), 0600)
+
+	// Testing the option read from the file.
+	cfg := Config{
+		"Trulioo": Options{"NAPILogin": "login", "NAPIPassword_FILE": secret},
+	}
+
+	errs := resolveFiles(cfg)
+
+	assert.Empty(errs)
+	assert.Equal(Options{"NAPILogin": "login", "NAPIPassword": "password"}, cfg["Trulioo"])
+
+	// Testing the option set both ways.
+	cfg = Config{
+		"Trulioo": Options{"NAPIPassword": "password", "NAPIPassword_FILE": secret},
+	}
+
+	errs = resolveFiles(cfg)
+
+	assert.Len(errs, 1)
+	assert.EqualError(errs[0], "Trulioo configuration error: both options 'NAPIPassword' and 'NAPIPassword_FILE' are set")
+
+	// Testing the missing file.
+	cfg = Config{
+		"Trulioo": Options{"NAPIPassword_FILE": filepath.Join(dir, "missing")},
+	}
+
+	errs = resolveFiles(cfg)
+
+	assert.Len(errs, 1)
+	assert.EqualError(errs[0], "Trulioo configuration error: invalid option 'NAPIPassword_FILE': open "+filepath.Join(dir, "missing")+": no such file or directory")
+}
@@ -1,119 +1,226 @@
 package config
 
-import "modulus/kyc/common"
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
 
-// validate ensures the config correctness for all KYC providers containing in the given config.
-func validate(config Config) (err error) {
-	for provider, options := range config {
-		switch common.KYCProvider(provider) {
-		case common.Coinfirm:
-			if len(options["Host"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "Host"}
-			}
-			if len(options["Email"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "Email"}
-			}
-			if len(options["Password"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "Password"}
-			}
-			if len(options["Company"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "Company"}
-			}
-		case common.ComplyAdvantage:
-			if len(options["Host"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "Host"}
-			}
-			if len(options["APIkey"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "APIkey"}
-			}
-			if len(options["Fuzziness"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "Fuzziness"}
-			}
-		case common.IdentityMind:
-			if len(options["Host"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "Host"}
-			}
-			if len(options["Username"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "Username"}
-			}
-			if len(options["Password"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "Password"}
-			}
-		case common.IDology:
-			if len(options["Host"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "Host"}
-			}
-			if len(options["Username"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "Username"}
-			}
-			if len(options["Password"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "Password"}
-			}
-			if len(options["UseSummaryResult"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "UseSummaryResult"}
-			}
-		case common.Jumio:
-			if len(options["BaseURL"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "BaseURL"}
-			}
-			if len(options["Token"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "Token"}
-			}
-			if len(options["Secret"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "Secret"}
-			}
-		case common.ShuftiPro:
-			if len(options["Host"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "Host"}
-			}
-			if len(options["SecretKey"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "SecretKey"}
-			}
-			if len(options["ClientID"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "ClientID"}
-			}
-			if len(options["CallbackURL"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "CallbackURL"}
-			}
-		case common.SumSub:
-			if len(options["Host"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "Host"}
-			}
-			if len(options["APIKey"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "APIKey"}
-			}
-		case common.SynapseFI:
-			if len(options["Host"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "Host"}
-			}
-			if len(options["ClientID"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "ClientID"}
-			}
-			if len(options["ClientSecret"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "ClientSecret"}
+	"modulus/kyc/common"
+	"modulus/kyc/http"
+	// Make implemented KYC providers available for the validation.
+	_ "modulus/kyc/integrations"
+	"modulus/kyc/main/auth"
+	"modulus/kyc/main/batch"
+	grpcconfig "modulus/kyc/main/grpcapi/config"
+	"modulus/kyc/main/idempotency"
+	"modulus/kyc/main/jobs"
+	"modulus/kyc/main/limits"
+	"modulus/kyc/main/logging"
+	"modulus/kyc/main/notify"
+	"modulus/kyc/main/poller"
+	"modulus/kyc/main/rules"
+	"modulus/kyc/main/secrets"
+	"modulus/kyc/main/server"
+	"modulus/kyc/main/store"
+	"modulus/kyc/main/tracing"
+)
+
+var (
+	keysMu sync.RWMutex
+	keys   secrets.KeyProvider = secrets.EnvKey{}
+)
+
+// SetKeyProvider sets the provider of the key decrypting the encrypted option values.
+// The key is taken from the environment variables by default, see secrets.EnvKey.
+func SetKeyProvider(provider secrets.KeyProvider) {
+	keysMu.Lock()
+	defer keysMu.Unlock()
+
+	keys = provider
+}
+
+// decrypt replaces the encrypted option values with the decrypted ones and returns the errors of the values that can't be decrypted.
+// The key is requested only if the config holds the encrypted values.
+func decrypt(config Config) (errs []error) {
+	var (
+		key    []byte
+		keyErr error
+	)
+
+	for _, section := range sortedSections(config) {
+		options := config[section]
+		for _, option := range sortedOptions(options) {
+			value := options[option]
+			if !secrets.IsEncrypted(value) {
+				continue
 			}
-		case common.ThomsonReuters:
-			if len(options["Host"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "Host"}
+
+			if key == nil && keyErr == nil {
+				keysMu.RLock()
+				key, keyErr = keys.Key()
+				keysMu.RUnlock()
 			}
-			if len(options["APIkey"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "APIkey"}
+			if keyErr != nil {
+				errs = append(errs, ErrUndecryptable{provider: section, option: option, err: keyErr.Error()})
+				continue
 			}
-			if len(options["APIsecret"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "APIsecret"}
+
+			decrypted, err := secrets.Decrypt(key, value)
+			if err != nil {
+				errs = append(errs, ErrUndecryptable{provider: section, option: option, err: err.Error()})
+				continue
 			}
-		case common.Trulioo:
-			if len(options["Host"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "Host"}
+			options[option] = decrypted
+		}
+	}
+
+	return
+}
+
+// validate ensures the config correctness for all KYC providers containing in the given config.
+// The options required for a provider are taken from the provider registry.
+// The encrypted option values left undecrypted are reported.
+// The provider is constructed from its options to catch the errors only its factory reports.
+// The HTTP client, polling, limits, batch and URL options of a provider, the limits options of the API clients and the authentication, logging, tracing, notification, async jobs, batch, idempotency keys, store, decision rules and HTTP server options of the service are checked as well.
+func validate(config Config) (err error) {
+	if errs := validationErrors(config); len(errs) > 0 {
+		err = errs[0]
+	}
+
+	return
+}
+
+// validationErrors checks the config like validate but returns all the errors found.
+// The errors of the service section go first, then the ones of the providers and the API clients sorted by the section names.
+func validationErrors(config Config) (errs []error) {
+	invalid := func(section string, err error) {
+		if err != nil {
+			errs = append(errs, ErrInvalidOption{provider: section, err: err.Error()})
+		}
+	}
+
+	undecrypted := map[string]bool{}
+	for _, section := range sortedSections(config) {
+		for _, option := range sortedOptions(config[section]) {
+			if secrets.IsEncrypted(config[section][option]) {
+				errs = append(errs, ErrUndecryptable{provider: section, option: option, err: "the value isn't decrypted"})
+				undecrypted[section] = true
 			}
-			if len(options["NAPILogin"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "NAPILogin"}
+		}
+	}
+
+	service := config[ServiceSection]
+
+	_, err := auth.ConfigFromOptions(service, config.Clients())
+	invalid(ServiceSection, err)
+	_, err = logging.ConfigFromOptions(service)
+	invalid(ServiceSection, err)
+	_, err = notify.ConfigFromOptions(service)
+	invalid(ServiceSection, err)
+	_, err = batch.ConfigFromOptions(service)
+	invalid(ServiceSection, err)
+	_, err = idempotency.ConfigFromOptions(service)
+	invalid(ServiceSection, err)
+	_, err = jobs.ConfigFromOptions(service)
+	invalid(ServiceSection, err)
+	_, err = store.ConfigFromOptions(service)
+	invalid(ServiceSection, err)
+	_, err = tracing.ConfigFromOptions(service)
+	invalid(ServiceSection, err)
+	_, err = rules.ConfigFromOptions(service)
+	invalid(ServiceSection, err)
+	_, err = server.ConfigFromOptions(service)
+	invalid(ServiceSection, err)
+	_, err = grpcconfig.ConfigFromOptions(service)
+	invalid(ServiceSection, err)
+
+	for _, provider := range sortedSections(config) {
+		spec, ok := common.LookupProvider(common.KYCProvider(provider))
+		if !ok {
+			continue
+		}
+		options := config[provider]
+		var missing []string
+		for _, option := range spec.Options {
+			if len(options[option]) == 0 {
+				errs = append(errs, ErrMissingOption{provider: provider, option: option})
+				missing = append(missing, option)
 			}
-			if len(options["NAPIPassword"]) == 0 {
-				return ErrMissingOption{provider: provider, option: "NAPIPassword"}
+		}
+		found := len(errs)
+		_, err = http.NewClientFromOptions(options)
+		invalid(provider, err)
+		_, err = poller.SettingsFromOptions(options)
+		invalid(provider, err)
+		_, err = limits.LimitsFromOptions(options)
+		invalid(provider, err)
+		_, err = batch.ConcurrencyFromOptions(options)
+		invalid(provider, err)
+		errs = append(errs, urlErrors(provider, options, append(append([]string{}, spec.Options...), spec.Optional...))...)
+
+		// The provider is constructed only from the options found valid to avoid reporting the same error twice.
+		// The missing options don't prevent constructing it, but the errors it reports about them are dropped.
+		if len(errs) == found && !undecrypted[provider] {
+			if _, err = spec.Factory(options); err != nil && !mentions(err, missing) {
+				invalid(provider, err)
 			}
 		}
 	}
 
+	for _, section := range sortedSections(config) {
+		if strings.HasPrefix(section, auth.ClientSectionPrefix) {
+			_, err = limits.LimitsFromOptions(config[section])
+			invalid(section, err)
+		}
+	}
+
+	return
+}
+
+// urlErrors returns the errors of the Host and URL options of the section that aren't absolute URLs.
+func urlErrors(section string, options Options, names []string) (errs []error) {
+	for _, name := range names {
+		value := options[name]
+		if len(value) == 0 || (name != "Host" && !strings.HasSuffix(name, "URL")) {
+			continue
+		}
+		if u, err := url.Parse(value); err != nil || len(u.Scheme) == 0 || len(u.Host) == 0 {
+			errs = append(errs, ErrInvalidOption{provider: section, err: fmt.Sprintf("invalid option '%s': malformed URL '%s'", name, value)})
+		}
+	}
+
+	return
+}
+
+// mentions reports whether the error is about one of the options.
+func mentions(err error, options []string) bool {
+	for _, option := range options {
+		if strings.Contains(err.Error(), "'"+option+"'") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sortedSections returns the names of the config sections sorted.
+func sortedSections(config Config) (sections []string) {
+	for section := range config {
+		sections = append(sections, section)
+	}
+	sort.Strings(sections)
+
+	return
+}
+
+// sortedOptions returns the names of the options sorted.
+func sortedOptions(options Options) (names []string) {
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
 	return
 }
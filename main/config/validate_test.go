@@ -1,38 +1,40 @@
 package config
 
 import (
+	"encoding/base64"
 	"reflect"
 	"testing"
 
 	"modulus/kyc/common"
+	"modulus/kyc/main/secrets"
 
 	"github.com/stretchr/testify/assert"
 )
 
 var validConfig = Config{
 	string(common.IdentityMind): Options{
-		"Host":     "host",
+		"Host":     "https://host.example.com",
 		"Username": "fakeuser",
 		"Password": "fakepassword",
 	},
 	string(common.IDology): Options{
-		"Host":             "host",
+		"Host":             "https://host.example.com",
 		"Username":         "fakeuser",
 		"Password":         "fakepassword",
 		"UseSummaryResult": "false",
 	},
 	string(common.ShuftiPro): Options{
-		"Host":        "host",
+		"Host":        "https://host.example.com",
 		"ClientID":    "fakeid",
 		"SecretKey":   "fakekey",
-		"CallbackURL": "host",
+		"CallbackURL": "https://host.example.com",
 	},
 	string(common.SumSub): Options{
-		"Host":   "host",
+		"Host":   "https://host.example.com",
 		"APIKey": "fakekey",
 	},
 	string(common.Trulioo): Options{
-		"Host":         "host",
+		"Host":         "https://host.example.com",
 		"NAPILogin":    "fakelogin",
 		"NAPIPassword": "fakepassword",
 	},
@@ -62,7 +64,7 @@ func TestVerifyComplyAdvantage(t *testing.T) {
 
 	config = Config{
 		string(common.ComplyAdvantage): Options{
-			"Host":      "host",
+			"Host":      "https://host.example.com",
 			"Fuzziness": "0",
 		},
 	}
@@ -73,7 +75,7 @@ func TestVerifyComplyAdvantage(t *testing.T) {
 
 	config = Config{
 		string(common.ComplyAdvantage): Options{
-			"Host":   "host",
+			"Host":   "https://host.example.com",
 			"APIkey": "key",
 		},
 	}
@@ -98,7 +100,7 @@ func TestVerifyIdentityMind(t *testing.T) {
 
 	config = Config{
 		string(common.IdentityMind): Options{
-			"Host":     "host",
+			"Host":     "https://host.example.com",
 			"Password": "fakepassword",
 		},
 	}
@@ -109,7 +111,7 @@ func TestVerifyIdentityMind(t *testing.T) {
 
 	config = Config{
 		string(common.IdentityMind): Options{
-			"Host":     "host",
+			"Host":     "https://host.example.com",
 			"Username": "fakeuser",
 		},
 	}
@@ -135,7 +137,7 @@ func TestVerifyIDology(t *testing.T) {
 
 	config = Config{
 		string(common.IDology): Options{
-			"Host":             "host",
+			"Host":             "https://host.example.com",
 			"Password":         "fakepassword",
 			"UseSummaryResult": "false",
 		},
@@ -147,7 +149,7 @@ func TestVerifyIDology(t *testing.T) {
 
 	config = Config{
 		string(common.IDology): Options{
-			"Host":             "host",
+			"Host":             "https://host.example.com",
 			"Username":         "fakeuser",
 			"UseSummaryResult": "false",
 		},
@@ -159,7 +161,7 @@ func TestVerifyIDology(t *testing.T) {
 
 	config = Config{
 		string(common.IDology): Options{
-			"Host":     "host",
+			"Host":     "https://host.example.com",
 			"Username": "fakeuser",
 			"Password": "fakepassword",
 		},
@@ -187,7 +189,7 @@ func TestVerifyJumio(t *testing.T) {
 
 	config = Config{
 		string(common.Jumio): Options{
-			"BaseURL": "base_url",
+			"BaseURL": "https://base.example.com",
 			"Secret":  "secret",
 		},
 	}
@@ -198,7 +200,7 @@ func TestVerifyJumio(t *testing.T) {
 
 	config = Config{
 		string(common.Jumio): Options{
-			"BaseURL": "base_url",
+			"BaseURL": "https://base.example.com",
 			"Token":   "token",
 		},
 	}
@@ -213,7 +215,7 @@ func TestVerifyShuftiPro(t *testing.T) {
 		string(common.ShuftiPro): Options{
 			"ClientID":    "fakeid",
 			"SecretKey":   "fakekey",
-			"RedirectURL": "host",
+			"RedirectURL": "https://host.example.com",
 		},
 	}
 
@@ -224,9 +226,9 @@ func TestVerifyShuftiPro(t *testing.T) {
 
 	config = Config{
 		string(common.ShuftiPro): Options{
-			"Host":        "host",
+			"Host":        "https://host.example.com",
 			"SecretKey":   "fakekey",
-			"RedirectURL": "host",
+			"RedirectURL": "https://host.example.com",
 		},
 	}
 
@@ -236,9 +238,9 @@ func TestVerifyShuftiPro(t *testing.T) {
 
 	config = Config{
 		string(common.ShuftiPro): Options{
-			"Host":        "host",
+			"Host":        "https://host.example.com",
 			"ClientID":    "fakeid",
-			"RedirectURL": "host",
+			"RedirectURL": "https://host.example.com",
 		},
 	}
 
@@ -248,7 +250,7 @@ func TestVerifyShuftiPro(t *testing.T) {
 
 	config = Config{
 		string(common.ShuftiPro): Options{
-			"Host":      "host",
+			"Host":      "https://host.example.com",
 			"ClientID":  "fakeid",
 			"SecretKey": "fakekey",
 		},
@@ -273,7 +275,7 @@ func TestVerifySumSub(t *testing.T) {
 
 	config = Config{
 		string(common.SumSub): Options{
-			"Host": "host",
+			"Host": "https://host.example.com",
 		},
 	}
 
@@ -299,7 +301,7 @@ func TestVerifySynapseFI(t *testing.T) {
 
 	config = Config{
 		string(common.SynapseFI): Options{
-			"Host":         "host",
+			"Host":         "https://host.example.com",
 			"ClientSecret": "secret",
 		},
 	}
@@ -310,7 +312,7 @@ func TestVerifySynapseFI(t *testing.T) {
 
 	config = Config{
 		string(common.SynapseFI): Options{
-			"Host":     "host",
+			"Host":     "https://host.example.com",
 			"ClientID": "clientID",
 		},
 	}
@@ -337,7 +339,7 @@ func TestVerifyThomsonReuters(t *testing.T) {
 
 	config = Config{
 		string(common.ThomsonReuters): Options{
-			"Host":      "host",
+			"Host":      "https://host.example.com",
 			"APIsecret": "secret",
 		},
 	}
@@ -348,7 +350,7 @@ func TestVerifyThomsonReuters(t *testing.T) {
 
 	config = Config{
 		string(common.ThomsonReuters): Options{
-			"Host":   "host",
+			"Host":   "https://host.example.com",
 			"APIkey": "key",
 		},
 	}
@@ -373,7 +375,7 @@ func TestVerifyTrulioo(t *testing.T) {
 
 	config = Config{
 		string(common.Trulioo): Options{
-			"Host":         "host",
+			"Host":         "https://host.example.com",
 			"NAPIPassword": "fakepassword",
 		},
 	}
@@ -384,7 +386,7 @@ func TestVerifyTrulioo(t *testing.T) {
 
 	config = Config{
 		string(common.Trulioo): Options{
-			"Host":      "host",
+			"Host":      "https://host.example.com",
 			"NAPILogin": "fakelogin",
 		},
 	}
@@ -393,3 +395,505 @@ func TestVerifyTrulioo(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Equal(t, `Trulioo configuration error: missing or empty option 'NAPIPassword'`, err.Error())
 }
+
+func TestVerifyHTTPOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	config := Config{
+		string(common.SumSub): Options{
+			"Host":           "https://host.example.com",
+			"APIKey":         "fakekey",
+			"Timeout":        "2m",
+			"ConnectTimeout": "10s",
+			"Proxy":          "http://proxy.example.com:3128",
+			"MaxRetries":     "3",
+			"RetryWait":      "1s",
+		},
+	}
+
+	err := validate(config)
+	assert.NoError(err)
+
+	config = Config{
+		string(common.SumSub): Options{
+			"Host":    "https://host.example.com",
+			"APIKey":  "fakekey",
+			"Timeout": "2 minutes",
+		},
+	}
+
+	err = validate(config)
+	assert.Error(err)
+	assert.Equal(reflect.TypeOf(ErrInvalidOption{}), reflect.TypeOf(err))
+	assert.Contains(err.Error(), `Sum&Substance configuration error: invalid option 'Timeout'`)
+
+	config = Config{
+		string(common.SumSub): Options{
+			"Host":       "https://host.example.com",
+			"APIKey":     "fakekey",
+			"ClientCert": "client.pem",
+		},
+	}
+
+	err = validate(config)
+	assert.Error(err)
+	assert.Equal(`Sum&Substance configuration error: options 'ClientCert' and 'ClientKey' must be specified together`, err.Error())
+}
+
+func TestVerifyNotificationOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	config := Config{
+		ServiceSection: Options{
+			"Port":                    "8080",
+			"NotificationSecret":      "secret",
+			"NotificationMaxAttempts": "5",
+		},
+	}
+
+	err := validate(config)
+	assert.NoError(err)
+
+	config = Config{
+		ServiceSection: Options{
+			"NotificationSecret":    "secret",
+			"NotificationRetryWait": "0s",
+		},
+	}
+
+	err = validate(config)
+	assert.Error(err)
+	assert.Equal(reflect.TypeOf(ErrInvalidOption{}), reflect.TypeOf(err))
+	assert.Equal("Config configuration error: invalid option 'NotificationRetryWait': non-positive duration", err.Error())
+}
+
+func TestVerifyJobsOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	config := Config{
+		ServiceSection: Options{
+			"Port":         "8080",
+			"JobWorkers":   "8",
+			"JobRetention": "1h",
+		},
+	}
+
+	err := validate(config)
+	assert.NoError(err)
+
+	config = Config{
+		ServiceSection: Options{
+			"JobMaxQueued": "0",
+		},
+	}
+
+	err = validate(config)
+	assert.Error(err)
+	assert.Equal(reflect.TypeOf(ErrInvalidOption{}), reflect.TypeOf(err))
+	assert.Equal("Config configuration error: invalid option 'JobMaxQueued': non-positive number", err.Error())
+}
+
+func TestVerifyBatchOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	config := Config{
+		ServiceSection: Options{
+			"Port":            "8080",
+			"BatchMaxRecords": "500",
+			"BatchRetention":  "24h",
+		},
+		string(common.SumSub): Options{
+			"Host":             "https://host.example.com",
+			"APIKey":           "fakekey",
+			"BatchConcurrency": "8",
+		},
+	}
+
+	err := validate(config)
+	assert.NoError(err)
+
+	config = Config{
+		ServiceSection: Options{
+			"BatchMaxRecords": "0",
+		},
+	}
+
+	err = validate(config)
+	assert.Error(err)
+	assert.Equal(reflect.TypeOf(ErrInvalidOption{}), reflect.TypeOf(err))
+	assert.Equal("Config configuration error: invalid option 'BatchMaxRecords': non-positive number", err.Error())
+
+	config = Config{
+		string(common.SumSub): Options{
+			"Host":             "https://host.example.com",
+			"APIKey":           "fakekey",
+			"BatchConcurrency": "-1",
+		},
+	}
+
+	err = validate(config)
+	assert.Error(err)
+	assert.Equal(reflect.TypeOf(ErrInvalidOption{}), reflect.TypeOf(err))
+	assert.Equal("Sum&Substance configuration error: invalid option 'BatchConcurrency': non-positive number", err.Error())
+}
+
+func TestVerifyIdempotencyOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	config := Config{
+		ServiceSection: Options{
+			"Port":           "8080",
+			"IdempotencyTTL": "48h",
+		},
+	}
+
+	err := validate(config)
+	assert.NoError(err)
+
+	config = Config{
+		ServiceSection: Options{
+			"IdempotencyTTL": "-1h",
+		},
+	}
+
+	err = validate(config)
+	assert.Error(err)
+	assert.Equal(reflect.TypeOf(ErrInvalidOption{}), reflect.TypeOf(err))
+	assert.Equal("Config configuration error: invalid option 'IdempotencyTTL': non-positive duration", err.Error())
+}
+
+func TestVerifyServerOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	config := Config{
+		ServiceSection: Options{
+			"HTTPWriteTimeout": "5m",
+			"TLSCert":          "/etc/kyc/tls.crt",
+			"TLSKey":           "/etc/kyc/tls.key",
+		},
+	}
+
+	err := validate(config)
+	assert.NoError(err)
+
+	delete(config[ServiceSection], "TLSKey")
+
+	err = validate(config)
+	assert.Error(err)
+	assert.Equal(reflect.TypeOf(ErrInvalidOption{}), reflect.TypeOf(err))
+	assert.Equal("Config configuration error: options 'TLSCert' and 'TLSKey' must be set together", err.Error())
+}
+
+func TestVerifyGRPCOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	config := Config{
+		ServiceSection: Options{
+			"Port":               "8080",
+			"GRPCPort":           "9090",
+			"GRPCUploadTTL":      "30m",
+			"GRPCMaxUploadsSize": "1048576",
+		},
+	}
+
+	err := validate(config)
+	assert.NoError(err)
+
+	config = Config{
+		ServiceSection: Options{
+			"GRPCUploadTTL": "-1h",
+		},
+	}
+
+	err = validate(config)
+	assert.Error(err)
+	assert.Equal(reflect.TypeOf(ErrInvalidOption{}), reflect.TypeOf(err))
+	assert.Equal("Config configuration error: invalid option 'GRPCUploadTTL': non-positive duration", err.Error())
+}
+
+func TestVerifyLoggingOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	config := Config{
+		ServiceSection: Options{
+			"Port":      "8080",
+			"LogLevel":  "warn",
+			"LogFormat": "json",
+		},
+	}
+
+	err := validate(config)
+	assert.NoError(err)
+
+	config = Config{
+		ServiceSection: Options{
+			"LogLevel": "verbose",
+		},
+	}
+
+	err = validate(config)
+	assert.Error(err)
+	assert.Equal(reflect.TypeOf(ErrInvalidOption{}), reflect.TypeOf(err))
+	assert.Equal("Config configuration error: invalid option 'LogLevel': unknown level verbose", err.Error())
+}
+
+func TestVerifyTracingOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	config := Config{
+		ServiceSection: Options{
+			"Port":               "8080",
+			"TracingEndpoint":    "http://localhost:4318",
+			"TracingSampleRatio": "0.5",
+		},
+	}
+
+	err := validate(config)
+	assert.NoError(err)
+
+	config = Config{
+		ServiceSection: Options{
+			"TracingSampleRatio": "-1",
+		},
+	}
+
+	err = validate(config)
+	assert.Error(err)
+	assert.Equal(reflect.TypeOf(ErrInvalidOption{}), reflect.TypeOf(err))
+	assert.Equal("Config configuration error: invalid option 'TracingSampleRatio': must be between 0 and 1", err.Error())
+}
+
+func TestVerifyStoreOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	config := Config{
+		ServiceSection: Options{
+			"Port":        "8080",
+			"StoreDriver": "postgres",
+			"StoreDSN":    "postgres://kyc@localhost/kyc?sslmode=disable",
+		},
+	}
+
+	err := validate(config)
+	assert.NoError(err)
+
+	config = Config{
+		ServiceSection: Options{
+			"StoreDriver": "postgres",
+		},
+	}
+
+	err = validate(config)
+	assert.Error(err)
+	assert.Equal(reflect.TypeOf(ErrInvalidOption{}), reflect.TypeOf(err))
+	assert.Equal("Config configuration error: missing option 'StoreDSN' for the postgres store", err.Error())
+}
+
+func TestVerifyPollingOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	config := Config{
+		string(common.SumSub): Options{
+			"Host":            "https://host.example.com",
+			"APIKey":          "fakekey",
+			"PollInterval":    "30s",
+			"PollMaxInterval": "10m",
+			"PollRateLimit":   "20",
+			"PollMaxAge":      "48h",
+		},
+	}
+
+	err := validate(config)
+	assert.NoError(err)
+
+	config = Config{
+		string(common.SumSub): Options{
+			"Host":            "https://host.example.com",
+			"APIKey":          "fakekey",
+			"PollInterval":    "1h",
+			"PollMaxInterval": "10m",
+		},
+	}
+
+	err = validate(config)
+	assert.Error(err)
+	assert.Equal(reflect.TypeOf(ErrInvalidOption{}), reflect.TypeOf(err))
+	assert.Equal("Sum&Substance configuration error: option 'PollMaxInterval' must not be less than 'PollInterval'", err.Error())
+}
+
+func TestVerifyAuthOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	config := Config{
+		ServiceSection: Options{
+			"Port":          "8080",
+			"AuthJWTSecret": "secret",
+		},
+		"Client:backoffice": Options{
+			"APIKey":    "key1,key2",
+			"Providers": "IDology,Jumio",
+			"Endpoints": "CheckCustomer,CheckStatus",
+		},
+	}
+
+	err := validate(config)
+	assert.NoError(err)
+
+	config = Config{
+		ServiceSection: Options{
+			"Port": "8080",
+		},
+		"Client:backoffice": Options{
+			"Providers": "IDology",
+		},
+	}
+
+	err = validate(config)
+	assert.Error(err)
+	assert.Equal(reflect.TypeOf(ErrInvalidOption{}), reflect.TypeOf(err))
+	assert.Equal("Config configuration error: client backoffice: missing option 'APIKey'", err.Error())
+
+	config = Config{
+		ServiceSection: Options{
+			"Port": "8080",
+		},
+		"Client:backoffice": Options{
+			"APIKey":    "key",
+			"Providers": "Foobar",
+		},
+	}
+
+	err = validate(config)
+	assert.Error(err)
+	assert.Equal(reflect.TypeOf(ErrInvalidOption{}), reflect.TypeOf(err))
+	assert.Equal("Config configuration error: client backoffice: invalid option 'Providers': unknown provider Foobar", err.Error())
+
+	config = Config{
+		ServiceSection: Options{
+			"AuthJWTSecret": "secret",
+		},
+	}
+
+	err = validate(config)
+	assert.Error(err)
+	assert.Equal(reflect.TypeOf(ErrInvalidOption{}), reflect.TypeOf(err))
+	assert.Equal("Config configuration error: the JWT authentication requires the clients to be defined", err.Error())
+}
+
+func TestVerifyLimitsOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	config := Config{
+		ServiceSection: Options{
+			"Port": "8080",
+		},
+		"IDology": Options{
+			"Host":             "https://web.idologylive.com/api/idiq.svc",
+			"Username":         "username",
+			"Password":         "password",
+			"UseSummaryResult": "false",
+			"RateLimit":        "120",
+			"MonthlyQuota":     "10000",
+		},
+		"Client:backoffice": Options{
+			"APIKey":     "key",
+			"RateLimit":  "60",
+			"RateBurst":  "10",
+			"DailyQuota": "500",
+		},
+	}
+
+	err := validate(config)
+	assert.NoError(err)
+
+	config["IDology"]["RateLimit"] = "-1"
+
+	err = validate(config)
+	assert.Error(err)
+	assert.Equal(reflect.TypeOf(ErrInvalidOption{}), reflect.TypeOf(err))
+	assert.Equal("IDology configuration error: invalid option 'RateLimit': negative number", err.Error())
+
+	config["IDology"]["RateLimit"] = "120"
+	config["Client:backoffice"]["DailyQuota"] = "unlimited"
+
+	err = validate(config)
+	assert.Error(err)
+	assert.Equal(reflect.TypeOf(ErrInvalidOption{}), reflect.TypeOf(err))
+	assert.Equal(`Client:backoffice configuration error: invalid option 'DailyQuota': strconv.Atoi: parsing "unlimited": invalid syntax`, err.Error())
+}
+
+func TestVerifyRulesOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	config := Config{
+		ServiceSection: Options{
+			"Port":      "8080",
+			"RulesFile": "missing_rules.yml",
+		},
+	}
+
+	err := validate(config)
+	assert.Error(err)
+	assert.Equal(reflect.TypeOf(ErrInvalidOption{}), reflect.TypeOf(err))
+	assert.Contains(err.Error(), "Config configuration error: invalid option 'RulesFile': open missing_rules.yml")
+
+	delete(config[ServiceSection], "RulesFile")
+
+	err = validate(config)
+	assert.NoError(err)
+}
+
+func TestVerifyEncryptedOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	key := make([]byte, secrets.KeySize)
+	SetKeyProvider(secrets.StaticKey(base64.StdEncoding.EncodeToString(key)))
+	defer SetKeyProvider(secrets.EnvKey{})
+
+	encrypted, err := secrets.Encrypt(key, "fakepassword")
+	assert.NoError(err)
+
+	config := Config{
+		string(common.IDology): Options{
+			"Host":             "https://host.example.com",
+			"Username":         "fakeuser",
+			"Password":         encrypted,
+			"UseSummaryResult": "false",
+		},
+	}
+
+	// Testing the encrypted value isn't valid until it's decrypted.
+	err = validate(config)
+	assert.Error(err)
+	assert.Equal(reflect.TypeOf(ErrUndecryptable{}), reflect.TypeOf(err))
+	assert.Equal("IDology configuration error: can't decrypt option 'Password': the value isn't decrypted", err.Error())
+
+	errs := decrypt(config)
+	assert.Empty(errs)
+	assert.Equal("fakepassword", config[string(common.IDology)]["Password"])
+
+	err = validate(config)
+	assert.NoError(err)
+
+	// Testing the value encrypted with another key.
+	key[0] = 1
+	encrypted, err = secrets.Encrypt(key, "fakepassword")
+	assert.NoError(err)
+
+	config[string(common.IDology)]["Password"] = encrypted
+
+	errs = decrypt(config)
+	assert.Len(errs, 1)
+	assert.Equal(reflect.TypeOf(ErrUndecryptable{}), reflect.TypeOf(errs[0]))
+	assert.EqualError(errs[0], "IDology configuration error: can't decrypt option 'Password': wrong key or corrupted value")
+
+	// Testing the missing key.
+	SetKeyProvider(secrets.KeyFile("missing.key"))
+
+	config[string(common.IDology)]["Username"] = encrypted
+
+	errs = decrypt(config)
+	assert.Len(errs, 2)
+	assert.EqualError(errs[0], "IDology configuration error: can't decrypt option 'Password': open missing.key: no such file or directory")
+	assert.EqualError(errs[1], "IDology configuration error: can't decrypt option 'Username': open missing.key: no such file or directory")
+}
@@ -0,0 +1,62 @@
+// Package events delivers the updates of verification results to the subsystems of the service.
+// The updates come from provider callbacks and other sources,
+// the subsystems subscribe to receive them.
+package events
+
+import (
+	"sync"
+	"time"
+
+	"modulus/kyc/common"
+)
+
+// Source represents the origin of the result update.
+type Source string
+
+// Possible values of Source.
+const (
+	Check    Source = "check"
+	Callback Source = "callback"
+	Polling  Source = "polling"
+)
+
+// Result represents the update of a verification result.
+type Result struct {
+	Provider    common.KYCProvider
+	ReferenceID string
+	Result      common.KYCResult
+	Source      Source
+	Time        time.Time
+}
+
+// Handler processes the result update.
+// Handlers are called synchronously in the order of subscription, so they shouldn't block for long.
+type Handler func(update Result)
+
+var (
+	mu       sync.RWMutex
+	handlers []Handler
+)
+
+// Subscribe adds the handler to receive all further result updates.
+func Subscribe(handler Handler) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	handlers = append(handlers, handler)
+}
+
+// Publish delivers the result update to all subscribed handlers.
+// The update time is set to the current time if it's zero.
+func Publish(update Result) {
+	if update.Time.IsZero() {
+		update.Time = time.Now()
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(update)
+	}
+}
@@ -0,0 +1,48 @@
+package events
+
+import (
+	"testing"
+
+	"modulus/kyc/common"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// reset removes all subscribed handlers.
+func reset() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	handlers = nil
+}
+
+func TestPublish(t *testing.T) {
+	assert := assert.New(t)
+
+	defer reset()
+
+	// Testing publishing without subscribers.
+	Publish(Result{Provider: common.Jumio, ReferenceID: "ref"})
+
+	// Testing delivery to all subscribers in the order of subscription.
+	received := []string{}
+
+	Subscribe(func(update Result) {
+		received = append(received, "first:"+update.ReferenceID)
+		assert.False(update.Time.IsZero())
+	})
+	Subscribe(func(update Result) {
+		received = append(received, "second:"+update.ReferenceID)
+		assert.Equal(Callback, update.Source)
+		assert.Equal(common.Approved, update.Result.Status)
+	})
+
+	Publish(Result{
+		Provider:    common.Jumio,
+		ReferenceID: "ref",
+		Result:      common.KYCResult{Status: common.Approved},
+		Source:      Callback,
+	})
+
+	assert.Equal([]string{"first:ref", "second:ref"}, received)
+}
@@ -0,0 +1,95 @@
+// Package config holds the settings of the gRPC API parsed from the service config section.
+// The package has no dependencies on the gRPC server, so the service config is validated without importing it.
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"modulus/kyc/main/option"
+)
+
+// The names of the gRPC API options in the service config section.
+const (
+	PortOption                 = "GRPCPort"
+	UploadTTLOption            = "GRPCUploadTTL"
+	MaxUploadSizeOption        = "GRPCMaxUploadSize"
+	MaxUploadsSizeOption       = "GRPCMaxUploadsSize"
+	MaxClientUploadsSizeOption = "GRPCMaxClientUploadsSize"
+)
+
+// The names of the TLS options in the service config section.
+// The gRPC API terminates TLS with the same certificate as the HTTP server.
+const (
+	TLSCertOption = "TLSCert"
+	TLSKeyOption  = "TLSKey"
+)
+
+// The default values of the gRPC API options.
+const (
+	DefaultUploadTTL            = time.Hour
+	DefaultMaxUploadSize        = 32 << 20
+	DefaultMaxUploadsSize       = 1 << 30
+	DefaultMaxClientUploadsSize = 256 << 20
+)
+
+// Config holds the settings of the gRPC API.
+//
+// * Port is the port the gRPC API listens on. The gRPC API is disabled if it's empty.
+// * UploadTTL is how long the uploaded documents are kept.
+// * MaxUploadSize limits the size of the uploaded document in bytes.
+// * MaxUploadsSize limits the total size of the documents kept in bytes.
+// * MaxClientUploadsSize limits the size of the documents of a client kept in bytes.
+// * TLSCert and TLSKey are the paths to the PEM-encoded certificate and key. The gRPC API is served without TLS if they're empty.
+type Config struct {
+	Port                 string
+	UploadTTL            time.Duration
+	MaxUploadSize        int
+	MaxUploadsSize       int
+	MaxClientUploadsSize int
+	TLSCert              string
+	TLSKey               string
+}
+
+// ConfigFromOptions parses the gRPC API options from the service config section.
+// Absent options take their default values.
+func ConfigFromOptions(options map[string]string) (config Config, err error) {
+	config = Config{
+		Port:                 options[PortOption],
+		UploadTTL:            DefaultUploadTTL,
+		MaxUploadSize:        DefaultMaxUploadSize,
+		MaxUploadsSize:       DefaultMaxUploadsSize,
+		MaxClientUploadsSize: DefaultMaxClientUploadsSize,
+		TLSCert:              options[TLSCertOption],
+		TLSKey:               options[TLSKeyOption],
+	}
+
+	if err = option.Duration(options, UploadTTLOption, &config.UploadTTL); err != nil {
+		return
+	}
+	if err = option.Int(options, MaxUploadSizeOption, &config.MaxUploadSize); err != nil {
+		return
+	}
+	if err = option.Int(options, MaxUploadsSizeOption, &config.MaxUploadsSize); err != nil {
+		return
+	}
+	if err = option.Int(options, MaxClientUploadsSizeOption, &config.MaxClientUploadsSize); err != nil {
+		return
+	}
+
+	if (len(config.TLSCert) == 0) != (len(config.TLSKey) == 0) {
+		err = fmt.Errorf("options '%s' and '%s' must be set together", TLSCertOption, TLSKeyOption)
+	}
+
+	return
+}
+
+// Enabled reports whether the gRPC API is enabled by the config.
+func (c Config) Enabled() bool {
+	return len(c.Port) > 0
+}
+
+// TLS reports whether the gRPC API terminates TLS.
+func (c Config) TLS() bool {
+	return len(c.TLSCert) > 0
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigFromOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing the default values.
+	config, err := ConfigFromOptions(map[string]string{})
+
+	assert.NoError(err)
+	assert.False(config.Enabled())
+	assert.Equal(DefaultUploadTTL, config.UploadTTL)
+	assert.Equal(DefaultMaxUploadSize, config.MaxUploadSize)
+	assert.Equal(DefaultMaxUploadsSize, config.MaxUploadsSize)
+	assert.Equal(DefaultMaxClientUploadsSize, config.MaxClientUploadsSize)
+
+	config, err = ConfigFromOptions(map[string]string{
+		PortOption:                 "9090",
+		UploadTTLOption:            "30m",
+		MaxUploadSizeOption:        "1024",
+		MaxUploadsSizeOption:       "4096",
+		MaxClientUploadsSizeOption: "2048",
+	})
+
+	assert.NoError(err)
+	assert.Equal(Config{Port: "9090", UploadTTL: 30 * time.Minute, MaxUploadSize: 1024, MaxUploadsSize: 4096, MaxClientUploadsSize: 2048}, config)
+	assert.True(config.Enabled())
+	assert.False(config.TLS())
+
+	config, err = ConfigFromOptions(map[string]string{TLSCertOption: "/etc/kyc/tls.crt", TLSKeyOption: "/etc/kyc/tls.key"})
+
+	assert.NoError(err)
+	assert.Equal("/etc/kyc/tls.crt", config.TLSCert)
+	assert.Equal("/etc/kyc/tls.key", config.TLSKey)
+	assert.True(config.TLS())
+
+	// Testing the invalid values.
+	testCases := []struct {
+		options map[string]string
+		err     string
+	}{
+		{map[string]string{UploadTTLOption: "soon"}, `invalid option 'GRPCUploadTTL': time: invalid duration "soon"`},
+		{map[string]string{UploadTTLOption: "-1h"}, "invalid option 'GRPCUploadTTL': non-positive duration"},
+		{map[string]string{MaxUploadSizeOption: "0"}, "invalid option 'GRPCMaxUploadSize': non-positive number"},
+		{map[string]string{MaxClientUploadsSizeOption: "lots"}, `invalid option 'GRPCMaxClientUploadsSize': strconv.Atoi: parsing "lots": invalid syntax`},
+		{map[string]string{TLSKeyOption: "/etc/kyc/tls.key"}, "options 'TLSCert' and 'TLSKey' must be set together"},
+	}
+
+	for _, tc := range testCases {
+		_, err := ConfigFromOptions(tc.options)
+
+		if assert.Error(err) {
+			assert.Equal(tc.err, err.Error())
+		}
+	}
+}
@@ -0,0 +1,414 @@
+package grpcapi
+
+import (
+	"modulus/kyc/common"
+	"modulus/kyc/main/events"
+	"modulus/kyc/main/grpcapi/kycpb"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// providers maps the protobuf providers to the KYC providers.
+var providers = map[kycpb.Provider]common.KYCProvider{
+	kycpb.Provider_EXAMPLE:           common.Example,
+	kycpb.Provider_COINFIRM:          common.Coinfirm,
+	kycpb.Provider_COMPLY_ADVANTAGE:  common.ComplyAdvantage,
+	kycpb.Provider_IDENTITY_MIND:     common.IdentityMind,
+	kycpb.Provider_IDOLOGY:           common.IDology,
+	kycpb.Provider_JUMIO:             common.Jumio,
+	kycpb.Provider_SHUFTI_PRO:        common.ShuftiPro,
+	kycpb.Provider_SUM_AND_SUBSTANCE: common.SumSub,
+	kycpb.Provider_SYNAPSE_FI:        common.SynapseFI,
+	kycpb.Provider_THOMSON_REUTERS:   common.ThomsonReuters,
+	kycpb.Provider_TRULIOO:           common.Trulioo,
+	kycpb.Provider_CIPHER_TRACE:      common.CipherTrace,
+}
+
+// protoProviders maps the KYC providers to the protobuf providers.
+var protoProviders = map[common.KYCProvider]kycpb.Provider{}
+
+func init() {
+	for p, provider := range providers {
+		protoProviders[provider] = p
+	}
+}
+
+// strategyModes maps the protobuf strategy modes to the strategy modes.
+var strategyModes = map[kycpb.StrategyMode]common.StrategyMode{
+	kycpb.StrategyMode_FALLBACK: common.Fallback,
+	kycpb.StrategyMode_PARALLEL: common.Parallel,
+}
+
+// consensuses maps the protobuf consensus rules to the consensus rules.
+var consensuses = map[kycpb.Consensus]common.Consensus{
+	kycpb.Consensus_ALL_APPROVE: common.AllApprove,
+	kycpb.Consensus_ANY_DENIAL:  common.AnyDenial,
+	kycpb.Consensus_MAJORITY:    common.Majority,
+}
+
+// documentTypes maps the protobuf document types to the document types.
+var documentTypes = map[kycpb.DocumentType]common.DocumentType{
+	kycpb.DocumentType_ID_CARD:        common.IDCardType,
+	kycpb.DocumentType_PASSPORT:       common.PassportType,
+	kycpb.DocumentType_DRIVER_LICENSE: common.DriverLicenseType,
+	kycpb.DocumentType_CREDIT_CARD:    common.CreditCardType,
+	kycpb.DocumentType_DEBIT_CARD:     common.DebitCardType,
+}
+
+// providerFromProto returns the KYC provider or the empty one if the provider is unspecified.
+func providerFromProto(provider kycpb.Provider) common.KYCProvider {
+	return providers[provider]
+}
+
+// strategyFromProto returns the strategy or nil if it isn't set.
+// The unspecified mode and consensus are left empty, so the strategy validation handles them.
+func strategyFromProto(s *kycpb.Strategy) *common.Strategy {
+	if s == nil {
+		return nil
+	}
+
+	strategy := &common.Strategy{
+		Mode:      strategyModes[s.Mode],
+		Consensus: consensuses[s.Consensus],
+	}
+	for _, p := range s.Providers {
+		strategy.Providers = append(strategy.Providers, providerFromProto(p))
+	}
+
+	return strategy
+}
+
+// timeFromProto returns the time of the timestamp or the zero time if it isn't set.
+func timeFromProto(t *timestamppb.Timestamp) common.Time {
+	if t == nil {
+		return common.Time{}
+	}
+
+	return common.Time(t.AsTime())
+}
+
+// converter converts the customer data from the protobuf messages.
+// The uploaded documents of the client are looked up by their ids.
+// The err holds the first error of the conversion.
+type converter struct {
+	uploads  *uploads
+	clientID string
+	err      error
+}
+
+// userDataFromProto converts the customer data from the protobuf message.
+// The uploaded documents referenced by the customer data must be uploaded by the client.
+func userDataFromProto(u *kycpb.UserData, uploads *uploads, clientID string) (customer *common.UserData, err error) {
+	c := &converter{
+		uploads:  uploads,
+		clientID: clientID,
+	}
+
+	customer = &common.UserData{
+		FirstName:                u.FirstName,
+		LastName:                 u.LastName,
+		MaternalLastName:         u.MaternalLastName,
+		MiddleName:               u.MiddleName,
+		FullName:                 u.FullName,
+		LegalName:                u.LegalName,
+		LatinISO1Name:            u.LatinIso1Name,
+		AccountName:              u.AccountName,
+		Email:                    u.Email,
+		IPaddress:                u.IpAddress,
+		Gender:                   common.Gender(u.Gender),
+		DateOfBirth:              timeFromProto(u.DateOfBirth),
+		PlaceOfBirth:             u.PlaceOfBirth,
+		CountryOfBirthAlpha2:     u.CountryOfBirthAlpha2,
+		StateOfBirth:             u.StateOfBirth,
+		CountryAlpha2:            u.CountryAlpha2,
+		Nationality:              u.Nationality,
+		Phone:                    u.Phone,
+		MobilePhone:              u.MobilePhone,
+		BankAccountNumber:        u.BankAccountNumber,
+		VehicleRegistrationPlate: u.VehicleRegistrationPlate,
+		CompanyName:              u.CompanyName,
+		Website:                  u.Website,
+	}
+
+	if u.CurrentAddress != nil {
+		customer.CurrentAddress = addressFromProto(u.CurrentAddress)
+	}
+	for _, a := range u.SupplementalAddresses {
+		customer.SupplementalAddresses = append(customer.SupplementalAddresses, addressFromProto(a))
+	}
+	if u.Location != nil {
+		customer.Location = &common.Location{
+			Latitude:  u.Location.Latitude,
+			Longitude: u.Location.Longitude,
+		}
+	}
+	if u.Business != nil {
+		customer.Business = &common.Business{
+			Name:                      u.Business.Name,
+			RegistrationNumber:        u.Business.RegistrationNumber,
+			IncorporationDate:         timeFromProto(u.Business.IncorporationDate),
+			IncorporationJurisdiction: u.Business.IncorporationJurisdiction,
+		}
+	}
+
+	c.documents(u, customer)
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return
+}
+
+// documents converts the documents of the customer.
+func (c *converter) documents(u *kycpb.UserData, customer *common.UserData) {
+	if d := u.Passport; d != nil {
+		customer.Passport = &common.Passport{
+			Number:        d.Number,
+			Mrz1:          d.Mrz1,
+			Mrz2:          d.Mrz2,
+			CountryAlpha2: d.CountryAlpha2,
+			State:         d.State,
+			IssuedDate:    timeFromProto(d.IssuedDate),
+			ValidUntil:    timeFromProto(d.ValidUntil),
+			Image:         c.file(d.Image),
+		}
+	}
+	if d := u.IdCard; d != nil {
+		customer.IDCard = &common.IDCard{
+			Number:        d.Number,
+			CountryAlpha2: d.CountryAlpha2,
+			IssuedDate:    timeFromProto(d.IssuedDate),
+			ValidUntil:    timeFromProto(d.ValidUntil),
+			Image:         c.file(d.Image),
+		}
+	}
+	if d := u.Snils; d != nil {
+		customer.SNILS = &common.SNILS{
+			Number:     d.Number,
+			IssuedDate: timeFromProto(d.IssuedDate),
+			Image:      c.file(d.Image),
+		}
+	}
+	if d := u.HealthId; d != nil {
+		customer.HealthID = &common.HealthID{
+			Number: d.Number,
+			Image:  c.file(d.Image),
+		}
+	}
+	if d := u.SocialServiceId; d != nil {
+		customer.SocialServiceID = &common.SocialServiceID{
+			Number:     d.Number,
+			IssuedDate: timeFromProto(d.IssuedDate),
+			Image:      c.file(d.Image),
+		}
+	}
+	if d := u.TaxId; d != nil {
+		customer.TaxID = &common.TaxID{
+			Number: d.Number,
+			Image:  c.file(d.Image),
+		}
+	}
+	if d := u.DriverLicense; d != nil {
+		customer.DriverLicense = &common.DriverLicense{
+			Number:        d.Number,
+			Version:       d.Version,
+			CountryAlpha2: d.CountryAlpha2,
+			State:         d.State,
+			IssuedDate:    timeFromProto(d.IssuedDate),
+			ValidUntil:    timeFromProto(d.ValidUntil),
+			FrontImage:    c.file(d.FrontImage),
+			BackImage:     c.file(d.BackImage),
+		}
+	}
+	if d := u.DriverLicenseTranslation; d != nil {
+		customer.DriverLicenseTranslation = &common.DriverLicenseTranslation{
+			Number:        d.Number,
+			CountryAlpha2: d.CountryAlpha2,
+			State:         d.State,
+			IssuedDate:    timeFromProto(d.IssuedDate),
+			ValidUntil:    timeFromProto(d.ValidUntil),
+			FrontImage:    c.file(d.FrontImage),
+			BackImage:     c.file(d.BackImage),
+		}
+	}
+	if d := u.CreditCard; d != nil {
+		customer.CreditCard = &common.CreditCard{
+			Number:     d.Number,
+			ValidUntil: timeFromProto(d.ValidUntil),
+			Image:      c.file(d.Image),
+		}
+	}
+	if d := u.DebitCard; d != nil {
+		customer.DebitCard = &common.DebitCard{
+			Number:     d.Number,
+			ValidUntil: timeFromProto(d.ValidUntil),
+			Image:      c.file(d.Image),
+		}
+	}
+	if d := u.UtilityBill; d != nil {
+		customer.UtilityBill = &common.UtilityBill{
+			CountryAlpha2: d.CountryAlpha2,
+			Image:         c.file(d.Image),
+		}
+	}
+	if d := u.ResidencePermit; d != nil {
+		customer.ResidencePermit = &common.ResidencePermit{
+			CountryAlpha2: d.CountryAlpha2,
+			IssuedDate:    timeFromProto(d.IssuedDate),
+			ValidUntil:    timeFromProto(d.ValidUntil),
+			Image:         c.file(d.Image),
+		}
+	}
+	if d := u.EmploymentCertificate; d != nil {
+		customer.EmploymentCertificate = &common.EmploymentCertificate{
+			IssuedDate: timeFromProto(d.IssuedDate),
+			Image:      c.file(d.Image),
+		}
+	}
+	if d := u.Agreement; d != nil {
+		customer.Agreement = &common.Agreement{Image: c.file(d.Image)}
+	}
+	if d := u.Contract; d != nil {
+		customer.Contract = &common.Contract{Image: c.file(d.Image)}
+	}
+	if d := u.DocumentPhoto; d != nil {
+		customer.DocumentPhoto = &common.DocumentPhoto{Image: c.file(d.Image)}
+	}
+	if d := u.Selfie; d != nil {
+		customer.Selfie = &common.Selfie{Image: c.file(d.Image)}
+	}
+	if d := u.Avatar; d != nil {
+		customer.Avatar = &common.Avatar{Image: c.file(d.Image)}
+	}
+	if d := u.Other; d != nil {
+		customer.Other = &common.Other{
+			Number:        d.Number,
+			CountryAlpha2: d.CountryAlpha2,
+			State:         d.State,
+			IssuedDate:    timeFromProto(d.IssuedDate),
+			ValidUntil:    timeFromProto(d.ValidUntil),
+			Image:         c.file(d.Image),
+		}
+	}
+	if d := u.Document; d != nil {
+		customer.Document = &common.Document{
+			Type:          documentTypes[d.Type],
+			Number:        d.Number,
+			CountryAlpha2: d.CountryAlpha2,
+			IssuedDate:    timeFromProto(d.IssuedDate),
+			ValidUntil:    timeFromProto(d.ValidUntil),
+			Image:         c.file(d.Image),
+		}
+	}
+	if f := c.file(u.VideoAuth); f != nil {
+		customer.VideoAuth = (*common.VideoAuth)(f)
+	}
+	if f := c.file(u.CompanyBoard); f != nil {
+		customer.CompanyBoard = (*common.CompanyBoard)(f)
+	}
+	if f := c.file(u.CompanyRegistration); f != nil {
+		customer.CompanyRegistration = (*common.CompanyRegistration)(f)
+	}
+}
+
+// file converts the document file or returns nil if it isn't set.
+// The content of the uploaded file is looked up by its id.
+// The filename and the content type of the uploaded file are used unless they're specified.
+func (c *converter) file(f *kycpb.DocumentFile) *common.DocumentFile {
+	if f == nil {
+		return nil
+	}
+
+	file := &common.DocumentFile{
+		Filename:    f.Filename,
+		ContentType: f.ContentType,
+		Data:        f.GetData(),
+	}
+
+	if id := f.GetUploadId(); len(id) > 0 {
+		uploaded, err := c.uploads.get(c.clientID, id)
+		if err != nil {
+			if c.err == nil {
+				c.err = err
+			}
+			return nil
+		}
+		file.Data = uploaded.Data
+		if len(file.Filename) == 0 {
+			file.Filename = uploaded.Filename
+		}
+		if len(file.ContentType) == 0 {
+			file.ContentType = uploaded.ContentType
+		}
+	}
+
+	return file
+}
+
+// addressFromProto converts the address from the protobuf message.
+func addressFromProto(a *kycpb.Address) common.Address {
+	return common.Address{
+		CountryAlpha2:     a.CountryAlpha2,
+		County:            a.County,
+		State:             a.State,
+		Town:              a.Town,
+		Suburb:            a.Suburb,
+		Street:            a.Street,
+		StreetType:        a.StreetType,
+		SubStreet:         a.SubStreet,
+		BuildingName:      a.BuildingName,
+		BuildingNumber:    a.BuildingNumber,
+		FlatNumber:        a.FlatNumber,
+		PostOfficeBox:     a.PostOfficeBox,
+		PostCode:          a.PostCode,
+		StateProvinceCode: a.StateProvinceCode,
+		StartDate:         timeFromProto(a.StartDate),
+		EndDate:           timeFromProto(a.EndDate),
+	}
+}
+
+// resultToProto converts the verification result to the protobuf message.
+func resultToProto(r common.KYCResult) *kycpb.KYCResult {
+	result := &kycpb.KYCResult{
+		Status:    kycpb.Status(r.Status),
+		ErrorCode: r.ErrorCode,
+	}
+
+	if r.Details != nil {
+		result.Details = &kycpb.Details{
+			Finality:    kycpb.Finality(r.Details.Finality),
+			Reasons:     r.Details.Reasons,
+			ReasonCodes: r.Details.ReasonCodes,
+		}
+	}
+	if r.StatusCheck != nil {
+		result.StatusCheck = &kycpb.StatusCheck{
+			Provider:    protoProviders[r.StatusCheck.Provider],
+			ReferenceId: r.StatusCheck.ReferenceID,
+		}
+		if !r.StatusCheck.LastCheck.IsZero() {
+			result.StatusCheck.LastCheck = timestamppb.New(r.StatusCheck.LastCheck)
+		}
+	}
+	for _, p := range r.Providers {
+		result.Providers = append(result.Providers, &kycpb.ProviderResult{
+			Provider: protoProviders[p.Provider],
+			Result:   resultToProto(p.Result),
+			Error:    p.Error,
+		})
+	}
+
+	return result
+}
+
+// updateToProto converts the result update to the protobuf message.
+func updateToProto(update events.Result) *kycpb.StatusUpdate {
+	return &kycpb.StatusUpdate{
+		Provider:    protoProviders[update.Provider],
+		ReferenceId: update.ReferenceID,
+		Result:      resultToProto(update.Result),
+		Source:      string(update.Source),
+		Time:        timestamppb.New(update.Time),
+	}
+}
@@ -0,0 +1,3598 @@
+// The gRPC API of the KYC service.
+// The messages mirror the models of the REST API from the common package.
+// The document images are sent as raw bytes or uploaded beforehand by the UploadDocument stream.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: kyc.proto
+
+package kycpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Provider represents a KYC provider.
+type Provider int32
+
+const (
+	Provider_PROVIDER_UNSPECIFIED Provider = 0
+	Provider_EXAMPLE              Provider = 1
+	Provider_COINFIRM             Provider = 2
+	Provider_COMPLY_ADVANTAGE     Provider = 3
+	Provider_IDENTITY_MIND        Provider = 4
+	Provider_IDOLOGY              Provider = 5
+	Provider_JUMIO                Provider = 6
+	Provider_SHUFTI_PRO           Provider = 7
+	Provider_SUM_AND_SUBSTANCE    Provider = 8
+	Provider_SYNAPSE_FI           Provider = 9
+	Provider_THOMSON_REUTERS      Provider = 10
+	Provider_TRULIOO              Provider = 11
+	Provider_CIPHER_TRACE         Provider = 12
+)
+
+// Enum value maps for Provider.
+var (
+	Provider_name = map[int32]string{
+		0:  "PROVIDER_UNSPECIFIED",
+		1:  "EXAMPLE",
+		2:  "COINFIRM",
+		3:  "COMPLY_ADVANTAGE",
+		4:  "IDENTITY_MIND",
+		5:  "IDOLOGY",
+		6:  "JUMIO",
+		7:  "SHUFTI_PRO",
+		8:  "SUM_AND_SUBSTANCE",
+		9:  "SYNAPSE_FI",
+		10: "THOMSON_REUTERS",
+		11: "TRULIOO",
+		12: "CIPHER_TRACE",
+	}
+	Provider_value = map[string]int32{
+		"PROVIDER_UNSPECIFIED": 0,
+		"EXAMPLE":              1,
+		"COINFIRM":             2,
+		"COMPLY_ADVANTAGE":     3,
+		"IDENTITY_MIND":        4,
+		"IDOLOGY":              5,
+		"JUMIO":                6,
+		"SHUFTI_PRO":           7,
+		"SUM_AND_SUBSTANCE":    8,
+		"SYNAPSE_FI":           9,
+		"THOMSON_REUTERS":      10,
+		"TRULIOO":              11,
+		"CIPHER_TRACE":         12,
+	}
+)
+
+func (x Provider) Enum() *Provider {
+	p := new(Provider)
+	*p = x
+	return p
+}
+
+func (x Provider) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Provider) Descriptor() protoreflect.EnumDescriptor {
+	return file_kyc_proto_enumTypes[0].Descriptor()
+}
+
+func (Provider) Type() protoreflect.EnumType {
+	return &file_kyc_proto_enumTypes[0]
+}
+
+func (x Provider) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Provider.Descriptor instead.
+func (Provider) EnumDescriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{0}
+}
+
+// Status represents the verification status.
+type Status int32
+
+const (
+	Status_ERROR    Status = 0
+	Status_APPROVED Status = 1
+	Status_DENIED   Status = 2
+	Status_UNCLEAR  Status = 3
+)
+
+// Enum value maps for Status.
+var (
+	Status_name = map[int32]string{
+		0: "ERROR",
+		1: "APPROVED",
+		2: "DENIED",
+		3: "UNCLEAR",
+	}
+	Status_value = map[string]int32{
+		"ERROR":    0,
+		"APPROVED": 1,
+		"DENIED":   2,
+		"UNCLEAR":  3,
+	}
+)
+
+func (x Status) Enum() *Status {
+	p := new(Status)
+	*p = x
+	return p
+}
+
+func (x Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_kyc_proto_enumTypes[1].Descriptor()
+}
+
+func (Status) Type() protoreflect.EnumType {
+	return &file_kyc_proto_enumTypes[1]
+}
+
+func (x Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Status.Descriptor instead.
+func (Status) EnumDescriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{1}
+}
+
+// Finality represents the finality of the verification result.
+type Finality int32
+
+const (
+	Finality_UNKNOWN   Finality = 0
+	Finality_FINAL     Finality = 1
+	Finality_NON_FINAL Finality = 2
+)
+
+// Enum value maps for Finality.
+var (
+	Finality_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "FINAL",
+		2: "NON_FINAL",
+	}
+	Finality_value = map[string]int32{
+		"UNKNOWN":   0,
+		"FINAL":     1,
+		"NON_FINAL": 2,
+	}
+)
+
+func (x Finality) Enum() *Finality {
+	p := new(Finality)
+	*p = x
+	return p
+}
+
+func (x Finality) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Finality) Descriptor() protoreflect.EnumDescriptor {
+	return file_kyc_proto_enumTypes[2].Descriptor()
+}
+
+func (Finality) Type() protoreflect.EnumType {
+	return &file_kyc_proto_enumTypes[2]
+}
+
+func (x Finality) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Finality.Descriptor instead.
+func (Finality) EnumDescriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{2}
+}
+
+// Gender represents the gender of the customer.
+type Gender int32
+
+const (
+	Gender_GENDER_UNSPECIFIED Gender = 0
+	Gender_MALE               Gender = 1
+	Gender_FEMALE             Gender = 2
+)
+
+// Enum value maps for Gender.
+var (
+	Gender_name = map[int32]string{
+		0: "GENDER_UNSPECIFIED",
+		1: "MALE",
+		2: "FEMALE",
+	}
+	Gender_value = map[string]int32{
+		"GENDER_UNSPECIFIED": 0,
+		"MALE":               1,
+		"FEMALE":             2,
+	}
+)
+
+func (x Gender) Enum() *Gender {
+	p := new(Gender)
+	*p = x
+	return p
+}
+
+func (x Gender) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Gender) Descriptor() protoreflect.EnumDescriptor {
+	return file_kyc_proto_enumTypes[3].Descriptor()
+}
+
+func (Gender) Type() protoreflect.EnumType {
+	return &file_kyc_proto_enumTypes[3]
+}
+
+func (x Gender) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Gender.Descriptor instead.
+func (Gender) EnumDescriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{3}
+}
+
+// DocumentType represents the type of the Document.
+type DocumentType int32
+
+const (
+	DocumentType_DOCUMENT_TYPE_UNSPECIFIED DocumentType = 0
+	DocumentType_ID_CARD                   DocumentType = 1
+	DocumentType_PASSPORT                  DocumentType = 2
+	DocumentType_DRIVER_LICENSE            DocumentType = 3
+	DocumentType_CREDIT_CARD               DocumentType = 4
+	DocumentType_DEBIT_CARD                DocumentType = 5
+)
+
+// Enum value maps for DocumentType.
+var (
+	DocumentType_name = map[int32]string{
+		0: "DOCUMENT_TYPE_UNSPECIFIED",
+		1: "ID_CARD",
+		2: "PASSPORT",
+		3: "DRIVER_LICENSE",
+		4: "CREDIT_CARD",
+		5: "DEBIT_CARD",
+	}
+	DocumentType_value = map[string]int32{
+		"DOCUMENT_TYPE_UNSPECIFIED": 0,
+		"ID_CARD":                   1,
+		"PASSPORT":                  2,
+		"DRIVER_LICENSE":            3,
+		"CREDIT_CARD":               4,
+		"DEBIT_CARD":                5,
+	}
+)
+
+func (x DocumentType) Enum() *DocumentType {
+	p := new(DocumentType)
+	*p = x
+	return p
+}
+
+func (x DocumentType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DocumentType) Descriptor() protoreflect.EnumDescriptor {
+	return file_kyc_proto_enumTypes[4].Descriptor()
+}
+
+func (DocumentType) Type() protoreflect.EnumType {
+	return &file_kyc_proto_enumTypes[4]
+}
+
+func (x DocumentType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DocumentType.Descriptor instead.
+func (DocumentType) EnumDescriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{4}
+}
+
+// StrategyMode defines how the providers of the Strategy are used.
+type StrategyMode int32
+
+const (
+	StrategyMode_STRATEGY_MODE_UNSPECIFIED StrategyMode = 0
+	StrategyMode_FALLBACK                  StrategyMode = 1
+	StrategyMode_PARALLEL                  StrategyMode = 2
+)
+
+// Enum value maps for StrategyMode.
+var (
+	StrategyMode_name = map[int32]string{
+		0: "STRATEGY_MODE_UNSPECIFIED",
+		1: "FALLBACK",
+		2: "PARALLEL",
+	}
+	StrategyMode_value = map[string]int32{
+		"STRATEGY_MODE_UNSPECIFIED": 0,
+		"FALLBACK":                  1,
+		"PARALLEL":                  2,
+	}
+)
+
+func (x StrategyMode) Enum() *StrategyMode {
+	p := new(StrategyMode)
+	*p = x
+	return p
+}
+
+func (x StrategyMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StrategyMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_kyc_proto_enumTypes[5].Descriptor()
+}
+
+func (StrategyMode) Type() protoreflect.EnumType {
+	return &file_kyc_proto_enumTypes[5]
+}
+
+func (x StrategyMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StrategyMode.Descriptor instead.
+func (StrategyMode) EnumDescriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{5}
+}
+
+// Consensus defines the rule merging the results of the providers checked in parallel.
+type Consensus int32
+
+const (
+	Consensus_CONSENSUS_UNSPECIFIED Consensus = 0
+	Consensus_ALL_APPROVE           Consensus = 1
+	Consensus_ANY_DENIAL            Consensus = 2
+	Consensus_MAJORITY              Consensus = 3
+)
+
+// Enum value maps for Consensus.
+var (
+	Consensus_name = map[int32]string{
+		0: "CONSENSUS_UNSPECIFIED",
+		1: "ALL_APPROVE",
+		2: "ANY_DENIAL",
+		3: "MAJORITY",
+	}
+	Consensus_value = map[string]int32{
+		"CONSENSUS_UNSPECIFIED": 0,
+		"ALL_APPROVE":           1,
+		"ANY_DENIAL":            2,
+		"MAJORITY":              3,
+	}
+)
+
+func (x Consensus) Enum() *Consensus {
+	p := new(Consensus)
+	*p = x
+	return p
+}
+
+func (x Consensus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Consensus) Descriptor() protoreflect.EnumDescriptor {
+	return file_kyc_proto_enumTypes[6].Descriptor()
+}
+
+func (Consensus) Type() protoreflect.EnumType {
+	return &file_kyc_proto_enumTypes[6]
+}
+
+func (x Consensus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Consensus.Descriptor instead.
+func (Consensus) EnumDescriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{6}
+}
+
+// Strategy represents the verification of the customer using several providers.
+type Strategy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mode          StrategyMode           `protobuf:"varint,1,opt,name=mode,proto3,enum=kyc.v1.StrategyMode" json:"mode,omitempty"`
+	Providers     []Provider             `protobuf:"varint,2,rep,packed,name=providers,proto3,enum=kyc.v1.Provider" json:"providers,omitempty"`
+	Consensus     Consensus              `protobuf:"varint,3,opt,name=consensus,proto3,enum=kyc.v1.Consensus" json:"consensus,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Strategy) Reset() {
+	*x = Strategy{}
+	mi := &file_kyc_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Strategy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Strategy) ProtoMessage() {}
+
+func (x *Strategy) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Strategy.ProtoReflect.Descriptor instead.
+func (*Strategy) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Strategy) GetMode() StrategyMode {
+	if x != nil {
+		return x.Mode
+	}
+	return StrategyMode_STRATEGY_MODE_UNSPECIFIED
+}
+
+func (x *Strategy) GetProviders() []Provider {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+func (x *Strategy) GetConsensus() Consensus {
+	if x != nil {
+		return x.Consensus
+	}
+	return Consensus_CONSENSUS_UNSPECIFIED
+}
+
+// CheckCustomerRequest represents the request of the CheckCustomer.
+// Either provider or strategy must be specified.
+type CheckCustomerRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Provider        Provider               `protobuf:"varint,1,opt,name=provider,proto3,enum=kyc.v1.Provider" json:"provider,omitempty"`
+	Strategy        *Strategy              `protobuf:"bytes,2,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Customer        *UserData              `protobuf:"bytes,3,opt,name=customer,proto3" json:"customer,omitempty"`
+	NotificationUrl string                 `protobuf:"bytes,4,opt,name=notification_url,json=notificationUrl,proto3" json:"notification_url,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CheckCustomerRequest) Reset() {
+	*x = CheckCustomerRequest{}
+	mi := &file_kyc_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckCustomerRequest) ProtoMessage() {}
+
+func (x *CheckCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckCustomerRequest.ProtoReflect.Descriptor instead.
+func (*CheckCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CheckCustomerRequest) GetProvider() Provider {
+	if x != nil {
+		return x.Provider
+	}
+	return Provider_PROVIDER_UNSPECIFIED
+}
+
+func (x *CheckCustomerRequest) GetStrategy() *Strategy {
+	if x != nil {
+		return x.Strategy
+	}
+	return nil
+}
+
+func (x *CheckCustomerRequest) GetCustomer() *UserData {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+func (x *CheckCustomerRequest) GetNotificationUrl() string {
+	if x != nil {
+		return x.NotificationUrl
+	}
+	return ""
+}
+
+// CheckCustomerResponse represents the response of the CheckCustomer.
+// The error holds the error of the provider if it occurred.
+// The verification_ids holds the ids of the verifications in the history by the provider names.
+type CheckCustomerResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Result          *KYCResult             `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	Error           string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	VerificationIds map[string]string      `protobuf:"bytes,3,rep,name=verification_ids,json=verificationIds,proto3" json:"verification_ids,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CheckCustomerResponse) Reset() {
+	*x = CheckCustomerResponse{}
+	mi := &file_kyc_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckCustomerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckCustomerResponse) ProtoMessage() {}
+
+func (x *CheckCustomerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckCustomerResponse.ProtoReflect.Descriptor instead.
+func (*CheckCustomerResponse) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CheckCustomerResponse) GetResult() *KYCResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *CheckCustomerResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CheckCustomerResponse) GetVerificationIds() map[string]string {
+	if x != nil {
+		return x.VerificationIds
+	}
+	return nil
+}
+
+// CheckStatusRequest represents the request of the CheckStatus.
+type CheckStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      Provider               `protobuf:"varint,1,opt,name=provider,proto3,enum=kyc.v1.Provider" json:"provider,omitempty"`
+	ReferenceId   string                 `protobuf:"bytes,2,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckStatusRequest) Reset() {
+	*x = CheckStatusRequest{}
+	mi := &file_kyc_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckStatusRequest) ProtoMessage() {}
+
+func (x *CheckStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckStatusRequest.ProtoReflect.Descriptor instead.
+func (*CheckStatusRequest) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CheckStatusRequest) GetProvider() Provider {
+	if x != nil {
+		return x.Provider
+	}
+	return Provider_PROVIDER_UNSPECIFIED
+}
+
+func (x *CheckStatusRequest) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+// CheckStatusResponse represents the response of the CheckStatus.
+type CheckStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *KYCResult             `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckStatusResponse) Reset() {
+	*x = CheckStatusResponse{}
+	mi := &file_kyc_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckStatusResponse) ProtoMessage() {}
+
+func (x *CheckStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckStatusResponse.ProtoReflect.Descriptor instead.
+func (*CheckStatusResponse) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CheckStatusResponse) GetResult() *KYCResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *CheckStatusResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// UploadDocumentRequest represents the message of the UploadDocument stream.
+type UploadDocumentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*UploadDocumentRequest_Info
+	//	*UploadDocumentRequest_Chunk
+	Payload       isUploadDocumentRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadDocumentRequest) Reset() {
+	*x = UploadDocumentRequest{}
+	mi := &file_kyc_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadDocumentRequest) ProtoMessage() {}
+
+func (x *UploadDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadDocumentRequest.ProtoReflect.Descriptor instead.
+func (*UploadDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UploadDocumentRequest) GetPayload() isUploadDocumentRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *UploadDocumentRequest) GetInfo() *DocumentInfo {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadDocumentRequest_Info); ok {
+			return x.Info
+		}
+	}
+	return nil
+}
+
+func (x *UploadDocumentRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadDocumentRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadDocumentRequest_Payload interface {
+	isUploadDocumentRequest_Payload()
+}
+
+type UploadDocumentRequest_Info struct {
+	Info *DocumentInfo `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
+}
+
+type UploadDocumentRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadDocumentRequest_Info) isUploadDocumentRequest_Payload() {}
+
+func (*UploadDocumentRequest_Chunk) isUploadDocumentRequest_Payload() {}
+
+// DocumentInfo represents the info of the uploaded document file.
+type DocumentInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentInfo) Reset() {
+	*x = DocumentInfo{}
+	mi := &file_kyc_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentInfo) ProtoMessage() {}
+
+func (x *DocumentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentInfo.ProtoReflect.Descriptor instead.
+func (*DocumentInfo) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DocumentInfo) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *DocumentInfo) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+// UploadDocumentResponse represents the response of the UploadDocument.
+type UploadDocumentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Expires       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires,proto3" json:"expires,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadDocumentResponse) Reset() {
+	*x = UploadDocumentResponse{}
+	mi := &file_kyc_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadDocumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadDocumentResponse) ProtoMessage() {}
+
+func (x *UploadDocumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadDocumentResponse.ProtoReflect.Descriptor instead.
+func (*UploadDocumentResponse) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UploadDocumentResponse) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UploadDocumentResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UploadDocumentResponse) GetExpires() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expires
+	}
+	return nil
+}
+
+// WatchStatusRequest represents the request of the WatchStatus.
+type WatchStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      Provider               `protobuf:"varint,1,opt,name=provider,proto3,enum=kyc.v1.Provider" json:"provider,omitempty"`
+	ReferenceId   string                 `protobuf:"bytes,2,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchStatusRequest) Reset() {
+	*x = WatchStatusRequest{}
+	mi := &file_kyc_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchStatusRequest) ProtoMessage() {}
+
+func (x *WatchStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchStatusRequest.ProtoReflect.Descriptor instead.
+func (*WatchStatusRequest) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WatchStatusRequest) GetProvider() Provider {
+	if x != nil {
+		return x.Provider
+	}
+	return Provider_PROVIDER_UNSPECIFIED
+}
+
+func (x *WatchStatusRequest) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+// StatusUpdate represents the update of the verification result.
+// The source is one of check, callback, polling or tracked for the latest known result.
+type StatusUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      Provider               `protobuf:"varint,1,opt,name=provider,proto3,enum=kyc.v1.Provider" json:"provider,omitempty"`
+	ReferenceId   string                 `protobuf:"bytes,2,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	Result        *KYCResult             `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
+	Source        string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusUpdate) Reset() {
+	*x = StatusUpdate{}
+	mi := &file_kyc_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusUpdate) ProtoMessage() {}
+
+func (x *StatusUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusUpdate.ProtoReflect.Descriptor instead.
+func (*StatusUpdate) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StatusUpdate) GetProvider() Provider {
+	if x != nil {
+		return x.Provider
+	}
+	return Provider_PROVIDER_UNSPECIFIED
+}
+
+func (x *StatusUpdate) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *StatusUpdate) GetResult() *KYCResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *StatusUpdate) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *StatusUpdate) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+// KYCResult represents the verification result.
+// The providers holds the individual results of the providers if the verification used the strategy.
+type KYCResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        Status                 `protobuf:"varint,1,opt,name=status,proto3,enum=kyc.v1.Status" json:"status,omitempty"`
+	Details       *Details               `protobuf:"bytes,2,opt,name=details,proto3" json:"details,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	StatusCheck   *StatusCheck           `protobuf:"bytes,4,opt,name=status_check,json=statusCheck,proto3" json:"status_check,omitempty"`
+	Providers     []*ProviderResult      `protobuf:"bytes,5,rep,name=providers,proto3" json:"providers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KYCResult) Reset() {
+	*x = KYCResult{}
+	mi := &file_kyc_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KYCResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KYCResult) ProtoMessage() {}
+
+func (x *KYCResult) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KYCResult.ProtoReflect.Descriptor instead.
+func (*KYCResult) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *KYCResult) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_ERROR
+}
+
+func (x *KYCResult) GetDetails() *Details {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *KYCResult) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *KYCResult) GetStatusCheck() *StatusCheck {
+	if x != nil {
+		return x.StatusCheck
+	}
+	return nil
+}
+
+func (x *KYCResult) GetProviders() []*ProviderResult {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+// Details represents additional details about the verification result.
+// The reason_codes holds the machine-readable codes of the reasons by their indexes if the provider supplies them.
+type Details struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Finality      Finality               `protobuf:"varint,1,opt,name=finality,proto3,enum=kyc.v1.Finality" json:"finality,omitempty"`
+	Reasons       []string               `protobuf:"bytes,2,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	ReasonCodes   []string               `protobuf:"bytes,3,rep,name=reason_codes,json=reasonCodes,proto3" json:"reason_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Details) Reset() {
+	*x = Details{}
+	mi := &file_kyc_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Details) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Details) ProtoMessage() {}
+
+func (x *Details) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Details.ProtoReflect.Descriptor instead.
+func (*Details) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Details) GetFinality() Finality {
+	if x != nil {
+		return x.Finality
+	}
+	return Finality_UNKNOWN
+}
+
+func (x *Details) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *Details) GetReasonCodes() []string {
+	if x != nil {
+		return x.ReasonCodes
+	}
+	return nil
+}
+
+// StatusCheck contains data required to do status check requests.
+type StatusCheck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      Provider               `protobuf:"varint,1,opt,name=provider,proto3,enum=kyc.v1.Provider" json:"provider,omitempty"`
+	ReferenceId   string                 `protobuf:"bytes,2,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	LastCheck     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_check,json=lastCheck,proto3" json:"last_check,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusCheck) Reset() {
+	*x = StatusCheck{}
+	mi := &file_kyc_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusCheck) ProtoMessage() {}
+
+func (x *StatusCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusCheck.ProtoReflect.Descriptor instead.
+func (*StatusCheck) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StatusCheck) GetProvider() Provider {
+	if x != nil {
+		return x.Provider
+	}
+	return Provider_PROVIDER_UNSPECIFIED
+}
+
+func (x *StatusCheck) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *StatusCheck) GetLastCheck() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastCheck
+	}
+	return nil
+}
+
+// ProviderResult represents the individual result of the provider.
+type ProviderResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      Provider               `protobuf:"varint,1,opt,name=provider,proto3,enum=kyc.v1.Provider" json:"provider,omitempty"`
+	Result        *KYCResult             `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProviderResult) Reset() {
+	*x = ProviderResult{}
+	mi := &file_kyc_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProviderResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderResult) ProtoMessage() {}
+
+func (x *ProviderResult) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderResult.ProtoReflect.Descriptor instead.
+func (*ProviderResult) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ProviderResult) GetProvider() Provider {
+	if x != nil {
+		return x.Provider
+	}
+	return Provider_PROVIDER_UNSPECIFIED
+}
+
+func (x *ProviderResult) GetResult() *KYCResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *ProviderResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// UserData represents the customer data.
+type UserData struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	FirstName                string                 `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName                 string                 `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	MaternalLastName         string                 `protobuf:"bytes,3,opt,name=maternal_last_name,json=maternalLastName,proto3" json:"maternal_last_name,omitempty"`
+	MiddleName               string                 `protobuf:"bytes,4,opt,name=middle_name,json=middleName,proto3" json:"middle_name,omitempty"`
+	FullName                 string                 `protobuf:"bytes,5,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	LegalName                string                 `protobuf:"bytes,6,opt,name=legal_name,json=legalName,proto3" json:"legal_name,omitempty"`
+	LatinIso1Name            string                 `protobuf:"bytes,7,opt,name=latin_iso1_name,json=latinIso1Name,proto3" json:"latin_iso1_name,omitempty"`
+	AccountName              string                 `protobuf:"bytes,8,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	Email                    string                 `protobuf:"bytes,9,opt,name=email,proto3" json:"email,omitempty"`
+	IpAddress                string                 `protobuf:"bytes,10,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	Gender                   Gender                 `protobuf:"varint,11,opt,name=gender,proto3,enum=kyc.v1.Gender" json:"gender,omitempty"`
+	DateOfBirth              *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	PlaceOfBirth             string                 `protobuf:"bytes,13,opt,name=place_of_birth,json=placeOfBirth,proto3" json:"place_of_birth,omitempty"`
+	CountryOfBirthAlpha2     string                 `protobuf:"bytes,14,opt,name=country_of_birth_alpha2,json=countryOfBirthAlpha2,proto3" json:"country_of_birth_alpha2,omitempty"`
+	StateOfBirth             string                 `protobuf:"bytes,15,opt,name=state_of_birth,json=stateOfBirth,proto3" json:"state_of_birth,omitempty"`
+	CountryAlpha2            string                 `protobuf:"bytes,16,opt,name=country_alpha2,json=countryAlpha2,proto3" json:"country_alpha2,omitempty"`
+	Nationality              string                 `protobuf:"bytes,17,opt,name=nationality,proto3" json:"nationality,omitempty"`
+	Phone                    string                 `protobuf:"bytes,18,opt,name=phone,proto3" json:"phone,omitempty"`
+	MobilePhone              string                 `protobuf:"bytes,19,opt,name=mobile_phone,json=mobilePhone,proto3" json:"mobile_phone,omitempty"`
+	BankAccountNumber        string                 `protobuf:"bytes,20,opt,name=bank_account_number,json=bankAccountNumber,proto3" json:"bank_account_number,omitempty"`
+	VehicleRegistrationPlate string                 `protobuf:"bytes,21,opt,name=vehicle_registration_plate,json=vehicleRegistrationPlate,proto3" json:"vehicle_registration_plate,omitempty"`
+	CurrentAddress           *Address               `protobuf:"bytes,22,opt,name=current_address,json=currentAddress,proto3" json:"current_address,omitempty"`
+	SupplementalAddresses    []*Address             `protobuf:"bytes,23,rep,name=supplemental_addresses,json=supplementalAddresses,proto3" json:"supplemental_addresses,omitempty"`
+	Location                 *Location              `protobuf:"bytes,24,opt,name=location,proto3" json:"location,omitempty"`
+	Business                 *Business              `protobuf:"bytes,25,opt,name=business,proto3" json:"business,omitempty"`
+	Passport                 *Passport              `protobuf:"bytes,26,opt,name=passport,proto3" json:"passport,omitempty"`
+	IdCard                   *IDCard                `protobuf:"bytes,27,opt,name=id_card,json=idCard,proto3" json:"id_card,omitempty"`
+	Snils                    *SNILS                 `protobuf:"bytes,28,opt,name=snils,proto3" json:"snils,omitempty"`
+	HealthId                 *HealthID              `protobuf:"bytes,29,opt,name=health_id,json=healthId,proto3" json:"health_id,omitempty"`
+	SocialServiceId          *SocialServiceID       `protobuf:"bytes,30,opt,name=social_service_id,json=socialServiceId,proto3" json:"social_service_id,omitempty"`
+	TaxId                    *TaxID                 `protobuf:"bytes,31,opt,name=tax_id,json=taxId,proto3" json:"tax_id,omitempty"`
+	DriverLicense            *DriverLicense         `protobuf:"bytes,32,opt,name=driver_license,json=driverLicense,proto3" json:"driver_license,omitempty"`
+	DriverLicenseTranslation *DriverLicense         `protobuf:"bytes,33,opt,name=driver_license_translation,json=driverLicenseTranslation,proto3" json:"driver_license_translation,omitempty"`
+	CreditCard               *CreditCard            `protobuf:"bytes,34,opt,name=credit_card,json=creditCard,proto3" json:"credit_card,omitempty"`
+	DebitCard                *CreditCard            `protobuf:"bytes,35,opt,name=debit_card,json=debitCard,proto3" json:"debit_card,omitempty"`
+	UtilityBill              *UtilityBill           `protobuf:"bytes,36,opt,name=utility_bill,json=utilityBill,proto3" json:"utility_bill,omitempty"`
+	ResidencePermit          *ResidencePermit       `protobuf:"bytes,37,opt,name=residence_permit,json=residencePermit,proto3" json:"residence_permit,omitempty"`
+	Agreement                *ImageDocument         `protobuf:"bytes,38,opt,name=agreement,proto3" json:"agreement,omitempty"`
+	EmploymentCertificate    *EmploymentCertificate `protobuf:"bytes,39,opt,name=employment_certificate,json=employmentCertificate,proto3" json:"employment_certificate,omitempty"`
+	Contract                 *ImageDocument         `protobuf:"bytes,40,opt,name=contract,proto3" json:"contract,omitempty"`
+	DocumentPhoto            *ImageDocument         `protobuf:"bytes,41,opt,name=document_photo,json=documentPhoto,proto3" json:"document_photo,omitempty"`
+	Selfie                   *ImageDocument         `protobuf:"bytes,42,opt,name=selfie,proto3" json:"selfie,omitempty"`
+	Avatar                   *ImageDocument         `protobuf:"bytes,43,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	Other                    *Other                 `protobuf:"bytes,44,opt,name=other,proto3" json:"other,omitempty"`
+	VideoAuth                *DocumentFile          `protobuf:"bytes,45,opt,name=video_auth,json=videoAuth,proto3" json:"video_auth,omitempty"`
+	Document                 *Document              `protobuf:"bytes,46,opt,name=document,proto3" json:"document,omitempty"`
+	CompanyName              string                 `protobuf:"bytes,47,opt,name=company_name,json=companyName,proto3" json:"company_name,omitempty"`
+	Website                  string                 `protobuf:"bytes,48,opt,name=website,proto3" json:"website,omitempty"`
+	CompanyBoard             *DocumentFile          `protobuf:"bytes,49,opt,name=company_board,json=companyBoard,proto3" json:"company_board,omitempty"`
+	CompanyRegistration      *DocumentFile          `protobuf:"bytes,50,opt,name=company_registration,json=companyRegistration,proto3" json:"company_registration,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *UserData) Reset() {
+	*x = UserData{}
+	mi := &file_kyc_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserData) ProtoMessage() {}
+
+func (x *UserData) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserData.ProtoReflect.Descriptor instead.
+func (*UserData) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UserData) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *UserData) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *UserData) GetMaternalLastName() string {
+	if x != nil {
+		return x.MaternalLastName
+	}
+	return ""
+}
+
+func (x *UserData) GetMiddleName() string {
+	if x != nil {
+		return x.MiddleName
+	}
+	return ""
+}
+
+func (x *UserData) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *UserData) GetLegalName() string {
+	if x != nil {
+		return x.LegalName
+	}
+	return ""
+}
+
+func (x *UserData) GetLatinIso1Name() string {
+	if x != nil {
+		return x.LatinIso1Name
+	}
+	return ""
+}
+
+func (x *UserData) GetAccountName() string {
+	if x != nil {
+		return x.AccountName
+	}
+	return ""
+}
+
+func (x *UserData) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UserData) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *UserData) GetGender() Gender {
+	if x != nil {
+		return x.Gender
+	}
+	return Gender_GENDER_UNSPECIFIED
+}
+
+func (x *UserData) GetDateOfBirth() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateOfBirth
+	}
+	return nil
+}
+
+func (x *UserData) GetPlaceOfBirth() string {
+	if x != nil {
+		return x.PlaceOfBirth
+	}
+	return ""
+}
+
+func (x *UserData) GetCountryOfBirthAlpha2() string {
+	if x != nil {
+		return x.CountryOfBirthAlpha2
+	}
+	return ""
+}
+
+func (x *UserData) GetStateOfBirth() string {
+	if x != nil {
+		return x.StateOfBirth
+	}
+	return ""
+}
+
+func (x *UserData) GetCountryAlpha2() string {
+	if x != nil {
+		return x.CountryAlpha2
+	}
+	return ""
+}
+
+func (x *UserData) GetNationality() string {
+	if x != nil {
+		return x.Nationality
+	}
+	return ""
+}
+
+func (x *UserData) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *UserData) GetMobilePhone() string {
+	if x != nil {
+		return x.MobilePhone
+	}
+	return ""
+}
+
+func (x *UserData) GetBankAccountNumber() string {
+	if x != nil {
+		return x.BankAccountNumber
+	}
+	return ""
+}
+
+func (x *UserData) GetVehicleRegistrationPlate() string {
+	if x != nil {
+		return x.VehicleRegistrationPlate
+	}
+	return ""
+}
+
+func (x *UserData) GetCurrentAddress() *Address {
+	if x != nil {
+		return x.CurrentAddress
+	}
+	return nil
+}
+
+func (x *UserData) GetSupplementalAddresses() []*Address {
+	if x != nil {
+		return x.SupplementalAddresses
+	}
+	return nil
+}
+
+func (x *UserData) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *UserData) GetBusiness() *Business {
+	if x != nil {
+		return x.Business
+	}
+	return nil
+}
+
+func (x *UserData) GetPassport() *Passport {
+	if x != nil {
+		return x.Passport
+	}
+	return nil
+}
+
+func (x *UserData) GetIdCard() *IDCard {
+	if x != nil {
+		return x.IdCard
+	}
+	return nil
+}
+
+func (x *UserData) GetSnils() *SNILS {
+	if x != nil {
+		return x.Snils
+	}
+	return nil
+}
+
+func (x *UserData) GetHealthId() *HealthID {
+	if x != nil {
+		return x.HealthId
+	}
+	return nil
+}
+
+func (x *UserData) GetSocialServiceId() *SocialServiceID {
+	if x != nil {
+		return x.SocialServiceId
+	}
+	return nil
+}
+
+func (x *UserData) GetTaxId() *TaxID {
+	if x != nil {
+		return x.TaxId
+	}
+	return nil
+}
+
+func (x *UserData) GetDriverLicense() *DriverLicense {
+	if x != nil {
+		return x.DriverLicense
+	}
+	return nil
+}
+
+func (x *UserData) GetDriverLicenseTranslation() *DriverLicense {
+	if x != nil {
+		return x.DriverLicenseTranslation
+	}
+	return nil
+}
+
+func (x *UserData) GetCreditCard() *CreditCard {
+	if x != nil {
+		return x.CreditCard
+	}
+	return nil
+}
+
+func (x *UserData) GetDebitCard() *CreditCard {
+	if x != nil {
+		return x.DebitCard
+	}
+	return nil
+}
+
+func (x *UserData) GetUtilityBill() *UtilityBill {
+	if x != nil {
+		return x.UtilityBill
+	}
+	return nil
+}
+
+func (x *UserData) GetResidencePermit() *ResidencePermit {
+	if x != nil {
+		return x.ResidencePermit
+	}
+	return nil
+}
+
+func (x *UserData) GetAgreement() *ImageDocument {
+	if x != nil {
+		return x.Agreement
+	}
+	return nil
+}
+
+func (x *UserData) GetEmploymentCertificate() *EmploymentCertificate {
+	if x != nil {
+		return x.EmploymentCertificate
+	}
+	return nil
+}
+
+func (x *UserData) GetContract() *ImageDocument {
+	if x != nil {
+		return x.Contract
+	}
+	return nil
+}
+
+func (x *UserData) GetDocumentPhoto() *ImageDocument {
+	if x != nil {
+		return x.DocumentPhoto
+	}
+	return nil
+}
+
+func (x *UserData) GetSelfie() *ImageDocument {
+	if x != nil {
+		return x.Selfie
+	}
+	return nil
+}
+
+func (x *UserData) GetAvatar() *ImageDocument {
+	if x != nil {
+		return x.Avatar
+	}
+	return nil
+}
+
+func (x *UserData) GetOther() *Other {
+	if x != nil {
+		return x.Other
+	}
+	return nil
+}
+
+func (x *UserData) GetVideoAuth() *DocumentFile {
+	if x != nil {
+		return x.VideoAuth
+	}
+	return nil
+}
+
+func (x *UserData) GetDocument() *Document {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+func (x *UserData) GetCompanyName() string {
+	if x != nil {
+		return x.CompanyName
+	}
+	return ""
+}
+
+func (x *UserData) GetWebsite() string {
+	if x != nil {
+		return x.Website
+	}
+	return ""
+}
+
+func (x *UserData) GetCompanyBoard() *DocumentFile {
+	if x != nil {
+		return x.CompanyBoard
+	}
+	return nil
+}
+
+func (x *UserData) GetCompanyRegistration() *DocumentFile {
+	if x != nil {
+		return x.CompanyRegistration
+	}
+	return nil
+}
+
+// Address represents the address of the customer.
+type Address struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CountryAlpha2     string                 `protobuf:"bytes,1,opt,name=country_alpha2,json=countryAlpha2,proto3" json:"country_alpha2,omitempty"`
+	County            string                 `protobuf:"bytes,2,opt,name=county,proto3" json:"county,omitempty"`
+	State             string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Town              string                 `protobuf:"bytes,4,opt,name=town,proto3" json:"town,omitempty"`
+	Suburb            string                 `protobuf:"bytes,5,opt,name=suburb,proto3" json:"suburb,omitempty"`
+	Street            string                 `protobuf:"bytes,6,opt,name=street,proto3" json:"street,omitempty"`
+	StreetType        string                 `protobuf:"bytes,7,opt,name=street_type,json=streetType,proto3" json:"street_type,omitempty"`
+	SubStreet         string                 `protobuf:"bytes,8,opt,name=sub_street,json=subStreet,proto3" json:"sub_street,omitempty"`
+	BuildingName      string                 `protobuf:"bytes,9,opt,name=building_name,json=buildingName,proto3" json:"building_name,omitempty"`
+	BuildingNumber    string                 `protobuf:"bytes,10,opt,name=building_number,json=buildingNumber,proto3" json:"building_number,omitempty"`
+	FlatNumber        string                 `protobuf:"bytes,11,opt,name=flat_number,json=flatNumber,proto3" json:"flat_number,omitempty"`
+	PostOfficeBox     string                 `protobuf:"bytes,12,opt,name=post_office_box,json=postOfficeBox,proto3" json:"post_office_box,omitempty"`
+	PostCode          string                 `protobuf:"bytes,13,opt,name=post_code,json=postCode,proto3" json:"post_code,omitempty"`
+	StateProvinceCode string                 `protobuf:"bytes,14,opt,name=state_province_code,json=stateProvinceCode,proto3" json:"state_province_code,omitempty"`
+	StartDate         *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate           *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_kyc_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Address) GetCountryAlpha2() string {
+	if x != nil {
+		return x.CountryAlpha2
+	}
+	return ""
+}
+
+func (x *Address) GetCounty() string {
+	if x != nil {
+		return x.County
+	}
+	return ""
+}
+
+func (x *Address) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Address) GetTown() string {
+	if x != nil {
+		return x.Town
+	}
+	return ""
+}
+
+func (x *Address) GetSuburb() string {
+	if x != nil {
+		return x.Suburb
+	}
+	return ""
+}
+
+func (x *Address) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *Address) GetStreetType() string {
+	if x != nil {
+		return x.StreetType
+	}
+	return ""
+}
+
+func (x *Address) GetSubStreet() string {
+	if x != nil {
+		return x.SubStreet
+	}
+	return ""
+}
+
+func (x *Address) GetBuildingName() string {
+	if x != nil {
+		return x.BuildingName
+	}
+	return ""
+}
+
+func (x *Address) GetBuildingNumber() string {
+	if x != nil {
+		return x.BuildingNumber
+	}
+	return ""
+}
+
+func (x *Address) GetFlatNumber() string {
+	if x != nil {
+		return x.FlatNumber
+	}
+	return ""
+}
+
+func (x *Address) GetPostOfficeBox() string {
+	if x != nil {
+		return x.PostOfficeBox
+	}
+	return ""
+}
+
+func (x *Address) GetPostCode() string {
+	if x != nil {
+		return x.PostCode
+	}
+	return ""
+}
+
+func (x *Address) GetStateProvinceCode() string {
+	if x != nil {
+		return x.StateProvinceCode
+	}
+	return ""
+}
+
+func (x *Address) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *Address) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+// Location represents the geopositional data.
+type Location struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      string                 `protobuf:"bytes,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     string                 `protobuf:"bytes,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	mi := &file_kyc_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Location) GetLatitude() string {
+	if x != nil {
+		return x.Latitude
+	}
+	return ""
+}
+
+func (x *Location) GetLongitude() string {
+	if x != nil {
+		return x.Longitude
+	}
+	return ""
+}
+
+// Business represents a business.
+type Business struct {
+	state                     protoimpl.MessageState `protogen:"open.v1"`
+	Name                      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	RegistrationNumber        string                 `protobuf:"bytes,2,opt,name=registration_number,json=registrationNumber,proto3" json:"registration_number,omitempty"`
+	IncorporationDate         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=incorporation_date,json=incorporationDate,proto3" json:"incorporation_date,omitempty"`
+	IncorporationJurisdiction string                 `protobuf:"bytes,4,opt,name=incorporation_jurisdiction,json=incorporationJurisdiction,proto3" json:"incorporation_jurisdiction,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *Business) Reset() {
+	*x = Business{}
+	mi := &file_kyc_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Business) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Business) ProtoMessage() {}
+
+func (x *Business) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Business.ProtoReflect.Descriptor instead.
+func (*Business) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Business) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Business) GetRegistrationNumber() string {
+	if x != nil {
+		return x.RegistrationNumber
+	}
+	return ""
+}
+
+func (x *Business) GetIncorporationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IncorporationDate
+	}
+	return nil
+}
+
+func (x *Business) GetIncorporationJurisdiction() string {
+	if x != nil {
+		return x.IncorporationJurisdiction
+	}
+	return ""
+}
+
+// DocumentFile represents the document file containing its original or an image.
+// The content is either the data of the file or the id of the file uploaded by the UploadDocument.
+// The filename and the content type of the uploaded file are used unless they're specified.
+type DocumentFile struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Filename    string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// Types that are valid to be assigned to Content:
+	//
+	//	*DocumentFile_Data
+	//	*DocumentFile_UploadId
+	Content       isDocumentFile_Content `protobuf_oneof:"content"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentFile) Reset() {
+	*x = DocumentFile{}
+	mi := &file_kyc_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentFile) ProtoMessage() {}
+
+func (x *DocumentFile) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentFile.ProtoReflect.Descriptor instead.
+func (*DocumentFile) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DocumentFile) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *DocumentFile) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *DocumentFile) GetContent() isDocumentFile_Content {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *DocumentFile) GetData() []byte {
+	if x != nil {
+		if x, ok := x.Content.(*DocumentFile_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *DocumentFile) GetUploadId() string {
+	if x != nil {
+		if x, ok := x.Content.(*DocumentFile_UploadId); ok {
+			return x.UploadId
+		}
+	}
+	return ""
+}
+
+type isDocumentFile_Content interface {
+	isDocumentFile_Content()
+}
+
+type DocumentFile_Data struct {
+	Data []byte `protobuf:"bytes,3,opt,name=data,proto3,oneof"`
+}
+
+type DocumentFile_UploadId struct {
+	UploadId string `protobuf:"bytes,4,opt,name=upload_id,json=uploadId,proto3,oneof"`
+}
+
+func (*DocumentFile_Data) isDocumentFile_Content() {}
+
+func (*DocumentFile_UploadId) isDocumentFile_Content() {}
+
+// Passport represents the passport.
+type Passport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        string                 `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
+	Mrz1          string                 `protobuf:"bytes,2,opt,name=mrz1,proto3" json:"mrz1,omitempty"`
+	Mrz2          string                 `protobuf:"bytes,3,opt,name=mrz2,proto3" json:"mrz2,omitempty"`
+	CountryAlpha2 string                 `protobuf:"bytes,4,opt,name=country_alpha2,json=countryAlpha2,proto3" json:"country_alpha2,omitempty"`
+	State         string                 `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	IssuedDate    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=issued_date,json=issuedDate,proto3" json:"issued_date,omitempty"`
+	ValidUntil    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
+	Image         *DocumentFile          `protobuf:"bytes,8,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Passport) Reset() {
+	*x = Passport{}
+	mi := &file_kyc_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Passport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Passport) ProtoMessage() {}
+
+func (x *Passport) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Passport.ProtoReflect.Descriptor instead.
+func (*Passport) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Passport) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *Passport) GetMrz1() string {
+	if x != nil {
+		return x.Mrz1
+	}
+	return ""
+}
+
+func (x *Passport) GetMrz2() string {
+	if x != nil {
+		return x.Mrz2
+	}
+	return ""
+}
+
+func (x *Passport) GetCountryAlpha2() string {
+	if x != nil {
+		return x.CountryAlpha2
+	}
+	return ""
+}
+
+func (x *Passport) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Passport) GetIssuedDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedDate
+	}
+	return nil
+}
+
+func (x *Passport) GetValidUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidUntil
+	}
+	return nil
+}
+
+func (x *Passport) GetImage() *DocumentFile {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+// IDCard represents the id card.
+type IDCard struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        string                 `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
+	CountryAlpha2 string                 `protobuf:"bytes,2,opt,name=country_alpha2,json=countryAlpha2,proto3" json:"country_alpha2,omitempty"`
+	IssuedDate    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=issued_date,json=issuedDate,proto3" json:"issued_date,omitempty"`
+	ValidUntil    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
+	Image         *DocumentFile          `protobuf:"bytes,5,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IDCard) Reset() {
+	*x = IDCard{}
+	mi := &file_kyc_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IDCard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IDCard) ProtoMessage() {}
+
+func (x *IDCard) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IDCard.ProtoReflect.Descriptor instead.
+func (*IDCard) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *IDCard) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *IDCard) GetCountryAlpha2() string {
+	if x != nil {
+		return x.CountryAlpha2
+	}
+	return ""
+}
+
+func (x *IDCard) GetIssuedDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedDate
+	}
+	return nil
+}
+
+func (x *IDCard) GetValidUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidUntil
+	}
+	return nil
+}
+
+func (x *IDCard) GetImage() *DocumentFile {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+// SNILS represents the Russian individual insurance account number.
+type SNILS struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        string                 `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
+	IssuedDate    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=issued_date,json=issuedDate,proto3" json:"issued_date,omitempty"`
+	Image         *DocumentFile          `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SNILS) Reset() {
+	*x = SNILS{}
+	mi := &file_kyc_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SNILS) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SNILS) ProtoMessage() {}
+
+func (x *SNILS) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SNILS.ProtoReflect.Descriptor instead.
+func (*SNILS) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SNILS) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *SNILS) GetIssuedDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedDate
+	}
+	return nil
+}
+
+func (x *SNILS) GetImage() *DocumentFile {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+// HealthID represents National Health Service Identification Information.
+type HealthID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        string                 `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
+	Image         *DocumentFile          `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthID) Reset() {
+	*x = HealthID{}
+	mi := &file_kyc_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthID) ProtoMessage() {}
+
+func (x *HealthID) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthID.ProtoReflect.Descriptor instead.
+func (*HealthID) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *HealthID) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *HealthID) GetImage() *DocumentFile {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+// SocialServiceID represents National Social Service Identification Information.
+type SocialServiceID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        string                 `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
+	IssuedDate    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=issued_date,json=issuedDate,proto3" json:"issued_date,omitempty"`
+	Image         *DocumentFile          `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SocialServiceID) Reset() {
+	*x = SocialServiceID{}
+	mi := &file_kyc_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SocialServiceID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SocialServiceID) ProtoMessage() {}
+
+func (x *SocialServiceID) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SocialServiceID.ProtoReflect.Descriptor instead.
+func (*SocialServiceID) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SocialServiceID) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *SocialServiceID) GetIssuedDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedDate
+	}
+	return nil
+}
+
+func (x *SocialServiceID) GetImage() *DocumentFile {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+// TaxID represents National Taxpayer Identification Information.
+type TaxID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        string                 `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
+	Image         *DocumentFile          `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaxID) Reset() {
+	*x = TaxID{}
+	mi := &file_kyc_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaxID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaxID) ProtoMessage() {}
+
+func (x *TaxID) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaxID.ProtoReflect.Descriptor instead.
+func (*TaxID) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TaxID) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *TaxID) GetImage() *DocumentFile {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+// DriverLicense represents the driver license or its translation.
+type DriverLicense struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        string                 `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	CountryAlpha2 string                 `protobuf:"bytes,3,opt,name=country_alpha2,json=countryAlpha2,proto3" json:"country_alpha2,omitempty"`
+	State         string                 `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	IssuedDate    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=issued_date,json=issuedDate,proto3" json:"issued_date,omitempty"`
+	ValidUntil    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
+	FrontImage    *DocumentFile          `protobuf:"bytes,7,opt,name=front_image,json=frontImage,proto3" json:"front_image,omitempty"`
+	BackImage     *DocumentFile          `protobuf:"bytes,8,opt,name=back_image,json=backImage,proto3" json:"back_image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriverLicense) Reset() {
+	*x = DriverLicense{}
+	mi := &file_kyc_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverLicense) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverLicense) ProtoMessage() {}
+
+func (x *DriverLicense) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverLicense.ProtoReflect.Descriptor instead.
+func (*DriverLicense) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DriverLicense) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *DriverLicense) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *DriverLicense) GetCountryAlpha2() string {
+	if x != nil {
+		return x.CountryAlpha2
+	}
+	return ""
+}
+
+func (x *DriverLicense) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *DriverLicense) GetIssuedDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedDate
+	}
+	return nil
+}
+
+func (x *DriverLicense) GetValidUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidUntil
+	}
+	return nil
+}
+
+func (x *DriverLicense) GetFrontImage() *DocumentFile {
+	if x != nil {
+		return x.FrontImage
+	}
+	return nil
+}
+
+func (x *DriverLicense) GetBackImage() *DocumentFile {
+	if x != nil {
+		return x.BackImage
+	}
+	return nil
+}
+
+// CreditCard represents the banking credit or debit card.
+type CreditCard struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        string                 `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
+	ValidUntil    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
+	Image         *DocumentFile          `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreditCard) Reset() {
+	*x = CreditCard{}
+	mi := &file_kyc_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreditCard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreditCard) ProtoMessage() {}
+
+func (x *CreditCard) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreditCard.ProtoReflect.Descriptor instead.
+func (*CreditCard) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CreditCard) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *CreditCard) GetValidUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidUntil
+	}
+	return nil
+}
+
+func (x *CreditCard) GetImage() *DocumentFile {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+// UtilityBill represents the utility bill.
+type UtilityBill struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CountryAlpha2 string                 `protobuf:"bytes,1,opt,name=country_alpha2,json=countryAlpha2,proto3" json:"country_alpha2,omitempty"`
+	Image         *DocumentFile          `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UtilityBill) Reset() {
+	*x = UtilityBill{}
+	mi := &file_kyc_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UtilityBill) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UtilityBill) ProtoMessage() {}
+
+func (x *UtilityBill) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UtilityBill.ProtoReflect.Descriptor instead.
+func (*UtilityBill) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UtilityBill) GetCountryAlpha2() string {
+	if x != nil {
+		return x.CountryAlpha2
+	}
+	return ""
+}
+
+func (x *UtilityBill) GetImage() *DocumentFile {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+// ResidencePermit represents the residence permit.
+type ResidencePermit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CountryAlpha2 string                 `protobuf:"bytes,1,opt,name=country_alpha2,json=countryAlpha2,proto3" json:"country_alpha2,omitempty"`
+	IssuedDate    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=issued_date,json=issuedDate,proto3" json:"issued_date,omitempty"`
+	ValidUntil    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
+	Image         *DocumentFile          `protobuf:"bytes,4,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResidencePermit) Reset() {
+	*x = ResidencePermit{}
+	mi := &file_kyc_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResidencePermit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResidencePermit) ProtoMessage() {}
+
+func (x *ResidencePermit) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResidencePermit.ProtoReflect.Descriptor instead.
+func (*ResidencePermit) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ResidencePermit) GetCountryAlpha2() string {
+	if x != nil {
+		return x.CountryAlpha2
+	}
+	return ""
+}
+
+func (x *ResidencePermit) GetIssuedDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedDate
+	}
+	return nil
+}
+
+func (x *ResidencePermit) GetValidUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidUntil
+	}
+	return nil
+}
+
+func (x *ResidencePermit) GetImage() *DocumentFile {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+// EmploymentCertificate represents a document from an employer.
+type EmploymentCertificate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IssuedDate    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=issued_date,json=issuedDate,proto3" json:"issued_date,omitempty"`
+	Image         *DocumentFile          `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmploymentCertificate) Reset() {
+	*x = EmploymentCertificate{}
+	mi := &file_kyc_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmploymentCertificate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmploymentCertificate) ProtoMessage() {}
+
+func (x *EmploymentCertificate) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmploymentCertificate.ProtoReflect.Descriptor instead.
+func (*EmploymentCertificate) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *EmploymentCertificate) GetIssuedDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedDate
+	}
+	return nil
+}
+
+func (x *EmploymentCertificate) GetImage() *DocumentFile {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+// ImageDocument represents the document consisting of the image only,
+// e.g. the agreement, the contract, the selfie, the avatar or the document photo.
+type ImageDocument struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Image         *DocumentFile          `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImageDocument) Reset() {
+	*x = ImageDocument{}
+	mi := &file_kyc_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageDocument) ProtoMessage() {}
+
+func (x *ImageDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageDocument.ProtoReflect.Descriptor instead.
+func (*ImageDocument) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ImageDocument) GetImage() *DocumentFile {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+// Other represents other documents.
+type Other struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        string                 `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
+	CountryAlpha2 string                 `protobuf:"bytes,2,opt,name=country_alpha2,json=countryAlpha2,proto3" json:"country_alpha2,omitempty"`
+	State         string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	IssuedDate    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=issued_date,json=issuedDate,proto3" json:"issued_date,omitempty"`
+	ValidUntil    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
+	Image         *DocumentFile          `protobuf:"bytes,6,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Other) Reset() {
+	*x = Other{}
+	mi := &file_kyc_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Other) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Other) ProtoMessage() {}
+
+func (x *Other) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Other.ProtoReflect.Descriptor instead.
+func (*Other) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Other) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *Other) GetCountryAlpha2() string {
+	if x != nil {
+		return x.CountryAlpha2
+	}
+	return ""
+}
+
+func (x *Other) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Other) GetIssuedDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedDate
+	}
+	return nil
+}
+
+func (x *Other) GetValidUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidUntil
+	}
+	return nil
+}
+
+func (x *Other) GetImage() *DocumentFile {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+// Document represents a document of the type.
+type Document struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          DocumentType           `protobuf:"varint,1,opt,name=type,proto3,enum=kyc.v1.DocumentType" json:"type,omitempty"`
+	Number        string                 `protobuf:"bytes,2,opt,name=number,proto3" json:"number,omitempty"`
+	CountryAlpha2 string                 `protobuf:"bytes,3,opt,name=country_alpha2,json=countryAlpha2,proto3" json:"country_alpha2,omitempty"`
+	IssuedDate    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=issued_date,json=issuedDate,proto3" json:"issued_date,omitempty"`
+	ValidUntil    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
+	Image         *DocumentFile          `protobuf:"bytes,6,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Document) Reset() {
+	*x = Document{}
+	mi := &file_kyc_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Document) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Document) ProtoMessage() {}
+
+func (x *Document) ProtoReflect() protoreflect.Message {
+	mi := &file_kyc_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Document.ProtoReflect.Descriptor instead.
+func (*Document) Descriptor() ([]byte, []int) {
+	return file_kyc_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Document) GetType() DocumentType {
+	if x != nil {
+		return x.Type
+	}
+	return DocumentType_DOCUMENT_TYPE_UNSPECIFIED
+}
+
+func (x *Document) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *Document) GetCountryAlpha2() string {
+	if x != nil {
+		return x.CountryAlpha2
+	}
+	return ""
+}
+
+func (x *Document) GetIssuedDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedDate
+	}
+	return nil
+}
+
+func (x *Document) GetValidUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidUntil
+	}
+	return nil
+}
+
+func (x *Document) GetImage() *DocumentFile {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+var File_kyc_proto protoreflect.FileDescriptor
+
+const file_kyc_proto_rawDesc = "" +
+	"\n" +
+	"\tkyc.proto\x12\x06kyc.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x95\x01\n" +
+	"\bStrategy\x12(\n" +
+	"\x04mode\x18\x01 \x01(\x0e2\x14.kyc.v1.StrategyModeR\x04mode\x12.\n" +
+	"\tproviders\x18\x02 \x03(\x0e2\x10.kyc.v1.ProviderR\tproviders\x12/\n" +
+	"\tconsensus\x18\x03 \x01(\x0e2\x11.kyc.v1.ConsensusR\tconsensus\"\xcb\x01\n" +
+	"\x14CheckCustomerRequest\x12,\n" +
+	"\bprovider\x18\x01 \x01(\x0e2\x10.kyc.v1.ProviderR\bprovider\x12,\n" +
+	"\bstrategy\x18\x02 \x01(\v2\x10.kyc.v1.StrategyR\bstrategy\x12,\n" +
+	"\bcustomer\x18\x03 \x01(\v2\x10.kyc.v1.UserDataR\bcustomer\x12)\n" +
+	"\x10notification_url\x18\x04 \x01(\tR\x0fnotificationUrl\"\xfb\x01\n" +
+	"\x15CheckCustomerResponse\x12)\n" +
+	"\x06result\x18\x01 \x01(\v2\x11.kyc.v1.KYCResultR\x06result\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12]\n" +
+	"\x10verification_ids\x18\x03 \x03(\v22.kyc.v1.CheckCustomerResponse.VerificationIdsEntryR\x0fverificationIds\x1aB\n" +
+	"\x14VerificationIdsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"e\n" +
+	"\x12CheckStatusRequest\x12,\n" +
+	"\bprovider\x18\x01 \x01(\x0e2\x10.kyc.v1.ProviderR\bprovider\x12!\n" +
+	"\freference_id\x18\x02 \x01(\tR\vreferenceId\"V\n" +
+	"\x13CheckStatusResponse\x12)\n" +
+	"\x06result\x18\x01 \x01(\v2\x11.kyc.v1.KYCResultR\x06result\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"f\n" +
+	"\x15UploadDocumentRequest\x12*\n" +
+	"\x04info\x18\x01 \x01(\v2\x14.kyc.v1.DocumentInfoH\x00R\x04info\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"M\n" +
+	"\fDocumentInfo\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\"\x7f\n" +
+	"\x16UploadDocumentResponse\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x124\n" +
+	"\aexpires\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aexpires\"e\n" +
+	"\x12WatchStatusRequest\x12,\n" +
+	"\bprovider\x18\x01 \x01(\x0e2\x10.kyc.v1.ProviderR\bprovider\x12!\n" +
+	"\freference_id\x18\x02 \x01(\tR\vreferenceId\"\xd2\x01\n" +
+	"\fStatusUpdate\x12,\n" +
+	"\bprovider\x18\x01 \x01(\x0e2\x10.kyc.v1.ProviderR\bprovider\x12!\n" +
+	"\freference_id\x18\x02 \x01(\tR\vreferenceId\x12)\n" +
+	"\x06result\x18\x03 \x01(\v2\x11.kyc.v1.KYCResultR\x06result\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12.\n" +
+	"\x04time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"\xeb\x01\n" +
+	"\tKYCResult\x12&\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x0e.kyc.v1.StatusR\x06status\x12)\n" +
+	"\adetails\x18\x02 \x01(\v2\x0f.kyc.v1.DetailsR\adetails\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\x126\n" +
+	"\fstatus_check\x18\x04 \x01(\v2\x13.kyc.v1.StatusCheckR\vstatusCheck\x124\n" +
+	"\tproviders\x18\x05 \x03(\v2\x16.kyc.v1.ProviderResultR\tproviders\"t\n" +
+	"\aDetails\x12,\n" +
+	"\bfinality\x18\x01 \x01(\x0e2\x10.kyc.v1.FinalityR\bfinality\x12\x18\n" +
+	"\areasons\x18\x02 \x03(\tR\areasons\x12!\n" +
+	"\freason_codes\x18\x03 \x03(\tR\vreasonCodes\"\x99\x01\n" +
+	"\vStatusCheck\x12,\n" +
+	"\bprovider\x18\x01 \x01(\x0e2\x10.kyc.v1.ProviderR\bprovider\x12!\n" +
+	"\freference_id\x18\x02 \x01(\tR\vreferenceId\x129\n" +
+	"\n" +
+	"last_check\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tlastCheck\"\x7f\n" +
+	"\x0eProviderResult\x12,\n" +
+	"\bprovider\x18\x01 \x01(\x0e2\x10.kyc.v1.ProviderR\bprovider\x12)\n" +
+	"\x06result\x18\x02 \x01(\v2\x11.kyc.v1.KYCResultR\x06result\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\xba\x12\n" +
+	"\bUserData\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x01 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x02 \x01(\tR\blastName\x12,\n" +
+	"\x12maternal_last_name\x18\x03 \x01(\tR\x10maternalLastName\x12\x1f\n" +
+	"\vmiddle_name\x18\x04 \x01(\tR\n" +
+	"middleName\x12\x1b\n" +
+	"\tfull_name\x18\x05 \x01(\tR\bfullName\x12\x1d\n" +
+	"\n" +
+	"legal_name\x18\x06 \x01(\tR\tlegalName\x12&\n" +
+	"\x0flatin_iso1_name\x18\a \x01(\tR\rlatinIso1Name\x12!\n" +
+	"\faccount_name\x18\b \x01(\tR\vaccountName\x12\x14\n" +
+	"\x05email\x18\t \x01(\tR\x05email\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\n" +
+	" \x01(\tR\tipAddress\x12&\n" +
+	"\x06gender\x18\v \x01(\x0e2\x0e.kyc.v1.GenderR\x06gender\x12>\n" +
+	"\rdate_of_birth\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\vdateOfBirth\x12$\n" +
+	"\x0eplace_of_birth\x18\r \x01(\tR\fplaceOfBirth\x125\n" +
+	"\x17country_of_birth_alpha2\x18\x0e \x01(\tR\x14countryOfBirthAlpha2\x12$\n" +
+	"\x0estate_of_birth\x18\x0f \x01(\tR\fstateOfBirth\x12%\n" +
+	"\x0ecountry_alpha2\x18\x10 \x01(\tR\rcountryAlpha2\x12 \n" +
+	"\vnationality\x18\x11 \x01(\tR\vnationality\x12\x14\n" +
+	"\x05phone\x18\x12 \x01(\tR\x05phone\x12!\n" +
+	"\fmobile_phone\x18\x13 \x01(\tR\vmobilePhone\x12.\n" +
+	"\x13bank_account_number\x18\x14 \x01(\tR\x11bankAccountNumber\x12<\n" +
+	"\x1avehicle_registration_plate\x18\x15 \x01(\tR\x18vehicleRegistrationPlate\x128\n" +
+	"\x0fcurrent_address\x18\x16 \x01(\v2\x0f.kyc.v1.AddressR\x0ecurrentAddress\x12F\n" +
+	"\x16supplemental_addresses\x18\x17 \x03(\v2\x0f.kyc.v1.AddressR\x15supplementalAddresses\x12,\n" +
+	"\blocation\x18\x18 \x01(\v2\x10.kyc.v1.LocationR\blocation\x12,\n" +
+	"\bbusiness\x18\x19 \x01(\v2\x10.kyc.v1.BusinessR\bbusiness\x12,\n" +
+	"\bpassport\x18\x1a \x01(\v2\x10.kyc.v1.PassportR\bpassport\x12'\n" +
+	"\aid_card\x18\x1b \x01(\v2\x0e.kyc.v1.IDCardR\x06idCard\x12#\n" +
+	"\x05snils\x18\x1c \x01(\v2\r.kyc.v1.SNILSR\x05snils\x12-\n" +
+	"\thealth_id\x18\x1d \x01(\v2\x10.kyc.v1.HealthIDR\bhealthId\x12C\n" +
+	"\x11social_service_id\x18\x1e \x01(\v2\x17.kyc.v1.SocialServiceIDR\x0fsocialServiceId\x12$\n" +
+	"\x06tax_id\x18\x1f \x01(\v2\r.kyc.v1.TaxIDR\x05taxId\x12<\n" +
+	"\x0edriver_license\x18  \x01(\v2\x15.kyc.v1.DriverLicenseR\rdriverLicense\x12S\n" +
+	"\x1adriver_license_translation\x18! \x01(\v2\x15.kyc.v1.DriverLicenseR\x18driverLicenseTranslation\x123\n" +
+	"\vcredit_card\x18\" \x01(\v2\x12.kyc.v1.CreditCardR\n" +
+	"creditCard\x121\n" +
+	"\n" +
+	"debit_card\x18# \x01(\v2\x12.kyc.v1.CreditCardR\tdebitCard\x126\n" +
+	"\futility_bill\x18$ \x01(\v2\x13.kyc.v1.UtilityBillR\vutilityBill\x12B\n" +
+	"\x10residence_permit\x18% \x01(\v2\x17.kyc.v1.ResidencePermitR\x0fresidencePermit\x123\n" +
+	"\tagreement\x18& \x01(\v2\x15.kyc.v1.ImageDocumentR\tagreement\x12T\n" +
+	"\x16employment_certificate\x18' \x01(\v2\x1d.kyc.v1.EmploymentCertificateR\x15employmentCertificate\x121\n" +
+	"\bcontract\x18( \x01(\v2\x15.kyc.v1.ImageDocumentR\bcontract\x12<\n" +
+	"\x0edocument_photo\x18) \x01(\v2\x15.kyc.v1.ImageDocumentR\rdocumentPhoto\x12-\n" +
+	"\x06selfie\x18* \x01(\v2\x15.kyc.v1.ImageDocumentR\x06selfie\x12-\n" +
+	"\x06avatar\x18+ \x01(\v2\x15.kyc.v1.ImageDocumentR\x06avatar\x12#\n" +
+	"\x05other\x18, \x01(\v2\r.kyc.v1.OtherR\x05other\x123\n" +
+	"\n" +
+	"video_auth\x18- \x01(\v2\x14.kyc.v1.DocumentFileR\tvideoAuth\x12,\n" +
+	"\bdocument\x18. \x01(\v2\x10.kyc.v1.DocumentR\bdocument\x12!\n" +
+	"\fcompany_name\x18/ \x01(\tR\vcompanyName\x12\x18\n" +
+	"\awebsite\x180 \x01(\tR\awebsite\x129\n" +
+	"\rcompany_board\x181 \x01(\v2\x14.kyc.v1.DocumentFileR\fcompanyBoard\x12G\n" +
+	"\x14company_registration\x182 \x01(\v2\x14.kyc.v1.DocumentFileR\x13companyRegistration\"\xb8\x04\n" +
+	"\aAddress\x12%\n" +
+	"\x0ecountry_alpha2\x18\x01 \x01(\tR\rcountryAlpha2\x12\x16\n" +
+	"\x06county\x18\x02 \x01(\tR\x06county\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12\x12\n" +
+	"\x04town\x18\x04 \x01(\tR\x04town\x12\x16\n" +
+	"\x06suburb\x18\x05 \x01(\tR\x06suburb\x12\x16\n" +
+	"\x06street\x18\x06 \x01(\tR\x06street\x12\x1f\n" +
+	"\vstreet_type\x18\a \x01(\tR\n" +
+	"streetType\x12\x1d\n" +
+	"\n" +
+	"sub_street\x18\b \x01(\tR\tsubStreet\x12#\n" +
+	"\rbuilding_name\x18\t \x01(\tR\fbuildingName\x12'\n" +
+	"\x0fbuilding_number\x18\n" +
+	" \x01(\tR\x0ebuildingNumber\x12\x1f\n" +
+	"\vflat_number\x18\v \x01(\tR\n" +
+	"flatNumber\x12&\n" +
+	"\x0fpost_office_box\x18\f \x01(\tR\rpostOfficeBox\x12\x1b\n" +
+	"\tpost_code\x18\r \x01(\tR\bpostCode\x12.\n" +
+	"\x13state_province_code\x18\x0e \x01(\tR\x11stateProvinceCode\x129\n" +
+	"\n" +
+	"start_date\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\"D\n" +
+	"\bLocation\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\tR\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\tR\tlongitude\"\xd9\x01\n" +
+	"\bBusiness\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12/\n" +
+	"\x13registration_number\x18\x02 \x01(\tR\x12registrationNumber\x12I\n" +
+	"\x12incorporation_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x11incorporationDate\x12=\n" +
+	"\x1aincorporation_jurisdiction\x18\x04 \x01(\tR\x19incorporationJurisdiction\"\x8d\x01\n" +
+	"\fDocumentFile\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x14\n" +
+	"\x04data\x18\x03 \x01(\fH\x00R\x04data\x12\x1d\n" +
+	"\tupload_id\x18\x04 \x01(\tH\x00R\buploadIdB\t\n" +
+	"\acontent\"\xad\x02\n" +
+	"\bPassport\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\tR\x06number\x12\x12\n" +
+	"\x04mrz1\x18\x02 \x01(\tR\x04mrz1\x12\x12\n" +
+	"\x04mrz2\x18\x03 \x01(\tR\x04mrz2\x12%\n" +
+	"\x0ecountry_alpha2\x18\x04 \x01(\tR\rcountryAlpha2\x12\x14\n" +
+	"\x05state\x18\x05 \x01(\tR\x05state\x12;\n" +
+	"\vissued_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"issuedDate\x12;\n" +
+	"\vvalid_until\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"validUntil\x12*\n" +
+	"\x05image\x18\b \x01(\v2\x14.kyc.v1.DocumentFileR\x05image\"\xed\x01\n" +
+	"\x06IDCard\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\tR\x06number\x12%\n" +
+	"\x0ecountry_alpha2\x18\x02 \x01(\tR\rcountryAlpha2\x12;\n" +
+	"\vissued_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"issuedDate\x12;\n" +
+	"\vvalid_until\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"validUntil\x12*\n" +
+	"\x05image\x18\x05 \x01(\v2\x14.kyc.v1.DocumentFileR\x05image\"\x88\x01\n" +
+	"\x05SNILS\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\tR\x06number\x12;\n" +
+	"\vissued_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"issuedDate\x12*\n" +
+	"\x05image\x18\x03 \x01(\v2\x14.kyc.v1.DocumentFileR\x05image\"N\n" +
+	"\bHealthID\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\tR\x06number\x12*\n" +
+	"\x05image\x18\x02 \x01(\v2\x14.kyc.v1.DocumentFileR\x05image\"\x92\x01\n" +
+	"\x0fSocialServiceID\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\tR\x06number\x12;\n" +
+	"\vissued_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"issuedDate\x12*\n" +
+	"\x05image\x18\x03 \x01(\v2\x14.kyc.v1.DocumentFileR\x05image\"K\n" +
+	"\x05TaxID\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\tR\x06number\x12*\n" +
+	"\x05image\x18\x02 \x01(\v2\x14.kyc.v1.DocumentFileR\x05image\"\xe4\x02\n" +
+	"\rDriverLicense\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\tR\x06number\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12%\n" +
+	"\x0ecountry_alpha2\x18\x03 \x01(\tR\rcountryAlpha2\x12\x14\n" +
+	"\x05state\x18\x04 \x01(\tR\x05state\x12;\n" +
+	"\vissued_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"issuedDate\x12;\n" +
+	"\vvalid_until\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"validUntil\x125\n" +
+	"\vfront_image\x18\a \x01(\v2\x14.kyc.v1.DocumentFileR\n" +
+	"frontImage\x123\n" +
+	"\n" +
+	"back_image\x18\b \x01(\v2\x14.kyc.v1.DocumentFileR\tbackImage\"\x8d\x01\n" +
+	"\n" +
+	"CreditCard\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\tR\x06number\x12;\n" +
+	"\vvalid_until\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"validUntil\x12*\n" +
+	"\x05image\x18\x03 \x01(\v2\x14.kyc.v1.DocumentFileR\x05image\"`\n" +
+	"\vUtilityBill\x12%\n" +
+	"\x0ecountry_alpha2\x18\x01 \x01(\tR\rcountryAlpha2\x12*\n" +
+	"\x05image\x18\x02 \x01(\v2\x14.kyc.v1.DocumentFileR\x05image\"\xde\x01\n" +
+	"\x0fResidencePermit\x12%\n" +
+	"\x0ecountry_alpha2\x18\x01 \x01(\tR\rcountryAlpha2\x12;\n" +
+	"\vissued_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"issuedDate\x12;\n" +
+	"\vvalid_until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"validUntil\x12*\n" +
+	"\x05image\x18\x04 \x01(\v2\x14.kyc.v1.DocumentFileR\x05image\"\x80\x01\n" +
+	"\x15EmploymentCertificate\x12;\n" +
+	"\vissued_date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"issuedDate\x12*\n" +
+	"\x05image\x18\x02 \x01(\v2\x14.kyc.v1.DocumentFileR\x05image\";\n" +
+	"\rImageDocument\x12*\n" +
+	"\x05image\x18\x01 \x01(\v2\x14.kyc.v1.DocumentFileR\x05image\"\x82\x02\n" +
+	"\x05Other\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\tR\x06number\x12%\n" +
+	"\x0ecountry_alpha2\x18\x02 \x01(\tR\rcountryAlpha2\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12;\n" +
+	"\vissued_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"issuedDate\x12;\n" +
+	"\vvalid_until\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"validUntil\x12*\n" +
+	"\x05image\x18\x06 \x01(\v2\x14.kyc.v1.DocumentFileR\x05image\"\x99\x02\n" +
+	"\bDocument\x12(\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x14.kyc.v1.DocumentTypeR\x04type\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\tR\x06number\x12%\n" +
+	"\x0ecountry_alpha2\x18\x03 \x01(\tR\rcountryAlpha2\x12;\n" +
+	"\vissued_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"issuedDate\x12;\n" +
+	"\vvalid_until\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"validUntil\x12*\n" +
+	"\x05image\x18\x06 \x01(\v2\x14.kyc.v1.DocumentFileR\x05image*\xeb\x01\n" +
+	"\bProvider\x12\x18\n" +
+	"\x14PROVIDER_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aEXAMPLE\x10\x01\x12\f\n" +
+	"\bCOINFIRM\x10\x02\x12\x14\n" +
+	"\x10COMPLY_ADVANTAGE\x10\x03\x12\x11\n" +
+	"\rIDENTITY_MIND\x10\x04\x12\v\n" +
+	"\aIDOLOGY\x10\x05\x12\t\n" +
+	"\x05JUMIO\x10\x06\x12\x0e\n" +
+	"\n" +
+	"SHUFTI_PRO\x10\a\x12\x15\n" +
+	"\x11SUM_AND_SUBSTANCE\x10\b\x12\x0e\n" +
+	"\n" +
+	"SYNAPSE_FI\x10\t\x12\x13\n" +
+	"\x0fTHOMSON_REUTERS\x10\n" +
+	"\x12\v\n" +
+	"\aTRULIOO\x10\v\x12\x10\n" +
+	"\fCIPHER_TRACE\x10\f*:\n" +
+	"\x06Status\x12\t\n" +
+	"\x05ERROR\x10\x00\x12\f\n" +
+	"\bAPPROVED\x10\x01\x12\n" +
+	"\n" +
+	"\x06DENIED\x10\x02\x12\v\n" +
+	"\aUNCLEAR\x10\x03*1\n" +
+	"\bFinality\x12\v\n" +
+	"\aUNKNOWN\x10\x00\x12\t\n" +
+	"\x05FINAL\x10\x01\x12\r\n" +
+	"\tNON_FINAL\x10\x02*6\n" +
+	"\x06Gender\x12\x16\n" +
+	"\x12GENDER_UNSPECIFIED\x10\x00\x12\b\n" +
+	"\x04MALE\x10\x01\x12\n" +
+	"\n" +
+	"\x06FEMALE\x10\x02*}\n" +
+	"\fDocumentType\x12\x1d\n" +
+	"\x19DOCUMENT_TYPE_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aID_CARD\x10\x01\x12\f\n" +
+	"\bPASSPORT\x10\x02\x12\x12\n" +
+	"\x0eDRIVER_LICENSE\x10\x03\x12\x0f\n" +
+	"\vCREDIT_CARD\x10\x04\x12\x0e\n" +
+	"\n" +
+	"DEBIT_CARD\x10\x05*I\n" +
+	"\fStrategyMode\x12\x1d\n" +
+	"\x19STRATEGY_MODE_UNSPECIFIED\x10\x00\x12\f\n" +
+	"\bFALLBACK\x10\x01\x12\f\n" +
+	"\bPARALLEL\x10\x02*U\n" +
+	"\tConsensus\x12\x19\n" +
+	"\x15CONSENSUS_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vALL_APPROVE\x10\x01\x12\x0e\n" +
+	"\n" +
+	"ANY_DENIAL\x10\x02\x12\f\n" +
+	"\bMAJORITY\x10\x032\xb1\x02\n" +
+	"\x03KYC\x12L\n" +
+	"\rCheckCustomer\x12\x1c.kyc.v1.CheckCustomerRequest\x1a\x1d.kyc.v1.CheckCustomerResponse\x12F\n" +
+	"\vCheckStatus\x12\x1a.kyc.v1.CheckStatusRequest\x1a\x1b.kyc.v1.CheckStatusResponse\x12Q\n" +
+	"\x0eUploadDocument\x12\x1d.kyc.v1.UploadDocumentRequest\x1a\x1e.kyc.v1.UploadDocumentResponse(\x01\x12A\n" +
+	"\vWatchStatus\x12\x1a.kyc.v1.WatchStatusRequest\x1a\x14.kyc.v1.StatusUpdate0\x01B Z\x1emodulus/kyc/main/grpcapi/kycpbb\x06proto3"
+
+var (
+	file_kyc_proto_rawDescOnce sync.Once
+	file_kyc_proto_rawDescData []byte
+)
+
+func file_kyc_proto_rawDescGZIP() []byte {
+	file_kyc_proto_rawDescOnce.Do(func() {
+		file_kyc_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_kyc_proto_rawDesc), len(file_kyc_proto_rawDesc)))
+	})
+	return file_kyc_proto_rawDescData
+}
+
+var file_kyc_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_kyc_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
+var file_kyc_proto_goTypes = []any{
+	(Provider)(0),                  // 0: kyc.v1.Provider
+	(Status)(0),                    // 1: kyc.v1.Status
+	(Finality)(0),                  // 2: kyc.v1.Finality
+	(Gender)(0),                    // 3: kyc.v1.Gender
+	(DocumentType)(0),              // 4: kyc.v1.DocumentType
+	(StrategyMode)(0),              // 5: kyc.v1.StrategyMode
+	(Consensus)(0),                 // 6: kyc.v1.Consensus
+	(*Strategy)(nil),               // 7: kyc.v1.Strategy
+	(*CheckCustomerRequest)(nil),   // 8: kyc.v1.CheckCustomerRequest
+	(*CheckCustomerResponse)(nil),  // 9: kyc.v1.CheckCustomerResponse
+	(*CheckStatusRequest)(nil),     // 10: kyc.v1.CheckStatusRequest
+	(*CheckStatusResponse)(nil),    // 11: kyc.v1.CheckStatusResponse
+	(*UploadDocumentRequest)(nil),  // 12: kyc.v1.UploadDocumentRequest
+	(*DocumentInfo)(nil),           // 13: kyc.v1.DocumentInfo
+	(*UploadDocumentResponse)(nil), // 14: kyc.v1.UploadDocumentResponse
+	(*WatchStatusRequest)(nil),     // 15: kyc.v1.WatchStatusRequest
+	(*StatusUpdate)(nil),           // 16: kyc.v1.StatusUpdate
+	(*KYCResult)(nil),              // 17: kyc.v1.KYCResult
+	(*Details)(nil),                // 18: kyc.v1.Details
+	(*StatusCheck)(nil),            // 19: kyc.v1.StatusCheck
+	(*ProviderResult)(nil),         // 20: kyc.v1.ProviderResult
+	(*UserData)(nil),               // 21: kyc.v1.UserData
+	(*Address)(nil),                // 22: kyc.v1.Address
+	(*Location)(nil),               // 23: kyc.v1.Location
+	(*Business)(nil),               // 24: kyc.v1.Business
+	(*DocumentFile)(nil),           // 25: kyc.v1.DocumentFile
+	(*Passport)(nil),               // 26: kyc.v1.Passport
+	(*IDCard)(nil),                 // 27: kyc.v1.IDCard
+	(*SNILS)(nil),                  // 28: kyc.v1.SNILS
+	(*HealthID)(nil),               // 29: kyc.v1.HealthID
+	(*SocialServiceID)(nil),        // 30: kyc.v1.SocialServiceID
+	(*TaxID)(nil),                  // 31: kyc.v1.TaxID
+	(*DriverLicense)(nil),          // 32: kyc.v1.DriverLicense
+	(*CreditCard)(nil),             // 33: kyc.v1.CreditCard
+	(*UtilityBill)(nil),            // 34: kyc.v1.UtilityBill
+	(*ResidencePermit)(nil),        // 35: kyc.v1.ResidencePermit
+	(*EmploymentCertificate)(nil),  // 36: kyc.v1.EmploymentCertificate
+	(*ImageDocument)(nil),          // 37: kyc.v1.ImageDocument
+	(*Other)(nil),                  // 38: kyc.v1.Other
+	(*Document)(nil),               // 39: kyc.v1.Document
+	nil,                            // 40: kyc.v1.CheckCustomerResponse.VerificationIdsEntry
+	(*timestamppb.Timestamp)(nil),  // 41: google.protobuf.Timestamp
+}
+var file_kyc_proto_depIdxs = []int32{
+	5,  // 0: kyc.v1.Strategy.mode:type_name -> kyc.v1.StrategyMode
+	0,  // 1: kyc.v1.Strategy.providers:type_name -> kyc.v1.Provider
+	6,  // 2: kyc.v1.Strategy.consensus:type_name -> kyc.v1.Consensus
+	0,  // 3: kyc.v1.CheckCustomerRequest.provider:type_name -> kyc.v1.Provider
+	7,  // 4: kyc.v1.CheckCustomerRequest.strategy:type_name -> kyc.v1.Strategy
+	21, // 5: kyc.v1.CheckCustomerRequest.customer:type_name -> kyc.v1.UserData
+	17, // 6: kyc.v1.CheckCustomerResponse.result:type_name -> kyc.v1.KYCResult
+	40, // 7: kyc.v1.CheckCustomerResponse.verification_ids:type_name -> kyc.v1.CheckCustomerResponse.VerificationIdsEntry
+	0,  // 8: kyc.v1.CheckStatusRequest.provider:type_name -> kyc.v1.Provider
+	17, // 9: kyc.v1.CheckStatusResponse.result:type_name -> kyc.v1.KYCResult
+	13, // 10: kyc.v1.UploadDocumentRequest.info:type_name -> kyc.v1.DocumentInfo
+	41, // 11: kyc.v1.UploadDocumentResponse.expires:type_name -> google.protobuf.Timestamp
+	0,  // 12: kyc.v1.WatchStatusRequest.provider:type_name -> kyc.v1.Provider
+	0,  // 13: kyc.v1.StatusUpdate.provider:type_name -> kyc.v1.Provider
+	17, // 14: kyc.v1.StatusUpdate.result:type_name -> kyc.v1.KYCResult
+	41, // 15: kyc.v1.StatusUpdate.time:type_name -> google.protobuf.Timestamp
+	1,  // 16: kyc.v1.KYCResult.status:type_name -> kyc.v1.Status
+	18, // 17: kyc.v1.KYCResult.details:type_name -> kyc.v1.Details
+	19, // 18: kyc.v1.KYCResult.status_check:type_name -> kyc.v1.StatusCheck
+	20, // 19: kyc.v1.KYCResult.providers:type_name -> kyc.v1.ProviderResult
+	2,  // 20: kyc.v1.Details.finality:type_name -> kyc.v1.Finality
+	0,  // 21: kyc.v1.StatusCheck.provider:type_name -> kyc.v1.Provider
+	41, // 22: kyc.v1.StatusCheck.last_check:type_name -> google.protobuf.Timestamp
+	0,  // 23: kyc.v1.ProviderResult.provider:type_name -> kyc.v1.Provider
+	17, // 24: kyc.v1.ProviderResult.result:type_name -> kyc.v1.KYCResult
+	3,  // 25: kyc.v1.UserData.gender:type_name -> kyc.v1.Gender
+	41, // 26: kyc.v1.UserData.date_of_birth:type_name -> google.protobuf.Timestamp
+	22, // 27: kyc.v1.UserData.current_address:type_name -> kyc.v1.Address
+	22, // 28: kyc.v1.UserData.supplemental_addresses:type_name -> kyc.v1.Address
+	23, // 29: kyc.v1.UserData.location:type_name -> kyc.v1.Location
+	24, // 30: kyc.v1.UserData.business:type_name -> kyc.v1.Business
+	26, // 31: kyc.v1.UserData.passport:type_name -> kyc.v1.Passport
+	27, // 32: kyc.v1.UserData.id_card:type_name -> kyc.v1.IDCard
+	28, // 33: kyc.v1.UserData.snils:type_name -> kyc.v1.SNILS
+	29, // 34: kyc.v1.UserData.health_id:type_name -> kyc.v1.HealthID
+	30, // 35: kyc.v1.UserData.social_service_id:type_name -> kyc.v1.SocialServiceID
+	31, // 36: kyc.v1.UserData.tax_id:type_name -> kyc.v1.TaxID
+	32, // 37: kyc.v1.UserData.driver_license:type_name -> kyc.v1.DriverLicense
+	32, // 38: kyc.v1.UserData.driver_license_translation:type_name -> kyc.v1.DriverLicense
+	33, // 39: kyc.v1.UserData.credit_card:type_name -> kyc.v1.CreditCard
+	33, // 40: kyc.v1.UserData.debit_card:type_name -> kyc.v1.CreditCard
+	34, // 41: kyc.v1.UserData.utility_bill:type_name -> kyc.v1.UtilityBill
+	35, // 42: kyc.v1.UserData.residence_permit:type_name -> kyc.v1.ResidencePermit
+	37, // 43: kyc.v1.UserData.agreement:type_name -> kyc.v1.ImageDocument
+	36, // 44: kyc.v1.UserData.employment_certificate:type_name -> kyc.v1.EmploymentCertificate
+	37, // 45: kyc.v1.UserData.contract:type_name -> kyc.v1.ImageDocument
+	37, // 46: kyc.v1.UserData.document_photo:type_name -> kyc.v1.ImageDocument
+	37, // 47: kyc.v1.UserData.selfie:type_name -> kyc.v1.ImageDocument
+	37, // 48: kyc.v1.UserData.avatar:type_name -> kyc.v1.ImageDocument
+	38, // 49: kyc.v1.UserData.other:type_name -> kyc.v1.Other
+	25, // 50: kyc.v1.UserData.video_auth:type_name -> kyc.v1.DocumentFile
+	39, // 51: kyc.v1.UserData.document:type_name -> kyc.v1.Document
+	25, // 52: kyc.v1.UserData.company_board:type_name -> kyc.v1.DocumentFile
+	25, // 53: kyc.v1.UserData.company_registration:type_name -> kyc.v1.DocumentFile
+	41, // 54: kyc.v1.Address.start_date:type_name -> google.protobuf.Timestamp
+	41, // 55: kyc.v1.Address.end_date:type_name -> google.protobuf.Timestamp
+	41, // 56: kyc.v1.Business.incorporation_date:type_name -> google.protobuf.Timestamp
+	41, // 57: kyc.v1.Passport.issued_date:type_name -> google.protobuf.Timestamp
+	41, // 58: kyc.v1.Passport.valid_until:type_name -> google.protobuf.Timestamp
+	25, // 59: kyc.v1.Passport.image:type_name -> kyc.v1.DocumentFile
+	41, // 60: kyc.v1.IDCard.issued_date:type_name -> google.protobuf.Timestamp
+	41, // 61: kyc.v1.IDCard.valid_until:type_name -> google.protobuf.Timestamp
+	25, // 62: kyc.v1.IDCard.image:type_name -> kyc.v1.DocumentFile
+	41, // 63: kyc.v1.SNILS.issued_date:type_name -> google.protobuf.Timestamp
+	25, // 64: kyc.v1.SNILS.image:type_name -> kyc.v1.DocumentFile
+	25, // 65: kyc.v1.HealthID.image:type_name -> kyc.v1.DocumentFile
+	41, // 66: kyc.v1.SocialServiceID.issued_date:type_name -> google.protobuf.Timestamp
+	25, // 67: kyc.v1.SocialServiceID.image:type_name -> kyc.v1.DocumentFile
+	25, // 68: kyc.v1.TaxID.image:type_name -> kyc.v1.DocumentFile
+	41, // 69: kyc.v1.DriverLicense.issued_date:type_name -> google.protobuf.Timestamp
+	41, // 70: kyc.v1.DriverLicense.valid_until:type_name -> google.protobuf.Timestamp
+	25, // 71: kyc.v1.DriverLicense.front_image:type_name -> kyc.v1.DocumentFile
+	25, // 72: kyc.v1.DriverLicense.back_image:type_name -> kyc.v1.DocumentFile
+	41, // 73: kyc.v1.CreditCard.valid_until:type_name -> google.protobuf.Timestamp
+	25, // 74: kyc.v1.CreditCard.image:type_name -> kyc.v1.DocumentFile
+	25, // 75: kyc.v1.UtilityBill.image:type_name -> kyc.v1.DocumentFile
+	41, // 76: kyc.v1.ResidencePermit.issued_date:type_name -> google.protobuf.Timestamp
+	41, // 77: kyc.v1.ResidencePermit.valid_until:type_name -> google.protobuf.Timestamp
+	25, // 78: kyc.v1.ResidencePermit.image:type_name -> kyc.v1.DocumentFile
+	41, // 79: kyc.v1.EmploymentCertificate.issued_date:type_name -> google.protobuf.Timestamp
+	25, // 80: kyc.v1.EmploymentCertificate.image:type_name -> kyc.v1.DocumentFile
+	25, // 81: kyc.v1.ImageDocument.image:type_name -> kyc.v1.DocumentFile
+	41, // 82: kyc.v1.Other.issued_date:type_name -> google.protobuf.Timestamp
+	41, // 83: kyc.v1.Other.valid_until:type_name -> google.protobuf.Timestamp
+	25, // 84: kyc.v1.Other.image:type_name -> kyc.v1.DocumentFile
+	4,  // 85: kyc.v1.Document.type:type_name -> kyc.v1.DocumentType
+	41, // 86: kyc.v1.Document.issued_date:type_name -> google.protobuf.Timestamp
+	41, // 87: kyc.v1.Document.valid_until:type_name -> google.protobuf.Timestamp
+	25, // 88: kyc.v1.Document.image:type_name -> kyc.v1.DocumentFile
+	8,  // 89: kyc.v1.KYC.CheckCustomer:input_type -> kyc.v1.CheckCustomerRequest
+	10, // 90: kyc.v1.KYC.CheckStatus:input_type -> kyc.v1.CheckStatusRequest
+	12, // 91: kyc.v1.KYC.UploadDocument:input_type -> kyc.v1.UploadDocumentRequest
+	15, // 92: kyc.v1.KYC.WatchStatus:input_type -> kyc.v1.WatchStatusRequest
+	9,  // 93: kyc.v1.KYC.CheckCustomer:output_type -> kyc.v1.CheckCustomerResponse
+	11, // 94: kyc.v1.KYC.CheckStatus:output_type -> kyc.v1.CheckStatusResponse
+	14, // 95: kyc.v1.KYC.UploadDocument:output_type -> kyc.v1.UploadDocumentResponse
+	16, // 96: kyc.v1.KYC.WatchStatus:output_type -> kyc.v1.StatusUpdate
+	93, // [93:97] is the sub-list for method output_type
+	89, // [89:93] is the sub-list for method input_type
+	89, // [89:89] is the sub-list for extension type_name
+	89, // [89:89] is the sub-list for extension extendee
+	0,  // [0:89] is the sub-list for field type_name
+}
+
+func init() { file_kyc_proto_init() }
+func file_kyc_proto_init() {
+	if File_kyc_proto != nil {
+		return
+	}
+	file_kyc_proto_msgTypes[5].OneofWrappers = []any{
+		(*UploadDocumentRequest_Info)(nil),
+		(*UploadDocumentRequest_Chunk)(nil),
+	}
+	file_kyc_proto_msgTypes[18].OneofWrappers = []any{
+		(*DocumentFile_Data)(nil),
+		(*DocumentFile_UploadId)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_kyc_proto_rawDesc), len(file_kyc_proto_rawDesc)),
+			NumEnums:      7,
+			NumMessages:   34,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_kyc_proto_goTypes,
+		DependencyIndexes: file_kyc_proto_depIdxs,
+		EnumInfos:         file_kyc_proto_enumTypes,
+		MessageInfos:      file_kyc_proto_msgTypes,
+	}.Build()
+	File_kyc_proto = out.File
+	file_kyc_proto_goTypes = nil
+	file_kyc_proto_depIdxs = nil
+}
@@ -0,0 +1,256 @@
+// The gRPC API of the KYC service.
+// The messages mirror the models of the REST API from the common package.
+// The document images are sent as raw bytes or uploaded beforehand by the UploadDocument stream.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: kyc.proto
+
+package kycpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	KYC_CheckCustomer_FullMethodName  = "/kyc.v1.KYC/CheckCustomer"
+	KYC_CheckStatus_FullMethodName    = "/kyc.v1.KYC/CheckStatus"
+	KYC_UploadDocument_FullMethodName = "/kyc.v1.KYC/UploadDocument"
+	KYC_WatchStatus_FullMethodName    = "/kyc.v1.KYC/WatchStatus"
+)
+
+// KYCClient is the client API for KYC service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// KYC verifies the customers using the KYC providers.
+type KYCClient interface {
+	// CheckCustomer verifies the customer using the provider or the strategy using several providers.
+	CheckCustomer(ctx context.Context, in *CheckCustomerRequest, opts ...grpc.CallOption) (*CheckCustomerResponse, error)
+	// CheckStatus checks the current status of the verification from the provider.
+	CheckStatus(ctx context.Context, in *CheckStatusRequest, opts ...grpc.CallOption) (*CheckStatusResponse, error)
+	// UploadDocument uploads the document file by chunks.
+	// The first message must hold the info of the file, the rest ones hold the chunks of its content.
+	// The returned upload id is referenced by the DocumentFile of the customer until the upload expires.
+	UploadDocument(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadDocumentRequest, UploadDocumentResponse], error)
+	// WatchStatus streams the status changes of the verification.
+	// The latest known result is sent first if the verification is tracked by the service.
+	// The stream ends after the final result.
+	WatchStatus(ctx context.Context, in *WatchStatusRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StatusUpdate], error)
+}
+
+type kYCClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewKYCClient(cc grpc.ClientConnInterface) KYCClient {
+	return &kYCClient{cc}
+}
+
+func (c *kYCClient) CheckCustomer(ctx context.Context, in *CheckCustomerRequest, opts ...grpc.CallOption) (*CheckCustomerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckCustomerResponse)
+	err := c.cc.Invoke(ctx, KYC_CheckCustomer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kYCClient) CheckStatus(ctx context.Context, in *CheckStatusRequest, opts ...grpc.CallOption) (*CheckStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckStatusResponse)
+	err := c.cc.Invoke(ctx, KYC_CheckStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kYCClient) UploadDocument(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadDocumentRequest, UploadDocumentResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &KYC_ServiceDesc.Streams[0], KYC_UploadDocument_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadDocumentRequest, UploadDocumentResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KYC_UploadDocumentClient = grpc.ClientStreamingClient[UploadDocumentRequest, UploadDocumentResponse]
+
+func (c *kYCClient) WatchStatus(ctx context.Context, in *WatchStatusRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StatusUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &KYC_ServiceDesc.Streams[1], KYC_WatchStatus_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchStatusRequest, StatusUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KYC_WatchStatusClient = grpc.ServerStreamingClient[StatusUpdate]
+
+// KYCServer is the server API for KYC service.
+// All implementations must embed UnimplementedKYCServer
+// for forward compatibility.
+//
+// KYC verifies the customers using the KYC providers.
+type KYCServer interface {
+	// CheckCustomer verifies the customer using the provider or the strategy using several providers.
+	CheckCustomer(context.Context, *CheckCustomerRequest) (*CheckCustomerResponse, error)
+	// CheckStatus checks the current status of the verification from the provider.
+	CheckStatus(context.Context, *CheckStatusRequest) (*CheckStatusResponse, error)
+	// UploadDocument uploads the document file by chunks.
+	// The first message must hold the info of the file, the rest ones hold the chunks of its content.
+	// The returned upload id is referenced by the DocumentFile of the customer until the upload expires.
+	UploadDocument(grpc.ClientStreamingServer[UploadDocumentRequest, UploadDocumentResponse]) error
+	// WatchStatus streams the status changes of the verification.
+	// The latest known result is sent first if the verification is tracked by the service.
+	// The stream ends after the final result.
+	WatchStatus(*WatchStatusRequest, grpc.ServerStreamingServer[StatusUpdate]) error
+	mustEmbedUnimplementedKYCServer()
+}
+
+// UnimplementedKYCServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedKYCServer struct{}
+
+func (UnimplementedKYCServer) CheckCustomer(context.Context, *CheckCustomerRequest) (*CheckCustomerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckCustomer not implemented")
+}
+func (UnimplementedKYCServer) CheckStatus(context.Context, *CheckStatusRequest) (*CheckStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckStatus not implemented")
+}
+func (UnimplementedKYCServer) UploadDocument(grpc.ClientStreamingServer[UploadDocumentRequest, UploadDocumentResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadDocument not implemented")
+}
+func (UnimplementedKYCServer) WatchStatus(*WatchStatusRequest, grpc.ServerStreamingServer[StatusUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchStatus not implemented")
+}
+func (UnimplementedKYCServer) mustEmbedUnimplementedKYCServer() {}
+func (UnimplementedKYCServer) testEmbeddedByValue()             {}
+
+// UnsafeKYCServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to KYCServer will
+// result in compilation errors.
+type UnsafeKYCServer interface {
+	mustEmbedUnimplementedKYCServer()
+}
+
+func RegisterKYCServer(s grpc.ServiceRegistrar, srv KYCServer) {
+	// If the following call pancis, it indicates UnimplementedKYCServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&KYC_ServiceDesc, srv)
+}
+
+func _KYC_CheckCustomer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckCustomerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KYCServer).CheckCustomer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KYC_CheckCustomer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KYCServer).CheckCustomer(ctx, req.(*CheckCustomerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KYC_CheckStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KYCServer).CheckStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KYC_CheckStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KYCServer).CheckStatus(ctx, req.(*CheckStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KYC_UploadDocument_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(KYCServer).UploadDocument(&grpc.GenericServerStream[UploadDocumentRequest, UploadDocumentResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KYC_UploadDocumentServer = grpc.ClientStreamingServer[UploadDocumentRequest, UploadDocumentResponse]
+
+func _KYC_WatchStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KYCServer).WatchStatus(m, &grpc.GenericServerStream[WatchStatusRequest, StatusUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KYC_WatchStatusServer = grpc.ServerStreamingServer[StatusUpdate]
+
+// KYC_ServiceDesc is the grpc.ServiceDesc for KYC service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var KYC_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "kyc.v1.KYC",
+	HandlerType: (*KYCServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CheckCustomer",
+			Handler:    _KYC_CheckCustomer_Handler,
+		},
+		{
+			MethodName: "CheckStatus",
+			Handler:    _KYC_CheckStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadDocument",
+			Handler:       _KYC_UploadDocument_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchStatus",
+			Handler:       _KYC_WatchStatus_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "kyc.proto",
+}
@@ -0,0 +1,432 @@
+// Package grpcapi serves the gRPC API of the service next to the REST API.
+// The API mirrors the CheckCustomer and the CheckStatus endpoints using the protobuf messages from the kycpb package.
+// The document images are sent as raw bytes instead of base64 strings or uploaded beforehand by the client stream.
+// The status changes of the verification are pushed to the clients by the server stream.
+package grpcapi
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative kycpb/kyc.proto
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/auth"
+	"modulus/kyc/main/events"
+	grpcconfig "modulus/kyc/main/grpcapi/config"
+	"modulus/kyc/main/grpcapi/kycpb"
+	"modulus/kyc/main/handlers"
+	"modulus/kyc/main/logging"
+	"modulus/kyc/main/poller"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RetryAfterKey is the trailer holding the wait in seconds before the next request if it exceeded the limits.
+const RetryAfterKey = "retry-after"
+
+// stopTimeout limits the wait for the pending requests on the stop, e.g. the watches of the pending verifications.
+const stopTimeout = 10 * time.Second
+
+// TrackedSource is the source of the latest known result sent first by the WatchStatus.
+const TrackedSource events.Source = "tracked"
+
+// The metadata keys holding the client credentials. They're the lowercase names of the REST API headers.
+var (
+	apiKeyKey        = strings.ToLower(auth.APIKeyHeader)
+	authorizationKey = strings.ToLower(auth.AuthorizationHeader)
+)
+
+// endpoints maps the gRPC methods to the names of the endpoints the clients may be allowed to request.
+// The methods share the endpoint names with their REST counterparts.
+var endpoints = map[string]string{
+	kycpb.KYC_CheckCustomer_FullMethodName:  "CheckCustomer",
+	kycpb.KYC_CheckStatus_FullMethodName:    "CheckStatus",
+	kycpb.KYC_UploadDocument_FullMethodName: "CheckCustomer",
+	kycpb.KYC_WatchStatus_FullMethodName:    "Status",
+}
+
+// httpCodes maps the HTTP statuses of the request errors to the gRPC codes.
+var httpCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusUnprocessableEntity: codes.FailedPrecondition,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+}
+
+// Server implements the KYC gRPC service.
+type Server struct {
+	kycpb.UnimplementedKYCServer
+
+	config  grpcconfig.Config
+	uploads *uploads
+}
+
+// New constructs the Server using the config.
+func New(config grpcconfig.Config) *Server {
+	subscribe.Do(func() {
+		events.Subscribe(watchers.handleResult)
+	})
+
+	return &Server{
+		config:  config,
+		uploads: newUploads(config),
+	}
+}
+
+// GRPCServer returns the gRPC server serving the s with the authentication of the clients.
+func (s *Server) GRPCServer(opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts, grpc.UnaryInterceptor(unaryAuth), grpc.StreamInterceptor(streamAuth))
+
+	server := grpc.NewServer(opts...)
+	kycpb.RegisterKYCServer(server, s)
+
+	return server
+}
+
+// CheckCustomer implements the KYC service.
+func (s *Server) CheckCustomer(ctx context.Context, req *kycpb.CheckCustomerRequest) (*kycpb.CheckCustomerResponse, error) {
+	if req.Customer == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing customer data in the request")
+	}
+
+	customer, err := userDataFromProto(req.Customer, s.uploads, auth.ClientID(ctx))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	check, err := handlers.CheckCustomerContext(ctx, common.CheckCustomerRequest{
+		Provider:        providerFromProto(req.Provider),
+		Strategy:        strategyFromProto(req.Strategy),
+		UserData:        customer,
+		NotificationURL: req.NotificationUrl,
+	})
+	if err != nil {
+		return nil, requestError(ctx, err)
+	}
+
+	response := &kycpb.CheckCustomerResponse{
+		Result:          resultToProto(check.Result),
+		VerificationIds: map[string]string{},
+	}
+	if check.Err != nil {
+		response.Error = check.Err.Error()
+	}
+	for provider, id := range check.IDs {
+		if len(id) > 0 {
+			response.VerificationIds[string(provider)] = id
+		}
+	}
+
+	return response, nil
+}
+
+// CheckStatus implements the KYC service.
+func (s *Server) CheckStatus(ctx context.Context, req *kycpb.CheckStatusRequest) (*kycpb.CheckStatusResponse, error) {
+	check, err := handlers.CheckStatusContext(ctx, common.CheckStatusRequest{
+		Provider:    providerFromProto(req.Provider),
+		ReferenceID: req.ReferenceId,
+	})
+	if err != nil {
+		return nil, requestError(ctx, err)
+	}
+
+	response := &kycpb.CheckStatusResponse{
+		Result: resultToProto(check.Result),
+	}
+	if check.Err != nil {
+		response.Error = check.Err.Error()
+	}
+
+	return response, nil
+}
+
+// UploadDocument implements the KYC service.
+func (s *Server) UploadDocument(stream kycpb.KYC_UploadDocumentServer) error {
+	req, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "missing document info")
+	}
+	if err != nil {
+		return err
+	}
+
+	info := req.GetInfo()
+	if info == nil {
+		return status.Error(codes.InvalidArgument, "the first message must hold the document info")
+	}
+
+	data := &bytes.Buffer{}
+	for {
+		req, err = stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if req.GetInfo() != nil {
+			return status.Error(codes.InvalidArgument, "duplicate document info")
+		}
+
+		chunk := req.GetChunk()
+		if data.Len()+len(chunk) > s.config.MaxUploadSize {
+			return status.Errorf(codes.ResourceExhausted, "the document exceeds the maximum size of %d bytes", s.config.MaxUploadSize)
+		}
+		data.Write(chunk)
+	}
+	if data.Len() == 0 {
+		return status.Error(codes.InvalidArgument, "empty document")
+	}
+
+	id, expires, err := s.uploads.add(auth.ClientID(stream.Context()), common.DocumentFile{
+		Filename:    info.Filename,
+		ContentType: info.ContentType,
+		Data:        data.Bytes(),
+	})
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	return stream.SendAndClose(&kycpb.UploadDocumentResponse{
+		UploadId: id,
+		Size:     int64(data.Len()),
+		Expires:  timestamppb.New(expires),
+	})
+}
+
+// WatchStatus implements the KYC service.
+func (s *Server) WatchStatus(req *kycpb.WatchStatusRequest, stream kycpb.KYC_WatchStatusServer) error {
+	ctx := stream.Context()
+
+	provider := providerFromProto(req.Provider)
+	if len(provider) == 0 {
+		return status.Error(codes.InvalidArgument, "missing KYC provider id in the request")
+	}
+	if len(req.ReferenceId) == 0 {
+		return status.Error(codes.InvalidArgument, "missing verification id in the request")
+	}
+	if err := auth.AuthorizeProvider(ctx, provider); err != nil {
+		return requestError(ctx, err)
+	}
+
+	// The watch starts before the lookup, so the updates following the latest known result aren't missed.
+	updates, cancel := watchers.watch(provider, req.ReferenceId)
+	defer cancel()
+
+	if tracked, ok := poller.Lookup(provider, req.ReferenceId); ok {
+		if tracked.Result != nil {
+			update := events.Result{
+				Provider:    provider,
+				ReferenceID: req.ReferenceId,
+				Result:      *tracked.Result,
+				Source:      TrackedSource,
+				Time:        tracked.Updated,
+			}
+			if err := stream.Send(updateToProto(update)); err != nil {
+				return err
+			}
+		}
+		if tracked.Final || tracked.Expired {
+			return nil
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case update := <-updates:
+			if err := stream.Send(updateToProto(update)); err != nil {
+				return err
+			}
+			if update.Result.IsFinal() {
+				return nil
+			}
+		}
+	}
+}
+
+// requestError returns the gRPC status error for the error of the request.
+// The wait before the next request is set to the trailer if the request exceeded the limits.
+func requestError(ctx context.Context, err error) error {
+	code := codes.Internal
+
+	switch e := err.(type) {
+	case handlers.RequestError:
+		if c, ok := httpCodes[e.HTTPStatus()]; ok {
+			code = c
+		}
+		if wait := e.RetryAfter(); wait > 0 {
+			seconds := int((wait + time.Second - 1) / time.Second)
+			grpc.SetTrailer(ctx, metadata.Pairs(RetryAfterKey, strconv.Itoa(seconds)))
+		}
+	case auth.Error:
+		if c, ok := httpCodes[e.Status]; ok {
+			code = c
+		}
+	}
+
+	return status.Error(code, err.Error())
+}
+
+// authorize authenticates the client of the gRPC method using the credentials from the metadata of the context.
+func authorize(ctx context.Context, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	first := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	ctx, err := auth.Authorize(ctx, endpoints[method], first(apiKeyKey), first(authorizationKey))
+	if err != nil {
+		return ctx, requestError(ctx, err)
+	}
+
+	return ctx, nil
+}
+
+// unaryAuth is the interceptor authenticating the clients of the unary methods.
+func unaryAuth(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// streamAuth is the interceptor authenticating the clients of the streaming methods.
+func streamAuth(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := authorize(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, authStream{ServerStream: stream, ctx: ctx})
+}
+
+// authStream is the server stream holding the context with the authenticated client.
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the stream.
+func (s authStream) Context() context.Context {
+	return s.ctx
+}
+
+var (
+	mu          sync.Mutex
+	current     *grpc.Server
+	currentCert *certificate
+	stopSweep   context.CancelFunc
+)
+
+// Start starts serving the gRPC API on the port from the config in the background.
+// The gRPC API terminates TLS if the certificate is configured.
+// Nothing is started if the gRPC API is disabled by the config.
+func Start(config grpcconfig.Config) error {
+	if !config.Enabled() {
+		return nil
+	}
+
+	opts := []grpc.ServerOption{}
+
+	var cert *certificate
+	if config.TLS() {
+		var err error
+		if cert, err = newCertificate(config.TLSCert, config.TLSKey); err != nil {
+			return err
+		}
+		opts = append(opts, cert.creds())
+	}
+
+	listener, err := net.Listen("tcp", ":"+config.Port)
+	if err != nil {
+		return err
+	}
+
+	s := New(config)
+	server := s.GRPCServer(opts...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go s.uploads.sweep(ctx)
+
+	mu.Lock()
+	current = server
+	currentCert = cert
+	stopSweep = cancel
+	mu.Unlock()
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			slog.Error("gRPC server error", logging.ErrorKey, err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops the gRPC API gracefully waiting for the pending requests to complete.
+// The requests still pending after the stop timeout are cancelled.
+func Stop() {
+	mu.Lock()
+	server := current
+	current = nil
+	currentCert = nil
+	cancel := stopSweep
+	stopSweep = nil
+	mu.Unlock()
+
+	if server == nil {
+		return
+	}
+
+	cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(stopTimeout):
+		server.Stop()
+	}
+}
+
+// ReloadCertificate reloads the certificate of the gRPC API if it terminates TLS.
+// The previous certificate is kept if the files can't be loaded.
+func ReloadCertificate() error {
+	mu.Lock()
+	cert := currentCert
+	mu.Unlock()
+
+	if cert == nil {
+		return nil
+	}
+
+	return cert.reload()
+}
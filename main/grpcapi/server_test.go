@@ -0,0 +1,598 @@
+package grpcapi
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/auth"
+	"modulus/kyc/main/events"
+	grpcconfig "modulus/kyc/main/grpcapi/config"
+	"modulus/kyc/main/grpcapi/kycpb"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testServer starts the gRPC server serving the s in memory and returns the client connected to it.
+func testServer(t *testing.T, s *Server) kycpb.KYCClient {
+	listener := bufconn.Listen(1 << 20)
+	server := s.GRPCServer()
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return kycpb.NewKYCClient(conn)
+}
+
+// testConfig is the config of the gRPC API in tests.
+var testConfig = grpcconfig.Config{
+	UploadTTL:            time.Minute,
+	MaxUploadSize:        16,
+	MaxUploadsSize:       64,
+	MaxClientUploadsSize: 32,
+}
+
+func TestCheckCustomer(t *testing.T) {
+	assert := assert.New(t)
+
+	client := testServer(t, New(testConfig))
+	ctx := context.Background()
+
+	// Testing the approved customer.
+	resp, err := client.CheckCustomer(ctx, &kycpb.CheckCustomerRequest{
+		Provider: kycpb.Provider_EXAMPLE,
+		Customer: &kycpb.UserData{FirstName: "Abby"},
+	})
+
+	if assert.NoError(err) {
+		assert.Equal(kycpb.Status_APPROVED, resp.Result.Status)
+		assert.Empty(resp.Error)
+	}
+
+	// Testing the pending verification.
+	resp, err = client.CheckCustomer(ctx, &kycpb.CheckCustomerRequest{
+		Provider: kycpb.Provider_EXAMPLE,
+		Customer: &kycpb.UserData{FirstName: "Urbi"},
+	})
+
+	if assert.NoError(err) {
+		assert.Equal(kycpb.Status_UNCLEAR, resp.Result.Status)
+		if assert.NotNil(resp.Result.StatusCheck) {
+			assert.Equal(kycpb.Provider_EXAMPLE, resp.Result.StatusCheck.Provider)
+			assert.Equal("lily_was_here", resp.Result.StatusCheck.ReferenceId)
+		}
+	}
+
+	// Testing the provider error.
+	resp, err = client.CheckCustomer(ctx, &kycpb.CheckCustomerRequest{
+		Provider: kycpb.Provider_EXAMPLE,
+		Customer: &kycpb.UserData{FirstName: "Erika"},
+	})
+
+	if assert.NoError(err) {
+		assert.Equal(kycpb.Status_ERROR, resp.Result.Status)
+		assert.Equal("429", resp.Result.ErrorCode)
+		assert.NotEmpty(resp.Error)
+	}
+
+	// Testing the strategy.
+	resp, err = client.CheckCustomer(ctx, &kycpb.CheckCustomerRequest{
+		Strategy: &kycpb.Strategy{Mode: kycpb.StrategyMode_FALLBACK, Providers: []kycpb.Provider{kycpb.Provider_EXAMPLE}},
+		Customer: &kycpb.UserData{FirstName: "Delilah"},
+	})
+
+	if assert.NoError(err) && assert.Len(resp.Result.Providers, 1) {
+		assert.Equal(kycpb.Status_DENIED, resp.Result.Status)
+		assert.Equal(kycpb.Provider_EXAMPLE, resp.Result.Providers[0].Provider)
+	}
+
+	// Testing the invalid requests.
+	testCases := []struct {
+		req  *kycpb.CheckCustomerRequest
+		code codes.Code
+		msg  string
+	}{
+		{&kycpb.CheckCustomerRequest{Provider: kycpb.Provider_EXAMPLE}, codes.InvalidArgument, "missing customer data in the request"},
+		{&kycpb.CheckCustomerRequest{Customer: &kycpb.UserData{}}, codes.InvalidArgument, "missing KYC provider id in the request"},
+		{&kycpb.CheckCustomerRequest{Strategy: &kycpb.Strategy{}, Customer: &kycpb.UserData{}}, codes.InvalidArgument, "missing strategy mode"},
+		{&kycpb.CheckCustomerRequest{
+			Provider: kycpb.Provider_EXAMPLE,
+			Customer: &kycpb.UserData{Selfie: &kycpb.ImageDocument{Image: &kycpb.DocumentFile{Content: &kycpb.DocumentFile_UploadId{UploadId: "unknown"}}}},
+		}, codes.InvalidArgument, "unknown or expired upload id: unknown"},
+	}
+
+	for _, tc := range testCases {
+		_, err := client.CheckCustomer(ctx, tc.req)
+
+		assert.Equal(tc.code, status.Code(err), tc.msg)
+		assert.Equal(tc.msg, status.Convert(err).Message())
+	}
+}
+
+func TestCheckStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	client := testServer(t, New(testConfig))
+	ctx := context.Background()
+
+	resp, err := client.CheckStatus(ctx, &kycpb.CheckStatusRequest{Provider: kycpb.Provider_EXAMPLE, ReferenceId: "uma"})
+
+	if assert.NoError(err) {
+		assert.Equal(kycpb.Status_UNCLEAR, resp.Result.Status)
+		if assert.NotNil(resp.Result.StatusCheck) {
+			assert.Equal("uma", resp.Result.StatusCheck.ReferenceId)
+		}
+	}
+
+	// Testing the provider error.
+	resp, err = client.CheckStatus(ctx, &kycpb.CheckStatusRequest{Provider: kycpb.Provider_EXAMPLE, ReferenceId: "elin"})
+
+	if assert.NoError(err) {
+		assert.Equal("401", resp.Result.ErrorCode)
+		assert.NotEmpty(resp.Error)
+	}
+
+	// Testing the invalid request.
+	_, err = client.CheckStatus(ctx, &kycpb.CheckStatusRequest{Provider: kycpb.Provider_EXAMPLE})
+
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+	assert.Equal("missing verification id in the request", status.Convert(err).Message())
+}
+
+// uploadDocument uploads the chunks of the document.
+// The error of the upload rejected by the server is returned on the close of the stream.
+func uploadDocument(ctx context.Context, client kycpb.KYCClient, info *kycpb.DocumentInfo, chunks ...string) (*kycpb.UploadDocumentResponse, error) {
+	stream, err := client.UploadDocument(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if info != nil {
+		if err := stream.Send(&kycpb.UploadDocumentRequest{Payload: &kycpb.UploadDocumentRequest_Info{Info: info}}); err != nil {
+			return nil, err
+		}
+	}
+	for _, chunk := range chunks {
+		err := stream.Send(&kycpb.UploadDocumentRequest{Payload: &kycpb.UploadDocumentRequest_Chunk{Chunk: []byte(chunk)}})
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return stream.CloseAndRecv()
+}
+
+func TestUploadDocument(t *testing.T) {
+	assert := assert.New(t)
+
+	s := New(testConfig)
+	client := testServer(t, s)
+	ctx := context.Background()
+
+	resp, err := uploadDocument(ctx, client, &kycpb.DocumentInfo{Filename: "selfie.png", ContentType: "image/png"}, "PNG", "data")
+	if !assert.NoError(err) {
+		return
+	}
+
+	assert.NotEmpty(resp.UploadId)
+	assert.Equal(int64(7), resp.Size)
+	assert.WithinDuration(time.Now().Add(time.Minute), resp.Expires.AsTime(), 10*time.Second)
+
+	// Testing the uploaded document referenced by the customer data.
+	customer, err := userDataFromProto(&kycpb.UserData{
+		Selfie: &kycpb.ImageDocument{Image: &kycpb.DocumentFile{Content: &kycpb.DocumentFile_UploadId{UploadId: resp.UploadId}}},
+		Passport: &kycpb.Passport{
+			Number: "123",
+			Image:  &kycpb.DocumentFile{Filename: "passport.jpg", Content: &kycpb.DocumentFile_UploadId{UploadId: resp.UploadId}},
+		},
+		IdCard: &kycpb.IDCard{Image: &kycpb.DocumentFile{Filename: "id.jpg", ContentType: "image/jpeg", Content: &kycpb.DocumentFile_Data{Data: []byte("JPG")}}},
+	}, s.uploads, "")
+
+	if assert.NoError(err) {
+		assert.Equal(&common.DocumentFile{Filename: "selfie.png", ContentType: "image/png", Data: []byte("PNGdata")}, customer.Selfie.Image)
+		assert.Equal(&common.DocumentFile{Filename: "passport.jpg", ContentType: "image/png", Data: []byte("PNGdata")}, customer.Passport.Image)
+		assert.Equal(&common.DocumentFile{Filename: "id.jpg", ContentType: "image/jpeg", Data: []byte("JPG")}, customer.IDCard.Image)
+	}
+
+	// Testing the document uploaded by another client.
+	_, err = userDataFromProto(&kycpb.UserData{
+		Selfie: &kycpb.ImageDocument{Image: &kycpb.DocumentFile{Content: &kycpb.DocumentFile_UploadId{UploadId: resp.UploadId}}},
+	}, s.uploads, "mobile")
+
+	assert.Error(err)
+
+	// Testing the invalid uploads.
+	testCases := []struct {
+		info   *kycpb.DocumentInfo
+		chunks []string
+		code   codes.Code
+		msg    string
+	}{
+		{nil, nil, codes.InvalidArgument, "missing document info"},
+		{nil, []string{"data"}, codes.InvalidArgument, "the first message must hold the document info"},
+		{&kycpb.DocumentInfo{}, nil, codes.InvalidArgument, "empty document"},
+		{&kycpb.DocumentInfo{}, []string{"0123456789", "0123456789"}, codes.ResourceExhausted, "the document exceeds the maximum size of 16 bytes"},
+	}
+
+	for _, tc := range testCases {
+		_, err := uploadDocument(ctx, client, tc.info, tc.chunks...)
+
+		assert.Equal(tc.code, status.Code(err), tc.msg)
+		assert.Equal(tc.msg, status.Convert(err).Message())
+	}
+}
+
+func TestUploadsExpire(t *testing.T) {
+	assert := assert.New(t)
+
+	u := newUploads(grpcconfig.Config{UploadTTL: time.Millisecond, MaxUploadsSize: 64, MaxClientUploadsSize: 64})
+	id, _, err := u.add("", common.DocumentFile{Data: []byte("data")})
+
+	assert.NoError(err)
+
+	time.Sleep(5 * time.Millisecond)
+
+	_, err = u.get("", id)
+
+	assert.Error(err)
+
+	// Testing the expired documents are removed on the next upload.
+	u.add("", common.DocumentFile{})
+
+	assert.Len(u.files, 1)
+	assert.Zero(u.size)
+
+	// Testing the expired documents are removed by the sweep.
+	u.add("", common.DocumentFile{Data: []byte("data")})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go u.sweep(ctx)
+
+	assert.Eventually(func() bool {
+		u.mu.Lock()
+		defer u.mu.Unlock()
+
+		return len(u.files) == 0 && u.size == 0 && len(u.clientSizes) == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestUploadsBudget(t *testing.T) {
+	assert := assert.New(t)
+
+	client := testServer(t, New(testConfig))
+
+	withKey := func(key string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", key)
+	}
+
+	config, err := auth.ConfigFromOptions(nil, map[string]map[string]string{
+		"backoffice": {"APIKey": "key1"},
+		"mobile":     {"APIKey": "key2"},
+		"partner":    {"APIKey": "key3"},
+	})
+	if !assert.NoError(err) {
+		return
+	}
+
+	auth.Setup(config)
+	defer auth.Setup(auth.Config{})
+
+	info := &kycpb.DocumentInfo{Filename: "selfie.png"}
+	document := "0123456789abcdef"
+
+	// Testing the budget of the client.
+	for i := 0; i < 2; i++ {
+		_, err = uploadDocument(withKey("key1"), client, info, document)
+
+		assert.NoError(err)
+	}
+
+	_, err = uploadDocument(withKey("key1"), client, info, document)
+
+	assert.Equal(codes.ResourceExhausted, status.Code(err))
+	assert.Equal(errClientUploadsFull.Error(), status.Convert(err).Message())
+
+	// Testing the total budget.
+	for i := 0; i < 2; i++ {
+		_, err = uploadDocument(withKey("key2"), client, info, document)
+
+		assert.NoError(err)
+	}
+
+	_, err = uploadDocument(withKey("key3"), client, info, document)
+
+	assert.Equal(codes.ResourceExhausted, status.Code(err))
+	assert.Equal(errUploadsFull.Error(), status.Convert(err).Message())
+}
+
+// waitWatched waits until the verification is watched or isn't watched anymore.
+func waitWatched(provider common.KYCProvider, referenceID string, watched bool) bool {
+	for i := 0; i < 100; i++ {
+		watchers.mu.Lock()
+		n := len(watchers.watchers[watchKey{provider: provider, referenceID: referenceID}])
+		watchers.mu.Unlock()
+		if (n > 0) == watched {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	return false
+}
+
+func TestWatchStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	client := testServer(t, New(testConfig))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.WatchStatus(ctx, &kycpb.WatchStatusRequest{Provider: kycpb.Provider_JUMIO, ReferenceId: "watched"})
+	if !assert.NoError(err) || !assert.True(waitWatched(common.Jumio, "watched", true)) {
+		return
+	}
+
+	pending := common.KYCResult{
+		Status:      common.Unclear,
+		StatusCheck: &common.KYCStatusCheck{Provider: common.Jumio, ReferenceID: "watched"},
+	}
+
+	events.Publish(events.Result{Provider: common.Jumio, ReferenceID: "other", Result: common.KYCResult{Status: common.Denied}, Source: events.Callback})
+	events.Publish(events.Result{Provider: common.Jumio, ReferenceID: "watched", Result: pending, Source: events.Polling})
+	events.Publish(events.Result{Provider: common.Jumio, ReferenceID: "watched", Result: common.KYCResult{Status: common.Approved}, Source: events.Callback})
+
+	update, err := stream.Recv()
+	if assert.NoError(err) {
+		assert.Equal(kycpb.Provider_JUMIO, update.Provider)
+		assert.Equal("watched", update.ReferenceId)
+		assert.Equal(kycpb.Status_UNCLEAR, update.Result.Status)
+		assert.Equal("polling", update.Source)
+		assert.False(update.Time.AsTime().IsZero())
+	}
+
+	update, err = stream.Recv()
+	if assert.NoError(err) {
+		assert.Equal(kycpb.Status_APPROVED, update.Result.Status)
+		assert.Equal("callback", update.Source)
+	}
+
+	// Testing the stream ends after the final result.
+	_, err = stream.Recv()
+
+	assert.Equal(io.EOF, err)
+	assert.True(waitWatched(common.Jumio, "watched", false))
+
+	// Testing the invalid request.
+	stream, err = client.WatchStatus(ctx, &kycpb.WatchStatusRequest{Provider: kycpb.Provider_JUMIO})
+	if assert.NoError(err) {
+		_, err = stream.Recv()
+
+		assert.Equal(codes.InvalidArgument, status.Code(err))
+	}
+}
+
+func TestWatchersDropOldest(t *testing.T) {
+	assert := assert.New(t)
+
+	updates, cancel := watchers.watch(common.Jumio, "slow")
+	defer cancel()
+
+	for i := 0; i <= watchBuffer; i++ {
+		watchers.handleResult(events.Result{Provider: common.Jumio, ReferenceID: "slow", Result: common.KYCResult{ErrorCode: string(rune('a' + i))}})
+	}
+
+	assert.Len(updates, watchBuffer)
+	assert.Equal("b", (<-updates).Result.ErrorCode)
+}
+
+func TestAuthentication(t *testing.T) {
+	assert := assert.New(t)
+
+	config, err := auth.ConfigFromOptions(nil, map[string]map[string]string{
+		"backoffice": {"APIKey": "key1", "Endpoints": "CheckCustomer"},
+		"mobile":     {"APIKey": "key2", "Endpoints": "CheckStatus", "Providers": "IDology"},
+	})
+	if !assert.NoError(err) {
+		return
+	}
+
+	auth.Setup(config)
+	defer auth.Setup(auth.Config{})
+
+	client := testServer(t, New(testConfig))
+
+	withKey := func(key string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", key)
+	}
+
+	customer := &kycpb.CheckCustomerRequest{Provider: kycpb.Provider_EXAMPLE, Customer: &kycpb.UserData{FirstName: "Abby"}}
+
+	// Testing the authenticated client.
+	_, err = client.CheckCustomer(withKey("key1"), customer)
+
+	assert.NoError(err)
+
+	// Testing the document uploaded by the client is available to it only.
+	resp, err := uploadDocument(withKey("key1"), client, &kycpb.DocumentInfo{Filename: "selfie.png"}, "PNG")
+	if assert.NoError(err) {
+		customer.Customer.Selfie = &kycpb.ImageDocument{Image: &kycpb.DocumentFile{Content: &kycpb.DocumentFile_UploadId{UploadId: resp.UploadId}}}
+
+		_, err = client.CheckCustomer(withKey("key1"), customer)
+
+		assert.NoError(err)
+
+		customer.Customer.Selfie = nil
+	}
+
+	// Testing the missing credentials.
+	_, err = client.CheckCustomer(context.Background(), customer)
+
+	assert.Equal(codes.Unauthenticated, status.Code(err))
+	assert.Equal("missing API key or token in the request", status.Convert(err).Message())
+
+	// Testing the invalid API key.
+	_, err = client.CheckStatus(withKey("key3"), &kycpb.CheckStatusRequest{Provider: kycpb.Provider_EXAMPLE, ReferenceId: "uma"})
+
+	assert.Equal(codes.Unauthenticated, status.Code(err))
+
+	// Testing the disallowed endpoint.
+	_, err = client.CheckCustomer(withKey("key2"), customer)
+
+	assert.Equal(codes.PermissionDenied, status.Code(err))
+	assert.Equal("client mobile isn't allowed to request CheckCustomer", status.Convert(err).Message())
+
+	// Testing the disallowed provider.
+	_, err = client.CheckStatus(withKey("key2"), &kycpb.CheckStatusRequest{Provider: kycpb.Provider_EXAMPLE, ReferenceId: "uma"})
+
+	assert.Equal(codes.PermissionDenied, status.Code(err))
+	assert.Equal("client mobile isn't allowed to use Example", status.Convert(err).Message())
+
+	// Testing the streaming method of the disallowed endpoint.
+	stream, err := client.WatchStatus(withKey("key1"), &kycpb.WatchStatusRequest{Provider: kycpb.Provider_EXAMPLE, ReferenceId: "uma"})
+	if assert.NoError(err) {
+		_, err = stream.Recv()
+
+		assert.Equal(codes.PermissionDenied, status.Code(err))
+		assert.Equal("client backoffice isn't allowed to request Status", status.Convert(err).Message())
+	}
+}
+
+// writeCertificate writes the self-signed certificate for the common name and its key to the files.
+func writeCertificate(t *testing.T, certFile, keyFile, commonName string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+}
+
+func TestTLS(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "tls")
+	if !assert.NoError(err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+
+	// Testing the missing certificate.
+	_, err = newCertificate(certFile, keyFile)
+
+	assert.Error(err)
+
+	// Testing the gRPC API terminating TLS.
+	writeCertificate(t, certFile, keyFile, "kyc-1")
+
+	cert, err := newCertificate(certFile, keyFile)
+	if !assert.NoError(err) {
+		return
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(err) {
+		return
+	}
+	server := New(testConfig).GRPCServer(cert.creds())
+
+	go server.Serve(listener)
+	defer server.Stop()
+
+	addr := listener.Addr().String()
+
+	commonName := func() string {
+		conn, err := tls.Dial("tcp", addr, &tls.Config{InsecureSkipVerify: true, NextProtos: []string{"h2"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+
+		return conn.ConnectionState().PeerCertificates[0].Subject.CommonName
+	}
+
+	assert.Equal("kyc-1", commonName())
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})))
+	if !assert.NoError(err) {
+		return
+	}
+	defer conn.Close()
+
+	resp, err := kycpb.NewKYCClient(conn).CheckCustomer(context.Background(), &kycpb.CheckCustomerRequest{
+		Provider: kycpb.Provider_EXAMPLE,
+		Customer: &kycpb.UserData{FirstName: "Abby"},
+	})
+	if assert.NoError(err) {
+		assert.Equal(kycpb.Status_APPROVED, resp.Result.Status)
+	}
+
+	// Testing the reloaded certificate.
+	writeCertificate(t, certFile, keyFile, "kyc-2")
+
+	assert.NoError(cert.reload())
+	assert.Equal("kyc-2", commonName())
+
+	// Testing the previous certificate is kept if the files are broken.
+	ioutil.WriteFile(keyFile, []byte("broken"), 0600)
+
+	assert.Error(cert.reload())
+	assert.Equal("kyc-2", commonName())
+
+	// Testing the gRPC API without TLS doesn't reload the certificate.
+	assert.NoError(ReloadCertificate())
+}
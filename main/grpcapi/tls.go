@@ -0,0 +1,57 @@
+package grpcapi
+
+import (
+	"crypto/tls"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// certificate holds the TLS certificate of the gRPC API loaded from its files.
+// The certificate is reloaded without the restart, e.g. when it's renewed.
+type certificate struct {
+	certFile string
+	keyFile  string
+	cert     atomic.Pointer[tls.Certificate]
+}
+
+// newCertificate loads the certificate and the key from their files.
+func newCertificate(certFile, keyFile string) (c *certificate, err error) {
+	c = &certificate{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+
+	if err = c.reload(); err != nil {
+		c = nil
+	}
+
+	return
+}
+
+// reload loads the certificate and the key from their files.
+// The previous certificate is kept if the files can't be loaded.
+func (c *certificate) reload() error {
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return err
+	}
+
+	c.cert.Store(&cert)
+
+	return nil
+}
+
+// get returns the current certificate for the TLS handshakes.
+func (c *certificate) get(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return c.cert.Load(), nil
+}
+
+// creds returns the server option terminating TLS with the current certificate.
+func (c *certificate) creds() grpc.ServerOption {
+	return grpc.Creds(credentials.NewTLS(&tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: c.get,
+	}))
+}
@@ -0,0 +1,146 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"modulus/kyc/common"
+	grpcconfig "modulus/kyc/main/grpcapi/config"
+
+	"github.com/google/uuid"
+)
+
+// maxSweepInterval limits the interval of removing the expired documents.
+const maxSweepInterval = time.Minute
+
+// The errors of the uploads exceeding the byte budgets.
+var (
+	errUploadsFull       = errors.New("too many uploaded documents, try again later")
+	errClientUploadsFull = errors.New("the client has too many uploaded documents, try again after they're used or expire")
+)
+
+// upload represents the uploaded document kept until it expires.
+// The document may be referenced only by the client uploaded it.
+type upload struct {
+	clientID string
+	file     common.DocumentFile
+	expires  time.Time
+}
+
+// uploads keeps the uploaded documents by their ids.
+// The total size of the documents and the size of the documents of every client are limited.
+type uploads struct {
+	mu            sync.Mutex
+	ttl           time.Duration
+	maxSize       int
+	maxClientSize int
+	files         map[string]upload
+	size          int
+	clientSizes   map[string]int
+}
+
+// newUploads constructs the uploads keeping the documents for the upload TTL from the config
+// within the byte budgets from the config.
+func newUploads(config grpcconfig.Config) *uploads {
+	return &uploads{
+		ttl:           config.UploadTTL,
+		maxSize:       config.MaxUploadsSize,
+		maxClientSize: config.MaxClientUploadsSize,
+		files:         map[string]upload{},
+		clientSizes:   map[string]int{},
+	}
+}
+
+// add keeps the document uploaded by the client and returns its id and the time it expires.
+// The expired documents are removed. The error is returned if the document exceeds the byte budgets.
+func (u *uploads) add(clientID string, file common.DocumentFile) (id string, expires time.Time, err error) {
+	now := time.Now()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.removeExpired(now)
+
+	size := len(file.Data)
+	switch {
+	case u.size+size > u.maxSize:
+		err = errUploadsFull
+		return
+	case u.clientSizes[clientID]+size > u.maxClientSize:
+		err = errClientUploadsFull
+		return
+	}
+
+	id = uuid.New().String()
+	expires = now.Add(u.ttl)
+
+	u.files[id] = upload{
+		clientID: clientID,
+		file:     file,
+		expires:  expires,
+	}
+	u.size += size
+	u.clientSizes[clientID] += size
+
+	return
+}
+
+// get returns the document uploaded by the client by its id.
+func (u *uploads) get(clientID, id string) (file common.DocumentFile, err error) {
+	u.mu.Lock()
+	f, ok := u.files[id]
+	u.mu.Unlock()
+
+	if !ok || f.clientID != clientID || time.Now().After(f.expires) {
+		err = fmt.Errorf("unknown or expired upload id: %s", id)
+		return
+	}
+
+	file = f.file
+
+	return
+}
+
+// sweep removes the expired documents periodically until the context is done.
+func (u *uploads) sweep(ctx context.Context) {
+	interval := u.ttl
+	if interval > maxSweepInterval {
+		interval = maxSweepInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			u.mu.Lock()
+			u.removeExpired(now)
+			u.mu.Unlock()
+		}
+	}
+}
+
+// removeExpired removes the documents expired by the time and releases their sizes.
+// It must be called with the lock held.
+func (u *uploads) removeExpired(now time.Time) {
+	for id, f := range u.files {
+		if !now.After(f.expires) {
+			continue
+		}
+
+		delete(u.files, id)
+
+		size := len(f.file.Data)
+		u.size -= size
+		u.clientSizes[f.clientID] -= size
+		if u.clientSizes[f.clientID] <= 0 {
+			delete(u.clientSizes, f.clientID)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package grpcapi
+
+import (
+	"sync"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/events"
+)
+
+// watchBuffer is the number of the updates buffered for the watcher.
+// The oldest update is dropped if the watcher doesn't keep up.
+const watchBuffer = 16
+
+// watchKey identifies the watched verification.
+type watchKey struct {
+	provider    common.KYCProvider
+	referenceID string
+}
+
+// watchHub delivers the result updates to the watchers of the verifications.
+type watchHub struct {
+	mu       sync.Mutex
+	watchers map[watchKey]map[chan events.Result]struct{}
+}
+
+var (
+	// watchers is the hub of the WatchStatus streams subscribed to the result updates.
+	watchers = &watchHub{watchers: map[watchKey]map[chan events.Result]struct{}{}}
+	// subscribe subscribes the hub to the result updates once.
+	subscribe sync.Once
+)
+
+// watch starts the watch of the verification.
+// The cancel must be called to stop the watch.
+func (h *watchHub) watch(provider common.KYCProvider, referenceID string) (updates <-chan events.Result, cancel func()) {
+	k := watchKey{provider: provider, referenceID: referenceID}
+	ch := make(chan events.Result, watchBuffer)
+
+	h.mu.Lock()
+	if h.watchers[k] == nil {
+		h.watchers[k] = map[chan events.Result]struct{}{}
+	}
+	h.watchers[k][ch] = struct{}{}
+	h.mu.Unlock()
+
+	cancel = func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		delete(h.watchers[k], ch)
+		if len(h.watchers[k]) == 0 {
+			delete(h.watchers, k)
+		}
+	}
+
+	return ch, cancel
+}
+
+// handleResult delivers the result update to the watchers of the verification without blocking.
+func (h *watchHub) handleResult(update events.Result) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.watchers[watchKey{provider: update.Provider, referenceID: update.ReferenceID}] {
+		for {
+			select {
+			case ch <- update:
+			default:
+				select {
+				case <-ch:
+				default:
+				}
+				continue
+			}
+			break
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/config"
+)
+
+// AdminConfigPath is the path of the AdminConfig endpoint.
+const AdminConfigPath = "/Admin/Config"
+
+// AdminConfig handles requests for the state of the service config.
+// It reports the version of the active config snapshot and the error of the latest reload if it's rejected.
+func AdminConfig(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	snapshot := config.Current()
+
+	response := common.ConfigResponse{
+		Version:  snapshot.Version,
+		Source:   snapshot.Source,
+		Hash:     snapshot.Hash,
+		Loaded:   snapshot.Loaded,
+		Sections: []string{},
+	}
+	for name := range snapshot.Config {
+		response.Sections = append(response.Sections, name)
+	}
+	sort.Strings(response.Sections)
+
+	if reload := config.LastReload(); !reload.Time.IsZero() {
+		response.LastReload = &reload.Time
+		response.LastError = reload.Error
+	}
+
+	resp, err := json.Marshal(response)
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Write(resp)
+}
@@ -0,0 +1,71 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/config"
+	"modulus/kyc/main/handlers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	previous := config.Get()
+	defer config.Set(previous)
+
+	tmpfile, err := ioutil.TempFile("", "kyc")
+	if !assert.NoError(err) {
+		return
+	}
+	defer os.Remove(tmpfile.Name())
+
+	tmpfile.WriteString("[Config]\nPort=8080\n")
+	tmpfile.Close()
+
+	serve := func() (response common.ConfigResponse) {
+		req := httptest.NewRequest(http.MethodGet, handlers.AdminConfigPath, nil)
+		w := httptest.NewRecorder()
+
+		handlers.AdminConfig(w, req)
+
+		assert.Equal(http.StatusOK, w.Code)
+		assert.Equal("application/json; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+
+		return
+	}
+
+	// Testing the loaded config.
+	snapshot, _, err := config.Reload(tmpfile.Name())
+
+	assert.NoError(err)
+
+	response := serve()
+
+	assert.Equal(snapshot.Version, response.Version)
+	assert.Equal(tmpfile.Name(), response.Source)
+	assert.Len(response.Hash, 64)
+	assert.Equal([]string{config.ServiceSection}, response.Sections)
+	assert.NotNil(response.LastReload)
+	assert.Empty(response.LastError)
+
+	// Testing the rejected reload keeps the active config.
+	ioutil.WriteFile(tmpfile.Name(), []byte("[Config]\nPort\n"), 0600)
+
+	_, _, err = config.Reload(tmpfile.Name())
+
+	assert.Error(err)
+
+	response = serve()
+
+	assert.Equal(snapshot.Version, response.Version)
+	assert.Equal(err.Error(), response.LastError)
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"modulus/kyc/common"
+)
+
+// RequestError represents the error of the request to the API.
+// HTTPStatus is the status the REST API responds with, the other APIs map it to their own error codes.
+// RetryAfter is the wait before the next request if it exceeded the limits.
+type RequestError interface {
+	error
+	HTTPStatus() int
+	RetryAfter() time.Duration
+}
+
+// CustomerCheck holds the outcome of the verification of the customer for the APIs other than REST.
+// Err is the error of the provider if it occurred.
+// IDs holds the ids of the verifications recorded in the history by the providers.
+type CustomerCheck struct {
+	Result common.KYCResult
+	Err    error
+	IDs    map[common.KYCProvider]string
+}
+
+// StatusCheck holds the outcome of the status check of the verification for the APIs other than REST.
+// Err is the error of the provider if it occurred.
+type StatusCheck struct {
+	Result common.KYCResult
+	Err    error
+}
+
+// CheckCustomerContext verifies the customer according to the request on behalf of the APIs other than REST, e.g. gRPC.
+// The errors of the request are returned as the RequestError.
+func CheckCustomerContext(ctx context.Context, req common.CheckCustomerRequest) (check CustomerCheck, err error) {
+	c, err1 := checkCustomer(ctx, req, false)
+	if err1 != nil {
+		err = err1
+		return
+	}
+
+	check = CustomerCheck{
+		Result: c.result,
+		Err:    c.err,
+		IDs:    c.ids,
+	}
+
+	return
+}
+
+// CheckStatusContext checks the status of the verification according to the request on behalf of the APIs other than REST.
+// The errors of the request are returned as the RequestError.
+func CheckStatusContext(ctx context.Context, req common.CheckStatusRequest) (check StatusCheck, err error) {
+	c, err1 := checkStatus(ctx, req, false)
+	if err1 != nil {
+		err = err1
+		return
+	}
+
+	check = StatusCheck{
+		Result: c.result,
+		Err:    c.err,
+	}
+
+	return
+}
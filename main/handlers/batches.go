@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/auth"
+	"modulus/kyc/main/batch"
+)
+
+// BatchesPath is the path prefix of the batch verifications handler.
+// The batch is submitted to /Batches/, its progress is at /Batches/{id} and its results are at /Batches/{id}/results.
+const BatchesPath = "/Batches/"
+
+// resultsSuffix is the suffix of the path of the batch results.
+const resultsSuffix = "/results"
+
+// Batches handles requests for the batch verifications.
+// The batch of the customer records is submitted with POST and the provider in the query params,
+// the progress and the results of the batch are fetched with GET.
+func Batches(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	id := strings.TrimPrefix(r.URL.Path, BatchesPath)
+
+	switch {
+	case r.Method == http.MethodPost && len(id) == 0:
+		submitBatch(w, r)
+	case r.Method == http.MethodGet && strings.HasSuffix(id, resultsSuffix):
+		batchResults(w, r, strings.TrimSuffix(id, resultsSuffix))
+	case r.Method == http.MethodGet && len(id) > 0:
+		b, err := ownBatch(r, id)
+		if err != nil {
+			writeBatchError(w, err)
+			return
+		}
+
+		resp, err := json.Marshal(b.BatchResponse())
+		if err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.Write(resp)
+	case len(id) == 0:
+		writeErrorResponse(w, http.StatusBadRequest, errors.New("missing batch id in the request"))
+	default:
+		writeErrorResponse(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+	}
+}
+
+// submitBatch queues the batch verification of the records from the request body.
+// The body is read as CSV if its content type is text/csv and as NDJSON otherwise.
+func submitBatch(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	provider := common.KYCProvider(r.Form.Get("provider"))
+	if _, _, err := prepareCustomerCheck(r.Context(), common.CheckCustomerRequest{Provider: provider}); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	b, err := batch.Submit(auth.ClientID(r.Context()), provider, mediaType, r.Body)
+	if err != nil {
+		writeBatchError(w, err)
+		return
+	}
+
+	resp, err := json.Marshal(b.BatchResponse())
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Location", BatchesPath+b.ID)
+	w.WriteHeader(http.StatusAccepted)
+	w.Write(resp)
+}
+
+// batchResults writes the results of the batch checkpointed so far as the NDJSON file.
+func batchResults(w http.ResponseWriter, r *http.Request, id string) {
+	if _, err := ownBatch(r, id); err != nil {
+		writeBatchError(w, err)
+		return
+	}
+
+	results, size, err := batch.Results(id)
+	if err != nil {
+		writeBatchError(w, err)
+		return
+	}
+	defer results.Close()
+
+	w.Header().Set("Content-Type", batch.NDJSON)
+	w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+	w.Header().Set("Content-Disposition", `attachment; filename="`+id+`.ndjson"`)
+	io.Copy(w, results)
+}
+
+// ownBatch returns the batch hiding the batch of another client as if it wasn't found.
+func ownBatch(r *http.Request, id string) (b batch.Batch, err error) {
+	b, err = batch.Get(id)
+	if clientID := auth.ClientID(r.Context()); err == nil && len(clientID) > 0 && b.ClientID != clientID {
+		err = batch.ErrNotFound
+	}
+
+	return
+}
+
+// writeBatchError writes the error response for the error of the batch.
+func writeBatchError(w http.ResponseWriter, err error) {
+	if _, ok := err.(batch.InputError); ok {
+		writeErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	switch err {
+	case batch.ErrNotFound:
+		writeErrorResponse(w, http.StatusNotFound, err)
+	case batch.ErrDisabled:
+		writeErrorResponse(w, http.StatusServiceUnavailable, err)
+	default:
+		writeErrorResponse(w, http.StatusInternalServerError, err)
+	}
+}
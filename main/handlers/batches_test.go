@@ -0,0 +1,144 @@
+package handlers_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/auth"
+	"modulus/kyc/main/batch"
+	"modulus/kyc/main/handlers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatches(t *testing.T) {
+	assert := assert.New(t)
+
+	records := `{"ID":"c-1","UserData":{"FirstName":"Abby"}}` + "\n" + `{"ID":"c-2","UserData":{"FirstName":"Delilah"}}` + "\n"
+
+	// Testing the batches aren't started.
+	w := serveOpenAPI(t, handlers.Batches, http.MethodPost, "/Batches/?provider=Example", records)
+
+	assert.Equal(http.StatusServiceUnavailable, w.Code)
+	assert.Equal(`{"Error":"batch verifications aren't configured"}`, w.Body.String())
+
+	dir, err := ioutil.TempDir("", "batches")
+	if !assert.NoError(err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	concurrency := func(provider common.KYCProvider) int { return 2 }
+
+	err = batch.Start(batch.Config{Dir: dir, MaxRecords: 10, Retention: time.Hour}, handlers.RunJob, concurrency)
+	if !assert.NoError(err) {
+		return
+	}
+	defer batch.Stop()
+
+	// Testing the invalid requests.
+	testCases := []struct {
+		target string
+		body   string
+		status int
+	}{
+		{"/Batches/", records, http.StatusBadRequest},
+		{"/Batches/?provider=Acme", records, http.StatusNotFound},
+		{"/Batches/?provider=Example", `{"ID":"c-1"}`, http.StatusBadRequest},
+		{"/Batches/?provider=Example", "", http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		w = serveOpenAPI(t, handlers.Batches, http.MethodPost, tc.target, tc.body)
+
+		assert.Equal(tc.status, w.Code, "%s %s", tc.target, tc.body)
+	}
+
+	// Testing the CSV batch.
+	client := auth.Client{ID: "backoffice"}
+
+	req := httptest.NewRequest(http.MethodPost, "/Batches/?provider=Example", strings.NewReader("ID,FirstName\nc-1,Abby\nc-2,Delilah\n"))
+	req.Header.Set("Content-Type", "text/csv; charset=utf-8")
+	req = req.WithContext(auth.WithClient(req.Context(), client))
+	w = httptest.NewRecorder()
+
+	handlers.Batches(w, req)
+
+	assert.Equal(http.StatusAccepted, w.Code)
+
+	b := common.BatchResponse{}
+
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &b))
+	assert.Equal(2, b.Total)
+	assert.Equal(common.Example, b.Provider)
+	assert.Equal(handlers.BatchesPath+b.ID, w.Header().Get("Location"))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for b.State != batch.Completed && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+
+		req = httptest.NewRequest(http.MethodGet, handlers.BatchesPath+b.ID, nil)
+		req = req.WithContext(auth.WithClient(req.Context(), client))
+		w = httptest.NewRecorder()
+
+		handlers.Batches(w, req)
+
+		assert.Equal(http.StatusOK, w.Code)
+		assert.NoError(json.Unmarshal(w.Body.Bytes(), &b))
+	}
+
+	assert.Equal(batch.Completed, b.State)
+	assert.Equal(2, b.Processed)
+
+	// Testing the results.
+	req = httptest.NewRequest(http.MethodGet, handlers.BatchesPath+b.ID+"/results", nil)
+	req = req.WithContext(auth.WithClient(req.Context(), client))
+	w = httptest.NewRecorder()
+
+	handlers.Batches(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal(batch.NDJSON, w.Header().Get("Content-Type"))
+	assert.Contains(w.Header().Get("Content-Disposition"), b.ID+".ndjson")
+
+	results := []common.BatchResult{}
+	scanner := bufio.NewScanner(w.Body)
+	for scanner.Scan() {
+		result := common.BatchResult{}
+		assert.NoError(json.Unmarshal(scanner.Bytes(), &result))
+		results = append(results, result)
+	}
+
+	if assert.Len(results, 2) {
+		assert.Equal("c-1", results[0].ID)
+		assert.Equal("Approved", results[0].Result.Status)
+		assert.Equal("c-2", results[1].ID)
+		assert.Equal("Denied", results[1].Result.Status)
+	}
+
+	// Testing the batch of another client isn't found.
+	req = httptest.NewRequest(http.MethodGet, handlers.BatchesPath+b.ID+"/results", nil)
+	req = req.WithContext(auth.WithClient(req.Context(), auth.Client{ID: "another"}))
+	w = httptest.NewRecorder()
+
+	handlers.Batches(w, req)
+
+	assert.Equal(http.StatusNotFound, w.Code)
+
+	// Testing the responses conform to the OpenAPI document.
+	w = serveOpenAPI(t, handlers.Batches, http.MethodPost, "/Batches/?provider=Example", records)
+
+	assert.Equal(http.StatusAccepted, w.Code)
+
+	serveOpenAPI(t, handlers.Batches, http.MethodGet, w.Header().Get("Location"), "")
+	serveOpenAPI(t, handlers.Batches, http.MethodGet, w.Header().Get("Location")+"/results", "")
+	serveOpenAPI(t, handlers.Batches, http.MethodGet, "/Batches/unknown", "")
+}
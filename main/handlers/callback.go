@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/events"
+	"modulus/kyc/main/logging"
+	"modulus/kyc/main/rules"
+	"modulus/kyc/main/tracing"
+)
+
+// CallbackPath is the path prefix of the callback handlers.
+// The full path of a provider callback is /Callback/{provider}.
+const CallbackPath = "/Callback/"
+
+// Callback handles callbacks of KYC providers delivering verification results.
+// The callback is verified using the provider's scheme and the result is published to the subscribers.
+// The callback is acknowledged with the bare 200 response, so the result isn't disclosed to the caller.
+func Callback(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeErrorResponse(w, http.StatusMethodNotAllowed, fmt.Errorf("method not allowed: %s", r.Method))
+		return
+	}
+
+	provider := common.KYCProvider(strings.TrimPrefix(r.URL.Path, CallbackPath))
+	if len(provider) == 0 {
+		writeErrorResponse(w, http.StatusBadRequest, errors.New("missing KYC provider id in the request"))
+		return
+	}
+
+	receiver, err1 := createCallbackReceiver(provider)
+	if err1 != nil {
+		slog.Warn("Callback error", logging.ProviderKey, provider, logging.ErrorKey, err1)
+		writeErrorResponse(w, err1.status, err1)
+		return
+	}
+
+	referenceID, result, err := receiver.ParseCallback(r)
+	if err != nil {
+		slog.Warn("Callback error", logging.ProviderKey, provider, logging.ErrorKey, err)
+		writeErrorResponse(w, callbackErrorStatus(err), err)
+		return
+	}
+
+	tracing.Annotate(r.Context(), provider, referenceID)
+
+	result = rules.Apply(provider, nil, result)
+
+	events.Publish(events.Result{
+		Provider:    provider,
+		ReferenceID: referenceID,
+		Result:      result,
+		Source:      events.Callback,
+	})
+
+	logResponse("Callback response", provider, referenceID, common.KYCResponse{
+		Result: common.ResultFromKYCResult(result),
+	})
+	w.WriteHeader(http.StatusOK)
+}
+
+// createCallbackReceiver returns the KYCCallbackReceiver object for the specified provider or an error if occurred.
+func createCallbackReceiver(provider common.KYCProvider) (receiver common.KYCCallbackReceiver, err *serviceError) {
+	spec, options, err := lookupProvider(provider)
+	if err != nil {
+		return
+	}
+
+	if !spec.Capabilities.Callbacks {
+		err = &serviceError{
+			status:  http.StatusUnprocessableEntity,
+			message: fmt.Sprintf("%s doesn't support callbacks", provider),
+		}
+		return
+	}
+
+	service, err := newPlatform(spec, options)
+	if err != nil {
+		return
+	}
+
+	receiver, ok := service.(common.KYCCallbackReceiver)
+	if !ok {
+		err = &serviceError{
+			status:  http.StatusUnprocessableEntity,
+			message: fmt.Sprintf("%s doesn't support callbacks", provider),
+		}
+	}
+
+	return
+}
+
+// callbackErrorStatus returns the HTTP status code of the response for the callback error.
+// The provider is expected to redeliver the callback on the server error.
+func callbackErrorStatus(err error) int {
+	if err == common.ErrCallbackSignature {
+		return http.StatusUnauthorized
+	}
+	if _, ok := err.(common.CallbackPayloadError); ok {
+		return http.StatusBadRequest
+	}
+
+	return http.StatusInternalServerError
+}
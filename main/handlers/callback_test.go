@@ -0,0 +1,134 @@
+package handlers_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/config"
+	"modulus/kyc/main/events"
+	"modulus/kyc/main/handlers"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/jarcoal/httpmock.v1"
+)
+
+func TestCallback(t *testing.T) {
+	assert := assert.New(t)
+
+	updates := []events.Result{}
+	events.Subscribe(func(update events.Result) {
+		updates = append(updates, update)
+	})
+
+	body := []byte(`{"reference":"shufti-ref","event":"verification.accepted"}`)
+	hash := sha256.Sum256(append(append([]byte{}, body...), "fakeKey"...))
+
+	// Testing valid callback.
+	req := httptest.NewRequest(http.MethodPost, "/Callback/ShuftiPro", bytes.NewReader(body))
+	req.Header.Set("Signature", hex.EncodeToString(hash[:]))
+	w := httptest.NewRecorder()
+
+	handlers.Callback(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Empty(w.Body.String())
+	if assert.Len(updates, 1) {
+		assert.Equal(common.ShuftiPro, updates[0].Provider)
+		assert.Equal("shufti-ref", updates[0].ReferenceID)
+		assert.Equal(events.Callback, updates[0].Source)
+		assert.Equal(common.Approved, updates[0].Result.Status)
+	}
+
+	// Testing invalid signature.
+	req = httptest.NewRequest(http.MethodPost, "/Callback/ShuftiPro", bytes.NewReader(body))
+	req.Header.Set("Signature", "forged")
+	w = httptest.NewRecorder()
+
+	handlers.Callback(w, req)
+
+	assert.Equal(http.StatusUnauthorized, w.Code)
+	assert.Equal(`{"Error":"invalid callback signature"}`, w.Body.String())
+	assert.Len(updates, 1)
+
+	// Testing wrong method.
+	req = httptest.NewRequest(http.MethodGet, "/Callback/ShuftiPro", nil)
+	w = httptest.NewRecorder()
+
+	handlers.Callback(w, req)
+
+	assert.Equal(http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(http.MethodPost, w.Header().Get("Allow"))
+
+	// Testing missing provider.
+	req = httptest.NewRequest(http.MethodPost, "/Callback/", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+
+	handlers.Callback(w, req)
+
+	assert.Equal(http.StatusBadRequest, w.Code)
+	assert.Equal(`{"Error":"missing KYC provider id in the request"}`, w.Body.String())
+
+	// Testing unknown provider.
+	req = httptest.NewRequest(http.MethodPost, "/Callback/Unknown", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+
+	handlers.Callback(w, req)
+
+	assert.Equal(http.StatusNotFound, w.Code)
+	assert.Equal(`{"Error":"unknown KYC provider in the request: Unknown"}`, w.Body.String())
+
+	// Testing provider without callbacks.
+	req = httptest.NewRequest(http.MethodPost, "/Callback/Trulioo", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+
+	handlers.Callback(w, req)
+
+	assert.Equal(http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(`{"Error":"Trulioo doesn't support callbacks"}`, w.Body.String())
+}
+
+func TestCallbackJumio(t *testing.T) {
+	assert := assert.New(t)
+
+	previous := config.Get()
+	defer config.Set(previous)
+
+	config.Set(config.Get().With(string(common.Jumio), map[string]string{
+		"BaseURL":       "https://netverify.com/api/netverify/v2",
+		"Token":         "fakeToken",
+		"Secret":        "fakeSecret",
+		"CallbackToken": "callbackToken",
+	}))
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	calls := 0
+	httpmock.RegisterNoResponder(func(*http.Request) (*http.Response, error) {
+		calls++
+		return httpmock.NewStringResponse(http.StatusOK, `{"status":"DONE"}`), nil
+	})
+
+	form := url.Values{"jumioIdScanReference": {"victim-ref"}}.Encode()
+
+	// Testing the forged callbacks don't call the provider and don't get the result.
+	for _, target := range []string{"/Callback/Jumio", "/Callback/Jumio?token=forged"} {
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(form))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+
+		handlers.Callback(w, req)
+
+		assert.Equal(http.StatusUnauthorized, w.Code, target)
+		assert.Equal(`{"Error":"invalid callback signature"}`, w.Body.String(), target)
+	}
+
+	assert.Zero(calls)
+}
@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"modulus/kyc/common"
 	"modulus/kyc/integrations/ciphertrace"
+	"modulus/kyc/main/auth"
 	"modulus/kyc/main/config"
+	"modulus/kyc/main/limits"
 
 	"github.com/pkg/errors"
 )
@@ -25,7 +28,11 @@ func CipherTraceCheck(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
-	cfg, ok := config.Cfg["CipherTrace"]
+	if err = auth.AuthorizeProvider(r.Context(), common.CipherTrace); err != nil {
+		writeErrorResponse(w, http.StatusForbidden, err)
+		return
+	}
+	cfg, ok := config.Get()["CipherTrace"]
 	if !ok {
 		err = &serviceError{
 			status:  http.StatusInternalServerError,
@@ -35,6 +42,11 @@ func CipherTraceCheck(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err = limits.AllowCheck(auth.ClientID(r.Context()), common.CipherTrace); err != nil {
+		writeLimitError(w, err)
+		return
+	}
+
 	service := ciphertrace.NewCipherService(cfg["URL"], cfg["Key"], cfg["Username"])
 	switch req.Coin {
 	case "BTC":
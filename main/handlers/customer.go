@@ -1,37 +1,41 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"io/ioutil"
-	"log"
+	"log/slog"
 	"net/http"
-	"strconv"
+	"sync"
 	"time"
 
 	"modulus/kyc/common"
-	"modulus/kyc/integrations/coinfirm"
-	"modulus/kyc/integrations/complyadvantage"
+	kychttp "modulus/kyc/http"
 	"modulus/kyc/integrations/example"
-	"modulus/kyc/integrations/identitymind"
-	"modulus/kyc/integrations/idology"
-	"modulus/kyc/integrations/jumio"
-	"modulus/kyc/integrations/shuftipro"
-	"modulus/kyc/integrations/sumsub"
-	"modulus/kyc/integrations/synapsefi"
-	"modulus/kyc/integrations/thomsonreuters"
-	"modulus/kyc/integrations/trulioo"
-	"modulus/kyc/main/config"
+	"modulus/kyc/main/auth"
+	"modulus/kyc/main/events"
+	"modulus/kyc/main/limits"
+	"modulus/kyc/main/logging"
+	"modulus/kyc/main/metrics"
+	"modulus/kyc/main/notify"
+	"modulus/kyc/main/orchestration"
+	"modulus/kyc/main/rules"
+	"modulus/kyc/main/store"
+	"modulus/kyc/main/tracing"
+
+	"github.com/google/uuid"
 )
 
 // CheckCustomer handles requests for KYC verifications.
+// The customer is verified using either the single provider or the strategy using several providers.
+// The verification is run as the async job if the request asks for it.
 func CheckCustomer(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 
 	body, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		writeErrorResponse(w, http.StatusInternalServerError, err)
+		writeErrorResponse(w, readStatus(err), err)
 		return
 	}
 	if len(body) == 0 {
@@ -46,149 +50,280 @@ func CheckCustomer(w http.ResponseWriter, r *http.Request) {
 		writeErrorResponse(w, http.StatusBadRequest, err)
 		return
 	}
-	requestTime := time.Now().UnixNano()
-	log.Println("CheckCustomer Request time: ", requestTime)
-	ioutil.WriteFile("requests/"+fmt.Sprint(requestTime)+".json", body, 0644)
 
-	if len(req.Provider) == 0 {
-		writeErrorResponse(w, http.StatusBadRequest, errors.New("missing KYC provider id in the request"))
+	if req.Async {
+		submitJob(w, r, req)
 		return
 	}
 
-	service, err1 := createCustomerChecker(req.Provider)
+	check, err1 := checkCustomer(r.Context(), req, false)
 	if err1 != nil {
-		log.Println("CheckCustomer Error: ", err1)
-		writeErrorResponse(w, err1.status, err1)
+		writeServiceError(w, err1)
 		return
 	}
 
-	response := common.KYCResponse{}
-
-	result, err := service.CheckCustomer(req.UserData)
-	if err != nil {
-		response.Error = err.Error()
+	for _, p := range check.providers {
+		if id := check.ids[p]; len(id) > 0 {
+			w.Header().Add(VerificationIDHeader, id)
+		}
 	}
 
-	response.Result = common.ResultFromKYCResult(result)
-
-	resp, err := json.Marshal(response)
+	resp, err := json.Marshal(check.response)
 	if err != nil {
 		writeErrorResponse(w, http.StatusInternalServerError, err)
 		return
 	}
-	log.Println("CheckCustomer Response: ", string(resp))
+	referenceID := ""
+	if check.result.StatusCheck != nil {
+		referenceID = check.result.StatusCheck.ReferenceID
+	}
+	tracing.Annotate(r.Context(), "", referenceID)
+	logResponse("CheckCustomer response", check.provider, referenceID, check.response)
 	w.Write(resp)
 }
 
-// createCustomerChecker returns the KYCPlatform object for the specified provider or an error if occurred.
-func createCustomerChecker(provider common.KYCProvider) (service common.KYCPlatform, err *serviceError) {
-	if provider == common.Example {
-		service = example.Example{}
+// customerCheck holds the outcome of the verification of the customer.
+//
+// * provider is the provider of the result. It's the last provider tried if the strategy is used.
+// * providers lists the providers of the request in order.
+// * response is the v1 API response the notification is sent with.
+// * ids holds the ids of the verifications recorded in the history by the providers.
+// * errs holds the errors of the providers failed.
+// * recorders holds the recorders of the provider API responses if the recording was requested.
+type customerCheck struct {
+	provider  common.KYCProvider
+	providers []common.KYCProvider
+	result    common.KYCResult
+	err       error
+	response  common.KYCResponse
+	ids       map[common.KYCProvider]string
+	errs      map[common.KYCProvider]error
+	recorders map[common.KYCProvider]*kychttp.Recorder
+}
+
+// checkCustomer verifies the customer according to the request on behalf of the API handlers of any version.
+// The errors of the request are returned as the serviceError holding the HTTP status of the response.
+// The responses of the provider APIs are recorded if the record is set.
+func checkCustomer(ctx context.Context, req common.CheckCustomerRequest, record bool) (check customerCheck, err *serviceError) {
+	providers, services, err := prepareCustomerCheck(ctx, req)
+	if err != nil {
 		return
 	}
 
-	if !common.KYCProviders[provider] {
-		err = &serviceError{
-			status:  http.StatusNotFound,
-			message: fmt.Sprintf("unknown KYC provider in the request: %s", provider),
+	if req.Strategy != nil {
+		slog.Debug("CheckCustomer request", logging.StrategyKey, req.Strategy.Mode, logging.ProviderKey, providers, logging.Customer(req.UserData))
+	} else {
+		slog.Debug("CheckCustomer request", logging.ProviderKey, req.Provider, logging.Customer(req.UserData))
+	}
+	tracing.Annotate(ctx, req.Provider, "")
+
+	clientID := auth.ClientID(ctx)
+
+	check = customerCheck{
+		provider:  req.Provider,
+		providers: providers,
+		ids:       map[common.KYCProvider]string{},
+		errs:      map[common.KYCProvider]error{},
+		recorders: map[common.KYCProvider]*kychttp.Recorder{},
+	}
+
+	// recording returns the context recording the responses of the provider API if it's requested.
+	recording := func(ctx context.Context, provider common.KYCProvider) context.Context {
+		if !record {
+			return ctx
 		}
-		return
+		recorder := &kychttp.Recorder{}
+		check.recorders[provider] = recorder
+		return kychttp.WithRecorder(ctx, recorder)
+	}
+
+	if req.Strategy == nil {
+		if err1 := limits.AllowCheck(clientID, req.Provider); err1 != nil {
+			err = limitError(err1)
+			return
+		}
+
+		var id string
+		check.result, id, check.err = checkProvider(recording(ctx, req.Provider), req.Provider, services[req.Provider], req.UserData, clientID)
+		check.ids[req.Provider] = id
+		if check.err != nil {
+			check.errs[req.Provider] = check.err
+		}
+	} else {
+		var (
+			mu       sync.Mutex
+			limited  int
+			limitErr error
+		)
+
+		check.result, check.err = orchestration.Run(ctx, *req.Strategy, func(ctx context.Context, provider common.KYCProvider) (common.KYCResult, error) {
+			if err := limits.AllowCheck(clientID, provider); err != nil {
+				mu.Lock()
+				limited, limitErr = limited+1, err
+				check.errs[provider] = err
+				mu.Unlock()
+				return common.KYCResult{}, err
+			}
+
+			mu.Lock()
+			ctx = recording(ctx, provider)
+			mu.Unlock()
+
+			ctx, span := tracing.StartOperation(ctx, provider, string(metrics.CheckCustomer), "")
+			result, id, err := checkProvider(ctx, provider, services[provider], req.UserData, clientID)
+			tracing.EndOperation(span, err)
+
+			mu.Lock()
+			check.ids[provider] = id
+			if err != nil {
+				check.errs[provider] = err
+			}
+			mu.Unlock()
+
+			return result, err
+		})
+		if limited == len(providers) {
+			err = limitError(limitErr)
+			return
+		}
+
+		if len(check.result.Providers) > 0 {
+			check.provider = check.result.Providers[len(check.result.Providers)-1].Provider
+		}
+	}
+
+	if check.err != nil {
+		check.response.Error = check.err.Error()
 	}
 
-	cfg, ok := config.Cfg[string(provider)]
-	if !ok {
-		err = &serviceError{
-			status:  http.StatusInternalServerError,
-			message: fmt.Sprintf("missing config for %s", provider),
+	check.response.Result = common.ResultFromKYCResult(check.result)
+
+	if len(req.NotificationURL) > 0 {
+		notifyResult(check.provider, req.NotificationURL, check.result, check.response)
+	}
+
+	return
+}
+
+// prepareCustomerCheck validates the CheckCustomer request and returns the providers of the request in order and their services.
+// The errors of the request are returned as the serviceError holding the HTTP status of the response.
+func prepareCustomerCheck(ctx context.Context, req common.CheckCustomerRequest) (providers []common.KYCProvider, services map[common.KYCProvider]common.KYCPlatformContext, err *serviceError) {
+	providers = []common.KYCProvider{req.Provider}
+	if req.Strategy != nil {
+		if len(req.Provider) > 0 {
+			err = badRequest(errors.New("either KYC provider id or strategy must be specified in the request"))
+			return
+		}
+		if err1 := req.Strategy.Validate(); err1 != nil {
+			err = badRequest(err1)
+			return
 		}
+		if req.Strategy.Mode == common.Parallel && len(req.NotificationURL) > 0 {
+			err = badRequest(errors.New("notifications aren't supported for the Parallel strategy"))
+			return
+		}
+		providers = req.Strategy.Providers
+	} else if len(req.Provider) == 0 {
+		err = badRequest(errors.New("missing KYC provider id in the request"))
 		return
 	}
+	for _, provider := range providers {
+		if err1 := auth.AuthorizeProvider(ctx, provider); err1 != nil {
+			err = &serviceError{status: http.StatusForbidden, message: err1.Error()}
+			return
+		}
+	}
 
-	switch provider {
-	case common.Coinfirm:
-		service = coinfirm.New(coinfirm.Config{
-			Host:     cfg["Host"],
-			Email:    cfg["Email"],
-			Password: cfg["Password"],
-			Company:  cfg["Company"],
-		})
-	case common.ComplyAdvantage:
-		fuzziness, err1 := strconv.ParseFloat(cfg["Fuzziness"], 32)
-		if err1 != nil {
-			err = &serviceError{
-				status:  http.StatusInternalServerError,
-				message: fmt.Sprintf("%s config error: %s", provider, err1),
-			}
+	if len(req.NotificationURL) > 0 {
+		if err1 := notify.ValidateURL(req.NotificationURL); err1 != nil {
+			err = badRequest(err1)
 			return
 		}
-		service = complyadvantage.New(complyadvantage.Config{
-			Host:      cfg["Host"],
-			APIkey:    cfg["APIkey"],
-			Fuzziness: float32(fuzziness),
-		})
-	case common.IdentityMind:
-		service = identitymind.New(identitymind.Config{
-			Host:     cfg["Host"],
-			Username: cfg["Username"],
-			Password: cfg["Password"],
-		})
-	case common.IDology:
-		useSummaryResult, err1 := strconv.ParseBool(cfg["UseSummaryResult"])
+		if !notify.Enabled() {
+			err = &serviceError{status: http.StatusUnprocessableEntity, message: notify.ErrDisabled.Error()}
+			return
+		}
+	}
+
+	services = map[common.KYCProvider]common.KYCPlatformContext{}
+	for _, provider := range providers {
+		service, err1 := createCustomerChecker(provider)
 		if err1 != nil {
-			err = &serviceError{
-				status:  http.StatusInternalServerError,
-				message: fmt.Sprintf("%s config error: %s", provider, err1),
-			}
+			slog.Warn("CheckCustomer error", logging.ProviderKey, provider, logging.ErrorKey, err1)
+			err = err1
 			return
 		}
-		service = idology.New(idology.Config{
-			Host:             cfg["Host"],
-			Username:         cfg["Username"],
-			Password:         cfg["Password"],
-			UseSummaryResult: useSummaryResult,
-		})
-	case common.Jumio:
-		service = jumio.New(jumio.Config{
-			BaseURL: cfg["BaseURL"],
-			Token:   cfg["Token"],
-			Secret:  cfg["Secret"],
-		})
-	case common.ShuftiPro:
-		service = shuftipro.New(shuftipro.Config{
-			Host:        cfg["Host"],
-			SecretKey:   cfg["SecretKey"],
-			ClientID:    cfg["ClientID"],
-			CallbackURL: cfg["CallbackURL"],
-		})
-	case common.SumSub:
-		service = sumsub.New(sumsub.Config{
-			Host:   cfg["Host"],
-			APIKey: cfg["APIKey"],
-		})
-	case common.SynapseFI:
-		service = synapsefi.New(synapsefi.Config{
-			Host:         cfg["Host"],
-			ClientID:     cfg["ClientID"],
-			ClientSecret: cfg["ClientSecret"],
-		})
-	case common.ThomsonReuters:
-		service = thomsonreuters.New(thomsonreuters.Config{
-			Host:      cfg["Host"],
-			APIkey:    cfg["APIkey"],
-			APIsecret: cfg["APIsecret"],
-		})
-	case common.Trulioo:
-		service = trulioo.New(trulioo.Config{
-			Host:         cfg["Host"],
-			NAPILogin:    cfg["NAPILogin"],
-			NAPIPassword: cfg["NAPIPassword"],
+		services[provider] = service
+	}
+
+	return
+}
+
+// checkProvider verifies the customer using the provider service.
+// The verification is recorded in the store and its id is returned if the store is opened.
+// The pending result is published for the polling.
+func checkProvider(ctx context.Context, provider common.KYCProvider, service common.KYCPlatformContext, customer *common.UserData, clientID string) (result common.KYCResult, id string, err error) {
+	started := time.Now()
+	result, err = service.CheckCustomerContext(metrics.WithOperation(ctx, provider, metrics.CheckCustomer), customer)
+	metrics.ObserveVerification(provider, metrics.CheckCustomer, started, result, err)
+	if err == nil {
+		result = rules.Apply(provider, customer, result)
+	}
+
+	verification := store.NewVerification(uuid.New().String(), provider, customer, result, err)
+	verification.ClientID = clientID
+	if err1 := store.Create(verification); err1 == nil {
+		id = verification.ID
+	} else if err1 != store.ErrDisabled {
+		slog.Error("CheckCustomer store error", logging.ProviderKey, provider, logging.ErrorKey, err1)
+	}
+
+	if err == nil && result.StatusCheck != nil {
+		events.Publish(events.Result{
+			Provider:    provider,
+			ReferenceID: result.StatusCheck.ReferenceID,
+			Result:      result,
+			Source:      events.Check,
 		})
-	default:
-		err = &serviceError{
-			status:  http.StatusUnprocessableEntity,
-			message: fmt.Sprintf("KYC provider not implemented yet: %s", provider),
-		}
 	}
 
 	return
 }
+
+// createCustomerChecker returns the KYCPlatformContext object for the specified provider or an error if occurred.
+func createCustomerChecker(provider common.KYCProvider) (service common.KYCPlatformContext, err *serviceError) {
+	if provider == common.Example {
+		service = example.Example{}
+		return
+	}
+
+	spec, options, err := lookupProvider(provider)
+	if err != nil {
+		return
+	}
+
+	service, err = newPlatform(spec, options)
+
+	return
+}
+
+// notifyResult registers the notification about the verification result of the provider for the notification url.
+// The pending verification is followed up and the notification is sent when its final result is obtained.
+// Otherwise, the notification is sent with the response right away.
+func notifyResult(provider common.KYCProvider, notificationURL string, result common.KYCResult, response common.KYCResponse) {
+	var err error
+
+	if len(response.Error) == 0 && !result.IsFinal() {
+		err = notify.Watch(provider, result.StatusCheck.ReferenceID, notificationURL)
+	} else {
+		referenceID := ""
+		if result.StatusCheck != nil {
+			referenceID = result.StatusCheck.ReferenceID
+		}
+		err = notify.Send(provider, referenceID, notificationURL, response)
+	}
+
+	if err != nil {
+		slog.Error("CheckCustomer notification error", logging.ProviderKey, provider, logging.ErrorKey, err)
+	}
+}
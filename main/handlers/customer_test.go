@@ -2,16 +2,26 @@ package handlers_test
 
 import (
 	"bytes"
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"log"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
 	"time"
 
 	"modulus/kyc/common"
 	"modulus/kyc/main/config"
 	"modulus/kyc/main/handlers"
+	"modulus/kyc/main/logging"
+	"modulus/kyc/main/notify"
+	"modulus/kyc/main/poller"
+	"modulus/kyc/main/rules"
 
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/jarcoal/httpmock.v1"
@@ -189,8 +199,8 @@ var truliooResponse = []byte(`
 }`)
 
 func init() {
-	if config.Cfg == nil {
-		config.Cfg = cfg
+	if len(config.Get()) == 0 {
+		config.Set(cfg)
 	}
 }
 
@@ -346,17 +356,13 @@ func TestCheckCustomer(t *testing.T) {
 
 	// Testing KYC provider without config.
 	request, err = json.Marshal(&common.CheckCustomerRequest{
-		Provider: "Provider Without Config",
+		Provider: common.SynapseFI,
 		UserData: &common.UserData{},
 	})
 
 	assert.NoError(err)
 	assert.NotEmpty(request)
 
-	if !common.KYCProviders["Provider Without Config"] {
-		common.KYCProviders["Provider Without Config"] = true
-	}
-
 	req = httptest.NewRequest(http.MethodPost, "/CheckCustomer", bytes.NewReader(request))
 	w = httptest.NewRecorder()
 
@@ -372,21 +378,18 @@ func TestCheckCustomer(t *testing.T) {
 	assert.NoError(err)
 	assert.Nil(resp.Result)
 	assert.NotEmpty(resp.Error)
-	assert.Equal("missing config for Provider Without Config", resp.Error)
+	assert.Equal("missing config for SynapseFI", resp.Error)
 
 	// Testing KYC provider not implemented yet.
 	request, err = json.Marshal(&common.CheckCustomerRequest{
-		Provider: "Not Implemented Provider",
+		Provider: common.CipherTrace,
 		UserData: &common.UserData{},
 	})
 
 	assert.NoError(err)
 	assert.NotEmpty(request)
 
-	if !common.KYCProviders["Not Implemented Provider"] {
-		common.KYCProviders["Not Implemented Provider"] = true
-	}
-	config.Cfg["Not Implemented Provider"] = map[string]string{"test": "test"}
+	config.Set(config.Get().With(string(common.CipherTrace), map[string]string{"test": "test"}))
 
 	req = httptest.NewRequest(http.MethodPost, "/CheckCustomer", bytes.NewReader(request))
 	w = httptest.NewRecorder()
@@ -403,7 +406,7 @@ func TestCheckCustomer(t *testing.T) {
 	assert.NoError(err)
 	assert.Nil(resp.Result)
 	assert.NotEmpty(resp.Error)
-	assert.Equal("KYC provider not implemented yet: Not Implemented Provider", resp.Error)
+	assert.Equal("KYC provider not implemented yet: CipherTrace", resp.Error)
 
 	// Testing error response from the KYC provider.
 	request, err = json.Marshal(&common.CheckCustomerRequest{
@@ -632,11 +635,11 @@ func TestCheckCustomer(t *testing.T) {
 	assert.NotEmpty(request)
 	assert.NotEmpty(response)
 
-	config.Cfg[string(common.IDology)] = map[string]string{
+	config.Set(config.Get().With(string(common.IDology), map[string]string{
 		"Host":     "https://web.idologylive.com/api/idiq.svc",
 		"Username": "fakeuser",
 		"Password": "fakepassword",
-	}
+	}))
 
 	req = httptest.NewRequest(http.MethodPost, "/CheckCustomer", bytes.NewReader(request))
 	w = httptest.NewRecorder()
@@ -653,5 +656,425 @@ func TestCheckCustomer(t *testing.T) {
 	assert.NoError(err)
 	assert.Nil(resp.Result)
 	assert.NotEmpty(resp.Error)
-	assert.Equal(`IDology config error: strconv.ParseBool: parsing "": invalid syntax`, resp.Error)
+	assert.Equal(`IDology config error: invalid option 'UseSummaryResult': strconv.ParseBool: parsing "": invalid syntax`, resp.Error)
+
+	// Testing canceled request.
+	request, err = json.Marshal(&common.CheckCustomerRequest{
+		Provider: common.Example,
+		UserData: &common.UserData{
+			FirstName: "Abby",
+		},
+	})
+
+	assert.NoError(err)
+	assert.NotEmpty(request)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req = httptest.NewRequest(http.MethodPost, "/CheckCustomer", bytes.NewReader(request)).WithContext(ctx)
+	w = httptest.NewRecorder()
+
+	handlers.CheckCustomer(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	resp = common.KYCResponse{}
+
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+
+	assert.NoError(err)
+	assert.NotNil(resp.Result)
+	assert.NotEqual(common.KYCStatus2Status[common.Approved], resp.Result.Status)
+	assert.Equal(context.Canceled.Error(), resp.Error)
+}
+
+func TestCheckCustomerNotification(t *testing.T) {
+	assert := assert.New(t)
+
+	bodies := make(chan []byte, 10)
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies <- body
+	}))
+	defer backend.Close()
+
+	// Testing invalid notification url.
+	request, err := json.Marshal(&common.CheckCustomerRequest{
+		Provider: common.Example,
+		UserData: &common.UserData{
+			FirstName: "Abby",
+		},
+		NotificationURL: "backend.example.com/notify",
+	})
+
+	assert.NoError(err)
+
+	req := httptest.NewRequest(http.MethodPost, "/CheckCustomer", bytes.NewReader(request))
+	w := httptest.NewRecorder()
+
+	handlers.CheckCustomer(w, req)
+
+	assert.Equal(http.StatusBadRequest, w.Code)
+	assert.Equal(`{"Error":"invalid notification url: backend.example.com/notify"}`, w.Body.String())
+
+	// Testing disabled notifications.
+	request, err = json.Marshal(&common.CheckCustomerRequest{
+		Provider: common.Example,
+		UserData: &common.UserData{
+			FirstName: "Abby",
+		},
+		NotificationURL: backend.URL,
+	})
+
+	assert.NoError(err)
+
+	req = httptest.NewRequest(http.MethodPost, "/CheckCustomer", bytes.NewReader(request))
+	w = httptest.NewRecorder()
+
+	handlers.CheckCustomer(w, req)
+
+	assert.Equal(http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(`{"Error":"result notifications aren't configured"}`, w.Body.String())
+
+	dir, err := ioutil.TempDir("", "outbox")
+	if !assert.NoError(err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	poller.Start(handlers.CheckVerificationStatus, func(common.KYCProvider) poller.Settings {
+		return poller.Settings{
+			Interval:    20 * time.Millisecond,
+			MaxInterval: time.Second,
+			MaxAge:      time.Hour,
+		}
+	}, nil)
+	defer poller.Stop()
+
+	err = notify.Start(notify.Config{
+		Secret:       "secret",
+		Outbox:       dir,
+		MaxAttempts:  3,
+		RetryWait:    10 * time.Millisecond,
+		RetryMaxWait: 10 * time.Millisecond,
+	}, poller.Track)
+	if !assert.NoError(err) {
+		return
+	}
+	defer notify.Stop()
+
+	// Testing the notification of the final result.
+	req = httptest.NewRequest(http.MethodPost, "/CheckCustomer", bytes.NewReader(request))
+	w = httptest.NewRecorder()
+
+	handlers.CheckCustomer(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	select {
+	case body := <-bodies:
+		assert.Equal(w.Body.String(), string(body))
+	case <-time.After(5 * time.Second):
+		assert.Fail("notification wasn't delivered")
+	}
+
+	// Testing the notification of the pending verification.
+	request, err = json.Marshal(&common.CheckCustomerRequest{
+		Provider: common.Example,
+		UserData: &common.UserData{
+			FirstName: "Urbi",
+		},
+		NotificationURL: backend.URL,
+	})
+
+	assert.NoError(err)
+
+	req = httptest.NewRequest(http.MethodPost, "/CheckCustomer", bytes.NewReader(request))
+	w = httptest.NewRecorder()
+
+	handlers.CheckCustomer(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	resp := common.KYCResponse{}
+
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+
+	assert.NoError(err)
+	if assert.NotNil(resp.Result) && assert.NotNil(resp.Result.StatusCheck) {
+		assert.Equal("lily_was_here", resp.Result.StatusCheck.ReferenceID)
+	}
+
+	select {
+	case body := <-bodies:
+		resp = common.KYCResponse{}
+
+		err = json.Unmarshal(body, &resp)
+
+		assert.NoError(err)
+		if assert.NotNil(resp.Result) {
+			assert.Equal(common.KYCStatus2Status[common.Error], resp.Result.Status)
+			assert.Equal("42", resp.Result.ErrorCode)
+			assert.Nil(resp.Result.StatusCheck)
+		}
+	case <-time.After(5 * time.Second):
+		assert.Fail("notification wasn't delivered")
+	}
+}
+
+func TestCheckCustomerLogging(t *testing.T) {
+	assert := assert.New(t)
+
+	defaultLogger := slog.Default()
+	defer slog.SetDefault(defaultLogger)
+	defer log.SetFlags(log.Flags())
+	defer log.SetOutput(log.Writer())
+
+	buf := &bytes.Buffer{}
+	slog.SetDefault(slog.New(logging.NewHandler(logging.JSONFormat, buf, slog.LevelDebug)))
+
+	request, err := json.Marshal(&common.CheckCustomerRequest{
+		Provider: common.Example,
+		UserData: &common.UserData{
+			FirstName: "Urbi",
+			Email:     "urbi@example.com",
+			Passport: &common.Passport{
+				Number: "AB1234567",
+				Image: &common.DocumentFile{
+					Filename: "passport.jpg",
+					Data:     []byte("passport image"),
+				},
+			},
+			CreditCard: &common.CreditCard{
+				Number: "4111111111111111",
+			},
+		},
+	})
+
+	assert.NoError(err)
+
+	req := httptest.NewRequest(http.MethodPost, "/CheckCustomer", bytes.NewReader(request))
+	w := httptest.NewRecorder()
+
+	handlers.CheckCustomer(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	out := buf.String()
+
+	assert.Contains(out, `"msg":"CheckCustomer request"`)
+	assert.Contains(out, `"msg":"CheckCustomer response"`)
+	assert.Contains(out, `"referenceID":"lily_was_here"`)
+	assert.Contains(out, "************1111")
+	assert.Contains(out, logging.HashEmail("urbi@example.com"))
+	assert.NotContains(out, "urbi@example.com")
+	assert.NotContains(out, "AB1234567")
+	assert.NotContains(out, "4111111111111111")
+	assert.NotContains(out, base64.StdEncoding.EncodeToString([]byte("passport image")))
+}
+
+func TestCheckCustomerStrategy(t *testing.T) {
+	assert := assert.New(t)
+
+	previous := config.Get()
+	defer config.Set(previous)
+
+	config.Set(config.Get().With(string(common.IDology), map[string]string{
+		"Host":             "https://web.idologylive.com/api/idiq.svc",
+		"Username":         "fakeuser",
+		"Password":         "fakepassword",
+		"UseSummaryResult": "false",
+	}))
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		http.MethodPost,
+		"https://web.idologylive.com/api/idiq.svc",
+		httpmock.NewBytesResponder(http.StatusOK, idologyResponse),
+	)
+
+	// Testing the parallel check of the providers.
+	request, err := json.Marshal(&common.CheckCustomerRequest{
+		Strategy: &common.Strategy{
+			Mode:      common.Parallel,
+			Providers: []common.KYCProvider{common.Example, common.IDology},
+			Consensus: common.AllApprove,
+		},
+		UserData: &common.UserData{FirstName: "Abby"},
+	})
+
+	assert.NoError(err)
+
+	req := httptest.NewRequest(http.MethodPost, "/CheckCustomer", bytes.NewReader(request))
+	w := httptest.NewRecorder()
+
+	handlers.CheckCustomer(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	resp := common.KYCResponse{}
+
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+
+	assert.NoError(err)
+	assert.Empty(resp.Error)
+	if assert.NotNil(resp.Result) && assert.Len(resp.Result.Providers, 2) {
+		assert.Equal(common.KYCStatus2Status[common.Denied], resp.Result.Status)
+		assert.Equal([]string{"IDology: COPPA Alert"}, resp.Result.Details.Reasons)
+		assert.Equal(common.Example, resp.Result.Providers[0].Provider)
+		assert.Equal(common.KYCStatus2Status[common.Approved], resp.Result.Providers[0].Result.Status)
+		assert.Equal(common.IDology, resp.Result.Providers[1].Provider)
+		assert.Equal(common.KYCStatus2Status[common.Denied], resp.Result.Providers[1].Result.Status)
+	}
+
+	// Testing the fallback to the next provider.
+	httpmock.RegisterResponder(
+		http.MethodPost,
+		"https://web.idologylive.com/api/idiq.svc",
+		httpmock.NewBytesResponder(http.StatusServiceUnavailable, nil),
+	)
+
+	request, err = json.Marshal(&common.CheckCustomerRequest{
+		Strategy: &common.Strategy{
+			Mode:      common.Fallback,
+			Providers: []common.KYCProvider{common.IDology, common.Example},
+		},
+		UserData: &common.UserData{FirstName: "Abby"},
+	})
+
+	assert.NoError(err)
+
+	req = httptest.NewRequest(http.MethodPost, "/CheckCustomer", bytes.NewReader(request))
+	w = httptest.NewRecorder()
+
+	handlers.CheckCustomer(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	resp = common.KYCResponse{}
+
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+
+	assert.NoError(err)
+	assert.Empty(resp.Error)
+	if assert.NotNil(resp.Result) && assert.Len(resp.Result.Providers, 2) {
+		assert.Equal(common.KYCStatus2Status[common.Approved], resp.Result.Status)
+		assert.Equal(common.IDology, resp.Result.Providers[0].Provider)
+		assert.NotEmpty(resp.Result.Providers[0].Error)
+		assert.Equal(common.Example, resp.Result.Providers[1].Provider)
+	}
+
+	// Testing invalid strategies.
+	testCases := []struct {
+		request *common.CheckCustomerRequest
+		status  int
+		message string
+	}{
+		{
+			&common.CheckCustomerRequest{
+				Provider: common.Example,
+				Strategy: &common.Strategy{Mode: common.Fallback, Providers: []common.KYCProvider{common.Example}},
+			},
+			http.StatusBadRequest,
+			`{"Error":"either KYC provider id or strategy must be specified in the request"}`,
+		},
+		{
+			&common.CheckCustomerRequest{
+				Strategy: &common.Strategy{Mode: common.Parallel, Providers: []common.KYCProvider{common.Example, common.Example}},
+			},
+			http.StatusBadRequest,
+			`{"Error":"duplicate KYC provider in the strategy: Example"}`,
+		},
+		{
+			&common.CheckCustomerRequest{
+				Strategy:        &common.Strategy{Mode: common.Parallel, Providers: []common.KYCProvider{common.Example}},
+				NotificationURL: "https://example.com/kyc",
+			},
+			http.StatusBadRequest,
+			`{"Error":"notifications aren't supported for the Parallel strategy"}`,
+		},
+		{
+			&common.CheckCustomerRequest{
+				Strategy: &common.Strategy{Mode: common.Fallback, Providers: []common.KYCProvider{common.Example, "Foobar"}},
+			},
+			http.StatusNotFound,
+			`{"Error":"unknown KYC provider in the request: Foobar"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		request, err = json.Marshal(tc.request)
+
+		assert.NoError(err)
+
+		req = httptest.NewRequest(http.MethodPost, "/CheckCustomer", bytes.NewReader(request))
+		w = httptest.NewRecorder()
+
+		handlers.CheckCustomer(w, req)
+
+		assert.Equal(tc.status, w.Code)
+		assert.Equal(tc.message, w.Body.String())
+	}
+}
+
+func TestCheckCustomerRules(t *testing.T) {
+	assert := assert.New(t)
+
+	rulesConfig, err := rules.Parse([]byte(`
+rules:
+  - name: manual-review
+    provider: Example
+    when: status == "Approved" && customer.country in ["GB", "IE"]
+    then: Unclear
+    reason: manual review of the customers from GB and IE
+`))
+	if !assert.NoError(err) {
+		return
+	}
+
+	rules.Setup(rulesConfig)
+	defer rules.Setup(rules.Config{})
+
+	testCases := []struct {
+		country string
+		status  common.KYCStatus
+		reasons []string
+	}{
+		{"GB", common.Unclear, []string{"Rule manual-review: manual review of the customers from GB and IE"}},
+		{"US", common.Approved, nil},
+	}
+
+	for _, tc := range testCases {
+		request, err := json.Marshal(&common.CheckCustomerRequest{
+			Provider: common.Example,
+			UserData: &common.UserData{FirstName: "Abby", CountryAlpha2: tc.country},
+		})
+
+		assert.NoError(err)
+
+		req := httptest.NewRequest(http.MethodPost, "/CheckCustomer", bytes.NewReader(request))
+		w := httptest.NewRecorder()
+
+		handlers.CheckCustomer(w, req)
+
+		assert.Equal(http.StatusOK, w.Code, tc.country)
+
+		resp := common.KYCResponse{}
+
+		err = json.Unmarshal(w.Body.Bytes(), &resp)
+
+		assert.NoError(err)
+		if assert.NotNil(resp.Result, tc.country) {
+			assert.Equal(common.KYCStatus2Status[tc.status], resp.Result.Status, tc.country)
+			if tc.reasons == nil {
+				assert.Nil(resp.Result.Details, tc.country)
+			} else if assert.NotNil(resp.Result.Details, tc.country) {
+				assert.Equal(tc.reasons, resp.Result.Details.Reasons, tc.country)
+			}
+		}
+	}
 }
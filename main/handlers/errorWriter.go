@@ -2,15 +2,56 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"modulus/kyc/common"
+	"modulus/kyc/main/limits"
 )
 
-// serviceError represents an error that might happen during creating the KYC provider service.
+// serviceError represents an error that might happen during creating the KYC provider service or handling the request.
+// The retryAfter is the wait before the next request if it exceeded the limits.
+// The config flag marks the missing or invalid service config of the KYC provider.
 type serviceError struct {
-	status  int
-	message string
+	status     int
+	message    string
+	retryAfter time.Duration
+	config     bool
+}
+
+// badRequest returns the serviceError for the malformed request.
+func badRequest(err error) *serviceError {
+	return &serviceError{
+		status:  http.StatusBadRequest,
+		message: err.Error(),
+	}
+}
+
+// limitError returns the serviceError for the request exceeding the limits.
+func limitError(err error) *serviceError {
+	e := &serviceError{
+		status:  http.StatusTooManyRequests,
+		message: err.Error(),
+	}
+	if le, ok := err.(limits.Error); ok {
+		e.retryAfter = le.RetryAfter
+	}
+
+	return e
+}
+
+// readStatus returns the HTTP status for the error reading the request body.
+// The body exceeding the HTTPMaxBodySize is answered with 413.
+func readStatus(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+
+	return http.StatusInternalServerError
 }
 
 // Error implements the error interface for the serviceError.
@@ -18,6 +59,16 @@ func (e serviceError) Error() string {
 	return e.message
 }
 
+// HTTPStatus implements the RequestError interface for the serviceError.
+func (e serviceError) HTTPStatus() int {
+	return e.status
+}
+
+// RetryAfter implements the RequestError interface for the serviceError.
+func (e serviceError) RetryAfter() time.Duration {
+	return e.retryAfter
+}
+
 // writeErrorResponse writes the error response to the connection using the specified HTTP status code and error object.
 func writeErrorResponse(w http.ResponseWriter, status int, err error) {
 	errorResponse := common.ErrorResponse{
@@ -29,3 +80,23 @@ func writeErrorResponse(w http.ResponseWriter, status int, err error) {
 	w.WriteHeader(status)
 	w.Write(resp)
 }
+
+// writeServiceError writes the error response for the serviceError.
+// The Retry-After header is set if the request exceeded the limits.
+func writeServiceError(w http.ResponseWriter, err *serviceError) {
+	if err.retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(err.retryAfter)))
+	}
+
+	writeErrorResponse(w, err.status, err)
+}
+
+// writeLimitError writes the response to the request exceeding the limits with the Retry-After header.
+func writeLimitError(w http.ResponseWriter, err error) {
+	writeServiceError(w, limitError(err))
+}
+
+// retryAfterSeconds returns the wait in the whole seconds rounded up.
+func retryAfterSeconds(wait time.Duration) int {
+	return int(math.Ceil(wait.Seconds()))
+}
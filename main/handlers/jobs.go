@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/auth"
+	"modulus/kyc/main/jobs"
+)
+
+// JobsPath is the path prefix of the async jobs handler.
+// The full path of a job is /Jobs/{id}.
+const JobsPath = "/Jobs/"
+
+// Jobs handles requests for the progress and the final response of the async CheckCustomer jobs.
+func Jobs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	id := strings.TrimPrefix(r.URL.Path, JobsPath)
+	if len(id) == 0 {
+		writeErrorResponse(w, http.StatusBadRequest, errors.New("missing job id in the request"))
+		return
+	}
+
+	job, err := jobs.Get(id)
+	if err == nil {
+		if clientID := auth.ClientID(r.Context()); len(clientID) > 0 && job.ClientID != clientID {
+			err = jobs.ErrNotFound
+		}
+	}
+
+	switch err {
+	case nil:
+	case jobs.ErrNotFound:
+		writeErrorResponse(w, http.StatusNotFound, err)
+		return
+	case jobs.ErrDisabled:
+		writeErrorResponse(w, http.StatusServiceUnavailable, err)
+		return
+	default:
+		writeErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp, err := json.Marshal(job.JobResponse())
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Write(resp)
+}
+
+// RunJob runs the CheckCustomer request of the async job or of the batch record on behalf of the client.
+// It's meant to be the runner of the jobs queue and of the batch processor.
+func RunJob(ctx context.Context, clientID string, req common.CheckCustomerRequest) (response common.KYCResponse, ids []string, err error) {
+	if len(clientID) > 0 {
+		ctx = auth.WithClient(ctx, auth.Client{ID: clientID})
+	}
+
+	check, err1 := checkCustomer(ctx, req, false)
+	if err1 != nil {
+		err = err1
+		return
+	}
+
+	for _, p := range check.providers {
+		if id := check.ids[p]; len(id) > 0 {
+			ids = append(ids, id)
+		}
+	}
+	response = check.response
+
+	return
+}
+
+// submitJob validates the CheckCustomer request and queues it as the async job.
+// The response holds the queued job and its location.
+func submitJob(w http.ResponseWriter, r *http.Request, req common.CheckCustomerRequest) {
+	if _, _, err := prepareCustomerCheck(r.Context(), req); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	job, err := jobs.Submit(auth.ClientID(r.Context()), req)
+	switch err {
+	case nil:
+	case jobs.ErrQueueFull, jobs.ErrDisabled:
+		writeErrorResponse(w, http.StatusServiceUnavailable, err)
+		return
+	default:
+		writeErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp, err := json.Marshal(job.JobResponse())
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Location", JobsPath+job.ID)
+	w.WriteHeader(http.StatusAccepted)
+	w.Write(resp)
+}
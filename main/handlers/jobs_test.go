@@ -0,0 +1,120 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/auth"
+	"modulus/kyc/main/handlers"
+	"modulus/kyc/main/jobs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobs(t *testing.T) {
+	assert := assert.New(t)
+
+	request, err := json.Marshal(&common.CheckCustomerRequest{
+		Provider: common.Example,
+		UserData: &common.UserData{FirstName: "Abby"},
+		Async:    true,
+	})
+	assert.NoError(err)
+
+	// Testing the jobs aren't started.
+	req := httptest.NewRequest(http.MethodPost, "/CheckCustomer", bytes.NewReader(request))
+	w := httptest.NewRecorder()
+
+	handlers.CheckCustomer(w, req)
+
+	assert.Equal(http.StatusServiceUnavailable, w.Code)
+	assert.Equal(`{"Error":"async jobs aren't configured"}`, w.Body.String())
+
+	dir, err := ioutil.TempDir("", "jobs")
+	if !assert.NoError(err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	err = jobs.Start(jobs.Config{Dir: dir, Workers: 1, MaxQueued: 10, Retention: time.Hour}, handlers.RunJob)
+	if !assert.NoError(err) {
+		return
+	}
+	defer jobs.Stop()
+
+	// Testing the invalid request is rejected before it's queued.
+	invalid, _ := json.Marshal(&common.CheckCustomerRequest{Async: true})
+
+	req = httptest.NewRequest(http.MethodPost, "/CheckCustomer", bytes.NewReader(invalid))
+	w = httptest.NewRecorder()
+
+	handlers.CheckCustomer(w, req)
+
+	assert.Equal(http.StatusBadRequest, w.Code)
+	assert.Equal(`{"Error":"missing KYC provider id in the request"}`, w.Body.String())
+
+	// Testing the async request.
+	client := auth.Client{ID: "backoffice"}
+
+	req = httptest.NewRequest(http.MethodPost, "/CheckCustomer", bytes.NewReader(request))
+	req = req.WithContext(auth.WithClient(req.Context(), client))
+	w = httptest.NewRecorder()
+
+	handlers.CheckCustomer(w, req)
+
+	assert.Equal(http.StatusAccepted, w.Code)
+
+	job := common.JobResponse{}
+
+	err = json.Unmarshal(w.Body.Bytes(), &job)
+	assert.NoError(err)
+	assert.NotEmpty(job.ID)
+	assert.Equal(jobs.Queued, job.State)
+	assert.Equal(handlers.JobsPath+job.ID, w.Header().Get("Location"))
+
+	// Testing the job is completed.
+	deadline := time.Now().Add(5 * time.Second)
+	for job.State != jobs.Completed && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+
+		req = httptest.NewRequest(http.MethodGet, handlers.JobsPath+job.ID, nil)
+		req = req.WithContext(auth.WithClient(req.Context(), client))
+		w = httptest.NewRecorder()
+
+		handlers.Jobs(w, req)
+
+		assert.Equal(http.StatusOK, w.Code)
+		assert.NoError(json.Unmarshal(w.Body.Bytes(), &job))
+	}
+
+	assert.Equal(jobs.Completed, job.State)
+	if assert.NotNil(job.Response) && assert.NotNil(job.Response.Result) {
+		assert.Equal("Approved", job.Response.Result.Status)
+	}
+	assert.NotNil(job.Finished)
+
+	// Testing the job of another client isn't found.
+	req = httptest.NewRequest(http.MethodGet, handlers.JobsPath+job.ID, nil)
+	req = req.WithContext(auth.WithClient(req.Context(), auth.Client{ID: "another"}))
+	w = httptest.NewRecorder()
+
+	handlers.Jobs(w, req)
+
+	assert.Equal(http.StatusNotFound, w.Code)
+	assert.Equal(`{"Error":"job not found"}`, w.Body.String())
+
+	// Testing the missing job id.
+	req = httptest.NewRequest(http.MethodGet, handlers.JobsPath, nil)
+	w = httptest.NewRecorder()
+
+	handlers.Jobs(w, req)
+
+	assert.Equal(http.StatusBadRequest, w.Code)
+}
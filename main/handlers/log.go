@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"log/slog"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/logging"
+)
+
+// logResponse logs the summary of the verification response.
+// The details of the result are logged on the debug level only.
+func logResponse(msg string, provider common.KYCProvider, referenceID string, response common.KYCResponse) {
+	attrs := []any{logging.ProviderKey, provider}
+	if len(referenceID) > 0 {
+		attrs = append(attrs, logging.ReferenceIDKey, referenceID)
+	}
+	if response.Result != nil {
+		attrs = append(attrs, "status", response.Result.Status)
+		if len(response.Result.ErrorCode) > 0 {
+			attrs = append(attrs, "errorCode", response.Result.ErrorCode)
+		}
+	}
+	if len(response.Error) > 0 {
+		attrs = append(attrs, logging.ErrorKey, response.Error)
+	}
+
+	slog.Info(msg, attrs...)
+
+	if response.Result != nil && response.Result.Details != nil {
+		slog.Debug(msg+" details", logging.ProviderKey, provider, logging.ReferenceIDKey, referenceID, "details", response.Result.Details)
+	}
+}
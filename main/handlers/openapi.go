@@ -0,0 +1,404 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+
+	"modulus/kyc/common"
+	"modulus/kyc/integrations/ciphertrace"
+	"modulus/kyc/main/auth"
+	"modulus/kyc/main/batch"
+	"modulus/kyc/main/events"
+	"modulus/kyc/main/handlers/providers"
+	"modulus/kyc/main/idempotency"
+	"modulus/kyc/main/jobs"
+	"modulus/kyc/main/limits"
+	"modulus/kyc/main/openapi"
+	"modulus/kyc/main/store"
+)
+
+// OpenAPIPath is the path of the OpenAPI document of the service.
+const OpenAPIPath = "/openapi.json"
+
+var (
+	specOnce sync.Once
+	spec     *openapi.Spec
+	specJSON []byte
+)
+
+// OpenAPISpec returns the OpenAPI specification of the API generated from the models of the handlers payloads.
+func OpenAPISpec() *openapi.Spec {
+	specOnce.Do(func() {
+		spec = newOpenAPISpec()
+		specJSON, _ = json.Marshal(spec.Document())
+	})
+
+	return spec
+}
+
+// OpenAPI handles requests for the OpenAPI document of the service.
+func OpenAPI(w http.ResponseWriter, r *http.Request) {
+	OpenAPISpec()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(specJSON)
+}
+
+// newOpenAPISpec describes the endpoints of the API.
+func newOpenAPISpec() *openapi.Spec {
+	s := openapi.New(openapi.Info{
+		Title:       "KYC service API",
+		Description: "The API of the KYC verifications using the KYC providers.",
+		Version:     "2.0.0",
+	})
+
+	kycProviders := []interface{}{string(common.Example)}
+	for _, provider := range common.KnownProviders() {
+		kycProviders = append(kycProviders, string(provider))
+	}
+	sort.Slice(kycProviders, func(i, j int) bool {
+		return kycProviders[i].(string) < kycProviders[j].(string)
+	})
+
+	s.Enum(common.KYCProvider(""), kycProviders...)
+	s.Enum(common.StrategyMode(""), string(common.Fallback), string(common.Parallel))
+	s.Enum(common.Consensus(""), string(common.AllApprove), string(common.AnyDenial), string(common.Majority))
+	s.Enum(common.DocumentType(""), string(common.IDCardType), string(common.PassportType), string(common.DriverLicenseType), string(common.CreditCardType), string(common.DebitCardType))
+	s.Enum(common.Gender(0), int(common.Male), int(common.Female))
+	s.Enum(common.ErrorType(""), string(common.ValidationError), string(common.UnauthorizedError), string(common.ForbiddenError), string(common.NotFoundError),
+		string(common.ConflictError), string(common.UnsupportedError), string(common.RateLimitedError), string(common.ConfigError), string(common.ProviderUnavailableError),
+		string(common.ProviderRejectedError), string(common.InternalError))
+	s.Enum(events.Source(""), string(events.Check), string(events.Callback), string(events.Polling))
+
+	s.FieldEnum(common.Result{}, "Status", enumValues(common.KYCStatus2Status)...)
+	s.FieldEnum(common.Details{}, "Finality", enumValues(common.KYCFinality2Finality)...)
+	s.FieldEnum(common.ResultV2{}, "Status", enumValues(common.KYCStatus2StatusV2)...)
+	s.FieldEnum(common.ResultV2{}, "Finality", enumValues(common.KYCFinality2FinalityV2)...)
+	s.FieldEnum(common.JobResponse{}, "State", jobs.Queued, jobs.Running, jobs.Completed, jobs.Failed)
+	s.FieldEnum(common.BatchResponse{}, "State", batch.Queued, batch.Running, batch.Completed, batch.Failed)
+
+	s.Describe(common.KYCProvider(""), "The identificator of the KYC provider")
+	s.Describe(common.Gender(0), "The gender of the customer: 1 is male, 2 is female")
+	s.Describe(common.UserData{}, "The verification data of the customer")
+	s.Describe(common.CustomerV2{}, "The verification data of the customer")
+
+	s.SecurityScheme("apiKey", &openapi.SecurityScheme{
+		Type: "apiKey",
+		Name: auth.APIKeyHeader,
+		In:   "header",
+	})
+	s.SecurityScheme("bearer", &openapi.SecurityScheme{
+		Type:         "http",
+		Scheme:       "bearer",
+		BearerFormat: "JWT",
+	})
+
+	errorResponse := common.ErrorResponse{}
+	errorResponseV2 := common.ErrorResponseV2{}
+
+	s.Add(openapi.Endpoint{
+		Method: http.MethodGet, Path: "/", ID: "welcome",
+		Summary:   "Answers with the welcome message",
+		Responses: map[int]interface{}{http.StatusOK: "The welcome message"},
+	})
+	s.Add(openapi.Endpoint{
+		Method: http.MethodGet, Path: "/Ping", ID: "ping",
+		Summary:   "Answers with the Pong! message",
+		Responses: map[int]interface{}{http.StatusOK: "Pong!"},
+	})
+	s.Add(openapi.Endpoint{
+		Method: http.MethodGet, Path: OpenAPIPath, ID: "openAPI",
+		Summary:   "Returns this document",
+		Responses: map[int]interface{}{http.StatusOK: map[string]interface{}{}},
+	})
+	s.Add(openapi.Endpoint{
+		Method: http.MethodPost, Path: "/CheckCustomer", ID: "checkCustomer",
+		Summary: "Verifies the customer using the KYC provider or the strategy using several providers",
+		Params:  []openapi.Parameter{idempotencyKeyParam(s)},
+		Request: common.CheckCustomerRequest{},
+		Responses: map[int]interface{}{
+			http.StatusOK:                  common.KYCResponse{},
+			http.StatusAccepted:            common.JobResponse{},
+			http.StatusBadRequest:          errorResponse,
+			http.StatusUnauthorized:        errorResponse,
+			http.StatusForbidden:           errorResponse,
+			http.StatusNotFound:            errorResponse,
+			http.StatusConflict:            errorResponse,
+			http.StatusUnprocessableEntity: errorResponse,
+			http.StatusTooManyRequests:     errorResponse,
+			http.StatusInternalServerError: errorResponse,
+			http.StatusServiceUnavailable:  errorResponse,
+		},
+	})
+	s.Add(openapi.Endpoint{
+		Method: http.MethodPost, Path: "/CheckStatus", ID: "checkStatus",
+		Summary: "Checks the current status of the verification",
+		Request: common.CheckStatusRequest{},
+		Responses: map[int]interface{}{
+			http.StatusOK:                  common.KYCResponse{},
+			http.StatusBadRequest:          errorResponse,
+			http.StatusUnauthorized:        errorResponse,
+			http.StatusForbidden:           errorResponse,
+			http.StatusNotFound:            errorResponse,
+			http.StatusUnprocessableEntity: errorResponse,
+			http.StatusTooManyRequests:     errorResponse,
+			http.StatusInternalServerError: errorResponse,
+		},
+	})
+	s.Add(openapi.Endpoint{
+		Method: http.MethodPost, Path: "/v2/CheckCustomer", ID: "checkCustomerV2",
+		Summary: "Verifies the customer using the KYC provider or the strategy using several providers",
+		Params:  []openapi.Parameter{idempotencyKeyParam(s)},
+		Request: common.CheckCustomerRequestV2{},
+		Responses: map[int]interface{}{
+			http.StatusOK:                  common.KYCResponseV2{},
+			http.StatusBadRequest:          errorResponseV2,
+			http.StatusUnauthorized:        errorResponseV2,
+			http.StatusForbidden:           errorResponseV2,
+			http.StatusNotFound:            errorResponseV2,
+			http.StatusConflict:            errorResponseV2,
+			http.StatusUnprocessableEntity: errorResponseV2,
+			http.StatusTooManyRequests:     errorResponseV2,
+			http.StatusInternalServerError: errorResponseV2,
+			http.StatusServiceUnavailable:  errorResponseV2,
+		},
+	})
+	s.Add(openapi.Endpoint{
+		Method: http.MethodPost, Path: "/v2/CheckStatus", ID: "checkStatusV2",
+		Summary: "Checks the current status of the verification",
+		Request: common.CheckStatusRequestV2{},
+		Responses: map[int]interface{}{
+			http.StatusOK:                  common.KYCResponseV2{},
+			http.StatusBadRequest:          errorResponseV2,
+			http.StatusUnauthorized:        errorResponseV2,
+			http.StatusForbidden:           errorResponseV2,
+			http.StatusNotFound:            errorResponseV2,
+			http.StatusUnprocessableEntity: errorResponseV2,
+			http.StatusTooManyRequests:     errorResponseV2,
+			http.StatusInternalServerError: errorResponseV2,
+		},
+	})
+	s.Add(openapi.Endpoint{
+		Method: http.MethodGet, Path: "/Provider", ID: "provider",
+		Summary: "Lists the implemented KYC providers or checks whether the provider is implemented",
+		Params: []openapi.Parameter{
+			queryParam(s, "name", "The KYC provider to check. The implemented providers are listed if it's omitted", false),
+		},
+		Responses: map[int]interface{}{
+			http.StatusOK:         openapi.OneOf{providers.ProviderList{}, isProviderImplementedResp{}},
+			http.StatusBadRequest: errorResponse,
+		},
+	})
+	s.Add(openapi.Endpoint{
+		Method: http.MethodGet, Path: "/Status", ID: "trackedStatus",
+		Summary: "Returns the latest known status of the verification tracked by the service",
+		Params: []openapi.Parameter{
+			queryParam(s, "provider", "The KYC provider of the verification", true),
+			queryParam(s, "referenceID", "The identificator of the verification", true),
+		},
+		Responses: map[int]interface{}{
+			http.StatusOK:           common.TrackedStatusResponse{},
+			http.StatusBadRequest:   errorResponse,
+			http.StatusUnauthorized: errorResponse,
+			http.StatusForbidden:    errorResponse,
+			http.StatusNotFound:     errorResponse,
+		},
+	})
+	s.Add(openapi.Endpoint{
+		Method: http.MethodGet, Path: JobsPath + "{id}", ID: "job",
+		Summary: "Returns the progress and the final response of the async CheckCustomer job",
+		Params: []openapi.Parameter{
+			{Name: "id", In: "path", Required: true, Description: "The identificator of the job", Schema: s.Schema("")},
+		},
+		Responses: map[int]interface{}{
+			http.StatusOK:                  common.JobResponse{},
+			http.StatusBadRequest:          errorResponse,
+			http.StatusUnauthorized:        errorResponse,
+			http.StatusForbidden:           errorResponse,
+			http.StatusNotFound:            errorResponse,
+			http.StatusInternalServerError: errorResponse,
+			http.StatusServiceUnavailable:  errorResponse,
+		},
+	})
+	s.Add(openapi.Endpoint{
+		Method: http.MethodPost, Path: BatchesPath, ID: "submitBatch",
+		Summary: "Queues the verification of the batch of the customers by the KYC provider",
+		Params: []openapi.Parameter{
+			queryParam(s, "provider", "The KYC provider verifying the customers", true),
+		},
+		Request: openapi.File{
+			batch.NDJSON: "The BatchRecord objects, one per line",
+			batch.CSV:    "The header with the ID and the UserData field names, e.g. CurrentAddress.Town, and the rows of the records",
+		},
+		Responses: map[int]interface{}{
+			http.StatusAccepted:            common.BatchResponse{},
+			http.StatusBadRequest:          errorResponse,
+			http.StatusUnauthorized:        errorResponse,
+			http.StatusForbidden:           errorResponse,
+			http.StatusNotFound:            errorResponse,
+			http.StatusUnprocessableEntity: errorResponse,
+			http.StatusInternalServerError: errorResponse,
+			http.StatusServiceUnavailable:  errorResponse,
+		},
+	})
+	s.Add(openapi.Endpoint{
+		Method: http.MethodGet, Path: BatchesPath + "{id}", ID: "batch",
+		Summary: "Returns the progress of the batch verification",
+		Params: []openapi.Parameter{
+			{Name: "id", In: "path", Required: true, Description: "The identificator of the batch", Schema: s.Schema("")},
+		},
+		Responses: map[int]interface{}{
+			http.StatusOK:                  common.BatchResponse{},
+			http.StatusUnauthorized:        errorResponse,
+			http.StatusForbidden:           errorResponse,
+			http.StatusNotFound:            errorResponse,
+			http.StatusInternalServerError: errorResponse,
+			http.StatusServiceUnavailable:  errorResponse,
+		},
+	})
+	s.Add(openapi.Endpoint{
+		Method: http.MethodGet, Path: BatchesPath + "{id}" + resultsSuffix, ID: "batchResults",
+		Summary: "Downloads the results of the batch verification checkpointed so far",
+		Params: []openapi.Parameter{
+			{Name: "id", In: "path", Required: true, Description: "The identificator of the batch", Schema: s.Schema("")},
+		},
+		Responses: map[int]interface{}{
+			http.StatusOK:                  openapi.File{batch.NDJSON: "The BatchResult objects in the order of the records, one per line"},
+			http.StatusUnauthorized:        errorResponse,
+			http.StatusForbidden:           errorResponse,
+			http.StatusNotFound:            errorResponse,
+			http.StatusInternalServerError: errorResponse,
+			http.StatusServiceUnavailable:  errorResponse,
+		},
+	})
+	s.Add(openapi.Endpoint{
+		Method: http.MethodGet, Path: VerificationsPath + "{id}", ID: "verification",
+		Summary: "Returns the recorded verification with its history",
+		Params: []openapi.Parameter{
+			{Name: "id", In: "path", Required: true, Description: "The identificator of the verification", Schema: s.Schema("")},
+		},
+		Responses: map[int]interface{}{
+			http.StatusOK:                  store.Verification{},
+			http.StatusUnauthorized:        errorResponse,
+			http.StatusForbidden:           errorResponse,
+			http.StatusNotFound:            errorResponse,
+			http.StatusInternalServerError: errorResponse,
+		},
+	})
+	s.Add(openapi.Endpoint{
+		Method: http.MethodGet, Path: VerificationsPath, ID: "verifications",
+		Summary: "Looks up the verification by the provider reference or the verifications of the customer by the fingerprint",
+		Params: []openapi.Parameter{
+			queryParam(s, "fingerprint", "The fingerprint of the customer data", false),
+			queryParam(s, "provider", "The KYC provider of the verification", false),
+			queryParam(s, "referenceID", "The identificator of the verification assigned by the provider", false),
+		},
+		Responses: map[int]interface{}{
+			http.StatusOK:                  openapi.OneOf{store.Verification{}, []store.Verification{}},
+			http.StatusBadRequest:          errorResponse,
+			http.StatusUnauthorized:        errorResponse,
+			http.StatusForbidden:           errorResponse,
+			http.StatusNotFound:            errorResponse,
+			http.StatusInternalServerError: errorResponse,
+		},
+	})
+	s.Add(openapi.Endpoint{
+		Method: http.MethodGet, Path: "/Usage", ID: "usage",
+		Summary: "Returns the usage of the KYC providers by the API clients in the current day and month",
+		Params: []openapi.Parameter{
+			queryParam(s, "client", "The API client. The authenticated client gets only its own usage", false),
+			queryParam(s, "provider", "The KYC provider", false),
+		},
+		Responses: map[int]interface{}{
+			http.StatusOK:           []limits.Usage{},
+			http.StatusUnauthorized: errorResponse,
+			http.StatusForbidden:    errorResponse,
+		},
+	})
+	s.Add(openapi.Endpoint{
+		Method: http.MethodGet, Path: AdminConfigPath, ID: "adminConfig",
+		Summary: "Returns the version of the active service config and the error of its latest reload",
+		Responses: map[int]interface{}{
+			http.StatusOK:           common.ConfigResponse{},
+			http.StatusUnauthorized: errorResponse,
+			http.StatusForbidden:    errorResponse,
+		},
+	})
+	s.Add(openapi.Endpoint{
+		Method: http.MethodPost, Path: CallbackPath + "{provider}", ID: "callback",
+		Summary: "Receives the callback of the KYC provider. The payload is specific for the provider. The callback is acknowledged without the result",
+		Params: []openapi.Parameter{
+			{Name: "provider", In: "path", Required: true, Description: "The KYC provider", Schema: s.Schema(common.KYCProvider(""))},
+		},
+		Responses: map[int]interface{}{
+			http.StatusOK:                  nil,
+			http.StatusBadRequest:          errorResponse,
+			http.StatusUnauthorized:        errorResponse,
+			http.StatusNotFound:            errorResponse,
+			http.StatusUnprocessableEntity: errorResponse,
+			http.StatusInternalServerError: errorResponse,
+		},
+	})
+	s.Add(openapi.Endpoint{
+		Method: http.MethodPost, Path: "/cipherTrace", ID: "cipherTrace",
+		Summary: "Returns the risk of the BTC or the ETH address",
+		Request: struct {
+			Coin   string `json:"coin"`
+			TxHash string `json:"txHash"`
+		}{},
+		Responses: map[int]interface{}{
+			http.StatusOK:                  ciphertrace.AddressRisk{},
+			http.StatusBadRequest:          errorResponse,
+			http.StatusUnauthorized:        errorResponse,
+			http.StatusForbidden:           errorResponse,
+			http.StatusTooManyRequests:     errorResponse,
+			http.StatusInternalServerError: errorResponse,
+		},
+	})
+	s.Add(openapi.Endpoint{
+		Method: http.MethodGet, Path: "/metrics", ID: "metrics",
+		Summary:   "Exposes the service metrics in the Prometheus text format",
+		Responses: map[int]interface{}{http.StatusOK: "The metrics"},
+	})
+
+	return s
+}
+
+// queryParam returns the string query parameter.
+func queryParam(s *openapi.Spec, name, description string, required bool) openapi.Parameter {
+	return openapi.Parameter{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Required:    required,
+		Schema:      s.Schema(""),
+	}
+}
+
+// idempotencyKeyParam returns the optional Idempotency-Key header param of the request.
+func idempotencyKeyParam(s *openapi.Spec) openapi.Parameter {
+	return openapi.Parameter{
+		Name:        idempotency.Header,
+		In:          "header",
+		Description: "The unique key of the request. The retries of the request with the same key get the response to the first one",
+		Schema:      s.Schema(""),
+	}
+}
+
+// enumValues returns the values of the mapping, e.g. common.KYCStatus2Status, sorted.
+func enumValues(mapping interface{}) (values []interface{}) {
+	names := []string{}
+	for iter := reflect.ValueOf(mapping).MapRange(); iter.Next(); {
+		names = append(names, iter.Value().String())
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		values = append(values, name)
+	}
+
+	return
+}
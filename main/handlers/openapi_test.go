@@ -0,0 +1,155 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"modulus/kyc/main/handlers"
+	"modulus/kyc/main/jobs"
+	"modulus/kyc/main/store"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// serveOpenAPI serves the request by the handler and checks the response conforms to the OpenAPI document.
+func serveOpenAPI(t *testing.T, handler http.HandlerFunc, method, target, body string) *httptest.ResponseRecorder {
+	spec := handlers.OpenAPISpec()
+	path := strings.Split(target, "?")[0]
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler(w, req)
+
+	err := spec.ValidateResponse(method, path, w.Code, w.Header().Get("Content-Type"), w.Body.Bytes())
+
+	assert.NoError(t, err, "%s %s response %d: %s", method, target, w.Code, w.Body.String())
+
+	return w
+}
+
+func TestOpenAPI(t *testing.T) {
+	assert := assert.New(t)
+
+	w := serveOpenAPI(t, handlers.OpenAPI, http.MethodGet, handlers.OpenAPIPath, "")
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	doc := map[string]interface{}{}
+
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal("3.0.3", doc["openapi"])
+	assert.Contains(doc["paths"], "/CheckCustomer")
+	assert.Contains(doc["paths"], "/v2/CheckCustomer")
+	assert.Contains(doc["components"].(map[string]interface{})["schemas"], "UserData")
+}
+
+func TestOpenAPIExamples(t *testing.T) {
+	assert := assert.New(t)
+
+	files, err := filepath.Glob("../../docs/examples/*_request.json")
+
+	assert.NoError(err)
+	assert.NotEmpty(files)
+
+	for _, file := range files {
+		request, err := ioutil.ReadFile(file)
+		if !assert.NoError(err) {
+			continue
+		}
+
+		assert.NoError(handlers.OpenAPISpec().ValidateRequest(http.MethodPost, "/CheckCustomer", request), file)
+	}
+}
+
+func TestOpenAPIHandlers(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "handlers")
+	if !assert.NoError(err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	err = store.Start(store.Config{Driver: store.BoltDriver, DSN: filepath.Join(dir, "kyc.db")})
+	if !assert.NoError(err) {
+		return
+	}
+	defer store.Stop()
+
+	err = jobs.Start(jobs.Config{Dir: filepath.Join(dir, "jobs"), Workers: 1, MaxQueued: 10, Retention: time.Hour}, handlers.RunJob)
+	if !assert.NoError(err) {
+		return
+	}
+	defer jobs.Stop()
+
+	testCases := []struct {
+		handler http.HandlerFunc
+		method  string
+		target  string
+		body    string
+		status  int
+	}{
+		{handlers.CheckCustomer, http.MethodPost, "/CheckCustomer", `{"Provider":"Example","UserData":{"FirstName":"Abby","Gender":1}}`, http.StatusOK},
+		{handlers.CheckCustomer, http.MethodPost, "/CheckCustomer", `{"Provider":"Example","UserData":{"FirstName":"Destiny"}}`, http.StatusOK},
+		{handlers.CheckCustomer, http.MethodPost, "/CheckCustomer", `{"Provider":"Example","UserData":{"FirstName":"Urbi"}}`, http.StatusOK},
+		{handlers.CheckCustomer, http.MethodPost, "/CheckCustomer", `{"Provider":"Example","UserData":{"FirstName":"Erika"}}`, http.StatusOK},
+		{handlers.CheckCustomer, http.MethodPost, "/CheckCustomer", `{"Strategy":{"Mode":"Parallel","Providers":["Example"],"Consensus":"Majority"},"UserData":{"FirstName":"Abby"}}`, http.StatusOK},
+		{handlers.CheckCustomer, http.MethodPost, "/CheckCustomer", `{"Provider":"Acme","UserData":{}}`, http.StatusNotFound},
+		{handlers.CheckCustomer, http.MethodPost, "/CheckCustomer", `{"UserData":{}}`, http.StatusBadRequest},
+		{handlers.CheckCustomer, http.MethodPost, "/CheckCustomer", `{"Provider":"Example","UserData":{"FirstName":"Abby"},"Async":true}`, http.StatusAccepted},
+		{handlers.CheckStatus, http.MethodPost, "/CheckStatus", `{"Provider":"Example","ReferenceID":"uma"}`, http.StatusOK},
+		{handlers.CheckStatus, http.MethodPost, "/CheckStatus", `{"Provider":"Example","ReferenceID":"elin"}`, http.StatusOK},
+		{handlers.CheckCustomerV2, http.MethodPost, "/v2/CheckCustomer", `{"provider":"Example","customer":{"first_name":"Delilah"}}`, http.StatusOK},
+		{handlers.CheckCustomerV2, http.MethodPost, "/v2/CheckCustomer", `{"provider":"Example","customer":{"first_name":"Erika"},"include_raw":true}`, http.StatusOK},
+		{handlers.CheckCustomerV2, http.MethodPost, "/v2/CheckCustomer", `{"strategy":{"mode":"Fallback","providers":["Example"]},"customer":{"first_name":"Abby"}}`, http.StatusOK},
+		{handlers.CheckCustomerV2, http.MethodPost, "/v2/CheckCustomer", `{"provider":"Example"}`, http.StatusBadRequest},
+		{handlers.CheckStatusV2, http.MethodPost, "/v2/CheckStatus", `{"provider":"Example","reference_id":"uma"}`, http.StatusOK},
+		{handlers.CheckStatusV2, http.MethodPost, "/v2/CheckStatus", `{"provider":"Acme","reference_id":"uma"}`, http.StatusNotFound},
+		{handlers.IsProviderImplemented, http.MethodGet, "/Provider", ``, http.StatusOK},
+		{handlers.IsProviderImplemented, http.MethodGet, "/Provider?name=IDology", ``, http.StatusOK},
+		{handlers.IsProviderImplemented, http.MethodGet, "/Provider?name=", ``, http.StatusBadRequest},
+		{handlers.TrackedStatus, http.MethodGet, "/Status?provider=Example&referenceID=unknown", ``, http.StatusNotFound},
+		{handlers.TrackedStatus, http.MethodGet, "/Status", ``, http.StatusBadRequest},
+		{handlers.Verifications, http.MethodGet, "/Verifications/unknown", ``, http.StatusNotFound},
+		{handlers.Verifications, http.MethodGet, "/Verifications/?provider=Example&referenceID=lily_was_here", ``, http.StatusOK},
+		{handlers.Verifications, http.MethodGet, "/Verifications/?fingerprint=unknown", ``, http.StatusOK},
+		{handlers.Verifications, http.MethodGet, "/Verifications/", ``, http.StatusBadRequest},
+		{handlers.Jobs, http.MethodGet, "/Jobs/unknown", ``, http.StatusNotFound},
+		{handlers.Usage, http.MethodGet, "/Usage", ``, http.StatusOK},
+		{handlers.AdminConfig, http.MethodGet, handlers.AdminConfigPath, ``, http.StatusOK},
+		{handlers.Callback, http.MethodPost, "/Callback/Acme", ``, http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		// The invalid requests don't conform to the document.
+		if len(tc.body) > 0 && (tc.status == http.StatusOK || tc.status == http.StatusAccepted) {
+			assert.NoError(handlers.OpenAPISpec().ValidateRequest(tc.method, tc.target, []byte(tc.body)), "%s %s", tc.target, tc.body)
+		}
+
+		w := serveOpenAPI(t, tc.handler, tc.method, tc.target, tc.body)
+
+		assert.Equal(tc.status, w.Code, "%s %s", tc.target, tc.body)
+	}
+
+	// Testing the recorded verification.
+	w := serveOpenAPI(t, handlers.Verifications, http.MethodGet, "/Verifications/?provider=Example&referenceID=lily_was_here", "")
+
+	verification := store.Verification{}
+
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &verification))
+
+	serveOpenAPI(t, handlers.Verifications, http.MethodGet, handlers.VerificationsPath+verification.ID, "")
+
+	// Testing the queued job.
+	w = serveOpenAPI(t, handlers.CheckCustomer, http.MethodPost, "/CheckCustomer", `{"Provider":"Example","UserData":{"FirstName":"Urbi"},"Async":true}`)
+
+	serveOpenAPI(t, handlers.Jobs, http.MethodGet, w.Header().Get("Location"), "")
+}
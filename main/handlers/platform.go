@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+
+	"modulus/kyc/common"
+	// Make implemented KYC providers available for the handlers.
+	_ "modulus/kyc/integrations"
+	"modulus/kyc/main/config"
+)
+
+// lookupProvider returns the spec and the config options for the specified provider or an error if occurred.
+func lookupProvider(provider common.KYCProvider) (spec common.ProviderSpec, options config.Options, err *serviceError) {
+	spec, ok := common.LookupProvider(provider)
+	if !ok && provider != common.CipherTrace {
+		err = &serviceError{
+			status:  http.StatusNotFound,
+			message: fmt.Sprintf("unknown KYC provider in the request: %s", provider),
+		}
+		return
+	}
+
+	options, found := config.Get()[string(provider)]
+	if !found {
+		err = &serviceError{
+			status:  http.StatusInternalServerError,
+			message: fmt.Sprintf("missing config for %s", provider),
+			config:  true,
+		}
+		return
+	}
+
+	if !ok {
+		err = &serviceError{
+			status:  http.StatusUnprocessableEntity,
+			message: fmt.Sprintf("KYC provider not implemented yet: %s", provider),
+		}
+	}
+
+	return
+}
+
+// platform is the KYCPlatformContext object along with the config options it's constructed with.
+type platform struct {
+	options config.Options
+	service common.KYCPlatformContext
+}
+
+// platforms caches the KYCPlatformContext objects of the providers.
+// The object is constructed again only when the config section of its provider changes,
+// so the HTTP clients of the providers with the unchanged config survive the config reloads.
+var (
+	platformsMu sync.Mutex
+	platforms   = map[common.KYCProvider]platform{}
+)
+
+// newPlatform returns the KYCPlatformContext object for the provider spec and its config options.
+// The cached object is returned if it's constructed with the same options.
+func newPlatform(spec common.ProviderSpec, options config.Options) (service common.KYCPlatformContext, err *serviceError) {
+	platformsMu.Lock()
+	defer platformsMu.Unlock()
+
+	if cached, ok := platforms[spec.Name]; ok && reflect.DeepEqual(cached.options, options) {
+		service = cached.service
+		return
+	}
+
+	service, err1 := spec.Factory(options)
+	if err1 != nil {
+		err = &serviceError{
+			status:  http.StatusInternalServerError,
+			message: fmt.Sprintf("%s config error: %s", spec.Name, err1),
+			config:  true,
+		}
+		return
+	}
+
+	platforms[spec.Name] = platform{options: options, service: service}
+
+	return
+}
@@ -0,0 +1,93 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/config"
+	"modulus/kyc/main/handlers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// cachedPlatform approves every customer.
+type cachedPlatform struct{}
+
+func (p cachedPlatform) CheckCustomer(customer *common.UserData) (common.KYCResult, error) {
+	return p.CheckCustomerContext(context.Background(), customer)
+}
+
+func (p cachedPlatform) CheckStatus(referenceID string) (common.KYCResult, error) {
+	return p.CheckStatusContext(context.Background(), referenceID)
+}
+
+func (cachedPlatform) CheckCustomerContext(ctx context.Context, customer *common.UserData) (common.KYCResult, error) {
+	return common.KYCResult{Status: common.Approved}, nil
+}
+
+func (cachedPlatform) CheckStatusContext(ctx context.Context, referenceID string) (common.KYCResult, error) {
+	return common.KYCResult{Status: common.Approved}, nil
+}
+
+func TestPlatformCache(t *testing.T) {
+	assert := assert.New(t)
+
+	const provider = common.KYCProvider("Cached Provider")
+
+	var constructed int32
+	common.RegisterProvider(common.ProviderSpec{
+		Name: provider,
+		Factory: func(options map[string]string) (common.KYCPlatformContext, error) {
+			atomic.AddInt32(&constructed, 1)
+			return cachedPlatform{}, nil
+		},
+	})
+
+	previous := config.Get()
+	defer config.Set(previous)
+
+	request, err := json.Marshal(&common.CheckCustomerRequest{
+		Provider: provider,
+		UserData: &common.UserData{FirstName: "Abby"},
+	})
+
+	assert.NoError(err)
+
+	check := func() {
+		req := httptest.NewRequest(http.MethodPost, "/CheckCustomer", bytes.NewReader(request))
+		w := httptest.NewRecorder()
+
+		handlers.CheckCustomer(w, req)
+
+		assert.Equal(http.StatusOK, w.Code)
+	}
+
+	// Testing the service is constructed once.
+	config.Set(config.Get().With(string(provider), config.Options{"Timeout": "10s"}))
+
+	check()
+	check()
+
+	assert.Equal(int32(1), atomic.LoadInt32(&constructed))
+
+	// Testing the unchanged section of the reloaded config reuses the service.
+	config.Set(config.Get().With(string(common.IDology), config.Options{"Host": "https://idology.example.com"}))
+
+	check()
+
+	assert.Equal(int32(1), atomic.LoadInt32(&constructed))
+
+	// Testing the changed section rebuilds the service.
+	config.Set(config.Get().With(string(provider), config.Options{"Timeout": "20s"}))
+
+	check()
+	check()
+
+	assert.Equal(int32(2), atomic.LoadInt32(&constructed))
+}
@@ -4,14 +4,14 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
-	"sort"
 
 	"modulus/kyc/common"
 	"modulus/kyc/main/handlers/providers"
 )
 
 type isProviderImplementedResp struct {
-	Implemented bool
+	Implemented  bool
+	Capabilities *common.ProviderCapabilities `json:",omitempty"`
 }
 
 // IsProviderImplemented handles requests for check whether the provider specified in the request is implemented.
@@ -34,17 +34,18 @@ func IsProviderImplemented(w http.ResponseWriter, r *http.Request) {
 	}
 
 	res := isProviderImplementedResp{
-		Implemented: name == string(common.Example) || common.KYCProviders[common.KYCProvider(name)],
+		Implemented: name == string(common.Example),
+	}
+	if spec, ok := common.LookupProvider(common.KYCProvider(name)); ok {
+		res.Implemented = true
+		res.Capabilities = &spec.Capabilities
 	}
 
 	json.NewEncoder(w).Encode(res)
 }
 
-// providerList forms the list of implemented providers.
+// providerList forms the list of known providers.
 func providerList() (list providers.ProviderList) {
-	for p := range common.KYCProviders {
-		list = append(list, p)
-	}
-	sort.Sort(list)
+	list = common.KnownProviders()
 	return
 }
@@ -0,0 +1,105 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/handlers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsProviderImplemented(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing the list of implemented providers.
+	req := httptest.NewRequest(http.MethodGet, "/Provider", nil)
+	w := httptest.NewRecorder()
+
+	handlers.IsProviderImplemented(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("application/json; charset=utf-8", w.Header().Get("Content-Type"))
+
+	list := []common.KYCProvider{}
+
+	err := json.Unmarshal(w.Body.Bytes(), &list)
+
+	assert.NoError(err)
+	assert.Len(list, len(common.KnownProviders()))
+	assert.Contains(list, common.IDology)
+	assert.Contains(list, common.SumSub)
+	assert.Contains(list, common.CipherTrace)
+
+	// Testing the provider supporting status polling.
+	req = httptest.NewRequest(http.MethodGet, "/Provider?name=Sum%26Substance", nil)
+	w = httptest.NewRecorder()
+
+	handlers.IsProviderImplemented(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	resp := struct {
+		Implemented  bool
+		Capabilities *common.ProviderCapabilities
+	}{}
+
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+
+	assert.NoError(err)
+	assert.True(resp.Implemented)
+	assert.NotNil(resp.Capabilities)
+	assert.True(resp.Capabilities.StatusPolling)
+	assert.True(resp.Capabilities.Documents)
+	assert.False(resp.Capabilities.Companies)
+
+	// Testing the example provider.
+	req = httptest.NewRequest(http.MethodGet, "/Provider?name=Example", nil)
+	w = httptest.NewRecorder()
+
+	handlers.IsProviderImplemented(w, req)
+
+	resp.Capabilities = nil
+
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+
+	assert.NoError(err)
+	assert.True(resp.Implemented)
+	assert.Nil(resp.Capabilities)
+
+	// Testing the unknown provider.
+	req = httptest.NewRequest(http.MethodGet, "/Provider?name=Unknown", nil)
+	w = httptest.NewRecorder()
+
+	handlers.IsProviderImplemented(w, req)
+
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+
+	assert.NoError(err)
+	assert.False(resp.Implemented)
+	assert.Nil(resp.Capabilities)
+
+	// Testing the known provider that isn't implemented.
+	req = httptest.NewRequest(http.MethodGet, "/Provider?name=CipherTrace", nil)
+	w = httptest.NewRecorder()
+
+	handlers.IsProviderImplemented(w, req)
+
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+
+	assert.NoError(err)
+	assert.False(resp.Implemented)
+	assert.Nil(resp.Capabilities)
+
+	// Testing missing provider name.
+	req = httptest.NewRequest(http.MethodGet, "/Provider?foo=bar", nil)
+	w = httptest.NewRecorder()
+
+	handlers.IsProviderImplemented(w, req)
+
+	assert.Equal(http.StatusBadRequest, w.Code)
+	assert.Equal(`{"Error":"missing provider name in the request"}`, w.Body.String())
+}
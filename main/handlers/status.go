@@ -1,22 +1,25 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"log"
+	"log/slog"
 	"net/http"
+	"time"
 
 	"modulus/kyc/common"
-	"modulus/kyc/integrations/coinfirm"
+	kychttp "modulus/kyc/http"
 	"modulus/kyc/integrations/example"
-	"modulus/kyc/integrations/identitymind"
-	"modulus/kyc/integrations/jumio"
-	"modulus/kyc/integrations/shuftipro"
-	"modulus/kyc/integrations/sumsub"
-	"modulus/kyc/integrations/synapsefi"
-	"modulus/kyc/main/config"
+	"modulus/kyc/main/auth"
+	"modulus/kyc/main/events"
+	"modulus/kyc/main/limits"
+	"modulus/kyc/main/logging"
+	"modulus/kyc/main/metrics"
+	"modulus/kyc/main/rules"
+	"modulus/kyc/main/tracing"
 )
 
 // CheckStatus handles requests for a status check.
@@ -25,14 +28,13 @@ func CheckStatus(w http.ResponseWriter, r *http.Request) {
 
 	body, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		writeErrorResponse(w, http.StatusInternalServerError, err)
+		writeErrorResponse(w, readStatus(err), err)
 		return
 	}
 	if len(body) == 0 {
 		writeErrorResponse(w, http.StatusBadRequest, errors.New("empty request"))
 		return
 	}
-	log.Println("CheckStatus Request: ", string(body))
 	req := common.CheckStatusRequest{}
 
 	err = json.Unmarshal(body, &req)
@@ -41,111 +43,130 @@ func CheckStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	check, err1 := checkStatus(r.Context(), req, false)
+	if err1 != nil {
+		writeServiceError(w, err1)
+		return
+	}
+
+	response := common.KYCResponse{}
+	if check.err != nil {
+		response.Error = check.err.Error()
+	}
+
+	response.Result = common.ResultFromKYCResult(check.result)
+
+	resp, err := json.Marshal(response)
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	logResponse("CheckStatus response", req.Provider, req.ReferenceID, response)
+	w.Write(resp)
+}
+
+// statusCheck holds the outcome of the status check of the verification.
+// The recorder holds the responses of the provider API if the recording was requested.
+type statusCheck struct {
+	result   common.KYCResult
+	err      error
+	recorder *kychttp.Recorder
+}
+
+// checkStatus checks the status of the verification according to the request on behalf of the API handlers of any version.
+// The errors of the request are returned as the serviceError holding the HTTP status of the response.
+// The responses of the provider API are recorded if the record is set.
+func checkStatus(ctx context.Context, req common.CheckStatusRequest, record bool) (check statusCheck, err *serviceError) {
 	if len(req.Provider) == 0 {
-		writeErrorResponse(w, http.StatusBadRequest, errors.New("missing KYC provider id in the request"))
+		err = badRequest(errors.New("missing KYC provider id in the request"))
 		return
 	}
 	if len(req.ReferenceID) == 0 {
-		writeErrorResponse(w, http.StatusBadRequest, errors.New("missing verification id in the request"))
+		err = badRequest(errors.New("missing verification id in the request"))
 		return
 	}
-
-	service, err1 := createStatusChecker(req.Provider)
-	if err1 != nil {
-		writeErrorResponse(w, err1.status, err1)
+	if err1 := auth.AuthorizeProvider(ctx, req.Provider); err1 != nil {
+		err = &serviceError{status: http.StatusForbidden, message: err1.Error()}
 		return
 	}
 
-	response := common.KYCResponse{}
+	slog.Info("CheckStatus request", logging.ProviderKey, req.Provider, logging.ReferenceIDKey, req.ReferenceID)
+	tracing.Annotate(ctx, req.Provider, req.ReferenceID)
 
-	result, err := service.CheckStatus(req.ReferenceID)
+	service, err := createStatusChecker(req.Provider)
 	if err != nil {
-		response.Error = err.Error()
+		return
+	}
+	if err1 := limits.AllowStatus(auth.ClientID(ctx), req.Provider); err1 != nil {
+		err = limitError(err1)
+		return
 	}
 
-	response.Result = common.ResultFromKYCResult(result)
+	if record {
+		check.recorder = &kychttp.Recorder{}
+		ctx = kychttp.WithRecorder(ctx, check.recorder)
+	}
 
-	resp, err := json.Marshal(response)
-	if err != nil {
-		writeErrorResponse(w, http.StatusInternalServerError, err)
-		return
+	started := time.Now()
+	check.result, check.err = service.CheckStatusContext(metrics.WithOperation(ctx, req.Provider, metrics.CheckStatus), req.ReferenceID)
+	metrics.ObserveVerification(req.Provider, metrics.CheckStatus, started, check.result, check.err)
+	if check.err == nil {
+		check.result = rules.Apply(req.Provider, nil, check.result)
+
+		events.Publish(events.Result{
+			Provider:    req.Provider,
+			ReferenceID: req.ReferenceID,
+			Result:      check.result,
+			Source:      events.Check,
+		})
 	}
-	log.Println("CheckCustomer Response: ", string(resp))
-	w.Write(resp)
+
+	return
 }
 
-// createStatusChecker returns the KYCPlatform object for the specified provider or an error if occurred.
-func createStatusChecker(provider common.KYCProvider) (service common.KYCPlatform, err *serviceError) {
+// createStatusChecker returns the KYCPlatformContext object for the specified provider or an error if occurred.
+func createStatusChecker(provider common.KYCProvider) (service common.KYCPlatformContext, err *serviceError) {
 	if provider == common.Example {
 		service = example.Example{}
 		return
 	}
 
-	if !common.KYCProviders[provider] {
-		err = &serviceError{
-			status:  http.StatusNotFound,
-			message: fmt.Sprintf("unknown KYC provider in the request: %s", provider),
-		}
+	spec, options, err := lookupProvider(provider)
+	if err != nil {
 		return
 	}
 
-	cfg, ok := config.Cfg[string(provider)]
-	if !ok {
+	if !spec.Capabilities.StatusPolling {
 		err = &serviceError{
-			status:  http.StatusInternalServerError,
-			message: fmt.Sprintf("missing config for %s", provider),
+			status:  http.StatusUnprocessableEntity,
+			message: fmt.Sprintf("%s doesn't support status polling", provider),
 		}
 		return
 	}
 
-	switch provider {
-	case common.ComplyAdvantage, common.IDology, common.ThomsonReuters, common.Trulioo:
-		err = &serviceError{
-			status:  http.StatusUnprocessableEntity,
-			message: fmt.Sprintf("%s doesn't support status polling", provider),
-		}
-	case common.Coinfirm:
-		service = coinfirm.New(coinfirm.Config{
-			Host:     cfg["Host"],
-			Email:    cfg["Email"],
-			Password: cfg["Password"],
-			Company:  cfg["Company"],
-		})
-	case common.IdentityMind:
-		service = identitymind.New(identitymind.Config{
-			Host:     cfg["Host"],
-			Username: cfg["Username"],
-			Password: cfg["Password"],
-		})
-	case common.Jumio:
-		service = jumio.New(jumio.Config{
-			BaseURL: cfg["BaseURL"],
-			Token:   cfg["Token"],
-			Secret:  cfg["Secret"],
-		})
-	case common.ShuftiPro:
-		service = shuftipro.New(shuftipro.Config{
-			Host:        cfg["Host"],
-			SecretKey:   cfg["SecretKey"],
-			ClientID:    cfg["ClientID"],
-			CallbackURL: cfg["CallbackURL"],
-		})
-	case common.SumSub:
-		service = sumsub.New(sumsub.Config{
-			Host:   cfg["Host"],
-			APIKey: cfg["APIKey"],
-		})
-	case common.SynapseFI:
-		service = synapsefi.New(synapsefi.Config{
-			Host:         cfg["Host"],
-			ClientID:     cfg["ClientID"],
-			ClientSecret: cfg["ClientSecret"],
-		})
-	default:
-		err = &serviceError{
-			status:  http.StatusUnprocessableEntity,
-			message: fmt.Sprintf("KYC provider not implemented yet: %s", provider),
-		}
+	service, err = newPlatform(spec, options)
+
+	return
+}
+
+// CheckVerificationStatus checks the current status of the verification on behalf of the background subsystems.
+func CheckVerificationStatus(ctx context.Context, provider common.KYCProvider, referenceID string) (result common.KYCResult, err error) {
+	ctx, span := tracing.StartOperation(ctx, provider, string(metrics.CheckStatus), referenceID)
+	defer func() {
+		tracing.EndOperation(span, err)
+	}()
+
+	service, err1 := createStatusChecker(provider)
+	if err1 != nil {
+		err = err1
+		return
+	}
+
+	started := time.Now()
+	result, err = service.CheckStatusContext(metrics.WithOperation(ctx, provider, metrics.CheckStatus), referenceID)
+	metrics.ObserveVerification(provider, metrics.CheckStatus, started, result, err)
+	if err == nil {
+		result = rules.Apply(provider, nil, result)
 	}
 
 	return
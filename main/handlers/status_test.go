@@ -73,8 +73,8 @@ var errorResponse = []byte(`
 }`)
 
 func init() {
-	if config.Cfg == nil {
-		config.Cfg = cfg
+	if len(config.Get()) == 0 {
+		config.Set(cfg)
 	}
 }
 
@@ -87,7 +87,7 @@ func (r FailedReader) Read(p []byte) (n int, err error) {
 func TestCheckStatus(t *testing.T) {
 	assert := assert.New(t)
 
-	cfg := config.Cfg[string(common.SumSub)]
+	cfg := config.Get()[string(common.SumSub)]
 
 	assert.NotNil(cfg)
 
@@ -267,7 +267,7 @@ func TestCheckStatus(t *testing.T) {
 
 	// Testing KYC provider without config.
 	request, err = json.Marshal(&common.CheckStatusRequest{
-		Provider:    "Fake Provider",
+		Provider:    common.SynapseFI,
 		ReferenceID: referenceID,
 	})
 
@@ -277,10 +277,6 @@ func TestCheckStatus(t *testing.T) {
 	req = httptest.NewRequest(http.MethodPost, "/CheckStatus", bytes.NewReader(request))
 	w = httptest.NewRecorder()
 
-	if !common.KYCProviders["Fake Provider"] {
-		common.KYCProviders["Fake Provider"] = true
-	}
-
 	handlers.CheckStatus(w, req)
 
 	assert.Equal(http.StatusInternalServerError, w.Code)
@@ -293,7 +289,7 @@ func TestCheckStatus(t *testing.T) {
 	assert.Nil(err)
 	assert.Nil(resp.Result)
 	assert.NotEmpty(resp.Error)
-	assert.Equal("missing config for Fake Provider", resp.Error)
+	assert.Equal("missing config for SynapseFI", resp.Error)
 
 	// Testing KYC provider that doesn't support status polling.
 	request, err = json.Marshal(&common.CheckStatusRequest{
@@ -323,14 +319,14 @@ func TestCheckStatus(t *testing.T) {
 
 	// Testing KYC provider not implemented yet.
 	request, err = json.Marshal(&common.CheckStatusRequest{
-		Provider:    "Fake Provider",
+		Provider:    common.CipherTrace,
 		ReferenceID: referenceID,
 	})
 
 	assert.Nil(err)
 	assert.NotEmpty(request)
 
-	config.Cfg["Fake Provider"] = map[string]string{"test": "test"}
+	config.Set(config.Get().With(string(common.CipherTrace), map[string]string{"test": "test"}))
 
 	req = httptest.NewRequest(http.MethodPost, "/CheckStatus", bytes.NewReader(request))
 	w = httptest.NewRecorder()
@@ -347,7 +343,7 @@ func TestCheckStatus(t *testing.T) {
 	assert.Nil(err)
 	assert.Nil(resp.Result)
 	assert.NotEmpty(resp.Error)
-	assert.Equal("KYC provider not implemented yet: Fake Provider", resp.Error)
+	assert.Equal("KYC provider not implemented yet: CipherTrace", resp.Error)
 
 	// Testing error response from the KYC provider.
 	request, err = json.Marshal(&common.CheckStatusRequest{
@@ -384,7 +380,7 @@ func TestCheckStatus(t *testing.T) {
 	assert.Equal("Access denied", resp.Error)
 
 	// Testing IdentityMind.
-	cfg = config.Cfg[string(common.IdentityMind)]
+	cfg = config.Get()[string(common.IdentityMind)]
 
 	assert.NotNil(cfg)
 
@@ -0,0 +1,73 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/handlers"
+	"modulus/kyc/main/tracing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestCheckStatusTracing(t *testing.T) {
+	assert := assert.New(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+
+	tracing.StartLocal(exporter)
+	defer tracing.Stop(context.Background())
+
+	request, err := json.Marshal(&common.CheckStatusRequest{
+		Provider:    common.Example,
+		ReferenceID: "ada",
+	})
+
+	assert.NoError(err)
+
+	// Testing the span of the inbound request.
+	req := httptest.NewRequest(http.MethodPost, "/CheckStatus", bytes.NewReader(request))
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+
+	tracing.Middleware("/CheckStatus", http.HandlerFunc(handlers.CheckStatus)).ServeHTTP(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	spans := exporter.GetSpans()
+	if assert.Len(spans, 1) {
+		attrs := attribute.NewSet(spans[0].Attributes...)
+
+		assert.Equal("4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext.TraceID().String())
+
+		provider, _ := attrs.Value("kyc.provider")
+		referenceID, _ := attrs.Value("kyc.reference_id")
+
+		assert.Equal("Example", provider.AsString())
+		assert.Equal("ada", referenceID.AsString())
+	}
+
+	// Testing the span of the background status check.
+	exporter.Reset()
+
+	result, err := handlers.CheckVerificationStatus(context.Background(), common.Example, "ada")
+
+	assert.NoError(err)
+	assert.Equal(common.Approved, result.Status)
+
+	spans = exporter.GetSpans()
+	if assert.Len(spans, 1) {
+		attrs := attribute.NewSet(spans[0].Attributes...)
+		operation, _ := attrs.Value("kyc.operation")
+
+		assert.Equal("CheckStatus", spans[0].Name)
+		assert.Equal("CheckStatus", operation.AsString())
+	}
+}
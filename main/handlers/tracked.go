@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/auth"
+	"modulus/kyc/main/poller"
+)
+
+// TrackedStatus handles requests for the latest known status of the verification tracked by the poller.
+// The status is taken from the poller cache, so the provider isn't requested.
+func TrackedStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if err := r.ParseForm(); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	provider := common.KYCProvider(r.Form.Get("provider"))
+	if len(provider) == 0 {
+		writeErrorResponse(w, http.StatusBadRequest, errors.New("missing KYC provider id in the request"))
+		return
+	}
+	referenceID := r.Form.Get("referenceID")
+	if len(referenceID) == 0 {
+		writeErrorResponse(w, http.StatusBadRequest, errors.New("missing verification id in the request"))
+		return
+	}
+	if err := auth.AuthorizeProvider(r.Context(), provider); err != nil {
+		writeErrorResponse(w, http.StatusForbidden, err)
+		return
+	}
+
+	status, ok := poller.Lookup(provider, referenceID)
+	if !ok {
+		writeErrorResponse(w, http.StatusNotFound, fmt.Errorf("verification isn't tracked: %s %s", provider, referenceID))
+		return
+	}
+
+	response := common.TrackedStatusResponse{
+		Provider:    status.Provider,
+		ReferenceID: status.ReferenceID,
+		Error:       status.Error,
+		Final:       status.Final,
+		Expired:     status.Expired,
+		Polling:     status.Polling,
+		Checks:      status.Checks,
+		Updated:     status.Updated,
+	}
+	if status.Result != nil {
+		response.Result = common.ResultFromKYCResult(*status.Result)
+	}
+	if !status.LastCheck.IsZero() {
+		response.LastCheck = &status.LastCheck
+	}
+	if status.Polling && !status.Final && !status.Expired {
+		response.NextCheck = &status.NextCheck
+	}
+
+	resp, err := json.Marshal(response)
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Write(resp)
+}
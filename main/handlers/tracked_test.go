@@ -0,0 +1,114 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/events"
+	"modulus/kyc/main/handlers"
+	"modulus/kyc/main/poller"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrackedStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	poller.Start(handlers.CheckVerificationStatus, func(common.KYCProvider) poller.Settings {
+		return poller.DefaultSettings
+	}, nil)
+	defer poller.Stop()
+
+	// Testing missing provider.
+	req := httptest.NewRequest(http.MethodGet, "/Status?referenceID=ref", nil)
+	w := httptest.NewRecorder()
+
+	handlers.TrackedStatus(w, req)
+
+	assert.Equal(http.StatusBadRequest, w.Code)
+	assert.Equal(`{"Error":"missing KYC provider id in the request"}`, w.Body.String())
+
+	// Testing missing reference id.
+	req = httptest.NewRequest(http.MethodGet, "/Status?provider=Example", nil)
+	w = httptest.NewRecorder()
+
+	handlers.TrackedStatus(w, req)
+
+	assert.Equal(http.StatusBadRequest, w.Code)
+	assert.Equal(`{"Error":"missing verification id in the request"}`, w.Body.String())
+
+	// Testing the untracked verification.
+	req = httptest.NewRequest(http.MethodGet, "/Status?provider=Example&referenceID=ref", nil)
+	w = httptest.NewRecorder()
+
+	handlers.TrackedStatus(w, req)
+
+	assert.Equal(http.StatusNotFound, w.Code)
+	assert.Equal(`{"Error":"verification isn't tracked: Example ref"}`, w.Body.String())
+
+	// Testing the pending verification.
+	events.Publish(events.Result{
+		Provider:    common.Example,
+		ReferenceID: "ref",
+		Result: common.KYCResult{
+			Status: common.Unclear,
+			StatusCheck: &common.KYCStatusCheck{
+				Provider:    common.Example,
+				ReferenceID: "ref",
+				LastCheck:   time.Now(),
+			},
+		},
+		Source: events.Check,
+	})
+
+	req = httptest.NewRequest(http.MethodGet, "/Status?provider=Example&referenceID=ref", nil)
+	w = httptest.NewRecorder()
+
+	handlers.TrackedStatus(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("application/json; charset=utf-8", w.Header().Get("Content-Type"))
+
+	resp := common.TrackedStatusResponse{}
+
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+
+	assert.NoError(err)
+	assert.Equal(common.Example, resp.Provider)
+	assert.Equal("ref", resp.ReferenceID)
+	assert.False(resp.Final)
+	assert.True(resp.Polling)
+	assert.Nil(resp.LastCheck)
+	assert.NotNil(resp.NextCheck)
+	if assert.NotNil(resp.Result) {
+		assert.Equal(common.KYCStatus2Status[common.Unclear], resp.Result.Status)
+	}
+
+	// Testing the final result from the callback.
+	events.Publish(events.Result{
+		Provider:    common.Example,
+		ReferenceID: "ref",
+		Result:      common.KYCResult{Status: common.Approved},
+		Source:      events.Callback,
+	})
+
+	req = httptest.NewRequest(http.MethodGet, "/Status?provider=Example&referenceID=ref", nil)
+	w = httptest.NewRecorder()
+
+	handlers.TrackedStatus(w, req)
+
+	resp = common.TrackedStatusResponse{}
+
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+
+	assert.NoError(err)
+	assert.True(resp.Final)
+	assert.Nil(resp.NextCheck)
+	if assert.NotNil(resp.Result) {
+		assert.Equal(common.KYCStatus2Status[common.Approved], resp.Result.Status)
+	}
+}
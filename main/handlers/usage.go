@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/auth"
+	"modulus/kyc/main/limits"
+)
+
+// Usage handles requests for the usage of the KYC providers by the API clients in the current day and month.
+// The usage is filtered by the client and the provider from the query params if they're specified.
+// The authenticated client gets only its own usage.
+func Usage(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if err := r.ParseForm(); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	client := r.Form.Get("client")
+	if clientID := auth.ClientID(r.Context()); len(clientID) > 0 {
+		client = clientID
+	}
+
+	resp, err := json.Marshal(limits.GetUsage(client, common.KYCProvider(r.Form.Get("provider"))))
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Write(resp)
+}
@@ -0,0 +1,85 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/auth"
+	"modulus/kyc/main/handlers"
+	"modulus/kyc/main/limits"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsage(t *testing.T) {
+	assert := assert.New(t)
+
+	limits.Setup(limits.Config{
+		Clients: map[string]limits.Limits{"quoted": {DailyQuota: 1}},
+	})
+	defer limits.Setup(limits.Config{})
+
+	quoted := auth.Client{ID: "quoted"}
+
+	request, err := json.Marshal(&common.CheckCustomerRequest{
+		Provider: common.Example,
+		UserData: &common.UserData{FirstName: "Urbi"},
+	})
+
+	assert.NoError(err)
+
+	req := httptest.NewRequest(http.MethodPost, "/CheckCustomer", bytes.NewReader(request))
+	req = req.WithContext(auth.WithClient(req.Context(), quoted))
+	w := httptest.NewRecorder()
+
+	handlers.CheckCustomer(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	// Testing the exhausted quota.
+	req = httptest.NewRequest(http.MethodPost, "/CheckCustomer", bytes.NewReader(request))
+	req = req.WithContext(auth.WithClient(req.Context(), quoted))
+	w = httptest.NewRecorder()
+
+	handlers.CheckCustomer(w, req)
+
+	assert.Equal(http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(w.Header().Get("Retry-After"))
+	assert.Equal(`{"Error":"daily quota of the client quoted exhausted"}`, w.Body.String())
+
+	// Testing the usage of the client.
+	req = httptest.NewRequest(http.MethodGet, "/Usage?client=quoted", nil)
+	w = httptest.NewRecorder()
+
+	handlers.Usage(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("application/json; charset=utf-8", w.Header().Get("Content-Type"))
+
+	usage := []limits.Usage{}
+
+	err = json.Unmarshal(w.Body.Bytes(), &usage)
+
+	assert.NoError(err)
+	if assert.Len(usage, 1) {
+		assert.Equal("quoted", usage[0].Client)
+		assert.Equal(common.Example, usage[0].Provider)
+		assert.Equal(1, usage[0].Daily)
+		assert.Equal(1, usage[0].DailyQuota)
+		assert.Equal(1, usage[0].Monthly)
+	}
+
+	// Testing the authenticated client gets only its own usage.
+	req = httptest.NewRequest(http.MethodGet, "/Usage?client=quoted", nil)
+	req = req.WithContext(auth.WithClient(req.Context(), auth.Client{ID: "mobile"}))
+	w = httptest.NewRecorder()
+
+	handlers.Usage(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("[]", w.Body.String())
+}
@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+
+	"modulus/kyc/common"
+	kychttp "modulus/kyc/http"
+	"modulus/kyc/main/auth"
+	"modulus/kyc/main/idempotency"
+	"modulus/kyc/main/limits"
+	"modulus/kyc/main/logging"
+	"modulus/kyc/main/tracing"
+)
+
+// statusErrorTypes maps the HTTP status of the serviceError to the v2 API error type.
+// The config errors of the providers are typed separately.
+var statusErrorTypes = map[int]common.ErrorType{
+	http.StatusBadRequest:            common.ValidationError,
+	http.StatusUnauthorized:          common.UnauthorizedError,
+	http.StatusForbidden:             common.ForbiddenError,
+	http.StatusNotFound:              common.NotFoundError,
+	http.StatusConflict:              common.ConflictError,
+	http.StatusRequestEntityTooLarge: common.ValidationError,
+	http.StatusUnprocessableEntity:   common.UnsupportedError,
+	http.StatusTooManyRequests:       common.RateLimitedError,
+	http.StatusInternalServerError:   common.InternalError,
+}
+
+// CheckCustomerV2 handles requests for KYC verifications of the v2 API.
+// The customer is verified using either the single provider or the strategy using several providers.
+func CheckCustomerV2(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	req := common.CheckCustomerRequestV2{}
+	if err := decodeRequestV2(r, &req); err != nil {
+		writeServiceErrorV2(w, err)
+		return
+	}
+	if req.Customer == nil {
+		writeServiceErrorV2(w, badRequest(errors.New("missing customer in the request")))
+		return
+	}
+
+	check, err := checkCustomer(r.Context(), common.CheckCustomerRequest{
+		Provider:        req.Provider,
+		Strategy:        req.Strategy.Strategy(),
+		UserData:        req.Customer.UserData(),
+		NotificationURL: req.NotificationURL,
+	}, true)
+	if err != nil {
+		writeServiceErrorV2(w, err)
+		return
+	}
+
+	for _, p := range check.providers {
+		if id := check.ids[p]; len(id) > 0 {
+			w.Header().Add(VerificationIDHeader, id)
+		}
+	}
+
+	result := common.ResultV2FromKYCResult(check.provider, check.result)
+	response := common.KYCResponseV2{
+		Result: result,
+	}
+
+	if req.Strategy == nil {
+		response.VerificationID = check.ids[req.Provider]
+		if req.IncludeRaw {
+			result.Raw = rawPayload(check.recorders[req.Provider])
+		}
+		if check.err != nil {
+			response.Error = providerErrorV2(check.err, check.result.ErrorCode, check.recorders[req.Provider])
+		}
+	} else {
+		// The strategy is considered unavailable if all the providers failed were unavailable.
+		unavailable := len(check.errs) > 0
+		for i := range result.Providers {
+			r := &result.Providers[i]
+			r.VerificationID = check.ids[r.Provider]
+			if req.IncludeRaw {
+				r.Result.Raw = rawPayload(check.recorders[r.Provider])
+			}
+			if err := check.errs[r.Provider]; err != nil {
+				r.Error = providerErrorV2(err, check.result.Providers[i].Result.ErrorCode, check.recorders[r.Provider])
+				unavailable = unavailable && r.Error.Type == common.ProviderUnavailableError
+			}
+		}
+		if check.err != nil {
+			response.Error = &common.ErrorV2{Type: common.ProviderRejectedError, Message: check.err.Error()}
+			if unavailable {
+				response.Error.Type = common.ProviderUnavailableError
+			}
+		}
+	}
+
+	resp, err1 := json.Marshal(response)
+	if err1 != nil {
+		writeErrorV2(w, http.StatusInternalServerError, common.ErrorV2{Type: common.InternalError, Message: err1.Error()})
+		return
+	}
+	tracing.Annotate(r.Context(), "", result.Reference)
+	logResponse("CheckCustomer v2 response", check.provider, result.Reference, check.response)
+	w.Write(resp)
+}
+
+// CheckStatusV2 handles requests for a status check of the v2 API.
+func CheckStatusV2(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	req := common.CheckStatusRequestV2{}
+	if err := decodeRequestV2(r, &req); err != nil {
+		writeServiceErrorV2(w, err)
+		return
+	}
+
+	check, err := checkStatus(r.Context(), common.CheckStatusRequest{
+		Provider:    req.Provider,
+		ReferenceID: req.ReferenceID,
+	}, true)
+	if err != nil {
+		writeServiceErrorV2(w, err)
+		return
+	}
+
+	result := common.ResultV2FromKYCResult(req.Provider, check.result)
+	if len(result.Reference) == 0 {
+		result.Reference = req.ReferenceID
+	}
+	if req.IncludeRaw {
+		result.Raw = rawPayload(check.recorder)
+	}
+
+	response := common.KYCResponseV2{
+		Result: result,
+	}
+	if check.err != nil {
+		response.Error = providerErrorV2(check.err, check.result.ErrorCode, check.recorder)
+	}
+
+	resp, err1 := json.Marshal(response)
+	if err1 != nil {
+		writeErrorV2(w, http.StatusInternalServerError, common.ErrorV2{Type: common.InternalError, Message: err1.Error()})
+		return
+	}
+
+	v1 := common.KYCResponse{Result: common.ResultFromKYCResult(check.result)}
+	if check.err != nil {
+		v1.Error = check.err.Error()
+	}
+	logResponse("CheckStatus v2 response", req.Provider, req.ReferenceID, v1)
+	w.Write(resp)
+}
+
+// WriteAuthErrorV2 writes the v2 API error response for the authentication or the authorization error.
+func WriteAuthErrorV2(w http.ResponseWriter, err error) {
+	status := http.StatusUnauthorized
+	if e, ok := err.(auth.Error); ok {
+		status = e.Status
+	}
+	if status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="kyc"`)
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	writeServiceErrorV2(w, &serviceError{status: status, message: err.Error()})
+}
+
+// WriteIdempotencyErrorV2 writes the v2 API error response for the error of the request with the idempotency key.
+// The reuse of the key with another request is the conflict as well as the concurrent request with the key.
+func WriteIdempotencyErrorV2(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	if e, ok := err.(idempotency.Error); ok {
+		status = e.Status
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if status == http.StatusUnprocessableEntity {
+		writeErrorV2(w, status, common.ErrorV2{Type: common.ConflictError, Message: err.Error()})
+		return
+	}
+	writeServiceErrorV2(w, &serviceError{status: status, message: err.Error()})
+}
+
+// decodeRequestV2 decodes the body of the v2 API request into the req.
+// The unknown fields of the request are rejected.
+func decodeRequestV2(r *http.Request, req interface{}) *serviceError {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return &serviceError{status: readStatus(err), message: err.Error()}
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return badRequest(errors.New("empty request"))
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(req); err != nil {
+		return badRequest(err)
+	}
+
+	return nil
+}
+
+// writeErrorV2 writes the v2 API error response to the connection using the specified HTTP status code.
+func writeErrorV2(w http.ResponseWriter, status int, err common.ErrorV2) {
+	resp, _ := json.Marshal(common.ErrorResponseV2{Error: err})
+
+	w.WriteHeader(status)
+	w.Write(resp)
+}
+
+// writeServiceErrorV2 writes the v2 API error response for the serviceError.
+// The Retry-After header is set if the request exceeded the limits.
+func writeServiceErrorV2(w http.ResponseWriter, err *serviceError) {
+	e := common.ErrorV2{
+		Type:    common.InternalError,
+		Message: err.message,
+	}
+	if t, ok := statusErrorTypes[err.status]; ok {
+		e.Type = t
+	}
+	if err.config {
+		e.Type = common.ConfigError
+	}
+	if err.retryAfter > 0 {
+		e.RetryAfter = retryAfterSeconds(err.retryAfter)
+		w.Header().Set("Retry-After", strconv.Itoa(e.RetryAfter))
+	}
+
+	writeErrorV2(w, err.status, e)
+}
+
+// providerErrorV2 classifies the error of the provider using the error code it returned if any
+// or the HTTP status of the last provider API response recorded otherwise.
+// The provider is considered unavailable if it couldn't be reached, timed out or answered with 408, 429 or 5xx.
+func providerErrorV2(err error, errorCode string, recorder *kychttp.Recorder) *common.ErrorV2 {
+	e := &common.ErrorV2{
+		Type:              common.ProviderRejectedError,
+		Message:           err.Error(),
+		ProviderErrorCode: errorCode,
+	}
+
+	var (
+		limitErr limits.Error
+		netErr   net.Error
+	)
+
+	if errors.As(err, &limitErr) {
+		e.Type = common.RateLimitedError
+		e.RetryAfter = retryAfterSeconds(limitErr.RetryAfter)
+	} else if code, err1 := strconv.Atoi(errorCode); err1 == nil {
+		if unavailableStatus(code) {
+			e.Type = common.ProviderUnavailableError
+		}
+	} else if last, ok := lastResponse(recorder); ok && unavailableStatus(last.Code) {
+		e.Type = common.ProviderUnavailableError
+	} else if errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) {
+		e.Type = common.ProviderUnavailableError
+	}
+
+	return e
+}
+
+// unavailableStatus reports whether the HTTP status of the provider API response means it's unavailable.
+func unavailableStatus(code int) bool {
+	return code == http.StatusRequestTimeout || code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// lastResponse returns the last response of the provider API recorded if any.
+// The recorder is nil if the provider API wasn't requested.
+func lastResponse(recorder *kychttp.Recorder) (response kychttp.Response, ok bool) {
+	if recorder == nil {
+		return
+	}
+
+	return recorder.Last()
+}
+
+// rawPayload returns the redacted last response of the provider API recorded if any.
+func rawPayload(recorder *kychttp.Recorder) json.RawMessage {
+	last, ok := lastResponse(recorder)
+	if !ok {
+		return nil
+	}
+
+	return logging.RedactPayload(last.Body)
+}
@@ -0,0 +1,360 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/auth"
+	"modulus/kyc/main/config"
+	"modulus/kyc/main/handlers"
+	"modulus/kyc/main/idempotency"
+	"modulus/kyc/main/limits"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/jarcoal/httpmock.v1"
+)
+
+// postV2 sends the request body to the v2 handler and decodes the response.
+func postV2(handler http.HandlerFunc, path, body string) (w *httptest.ResponseRecorder, resp common.KYCResponseV2, err error) {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	w = httptest.NewRecorder()
+
+	handler(w, req)
+
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+
+	return
+}
+
+func TestCheckCustomerV2(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing the valid request.
+	w, resp, err := postV2(handlers.CheckCustomerV2, "/v2/CheckCustomer", `{"provider":"Example","customer":{"first_name":"Abby"}}`)
+
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(w.Body.String(), `"status":"approved","finality":"unknown","reasons":[],"provider":"Example","pending":false`)
+	assert.Nil(resp.Error)
+	if assert.NotNil(resp.Result) {
+		assert.Equal("approved", resp.Result.Status)
+		assert.Nil(resp.Result.Raw)
+	}
+
+	// Testing the reasons without the codes.
+	_, resp, err = postV2(handlers.CheckCustomerV2, "/v2/CheckCustomer", `{"provider":"Example","customer":{"first_name":"Delilah"}}`)
+
+	assert.NoError(err)
+	if assert.NotNil(resp.Result) && assert.NotEmpty(resp.Result.Reasons) {
+		assert.Equal("denied", resp.Result.Status)
+		assert.Equal(common.UnspecifiedReasonCode, resp.Result.Reasons[0].Code)
+		assert.Equal("This is the example reason of denial", resp.Result.Reasons[0].Message)
+	}
+
+	// Testing the provider unavailable.
+	w, resp, err = postV2(handlers.CheckCustomerV2, "/v2/CheckCustomer", `{"provider":"Example","customer":{"first_name":"Erika"}}`)
+
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, w.Code)
+	if assert.NotNil(resp.Error) {
+		assert.Equal(common.ProviderUnavailableError, resp.Error.Type)
+		assert.Equal("during sending request: http error", resp.Error.Message)
+		assert.Equal("429", resp.Error.ProviderErrorCode)
+	}
+	if assert.NotNil(resp.Result) {
+		assert.Equal("error", resp.Result.Status)
+		assert.Equal("429", resp.Result.ProviderErrorCode)
+	}
+
+	// Testing the invalid requests.
+	testCases := []struct {
+		name   string
+		body   string
+		status int
+		typ    common.ErrorType
+	}{
+		{"empty request", ``, http.StatusBadRequest, common.ValidationError},
+		{"malformed request", `{"provider":`, http.StatusBadRequest, common.ValidationError},
+		{"unknown field", `{"Provider":"Example","UserData":{}}`, http.StatusBadRequest, common.ValidationError},
+		{"v1 customer field", `{"provider":"Example","customer":{"FirstName":"Abby"}}`, http.StatusBadRequest, common.ValidationError},
+		{"missing customer", `{"provider":"Example"}`, http.StatusBadRequest, common.ValidationError},
+		{"missing provider", `{"customer":{}}`, http.StatusBadRequest, common.ValidationError},
+		{"unknown provider", `{"provider":"Acme","customer":{}}`, http.StatusNotFound, common.NotFoundError},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/v2/CheckCustomer", strings.NewReader(tc.body))
+		w := httptest.NewRecorder()
+
+		handlers.CheckCustomerV2(w, req)
+
+		assert.Equal(tc.status, w.Code, tc.name)
+
+		resp := common.ErrorResponseV2{}
+
+		assert.NoError(json.Unmarshal(w.Body.Bytes(), &resp), tc.name)
+		assert.Equal(tc.typ, resp.Error.Type, tc.name)
+		assert.NotEmpty(resp.Error.Message, tc.name)
+	}
+}
+
+func TestCheckCustomerV2Raw(t *testing.T) {
+	assert := assert.New(t)
+
+	previous := config.Get()
+	defer config.Set(previous)
+
+	config.Set(config.Get().With(string(common.IDology), map[string]string{
+		"Host":             "https://web.idologylive.com/api/idiq.svc",
+		"Username":         "fakeuser",
+		"Password":         "fakepassword",
+		"UseSummaryResult": "false",
+	}))
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		http.MethodPost,
+		"https://web.idologylive.com/api/idiq.svc",
+		httpmock.NewBytesResponder(http.StatusOK, idologyResponse),
+	)
+
+	// Testing the reason codes, the provider reference and the raw payload.
+	w, resp, err := postV2(handlers.CheckCustomerV2, "/v2/CheckCustomer", `{"provider":"IDology","customer":{"first_name":"John"},"include_raw":true}`)
+
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Nil(resp.Error)
+	if assert.NotNil(resp.Result) {
+		assert.Equal("denied", resp.Result.Status)
+		assert.Equal([]common.ReasonV2{{Code: "resultcode.coppa.alert", Message: "COPPA Alert"}}, resp.Result.Reasons)
+		assert.Equal("2073386264", resp.Result.Reference)
+
+		raw := ""
+		if assert.NoError(json.Unmarshal(resp.Result.Raw, &raw)) {
+			assert.Contains(raw, "<id-number>**********</id-number>")
+			assert.Contains(raw, "<key>resultcode.coppa.alert</key>")
+		}
+	}
+
+	// Testing the raw payload isn't included by default.
+	_, resp, err = postV2(handlers.CheckCustomerV2, "/v2/CheckCustomer", `{"provider":"IDology","customer":{"first_name":"John"}}`)
+
+	assert.NoError(err)
+	if assert.NotNil(resp.Result) {
+		assert.Nil(resp.Result.Raw)
+	}
+
+	// Testing the individual results of the strategy.
+	httpmock.RegisterResponder(
+		http.MethodPost,
+		"https://web.idologylive.com/api/idiq.svc",
+		httpmock.NewBytesResponder(http.StatusServiceUnavailable, nil),
+	)
+
+	request := `{"strategy":{"mode":"Fallback","providers":["IDology","Example"]},"customer":{"first_name":"Abby"},"include_raw":true}`
+
+	w, resp, err = postV2(handlers.CheckCustomerV2, "/v2/CheckCustomer", request)
+
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Nil(resp.Error)
+	if assert.NotNil(resp.Result) && assert.Len(resp.Result.Providers, 2) {
+		assert.Equal("approved", resp.Result.Status)
+		assert.Equal(common.Example, resp.Result.Provider)
+
+		idology := resp.Result.Providers[0]
+
+		assert.Equal(common.IDology, idology.Provider)
+		if assert.NotNil(idology.Error) {
+			assert.Equal(common.ProviderUnavailableError, idology.Error.Type)
+			assert.Empty(idology.Error.ProviderErrorCode)
+		}
+		assert.Equal(json.RawMessage(`""`), idology.Result.Raw)
+
+		example := resp.Result.Providers[1]
+
+		assert.Equal(common.Example, example.Provider)
+		assert.Nil(example.Error)
+		assert.Equal("approved", example.Result.Status)
+		assert.Nil(example.Result.Raw)
+	}
+}
+
+func TestCheckCustomerV2Limits(t *testing.T) {
+	assert := assert.New(t)
+
+	limits.Setup(limits.Config{
+		Providers: map[common.KYCProvider]limits.Limits{common.Example: {RateLimit: 1}},
+	})
+	defer limits.Setup(limits.Config{})
+
+	request := `{"provider":"Example","customer":{"first_name":"Abby"}}`
+
+	w, _, err := postV2(handlers.CheckCustomerV2, "/v2/CheckCustomer", request)
+
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, w.Code)
+
+	// Testing the exceeded rate limit.
+	req := httptest.NewRequest(http.MethodPost, "/v2/CheckCustomer", strings.NewReader(request))
+	w = httptest.NewRecorder()
+
+	handlers.CheckCustomerV2(w, req)
+
+	assert.Equal(http.StatusTooManyRequests, w.Code)
+
+	resp := common.ErrorResponseV2{}
+
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(common.RateLimitedError, resp.Error.Type)
+	assert.True(resp.Error.RetryAfter > 0)
+	assert.Equal(fmt.Sprint(resp.Error.RetryAfter), w.Header().Get("Retry-After"))
+}
+
+func TestCheckCustomerV2TooLarge(t *testing.T) {
+	assert := assert.New(t)
+
+	body := `{"provider":"Example","customer":{"first_name":"Abby"}}`
+
+	req := httptest.NewRequest(http.MethodPost, "/v2/CheckCustomer", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	req.Body = http.MaxBytesReader(w, req.Body, 16)
+
+	handlers.CheckCustomerV2(w, req)
+
+	assert.Equal(http.StatusRequestEntityTooLarge, w.Code)
+
+	resp := common.ErrorResponseV2{}
+
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(common.ValidationError, resp.Error.Type)
+	assert.Equal("http: request body too large", resp.Error.Message)
+
+	// Testing the v1 API.
+	req = httptest.NewRequest(http.MethodPost, "/CheckCustomer", strings.NewReader(body))
+	w = httptest.NewRecorder()
+	req.Body = http.MaxBytesReader(w, req.Body, 16)
+
+	handlers.CheckCustomer(w, req)
+
+	assert.Equal(http.StatusRequestEntityTooLarge, w.Code)
+	assert.Equal(`{"Error":"http: request body too large"}`, w.Body.String())
+}
+
+func TestCheckCustomerV2Config(t *testing.T) {
+	assert := assert.New(t)
+
+	previous := config.Get()
+	defer config.Set(previous)
+
+	config.Set(config.Get().With(string(common.IDology), map[string]string{
+		"Host":     "https://web.idologylive.com/api/idiq.svc",
+		"Username": "fakeuser",
+		"Password": "fakepassword",
+	}))
+
+	// Testing the invalid config of the provider.
+	req := httptest.NewRequest(http.MethodPost, "/v2/CheckCustomer", strings.NewReader(`{"provider":"IDology","customer":{}}`))
+	w := httptest.NewRecorder()
+
+	handlers.CheckCustomerV2(w, req)
+
+	assert.Equal(http.StatusInternalServerError, w.Code)
+
+	resp := common.ErrorResponseV2{}
+
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(common.ConfigError, resp.Error.Type)
+	assert.Equal(`IDology config error: invalid option 'UseSummaryResult': strconv.ParseBool: parsing "": invalid syntax`, resp.Error.Message)
+}
+
+func TestCheckStatusV2(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing the valid request.
+	w, resp, err := postV2(handlers.CheckStatusV2, "/v2/CheckStatus", `{"provider":"Example","reference_id":"uma"}`)
+
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Nil(resp.Error)
+	if assert.NotNil(resp.Result) {
+		assert.Equal("unclear", resp.Result.Status)
+		assert.Equal("unknown", resp.Result.Finality)
+		assert.Equal("uma", resp.Result.Reference)
+		assert.True(resp.Result.Pending)
+	}
+
+	// Testing the provider rejection.
+	w, resp, err = postV2(handlers.CheckStatusV2, "/v2/CheckStatus", `{"provider":"Example","reference_id":"elin"}`)
+
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, w.Code)
+	if assert.NotNil(resp.Error) {
+		assert.Equal(common.ProviderRejectedError, resp.Error.Type)
+		assert.Equal("401", resp.Error.ProviderErrorCode)
+	}
+
+	// Testing the missing verification id.
+	req := httptest.NewRequest(http.MethodPost, "/v2/CheckStatus", bytes.NewReader([]byte(`{"provider":"Example"}`)))
+	w = httptest.NewRecorder()
+
+	handlers.CheckStatusV2(w, req)
+
+	assert.Equal(http.StatusBadRequest, w.Code)
+	assert.Equal(`{"error":{"type":"validation","message":"missing verification id in the request"}}`, w.Body.String())
+}
+
+func TestWriteAuthErrorV2(t *testing.T) {
+	assert := assert.New(t)
+
+	w := httptest.NewRecorder()
+
+	handlers.WriteAuthErrorV2(w, auth.Error{Status: http.StatusUnauthorized, Err: auth.ErrInvalidAPIKey})
+
+	assert.Equal(http.StatusUnauthorized, w.Code)
+	assert.Equal(`Bearer realm="kyc"`, w.Header().Get("WWW-Authenticate"))
+	assert.Equal(`{"error":{"type":"unauthorized","message":"invalid API key"}}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+
+	handlers.WriteAuthErrorV2(w, auth.Error{Status: http.StatusForbidden, Err: errors.New("client c isn't allowed to request CheckCustomer")})
+
+	assert.Equal(http.StatusForbidden, w.Code)
+	assert.Empty(w.Header().Get("WWW-Authenticate"))
+	assert.Equal(`{"error":{"type":"forbidden","message":"client c isn't allowed to request CheckCustomer"}}`, w.Body.String())
+}
+
+func TestWriteIdempotencyErrorV2(t *testing.T) {
+	assert := assert.New(t)
+
+	w := httptest.NewRecorder()
+
+	handlers.WriteIdempotencyErrorV2(w, idempotency.Error{Status: http.StatusUnprocessableEntity, Err: idempotency.ErrMismatch})
+
+	assert.Equal(http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(`{"error":{"type":"conflict","message":"Idempotency-Key is already used with another request"}}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+
+	handlers.WriteIdempotencyErrorV2(w, idempotency.Error{Status: http.StatusConflict, Err: idempotency.ErrInProgress})
+
+	assert.Equal(http.StatusConflict, w.Code)
+	assert.Equal(`{"error":{"type":"conflict","message":"the request with the Idempotency-Key is in progress, try again later"}}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+
+	handlers.WriteIdempotencyErrorV2(w, idempotency.Error{Status: http.StatusBadRequest, Err: idempotency.ErrKeyTooLong})
+
+	assert.Equal(http.StatusBadRequest, w.Code)
+	assert.Equal(`{"error":{"type":"validation","message":"Idempotency-Key header is longer than 255 characters"}}`, w.Body.String())
+}
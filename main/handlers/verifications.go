@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/auth"
+	"modulus/kyc/main/store"
+)
+
+// VerificationsPath is the path prefix of the verifications lookup handler.
+// The full path of a verification is /Verifications/{id}.
+const VerificationsPath = "/Verifications/"
+
+// VerificationIDHeader is the response header holding the id of the recorded verification.
+const VerificationIDHeader = "X-KYC-Verification-Id"
+
+// Verifications handles requests for the recorded verifications.
+// The verification is looked up by its id from the path, or by the provider and the reference id,
+// or the history of the customer is looked up by the fingerprint of the customer data from the query params.
+func Verifications(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if err := r.ParseForm(); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var (
+		result interface{}
+		err    error
+	)
+
+	id := strings.TrimPrefix(r.URL.Path, VerificationsPath)
+	fingerprint := r.Form.Get("fingerprint")
+	provider := common.KYCProvider(r.Form.Get("provider"))
+	referenceID := r.Form.Get("referenceID")
+
+	clientID := auth.ClientID(r.Context())
+
+	switch {
+	case len(id) > 0:
+		verification, err1 := store.Get(id)
+		result, err = ownVerification(clientID, verification, err1)
+	case len(fingerprint) > 0:
+		verifications, err1 := store.FindByFingerprint(fingerprint)
+		own := []store.Verification{}
+		for _, v := range verifications {
+			if len(clientID) == 0 || v.ClientID == clientID {
+				own = append(own, v)
+			}
+		}
+		result, err = own, err1
+	case len(provider) > 0 && len(referenceID) > 0:
+		verification, err1 := store.FindByReference(provider, referenceID)
+		result, err = ownVerification(clientID, verification, err1)
+	default:
+		writeErrorResponse(w, http.StatusBadRequest, errors.New("missing verification id in the request"))
+		return
+	}
+
+	switch err {
+	case nil:
+	case store.ErrNotFound:
+		writeErrorResponse(w, http.StatusNotFound, err)
+		return
+	default:
+		writeErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp, err := json.Marshal(result)
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Write(resp)
+}
+
+// ownVerification hides the verification recorded for another client as if it wasn't found.
+func ownVerification(clientID string, verification store.Verification, err error) (store.Verification, error) {
+	if err == nil && len(clientID) > 0 && verification.ClientID != clientID {
+		return store.Verification{}, store.ErrNotFound
+	}
+	return verification, err
+}
@@ -0,0 +1,239 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/auth"
+	"modulus/kyc/main/events"
+	"modulus/kyc/main/handlers"
+	"modulus/kyc/main/store"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifications(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing the disabled store.
+	req := httptest.NewRequest(http.MethodGet, "/Verifications/id", nil)
+	w := httptest.NewRecorder()
+
+	handlers.Verifications(w, req)
+
+	assert.Equal(http.StatusInternalServerError, w.Code)
+	assert.Equal(`{"Error":"verification store isn't opened"}`, w.Body.String())
+
+	dir, err := ioutil.TempDir("", "handlers")
+	if !assert.NoError(err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	err = store.Start(store.Config{Driver: store.BoltDriver, DSN: filepath.Join(dir, "kyc.db")})
+	if !assert.NoError(err) {
+		return
+	}
+	defer store.Stop()
+
+	// Testing the verification recorded by the check.
+	customer := &common.UserData{FirstName: "Urbi"}
+
+	request, err := json.Marshal(&common.CheckCustomerRequest{
+		Provider: common.Example,
+		UserData: customer,
+	})
+
+	assert.NoError(err)
+
+	req = httptest.NewRequest(http.MethodPost, "/CheckCustomer", bytes.NewReader(request))
+	w = httptest.NewRecorder()
+
+	handlers.CheckCustomer(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	id := w.Header().Get(handlers.VerificationIDHeader)
+
+	assert.NotEmpty(id)
+
+	req = httptest.NewRequest(http.MethodGet, "/Verifications/"+id, nil)
+	w = httptest.NewRecorder()
+
+	handlers.Verifications(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("application/json; charset=utf-8", w.Header().Get("Content-Type"))
+
+	verification := store.Verification{}
+
+	err = json.Unmarshal(w.Body.Bytes(), &verification)
+
+	assert.NoError(err)
+	assert.Equal(id, verification.ID)
+	assert.Equal(store.Fingerprint(customer), verification.Fingerprint)
+	assert.Equal(common.Example, verification.Provider)
+	assert.Equal("lily_was_here", verification.ReferenceID)
+	assert.False(verification.Final)
+	assert.Len(verification.Transitions, 1)
+
+	// Testing the lookup by reference id after the final result.
+	events.Publish(events.Result{
+		Provider:    common.Example,
+		ReferenceID: "lily_was_here",
+		Result:      common.KYCResult{Status: common.Approved},
+		Source:      events.Callback,
+	})
+
+	req = httptest.NewRequest(http.MethodGet, "/Verifications/?provider=Example&referenceID=lily_was_here", nil)
+	w = httptest.NewRecorder()
+
+	handlers.Verifications(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	verification = store.Verification{}
+
+	err = json.Unmarshal(w.Body.Bytes(), &verification)
+
+	assert.NoError(err)
+	assert.Equal(id, verification.ID)
+	assert.True(verification.Final)
+	if assert.NotNil(verification.Result) {
+		assert.Equal(common.KYCStatus2Status[common.Approved], verification.Result.Status)
+	}
+	if assert.Len(verification.Transitions, 2) {
+		assert.Equal(events.Callback, verification.Transitions[1].Source)
+	}
+
+	// Testing the lookup by fingerprint.
+	req = httptest.NewRequest(http.MethodGet, "/Verifications/?fingerprint="+store.Fingerprint(customer), nil)
+	w = httptest.NewRecorder()
+
+	handlers.Verifications(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	verifications := []store.Verification{}
+
+	err = json.Unmarshal(w.Body.Bytes(), &verifications)
+
+	assert.NoError(err)
+	if assert.Len(verifications, 1) {
+		assert.Equal(id, verifications[0].ID)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/Verifications/?fingerprint=unknown", nil)
+	w = httptest.NewRecorder()
+
+	handlers.Verifications(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("[]", w.Body.String())
+
+	// Testing unknown verification.
+	req = httptest.NewRequest(http.MethodGet, "/Verifications/unknown", nil)
+	w = httptest.NewRecorder()
+
+	handlers.Verifications(w, req)
+
+	assert.Equal(http.StatusNotFound, w.Code)
+	assert.Equal(`{"Error":"verification not found"}`, w.Body.String())
+
+	// Testing missing verification id.
+	req = httptest.NewRequest(http.MethodGet, "/Verifications/", nil)
+	w = httptest.NewRecorder()
+
+	handlers.Verifications(w, req)
+
+	assert.Equal(http.StatusBadRequest, w.Code)
+	assert.Equal(`{"Error":"missing verification id in the request"}`, w.Body.String())
+}
+
+func TestVerificationsOfClient(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "handlers")
+	if !assert.NoError(err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	err = store.Start(store.Config{Driver: store.BoltDriver, DSN: filepath.Join(dir, "kyc.db")})
+	if !assert.NoError(err) {
+		return
+	}
+	defer store.Stop()
+
+	backoffice := auth.Client{ID: "backoffice", Providers: []common.KYCProvider{common.Example}}
+	mobile := auth.Client{ID: "mobile", Providers: []common.KYCProvider{common.IDology}}
+
+	customer := &common.UserData{FirstName: "Urbi"}
+
+	request, err := json.Marshal(&common.CheckCustomerRequest{
+		Provider: common.Example,
+		UserData: customer,
+	})
+
+	assert.NoError(err)
+
+	// Testing the provider the client isn't allowed to use.
+	req := httptest.NewRequest(http.MethodPost, "/CheckCustomer", bytes.NewReader(request))
+	req = req.WithContext(auth.WithClient(req.Context(), mobile))
+	w := httptest.NewRecorder()
+
+	handlers.CheckCustomer(w, req)
+
+	assert.Equal(http.StatusForbidden, w.Code)
+	assert.Equal(`{"Error":"client mobile isn't allowed to use Example"}`, w.Body.String())
+
+	// Testing the client id recorded with the verification.
+	req = httptest.NewRequest(http.MethodPost, "/CheckCustomer", bytes.NewReader(request))
+	req = req.WithContext(auth.WithClient(req.Context(), backoffice))
+	w = httptest.NewRecorder()
+
+	handlers.CheckCustomer(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	id := w.Header().Get(handlers.VerificationIDHeader)
+
+	verification, err := store.Get(id)
+
+	assert.NoError(err)
+	assert.Equal("backoffice", verification.ClientID)
+
+	req = httptest.NewRequest(http.MethodGet, "/Verifications/"+id, nil)
+	req = req.WithContext(auth.WithClient(req.Context(), backoffice))
+	w = httptest.NewRecorder()
+
+	handlers.Verifications(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	// Testing the verification of another client.
+	req = httptest.NewRequest(http.MethodGet, "/Verifications/"+id, nil)
+	req = req.WithContext(auth.WithClient(req.Context(), mobile))
+	w = httptest.NewRecorder()
+
+	handlers.Verifications(w, req)
+
+	assert.Equal(http.StatusNotFound, w.Code)
+	assert.Equal(`{"Error":"verification not found"}`, w.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/Verifications/?fingerprint="+store.Fingerprint(customer), nil)
+	req = req.WithContext(auth.WithClient(req.Context(), mobile))
+	w = httptest.NewRecorder()
+
+	handlers.Verifications(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("[]", w.Body.String())
+}
@@ -0,0 +1,32 @@
+package idempotency
+
+import (
+	"time"
+
+	"modulus/kyc/main/option"
+)
+
+// TTLOption is the name of the idempotency keys option in the service config section.
+const TTLOption = "IdempotencyTTL"
+
+// DefaultTTL is the default value of the TTLOption.
+const DefaultTTL = 24 * time.Hour
+
+// Config holds the settings of the idempotency keys.
+//
+// * TTL is how long the response to the request with the idempotency key is replayed.
+type Config struct {
+	TTL time.Duration
+}
+
+// ConfigFromOptions parses the idempotency keys options from the service config section.
+// Absent options take their default values.
+func ConfigFromOptions(options map[string]string) (config Config, err error) {
+	config = Config{
+		TTL: DefaultTTL,
+	}
+
+	err = option.Duration(options, TTLOption, &config.TTL)
+
+	return
+}
@@ -0,0 +1,303 @@
+// Package idempotency makes the retries of the requests safe, so the retried verification isn't paid twice.
+// The client sends the request with the unique Idempotency-Key header. The key is recorded in the service store
+// with the hash of the request and the response to it. The retries of the request with the same key get the
+// recorded response instead of being handled again, and the reuse of the key with another request is rejected.
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/auth"
+	"modulus/kyc/main/logging"
+	"modulus/kyc/main/store"
+)
+
+// The headers of the idempotent requests and the replayed responses.
+const (
+	Header         = "Idempotency-Key"
+	ReplayedHeader = "Idempotent-Replayed"
+)
+
+// MaxKeyLength is the maximum length of the idempotency key.
+const MaxKeyLength = 255
+
+// lockTTL is how long the key of the request in progress is kept unless the response is recorded.
+// It frees the key of the request interrupted by the restart of the service.
+const lockTTL = 10 * time.Minute
+
+// The errors of the requests with the idempotency keys.
+var (
+	ErrKeyTooLong = fmt.Errorf("%s header is longer than %d characters", Header, MaxKeyLength)
+	ErrMismatch   = fmt.Errorf("%s is already used with another request", Header)
+	ErrInProgress = fmt.Errorf("the request with the %s is in progress, try again later", Header)
+)
+
+// Error represents the error of the request with the idempotency key.
+// Status is the HTTP status of the response.
+type Error struct {
+	Status int
+	Err    error
+}
+
+// Error implements error interface for the Error.
+func (e Error) Error() string {
+	return e.Err.Error()
+}
+
+// ErrorWriter writes the response for the error of the request with the idempotency key.
+type ErrorWriter func(w http.ResponseWriter, err error)
+
+// Keeper records the idempotency keys in the service store and purges the expired ones.
+type Keeper struct {
+	config Config
+	cancel context.CancelFunc
+	done   sync.WaitGroup
+}
+
+// New constructs the Keeper using the config.
+func New(config Config) *Keeper {
+	return &Keeper{config: config}
+}
+
+// Start launches the purge of the expired keys.
+func (k *Keeper) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	k.cancel = cancel
+
+	k.done.Add(1)
+	go k.purge(ctx)
+}
+
+// Stop stops the purge of the expired keys and waits for it to finish.
+func (k *Keeper) Stop() {
+	if k.cancel == nil {
+		return
+	}
+
+	k.cancel()
+	k.done.Wait()
+	k.cancel = nil
+}
+
+// purge removes the expired keys from the store periodically.
+func (k *Keeper) purge(ctx context.Context) {
+	defer k.done.Done()
+
+	interval := k.config.TTL
+	if interval > time.Hour {
+		interval = time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := store.PurgeKeys(now); err != nil && err != store.ErrDisabled {
+				slog.Error("Idempotency keys purge error", logging.ErrorKey, err)
+			}
+		}
+	}
+}
+
+// Serve handles the request with the idempotency key using the next handler.
+// The recorded response is written instead if the request is the retry.
+// The key is released if the request exceeded the limits or failed in the service, so it can be retried.
+func (k *Keeper) Serve(w http.ResponseWriter, r *http.Request, key string, next http.Handler) error {
+	if len(key) > MaxKeyLength {
+		return Error{Status: http.StatusBadRequest, Err: ErrKeyTooLong}
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return Error{Status: http.StatusRequestEntityTooLarge, Err: err}
+		}
+		return Error{Status: http.StatusBadRequest, Err: err}
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	now := time.Now()
+	reserved := store.IdempotencyKey{
+		Key:      key,
+		ClientID: auth.ClientID(r.Context()),
+		Hash:     requestHash(r, body),
+		Created:  now,
+		Expires:  now.Add(lockTTL),
+	}
+
+	existing, ok, err := store.ReserveKey(reserved)
+	switch {
+	case err == store.ErrDisabled:
+		return Error{Status: http.StatusServiceUnavailable, Err: err}
+	case err != nil:
+		return Error{Status: http.StatusInternalServerError, Err: err}
+	case ok:
+	case existing.Hash != reserved.Hash:
+		return Error{Status: http.StatusUnprocessableEntity, Err: ErrMismatch}
+	case !existing.Completed:
+		return Error{Status: http.StatusConflict, Err: ErrInProgress}
+	default:
+		replay(w, existing)
+		return nil
+	}
+
+	rw := &responseWriter{ResponseWriter: w}
+
+	next.ServeHTTP(rw, r)
+	if rw.status == 0 {
+		rw.WriteHeader(http.StatusOK)
+	}
+
+	if rw.status == http.StatusTooManyRequests || rw.status >= http.StatusInternalServerError {
+		err = store.ReleaseKey(reserved.ClientID, reserved.Key)
+	} else {
+		reserved.Completed = true
+		reserved.Status = rw.status
+		reserved.Header = rw.header
+		reserved.Body = rw.body.Bytes()
+		reserved.Expires = now.Add(k.config.TTL)
+
+		err = store.CompleteKey(reserved)
+	}
+	if err != nil {
+		slog.Error("Idempotency key store error", logging.ErrorKey, err)
+	}
+
+	return nil
+}
+
+// requestHash returns the hex-encoded SHA256 hash of the method, the path and the body of the request.
+func requestHash(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// replay writes the recorded response marked with the ReplayedHeader.
+func replay(w http.ResponseWriter, key store.IdempotencyKey) {
+	for name, values := range key.Header {
+		w.Header()[name] = values
+	}
+	w.Header().Set(ReplayedHeader, "true")
+	w.WriteHeader(key.Status)
+	w.Write(key.Body)
+}
+
+// responseWriter captures the status, the header and the body of the response.
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	header http.Header
+	body   bytes.Buffer
+}
+
+// WriteHeader implements http.ResponseWriter interface for the responseWriter.
+func (w *responseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+		w.header = w.Header().Clone()
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write implements http.ResponseWriter interface for the responseWriter.
+func (w *responseWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	w.body.Write(data)
+
+	return w.ResponseWriter.Write(data)
+}
+
+var (
+	mu      sync.RWMutex
+	current *Keeper
+)
+
+// Start constructs the service Keeper using the config and launches it.
+func Start(config Config) {
+	keeper := New(config)
+	keeper.Start()
+
+	mu.Lock()
+	previous := current
+	current = keeper
+	mu.Unlock()
+
+	if previous != nil {
+		previous.Stop()
+	}
+}
+
+// Stop stops the service Keeper.
+// The requests with the idempotency keys pass through afterwards.
+func Stop() {
+	mu.Lock()
+	previous := current
+	current = nil
+	mu.Unlock()
+
+	if previous != nil {
+		previous.Stop()
+	}
+}
+
+// Middleware replays the responses to the requests with the same idempotency key using the service Keeper.
+// The requests without the key pass through.
+func Middleware(next http.Handler) http.Handler {
+	return MiddlewareWithErrorWriter(next, WriteError)
+}
+
+// MiddlewareWithErrorWriter is like Middleware but writes the errors using the writeError.
+func MiddlewareWithErrorWriter(next http.Handler, writeError ErrorWriter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.RLock()
+		k := current
+		mu.RUnlock()
+
+		key := r.Header.Get(Header)
+		if k == nil || len(key) == 0 || r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if err := k.Serve(w, r, key, next); err != nil {
+			writeError(w, err)
+		}
+	})
+}
+
+// WriteError writes the error response for the error of the request with the idempotency key.
+func WriteError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	if e, ok := err.(Error); ok {
+		status = e.Status
+	}
+
+	resp, _ := json.Marshal(common.ErrorResponse{Error: err.Error()})
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(resp)
+}
@@ -0,0 +1,199 @@
+package idempotency
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"modulus/kyc/main/auth"
+	"modulus/kyc/main/store"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigFromOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing the default config.
+	config, err := ConfigFromOptions(map[string]string{})
+
+	assert.NoError(err)
+	assert.Equal(DefaultTTL, config.TTL)
+
+	// Testing the valid option.
+	config, err = ConfigFromOptions(map[string]string{TTLOption: "1h"})
+
+	assert.NoError(err)
+	assert.Equal(time.Hour, config.TTL)
+
+	// Testing the invalid options.
+	_, err = ConfigFromOptions(map[string]string{TTLOption: "0s"})
+
+	assert.EqualError(err, "invalid option 'IdempotencyTTL': non-positive duration")
+
+	_, err = ConfigFromOptions(map[string]string{TTLOption: "day"})
+
+	assert.Error(err)
+}
+
+func TestMiddleware(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls int32
+	release := make(chan struct{})
+
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+
+		body, _ := ioutil.ReadAll(r.Body)
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		switch string(body) {
+		case "limited":
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "slow":
+			<-release
+		}
+		w.Write(append([]byte("echo "), body...))
+	}))
+
+	serve := func(key, body string, client auth.Client) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/CheckCustomer", strings.NewReader(body))
+		if len(key) > 0 {
+			req.Header.Set(Header, key)
+		}
+		if len(client.ID) > 0 {
+			req = req.WithContext(auth.WithClient(req.Context(), client))
+		}
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		return w
+	}
+
+	// Testing the requests pass through if the keys aren't started.
+	serve("key", "first", auth.Client{})
+	serve("key", "first", auth.Client{})
+
+	assert.Equal(int32(2), atomic.LoadInt32(&calls))
+
+	// Testing the store isn't opened.
+	Start(Config{TTL: time.Hour})
+	defer Stop()
+
+	w := serve("key", "first", auth.Client{})
+
+	assert.Equal(http.StatusServiceUnavailable, w.Code)
+	assert.Equal(int32(2), atomic.LoadInt32(&calls))
+
+	dir, err := ioutil.TempDir("", "idempotency")
+	if !assert.NoError(err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	err = store.Start(store.Config{Driver: store.BoltDriver, DSN: filepath.Join(dir, "kyc.db")})
+	if !assert.NoError(err) {
+		return
+	}
+	defer store.Stop()
+
+	// Testing the first request is handled.
+	w = serve("key", "first", auth.Client{})
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("echo first", w.Body.String())
+	assert.Empty(w.Header().Get(ReplayedHeader))
+	assert.Equal(int32(3), atomic.LoadInt32(&calls))
+
+	// Testing the retry gets the recorded response.
+	w = serve("key", "first", auth.Client{})
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("echo first", w.Body.String())
+	assert.Equal("true", w.Header().Get(ReplayedHeader))
+	assert.Equal("application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(int32(3), atomic.LoadInt32(&calls))
+
+	// Testing the reuse of the key with another request.
+	w = serve("key", "second", auth.Client{})
+
+	assert.Equal(http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(`{"Error":"Idempotency-Key is already used with another request"}`, w.Body.String())
+	assert.Equal(int32(3), atomic.LoadInt32(&calls))
+
+	// Testing the same key of another client.
+	w = serve("key", "second", auth.Client{ID: "backoffice"})
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("echo second", w.Body.String())
+	assert.Equal(int32(4), atomic.LoadInt32(&calls))
+
+	// Testing the requests without the key.
+	serve("", "first", auth.Client{})
+	serve("", "first", auth.Client{})
+
+	assert.Equal(int32(6), atomic.LoadInt32(&calls))
+
+	// Testing the key of the request exceeded the limits is released.
+	w = serve("limited", "limited", auth.Client{})
+
+	assert.Equal(http.StatusTooManyRequests, w.Code)
+
+	w = serve("limited", "limited", auth.Client{})
+
+	assert.Equal(http.StatusTooManyRequests, w.Code)
+	assert.Empty(w.Header().Get(ReplayedHeader))
+	assert.Equal(int32(8), atomic.LoadInt32(&calls))
+
+	// Testing the too long key.
+	w = serve(strings.Repeat("k", MaxKeyLength+1), "first", auth.Client{})
+
+	assert.Equal(http.StatusBadRequest, w.Code)
+	assert.Equal(int32(8), atomic.LoadInt32(&calls))
+
+	// Testing the body exceeding the limit.
+	req := httptest.NewRequest(http.MethodPost, "/CheckCustomer", strings.NewReader("too large"))
+	req.Header.Set(Header, "large")
+	w = httptest.NewRecorder()
+	req.Body = http.MaxBytesReader(w, req.Body, 4)
+
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(http.StatusRequestEntityTooLarge, w.Code)
+	assert.Equal(int32(8), atomic.LoadInt32(&calls))
+
+	// Testing the retry of the request in progress.
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- serve("slow", "slow", auth.Client{})
+	}()
+
+	for atomic.LoadInt32(&calls) < 9 {
+		time.Sleep(time.Millisecond)
+	}
+
+	w = serve("slow", "slow", auth.Client{})
+
+	assert.Equal(http.StatusConflict, w.Code)
+
+	close(release)
+	w = <-done
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("echo slow", w.Body.String())
+
+	w = serve("slow", "slow", auth.Client{})
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("true", w.Header().Get(ReplayedHeader))
+	assert.Equal(int32(9), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,61 @@
+package jobs
+
+import (
+	"time"
+
+	"modulus/kyc/main/option"
+)
+
+// The names of the jobs options in the service config section.
+const (
+	DirOption       = "JobsDir"
+	WorkersOption   = "JobWorkers"
+	MaxQueuedOption = "JobMaxQueued"
+	RetentionOption = "JobRetention"
+)
+
+// The default values of the jobs options.
+const (
+	DefaultDir       = "jobs"
+	DefaultWorkers   = 4
+	DefaultMaxQueued = 1000
+	DefaultRetention = 24 * time.Hour
+)
+
+// Config holds the settings of the Queue.
+//
+// * Dir is the directory where the jobs are stored.
+// * Workers is the number of the jobs run at once.
+// * MaxQueued limits the number of the jobs waiting for a worker. The new jobs are rejected when it's reached.
+// * Retention is how long the finished jobs are kept.
+type Config struct {
+	Dir       string
+	Workers   int
+	MaxQueued int
+	Retention time.Duration
+}
+
+// ConfigFromOptions parses the jobs options from the service config section.
+// Absent options take their default values.
+func ConfigFromOptions(options map[string]string) (config Config, err error) {
+	config = Config{
+		Dir:       options[DirOption],
+		Workers:   DefaultWorkers,
+		MaxQueued: DefaultMaxQueued,
+		Retention: DefaultRetention,
+	}
+
+	if len(config.Dir) == 0 {
+		config.Dir = DefaultDir
+	}
+
+	if err = option.Int(options, WorkersOption, &config.Workers); err != nil {
+		return
+	}
+	if err = option.Int(options, MaxQueuedOption, &config.MaxQueued); err != nil {
+		return
+	}
+	err = option.Duration(options, RetentionOption, &config.Retention)
+
+	return
+}
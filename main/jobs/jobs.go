@@ -0,0 +1,400 @@
+// Package jobs runs the CheckCustomer requests in the async mode.
+// The request is stored in the durable queue as the job and the client gets the job id at once.
+// The jobs are run by the bounded pool of workers and the client fetches the progress and the final response by the job id.
+// The queued jobs survive the restart of the service and are run after it. The jobs interrupted by the stop or the crash
+// are failed instead of being run again, as the verifications they might have requested are paid already.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/logging"
+
+	"github.com/google/uuid"
+)
+
+// The states of the job.
+const (
+	Queued    = "queued"
+	Running   = "running"
+	Completed = "completed"
+	Failed    = "failed"
+)
+
+// The errors of the jobs.
+var (
+	ErrDisabled    = errors.New("async jobs aren't configured")
+	ErrQueueFull   = errors.New("too many queued jobs, try again later")
+	ErrNotFound    = errors.New("job not found")
+	ErrInterrupted = errors.New("interrupted")
+)
+
+// Runner runs the CheckCustomer request of the client.
+// It returns the response and the ids of the verifications recorded in the history.
+// The error is returned if the request itself has failed, e.g. it exceeded the limits of the client.
+type Runner func(ctx context.Context, clientID string, req common.CheckCustomerRequest) (response common.KYCResponse, ids []string, err error)
+
+// Job represents the CheckCustomer request run in the background.
+// Request is cleared once the job is finished to not keep the customer data longer than needed.
+type Job struct {
+	ID              string
+	ClientID        string
+	State           string
+	Request         *common.CheckCustomerRequest `json:",omitempty"`
+	Response        *common.KYCResponse          `json:",omitempty"`
+	Error           string                       `json:",omitempty"`
+	VerificationIDs []string                     `json:",omitempty"`
+	Created         time.Time
+	Started         *time.Time `json:",omitempty"`
+	Finished        *time.Time `json:",omitempty"`
+}
+
+// Done reports whether the job is completed or failed.
+func (j Job) Done() bool {
+	return j.State == Completed || j.State == Failed
+}
+
+// JobResponse returns the representation of the job for the API.
+func (j Job) JobResponse() common.JobResponse {
+	return common.JobResponse{
+		ID:              j.ID,
+		State:           j.State,
+		Response:        j.Response,
+		Error:           j.Error,
+		VerificationIDs: j.VerificationIDs,
+		Created:         j.Created,
+		Started:         j.Started,
+		Finished:        j.Finished,
+	}
+}
+
+// Queue stores the jobs and runs them with the pool of workers.
+type Queue struct {
+	config  Config
+	storage *storage
+	runner  Runner
+	mu      sync.Mutex
+	wake    chan struct{}
+	cancel  context.CancelFunc
+	drain   context.CancelFunc
+	done    sync.WaitGroup
+}
+
+// New constructs a new Queue using the config and loads the stored jobs.
+// The jobs left running by the crash of the service are failed with ErrInterrupted.
+func New(config Config, runner Runner) (queue *Queue, err error) {
+	storage, err := openStorage(config.Dir)
+	if err != nil {
+		err = fmt.Errorf("opening the jobs directory %s: %s", config.Dir, err)
+		return
+	}
+
+	for _, job := range storage.list(func(job Job) bool { return job.State == Running }) {
+		finish(&job, nil, nil, ErrInterrupted)
+		if err = storage.put(job); err != nil {
+			err = fmt.Errorf("failing the interrupted job %s: %s", job.ID, err)
+			return
+		}
+	}
+
+	queue = &Queue{
+		config:  config,
+		storage: storage,
+		runner:  runner,
+		wake:    make(chan struct{}, config.Workers),
+	}
+
+	return
+}
+
+// Start launches the workers and the cleanup of the finished jobs.
+// The running jobs are cancelled by the context, while the workers stop taking the next jobs once the idle context is done.
+func (q *Queue) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	idle, drain := context.WithCancel(ctx)
+
+	q.cancel = cancel
+	q.drain = drain
+
+	q.done.Add(q.config.Workers + 1)
+	for i := 0; i < q.config.Workers; i++ {
+		go q.work(ctx, idle)
+	}
+	go q.clean(idle)
+}
+
+// Stop stops the workers and waits for them to finish.
+// The running jobs are cancelled and failed with ErrInterrupted.
+func (q *Queue) Stop() {
+	if q.cancel == nil {
+		return
+	}
+
+	q.cancel()
+	q.done.Wait()
+	q.cancel = nil
+	q.drain = nil
+}
+
+// Drain stops taking the queued jobs and waits for the running ones to finish until the context is done.
+// The jobs still running then are cancelled and failed like by Stop. The queued jobs are run after the next start.
+func (q *Queue) Drain(ctx context.Context) {
+	if q.drain == nil {
+		return
+	}
+
+	q.drain()
+
+	finished := make(chan struct{})
+	go func() {
+		q.done.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+	case <-ctx.Done():
+	}
+
+	q.Stop()
+}
+
+// Submit queues the request of the client and returns the job.
+// ErrQueueFull is returned if the queue has reached its limit.
+func (q *Queue) Submit(clientID string, req common.CheckCustomerRequest) (job Job, err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.storage.list(func(job Job) bool { return job.State == Queued })) >= q.config.MaxQueued {
+		err = ErrQueueFull
+		return
+	}
+
+	req.Async = false
+
+	job = Job{
+		ID:       uuid.New().String(),
+		ClientID: clientID,
+		State:    Queued,
+		Request:  &req,
+		Created:  time.Now(),
+	}
+
+	if err = q.storage.put(job); err != nil {
+		return
+	}
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+
+	return
+}
+
+// Get returns the job by its id.
+// The jobs finished longer than the retention ago aren't returned.
+func (q *Queue) Get(id string) (job Job, err error) {
+	job, ok := q.storage.get(id)
+	if !ok || q.expired(job, time.Now()) {
+		err = ErrNotFound
+	}
+
+	return
+}
+
+// work runs the queued jobs until the idle context is done. The running job is cancelled by the context.
+func (q *Queue) work(ctx, idle context.Context) {
+	defer q.done.Done()
+
+	for idle.Err() == nil {
+		job, ok := q.next()
+		if !ok {
+			select {
+			case <-idle.Done():
+				return
+			case <-q.wake:
+				continue
+			}
+		}
+
+		q.run(ctx, job)
+	}
+}
+
+// next takes the oldest queued job and marks it running.
+func (q *Queue) next() (job Job, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	queued := q.storage.list(func(job Job) bool { return job.State == Queued })
+	if len(queued) == 0 {
+		return
+	}
+
+	job = queued[0]
+	now := time.Now()
+	job.State = Running
+	job.Started = &now
+
+	if err := q.storage.put(job); err != nil {
+		slog.Error("Job state store error", "jobID", job.ID, logging.ErrorKey, err)
+		return
+	}
+	ok = true
+
+	return
+}
+
+// run runs the job and stores its outcome.
+// The job cancelled by the stop is failed with ErrInterrupted.
+func (q *Queue) run(ctx context.Context, job Job) {
+	response, ids, err := q.runner(ctx, job.ClientID, *job.Request)
+	if ctx.Err() != nil {
+		err = ErrInterrupted
+	}
+
+	finish(&job, &response, ids, err)
+
+	if err := q.storage.put(job); err != nil {
+		slog.Error("Job result store error", "jobID", job.ID, logging.ErrorKey, err)
+	}
+}
+
+// clean removes the expired jobs periodically until the context is done.
+func (q *Queue) clean(ctx context.Context) {
+	defer q.done.Done()
+
+	interval := q.config.Retention
+	if interval > time.Hour {
+		interval = time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			q.cleanup(now)
+		}
+	}
+}
+
+// cleanup removes the jobs expired by the time.
+func (q *Queue) cleanup(now time.Time) {
+	for _, job := range q.storage.list(func(job Job) bool { return q.expired(job, now) }) {
+		if err := q.storage.remove(job.ID); err != nil {
+			slog.Error("Job removal error", "jobID", job.ID, logging.ErrorKey, err)
+		}
+	}
+}
+
+// expired reports whether the job has finished longer than the retention ago by the time.
+func (q *Queue) expired(job Job, now time.Time) bool {
+	return job.Done() && job.Finished != nil && now.Sub(*job.Finished) > q.config.Retention
+}
+
+// finish sets the outcome of the job and clears its request.
+func finish(job *Job, response *common.KYCResponse, ids []string, err error) {
+	now := time.Now()
+
+	if err != nil {
+		job.State = Failed
+		job.Error = err.Error()
+	} else {
+		job.State = Completed
+		job.Response = response
+		job.VerificationIDs = ids
+	}
+	job.Request = nil
+	job.Finished = &now
+}
+
+var (
+	mu      sync.RWMutex
+	current *Queue
+)
+
+// Start constructs the service Queue using the config and launches it.
+// The runner is used to run the jobs.
+func Start(config Config, runner Runner) (err error) {
+	queue, err := New(config, runner)
+	if err != nil {
+		return
+	}
+
+	queue.Start()
+
+	if previous := swap(queue); previous != nil {
+		previous.Stop()
+	}
+
+	return
+}
+
+// Stop stops the service Queue.
+func Stop() {
+	if previous := swap(nil); previous != nil {
+		previous.Stop()
+	}
+}
+
+// Drain drains the service Queue, see Queue.Drain.
+func Drain(ctx context.Context) {
+	if previous := swap(nil); previous != nil {
+		previous.Drain(ctx)
+	}
+}
+
+// swap replaces the service Queue and returns the previous one.
+func swap(queue *Queue) (previous *Queue) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	previous, current = current, queue
+
+	return
+}
+
+// Enabled reports whether the service Queue is running.
+func Enabled() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return current != nil
+}
+
+// Submit queues the request of the client with the service Queue.
+func Submit(clientID string, req common.CheckCustomerRequest) (job Job, err error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current == nil {
+		err = ErrDisabled
+		return
+	}
+
+	return current.Submit(clientID, req)
+}
+
+// Get returns the job by its id from the service Queue.
+func Get(id string) (job Job, err error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current == nil {
+		err = ErrDisabled
+		return
+	}
+
+	return current.Get(id)
+}
@@ -0,0 +1,327 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"modulus/kyc/common"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testConfig returns the config of the Queue with the jobs in the temporary directory.
+func testConfig(t *testing.T) Config {
+	dir, err := ioutil.TempDir("", "jobs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	return Config{
+		Dir:       dir,
+		Workers:   2,
+		MaxQueued: 10,
+		Retention: time.Hour,
+	}
+}
+
+// testRequest is the CheckCustomer request of the jobs in tests.
+var testRequest = common.CheckCustomerRequest{
+	Provider: common.Example,
+	UserData: &common.UserData{FirstName: "Abby"},
+	Async:    true,
+}
+
+// approve is the runner approving every customer but the one named Limited whose request fails.
+func approve(ctx context.Context, clientID string, req common.CheckCustomerRequest) (common.KYCResponse, []string, error) {
+	if req.UserData.FirstName == "Limited" {
+		return common.KYCResponse{}, nil, errors.New("limit exceeded")
+	}
+	return common.KYCResponse{Result: &common.Result{Status: "Approved"}}, []string{"id-" + clientID}, nil
+}
+
+// waitJob waits until the job gets the state.
+func waitJob(t *testing.T, q *Queue, id, state string) (job Job) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := q.Get(id)
+		if err == nil && job.State == state {
+			return job
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("job %s didn't get %s", id, state)
+
+	return
+}
+
+func TestConfigFromOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing the defaults.
+	config, err := ConfigFromOptions(map[string]string{})
+	assert.NoError(err)
+	assert.Equal(Config{Dir: DefaultDir, Workers: DefaultWorkers, MaxQueued: DefaultMaxQueued, Retention: DefaultRetention}, config)
+
+	// Testing the options.
+	config, err = ConfigFromOptions(map[string]string{
+		DirOption:       "/var/lib/kyc/jobs",
+		WorkersOption:   "8",
+		MaxQueuedOption: "100",
+		RetentionOption: "1h",
+	})
+	assert.NoError(err)
+	assert.Equal(Config{Dir: "/var/lib/kyc/jobs", Workers: 8, MaxQueued: 100, Retention: time.Hour}, config)
+
+	// Testing invalid options.
+	_, err = ConfigFromOptions(map[string]string{WorkersOption: "0"})
+	assert.EqualError(err, "invalid option 'JobWorkers': non-positive number")
+
+	_, err = ConfigFromOptions(map[string]string{MaxQueuedOption: "many"})
+	assert.Error(err)
+
+	_, err = ConfigFromOptions(map[string]string{RetentionOption: "-1h"})
+	assert.EqualError(err, "invalid option 'JobRetention': non-positive duration")
+}
+
+func TestSubmit(t *testing.T) {
+	assert := assert.New(t)
+
+	q, err := New(testConfig(t), approve)
+	if !assert.NoError(err) {
+		return
+	}
+	q.Start()
+	defer q.Stop()
+
+	job, err := q.Submit("backoffice", testRequest)
+	assert.NoError(err)
+	assert.Equal(Queued, job.State)
+	assert.NotEmpty(job.ID)
+	assert.False(job.Request.Async)
+
+	job = waitJob(t, q, job.ID, Completed)
+	assert.Equal("Approved", job.Response.Result.Status)
+	assert.Equal([]string{"id-backoffice"}, job.VerificationIDs)
+	assert.Nil(job.Request)
+	assert.NotNil(job.Started)
+	assert.NotNil(job.Finished)
+
+	// Testing the failed request.
+	req := testRequest
+	req.UserData = &common.UserData{FirstName: "Limited"}
+
+	job, err = q.Submit("backoffice", req)
+	assert.NoError(err)
+
+	job = waitJob(t, q, job.ID, Failed)
+	assert.Equal("limit exceeded", job.Error)
+	assert.Nil(job.Response)
+
+	_, err = q.Get("unknown")
+	assert.Equal(ErrNotFound, err)
+}
+
+func TestQueueFull(t *testing.T) {
+	assert := assert.New(t)
+
+	config := testConfig(t)
+	config.MaxQueued = 2
+
+	q, err := New(config, approve)
+	if !assert.NoError(err) {
+		return
+	}
+
+	// The queue isn't started so the jobs stay queued.
+	for i := 0; i < config.MaxQueued; i++ {
+		_, err = q.Submit("", testRequest)
+		assert.NoError(err)
+	}
+
+	_, err = q.Submit("", testRequest)
+	assert.Equal(ErrQueueFull, err)
+}
+
+func TestRestart(t *testing.T) {
+	assert := assert.New(t)
+
+	config := testConfig(t)
+	started := make(chan struct{}, 1)
+
+	// Testing the job interrupted by the stop is failed.
+	q, err := New(config, func(ctx context.Context, clientID string, req common.CheckCustomerRequest) (common.KYCResponse, []string, error) {
+		started <- struct{}{}
+		<-ctx.Done()
+		return common.KYCResponse{}, nil, ctx.Err()
+	})
+	if !assert.NoError(err) {
+		return
+	}
+	q.Start()
+
+	job, err := q.Submit("", testRequest)
+	assert.NoError(err)
+
+	<-started
+	q.Stop()
+
+	q, err = New(config, approve)
+	if !assert.NoError(err) {
+		return
+	}
+
+	job, err = q.Get(job.ID)
+	assert.NoError(err)
+	assert.Equal(Failed, job.State)
+	assert.Equal(ErrInterrupted.Error(), job.Error)
+	assert.Nil(job.Request)
+	assert.NotNil(job.Finished)
+
+	// Testing the job left running by the crash is failed.
+	config = testConfig(t)
+
+	s, err := openStorage(config.Dir)
+	if !assert.NoError(err) {
+		return
+	}
+
+	request := testRequest
+	job = Job{ID: "interrupted", State: Running, Request: &request, Created: time.Now()}
+	assert.NoError(s.put(job))
+
+	q, err = New(config, approve)
+	if !assert.NoError(err) {
+		return
+	}
+
+	job, err = q.Get(job.ID)
+	assert.NoError(err)
+	assert.Equal(Failed, job.State)
+	assert.Equal(ErrInterrupted.Error(), job.Error)
+	assert.Nil(job.Request)
+}
+
+func TestDrain(t *testing.T) {
+	assert := assert.New(t)
+
+	config := testConfig(t)
+	config.Workers = 1
+	started, release := make(chan struct{}, 1), make(chan struct{})
+
+	q, err := New(config, func(ctx context.Context, clientID string, req common.CheckCustomerRequest) (common.KYCResponse, []string, error) {
+		started <- struct{}{}
+		select {
+		case <-release:
+			return approve(ctx, clientID, req)
+		case <-ctx.Done():
+			return common.KYCResponse{}, nil, ctx.Err()
+		}
+	})
+	if !assert.NoError(err) {
+		return
+	}
+	q.Start()
+
+	// Testing the running job is completed and the queued one is left for the next start.
+	running, err := q.Submit("", testRequest)
+	assert.NoError(err)
+
+	<-started
+
+	queued, err := q.Submit("", testRequest)
+	assert.NoError(err)
+
+	drained := make(chan struct{})
+	go func() {
+		q.Drain(context.Background())
+		close(drained)
+	}()
+
+	// Let the drain begin before the running job is completed.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	<-drained
+
+	job, err := q.Get(running.ID)
+	assert.NoError(err)
+	assert.Equal(Completed, job.State)
+
+	job, err = q.Get(queued.ID)
+	assert.NoError(err)
+	assert.Equal(Queued, job.State)
+
+	// Testing the job still running after the drain timeout is cancelled and failed.
+	release = make(chan struct{})
+	q.Start()
+
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	q.Drain(ctx)
+
+	job, err = q.Get(queued.ID)
+	assert.NoError(err)
+	assert.Equal(Failed, job.State)
+	assert.Equal(ErrInterrupted.Error(), job.Error)
+}
+
+func TestCleanup(t *testing.T) {
+	assert := assert.New(t)
+
+	q, err := New(testConfig(t), approve)
+	if !assert.NoError(err) {
+		return
+	}
+	q.Start()
+	defer q.Stop()
+
+	job, err := q.Submit("", testRequest)
+	assert.NoError(err)
+
+	job = waitJob(t, q, job.ID, Completed)
+
+	q.cleanup(time.Now())
+	_, err = q.Get(job.ID)
+	assert.NoError(err)
+
+	q.cleanup(job.Finished.Add(2 * time.Hour))
+	_, ok := q.storage.get(job.ID)
+	assert.False(ok)
+
+	_, err = os.Stat(q.storage.filename(job.ID))
+	assert.True(os.IsNotExist(err))
+}
+
+func TestServiceQueue(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing the service Queue isn't started.
+	assert.False(Enabled())
+
+	_, err := Submit("", testRequest)
+	assert.Equal(ErrDisabled, err)
+
+	_, err = Get("id")
+	assert.Equal(ErrDisabled, err)
+
+	// Testing the started service Queue.
+	assert.NoError(Start(testConfig(t), approve))
+	defer Stop()
+
+	assert.True(Enabled())
+
+	job, err := Submit("", testRequest)
+	assert.NoError(err)
+
+	_, err = Get(job.ID)
+	assert.NoError(err)
+
+	Stop()
+	assert.False(Enabled())
+}
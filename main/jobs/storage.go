@@ -0,0 +1,133 @@
+package jobs
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// storage represents the durable store of the jobs.
+// Every job is kept in its own JSON file in the storage directory until it's removed after the retention.
+type storage struct {
+	mu   sync.Mutex
+	dir  string
+	jobs map[string]Job
+}
+
+// openStorage creates the storage directory if needed and loads the stored jobs.
+func openStorage(dir string) (s *storage, err error) {
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	s = &storage{
+		dir:  dir,
+		jobs: map[string]Job{},
+	}
+
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+
+		data, err1 := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		if err1 != nil {
+			err = err1
+			s = nil
+			return
+		}
+
+		job := Job{}
+		if err1 = json.Unmarshal(data, &job); err1 != nil {
+			err = err1
+			s = nil
+			return
+		}
+
+		s.jobs[job.ID] = job
+	}
+
+	return
+}
+
+// put stores the job.
+// The file is replaced atomically so the job is never lost halfway.
+func (s *storage) put(job Job) (err error) {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	filename := s.filename(job.ID)
+	tmp := filename + ".tmp"
+
+	if err = ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return
+	}
+	if err = os.Rename(tmp, filename); err != nil {
+		os.Remove(tmp)
+		return
+	}
+
+	s.jobs[job.ID] = job
+
+	return
+}
+
+// get returns the job by its id.
+func (s *storage) get(id string) (job Job, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok = s.jobs[id]
+
+	return
+}
+
+// remove deletes the job from the storage.
+func (s *storage) remove(id string) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err = os.Remove(s.filename(id))
+	if os.IsNotExist(err) {
+		err = nil
+	}
+	if err == nil {
+		delete(s.jobs, id)
+	}
+
+	return
+}
+
+// list returns the jobs matching the filter sorted by creation time.
+func (s *storage) list(filter func(job Job) bool) (jobs []Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, job := range s.jobs {
+		if filter(job) {
+			jobs = append(jobs, job)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Created.Before(jobs[j].Created) })
+
+	return
+}
+
+// filename returns the name of the file holding the job.
+func (s *storage) filename(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
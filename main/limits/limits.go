@@ -0,0 +1,472 @@
+// Package limits throttles the requests of the API clients to the KYC providers and accounts their usage.
+// The rate of the requests is limited with the token buckets of the clients and the providers.
+// The verification checks are counted against the daily and the monthly quotas of the clients and the providers.
+// The counts are recorded in the store, so the quotas aren't renewed when the service restarts.
+// The requests exceeding the limits are rejected before the provider API is requested.
+package limits
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/logging"
+	"modulus/kyc/main/option"
+	"modulus/kyc/main/store"
+)
+
+// The names of the limits options in the config sections of the KYC providers and the API clients.
+const (
+	RateLimitOption    = "RateLimit"
+	RateBurstOption    = "RateBurst"
+	DailyQuotaOption   = "DailyQuota"
+	MonthlyQuotaOption = "MonthlyQuota"
+)
+
+// The layouts of the quota periods.
+const (
+	dayLayout   = "2006-01-02"
+	monthLayout = "2006-01"
+)
+
+// Limits holds the limits of an API client or a KYC provider.
+// The zero value of a limit means it's unlimited.
+//
+// * RateLimit is the maximum number of the requests per minute.
+// * RateBurst is the maximum number of the requests in a burst. It's equal to RateLimit if not specified.
+// * DailyQuota is the maximum number of the verification checks per day (UTC).
+// * MonthlyQuota is the maximum number of the verification checks per month (UTC).
+type Limits struct {
+	RateLimit    int
+	RateBurst    int
+	DailyQuota   int
+	MonthlyQuota int
+}
+
+// LimitsFromOptions parses the limits options from the config section of a KYC provider or an API client.
+func LimitsFromOptions(options map[string]string) (limits Limits, err error) {
+	if err = option.NonNegativeInt(options, RateLimitOption, &limits.RateLimit); err != nil {
+		return
+	}
+	if err = option.NonNegativeInt(options, RateBurstOption, &limits.RateBurst); err != nil {
+		return
+	}
+	if err = option.NonNegativeInt(options, DailyQuotaOption, &limits.DailyQuota); err != nil {
+		return
+	}
+	if err = option.NonNegativeInt(options, MonthlyQuotaOption, &limits.MonthlyQuota); err != nil {
+		return
+	}
+
+	if limits.RateBurst > 0 && limits.RateLimit == 0 {
+		err = fmt.Errorf("option '%s' requires '%s'", RateBurstOption, RateLimitOption)
+	}
+
+	return
+}
+
+// burst returns the capacity of the token bucket.
+func (l Limits) burst() float64 {
+	if l.RateBurst > 0 {
+		return float64(l.RateBurst)
+	}
+
+	return float64(l.RateLimit)
+}
+
+// Config holds the limits of the API clients keyed by their ids and of the KYC providers.
+type Config struct {
+	Clients   map[string]Limits
+	Providers map[common.KYCProvider]Limits
+}
+
+// ConfigFromOptions parses the limits options from the config sections of the KYC providers
+// and the API clients keyed by the client ids.
+func ConfigFromOptions(providers map[common.KYCProvider]map[string]string, clients map[string]map[string]string) (config Config, err error) {
+	config = Config{
+		Clients:   map[string]Limits{},
+		Providers: map[common.KYCProvider]Limits{},
+	}
+
+	for provider, options := range providers {
+		if config.Providers[provider], err = LimitsFromOptions(options); err != nil {
+			err = fmt.Errorf("%s: %s", provider, err)
+			return
+		}
+	}
+	for id, options := range clients {
+		if config.Clients[id], err = LimitsFromOptions(options); err != nil {
+			err = fmt.Errorf("client %s: %s", id, err)
+			return
+		}
+	}
+
+	return
+}
+
+// Error represents the exceeded limit.
+// RetryAfter is the time after which the request may be retried.
+type Error struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+// Error implements error interface for the Error.
+func (e Error) Error() string {
+	return e.Err.Error()
+}
+
+// Usage represents the number of the verification checks of an API client using a KYC provider.
+// Client is empty for the requests of the unauthenticated clients.
+type Usage struct {
+	Client       string
+	Provider     common.KYCProvider
+	Day          string
+	Daily        int
+	DailyQuota   int `json:",omitempty"`
+	Month        string
+	Monthly      int
+	MonthlyQuota int `json:",omitempty"`
+}
+
+// bucket represents the token bucket.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// take refills the bucket according to the limits and takes the token from it.
+// It returns the wait until the token is available if the bucket is empty.
+func (b *bucket) take(limits Limits, now time.Time, apply bool) (wait time.Duration) {
+	rate := float64(limits.RateLimit) / time.Minute.Seconds()
+
+	tokens := math.Min(limits.burst(), b.tokens+now.Sub(b.last).Seconds()*rate)
+	if tokens < 1 {
+		return time.Duration(math.Ceil((1 - tokens) / rate * float64(time.Second)))
+	}
+	if apply {
+		b.tokens, b.last = tokens-1, now
+	}
+
+	return
+}
+
+// counter counts the verification checks of the current day and month.
+type counter struct {
+	day     string
+	daily   int
+	month   string
+	monthly int
+}
+
+// roll resets the counts of the passed periods.
+func (c *counter) roll(now time.Time) {
+	if day := now.Format(dayLayout); c.day != day {
+		c.day, c.daily = day, 0
+	}
+	if month := now.Format(monthLayout); c.month != month {
+		c.month, c.monthly = month, 0
+	}
+}
+
+type usageKey struct {
+	client   string
+	provider common.KYCProvider
+}
+
+// Limiter enforces the limits of the API clients and the KYC providers.
+type Limiter struct {
+	mu        sync.Mutex
+	config    Config
+	clients   map[string]*bucket
+	providers map[common.KYCProvider]*bucket
+	usage     map[usageKey]*counter
+	now       func() time.Time
+}
+
+// New constructs the Limiter using the config.
+func New(config Config) *Limiter {
+	return &Limiter{
+		config:    config,
+		clients:   map[string]*bucket{},
+		providers: map[common.KYCProvider]*bucket{},
+		usage:     map[usageKey]*counter{},
+		now:       time.Now,
+	}
+}
+
+// SetConfig replaces the limits keeping the usage counted so far.
+func (l *Limiter) SetConfig(config Config) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.config = config
+}
+
+// Load replaces the usage counted so far with the usage of the current day and month recorded in the store.
+func (l *Limiter) Load() error {
+	now := l.now().UTC()
+
+	recorded, err := store.FindUsage(now.Format(dayLayout), now.Format(monthLayout))
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.usage = map[usageKey]*counter{}
+	for _, u := range recorded {
+		key := usageKey{client: u.Client, provider: u.Provider}
+		c, ok := l.usage[key]
+		if !ok {
+			c = &counter{}
+			c.roll(now)
+			l.usage[key] = c
+		}
+		if u.Period == c.day {
+			c.daily = u.Count
+		} else {
+			c.monthly = u.Count
+		}
+	}
+
+	return nil
+}
+
+// AllowCheck admits the verification check of the client using the provider.
+// The check is counted against the quotas if admitted, and the count is recorded in the store if it's opened.
+// The check is still admitted if the count can't be recorded.
+func (l *Limiter) AllowCheck(client string, provider common.KYCProvider) error {
+	now, err := l.allow(client, provider, true)
+	if err != nil {
+		return err
+	}
+
+	err = store.AddUsage(client, provider, now.Format(dayLayout), now.Format(monthLayout))
+	if err != nil && err != store.ErrDisabled {
+		slog.Error("Recording usage", "client", client, "provider", provider, logging.ErrorKey, err)
+	}
+
+	return nil
+}
+
+// AllowStatus admits the status check of the client using the provider.
+// The status checks are throttled but they aren't counted against the quotas.
+func (l *Limiter) AllowStatus(client string, provider common.KYCProvider) (err error) {
+	_, err = l.allow(client, provider, false)
+	return
+}
+
+// allow checks all the limits of the client and the provider and consumes them only if none is exceeded.
+// It returns the time the limits are checked at.
+func (l *Limiter) allow(client string, provider common.KYCProvider, check bool) (now time.Time, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now = l.now().UTC()
+	clientLimits := l.config.Clients[client]
+	providerLimits := l.config.Providers[provider]
+
+	if err = l.take(client, provider, clientLimits, providerLimits, now, false); err != nil {
+		return
+	}
+
+	if check {
+		daily, monthly := 0, 0
+		providerDaily, providerMonthly := 0, 0
+		for key, c := range l.usage {
+			c.roll(now)
+			if key.client == client {
+				daily += c.daily
+				monthly += c.monthly
+			}
+			if key.provider == provider {
+				providerDaily += c.daily
+				providerMonthly += c.monthly
+			}
+		}
+
+		switch {
+		case exhausted(clientLimits.DailyQuota, daily):
+			err = quotaError(fmt.Sprintf("daily quota of the client %s", client), nextDay(now), now)
+		case exhausted(clientLimits.MonthlyQuota, monthly):
+			err = quotaError(fmt.Sprintf("monthly quota of the client %s", client), nextMonth(now), now)
+		case exhausted(providerLimits.DailyQuota, providerDaily):
+			err = quotaError(fmt.Sprintf("daily quota of %s", provider), nextDay(now), now)
+		case exhausted(providerLimits.MonthlyQuota, providerMonthly):
+			err = quotaError(fmt.Sprintf("monthly quota of %s", provider), nextMonth(now), now)
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	l.take(client, provider, clientLimits, providerLimits, now, true)
+
+	if check {
+		key := usageKey{client: client, provider: provider}
+		c, ok := l.usage[key]
+		if !ok {
+			c = &counter{}
+			c.roll(now)
+			l.usage[key] = c
+		}
+		c.daily++
+		c.monthly++
+	}
+
+	return
+}
+
+// take takes the tokens from the buckets of the client and the provider if both have them.
+func (l *Limiter) take(client string, provider common.KYCProvider, clientLimits, providerLimits Limits, now time.Time, apply bool) error {
+	if clientLimits.RateLimit > 0 {
+		b, ok := l.clients[client]
+		if !ok {
+			b = &bucket{tokens: clientLimits.burst(), last: now}
+			l.clients[client] = b
+		}
+		if wait := b.take(clientLimits, now, apply); wait > 0 {
+			return Error{Err: fmt.Errorf("rate limit of the client %s exceeded", client), RetryAfter: wait}
+		}
+	}
+
+	if providerLimits.RateLimit > 0 {
+		b, ok := l.providers[provider]
+		if !ok {
+			b = &bucket{tokens: providerLimits.burst(), last: now}
+			l.providers[provider] = b
+		}
+		if wait := b.take(providerLimits, now, apply); wait > 0 {
+			return Error{Err: fmt.Errorf("rate limit of %s exceeded", provider), RetryAfter: wait}
+		}
+	}
+
+	return nil
+}
+
+// Usage returns the usage of the current day and month sorted by the clients and the providers.
+// The usage is filtered by the client and the provider if they aren't empty.
+func (l *Limiter) Usage(client string, provider common.KYCProvider) (usage []Usage) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now().UTC()
+
+	usage = []Usage{}
+	for key, c := range l.usage {
+		if len(client) > 0 && key.client != client || len(provider) > 0 && key.provider != provider {
+			continue
+		}
+		c.roll(now)
+		clientLimits := l.config.Clients[key.client]
+		usage = append(usage, Usage{
+			Client:       key.client,
+			Provider:     key.provider,
+			Day:          c.day,
+			Daily:        c.daily,
+			DailyQuota:   clientLimits.DailyQuota,
+			Month:        c.month,
+			Monthly:      c.monthly,
+			MonthlyQuota: clientLimits.MonthlyQuota,
+		})
+	}
+
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Client != usage[j].Client {
+			return usage[i].Client < usage[j].Client
+		}
+		return usage[i].Provider < usage[j].Provider
+	})
+
+	return
+}
+
+// exhausted reports whether the count reached the quota.
+func exhausted(quota, count int) bool {
+	return quota > 0 && count >= quota
+}
+
+// quotaError returns the error of the exhausted quota renewed at the time.
+func quotaError(quota string, renewal, now time.Time) error {
+	return Error{Err: fmt.Errorf("%s exhausted", quota), RetryAfter: renewal.Sub(now)}
+}
+
+// nextDay returns the start of the next day.
+func nextDay(now time.Time) time.Time {
+	y, m, d := now.Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+}
+
+// nextMonth returns the start of the next month.
+func nextMonth(now time.Time) time.Time {
+	y, m, _ := now.Date()
+	return time.Date(y, m+1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+var (
+	mu      sync.RWMutex
+	current *Limiter
+)
+
+// Setup sets up the limits of the service using the config.
+// The usage recorded in the store is loaded when the limits are set up first if the store is opened.
+// The usage counted so far is kept when the limits are reloaded.
+func Setup(config Config) (err error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if current != nil {
+		current.SetConfig(config)
+		return
+	}
+
+	current = New(config)
+	if err = current.Load(); err == store.ErrDisabled {
+		err = nil
+	}
+
+	return
+}
+
+// service returns the limiter of the service.
+func service() *Limiter {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return current
+}
+
+// AllowCheck admits the verification check of the client using the provider by the service limits.
+// The checks are always admitted if the limits aren't set up.
+func AllowCheck(client string, provider common.KYCProvider) error {
+	if l := service(); l != nil {
+		return l.AllowCheck(client, provider)
+	}
+
+	return nil
+}
+
+// AllowStatus admits the status check of the client using the provider by the service limits.
+// The checks are always admitted if the limits aren't set up.
+func AllowStatus(client string, provider common.KYCProvider) error {
+	if l := service(); l != nil {
+		return l.AllowStatus(client, provider)
+	}
+
+	return nil
+}
+
+// GetUsage returns the usage counted by the service limits.
+func GetUsage(client string, provider common.KYCProvider) []Usage {
+	if l := service(); l != nil {
+		return l.Usage(client, provider)
+	}
+
+	return []Usage{}
+}
@@ -0,0 +1,312 @@
+package limits
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/store"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// clock is the controllable time source of the tests.
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+// newTestLimiter returns the limiter using the clock.
+func newTestLimiter(config Config, c *clock) *Limiter {
+	l := New(config)
+	l.now = c.Now
+
+	return l
+}
+
+func TestLimitsFromOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing the unlimited limits.
+	limits, err := LimitsFromOptions(map[string]string{
+		"Host": "https://example.com",
+	})
+
+	assert.NoError(err)
+	assert.Equal(Limits{}, limits)
+
+	// Testing the full limits.
+	limits, err = LimitsFromOptions(map[string]string{
+		"RateLimit":    "60",
+		"RateBurst":    "10",
+		"DailyQuota":   "1000",
+		"MonthlyQuota": "20000",
+	})
+
+	assert.NoError(err)
+	assert.Equal(Limits{RateLimit: 60, RateBurst: 10, DailyQuota: 1000, MonthlyQuota: 20000}, limits)
+
+	// Testing invalid values.
+	_, err = LimitsFromOptions(map[string]string{
+		"DailyQuota": "-1",
+	})
+
+	if assert.Error(err) {
+		assert.Equal("invalid option 'DailyQuota': negative number", err.Error())
+	}
+
+	_, err = LimitsFromOptions(map[string]string{
+		"RateLimit": "fast",
+	})
+
+	if assert.Error(err) {
+		assert.Equal(`invalid option 'RateLimit': strconv.Atoi: parsing "fast": invalid syntax`, err.Error())
+	}
+
+	_, err = LimitsFromOptions(map[string]string{
+		"RateBurst": "10",
+	})
+
+	if assert.Error(err) {
+		assert.Equal("option 'RateBurst' requires 'RateLimit'", err.Error())
+	}
+}
+
+func TestConfigFromOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	config, err := ConfigFromOptions(map[common.KYCProvider]map[string]string{
+		common.IDology: {"RateLimit": "120"},
+	}, map[string]map[string]string{
+		"backoffice": {"DailyQuota": "100"},
+	})
+
+	assert.NoError(err)
+	assert.Equal(Config{
+		Clients:   map[string]Limits{"backoffice": {DailyQuota: 100}},
+		Providers: map[common.KYCProvider]Limits{common.IDology: {RateLimit: 120}},
+	}, config)
+
+	_, err = ConfigFromOptions(nil, map[string]map[string]string{
+		"backoffice": {"MonthlyQuota": "-100"},
+	})
+
+	if assert.Error(err) {
+		assert.Equal("client backoffice: invalid option 'MonthlyQuota': negative number", err.Error())
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &clock{now: time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)}
+	l := newTestLimiter(Config{
+		Clients:   map[string]Limits{"backoffice": {RateLimit: 60, RateBurst: 2}},
+		Providers: map[common.KYCProvider]Limits{common.IDology: {RateLimit: 3}},
+	}, c)
+
+	// Testing the burst of the client.
+	assert.NoError(l.AllowStatus("backoffice", common.Jumio))
+	assert.NoError(l.AllowStatus("backoffice", common.Jumio))
+
+	err := l.AllowStatus("backoffice", common.Jumio)
+
+	assert.Equal(Error{Err: err.(Error).Err, RetryAfter: time.Second}, err)
+	assert.Equal("rate limit of the client backoffice exceeded", err.Error())
+
+	// Testing the other client isn't throttled.
+	assert.NoError(l.AllowStatus("mobile", common.Jumio))
+
+	// Testing the refill of the bucket.
+	c.now = c.now.Add(time.Second)
+
+	assert.NoError(l.AllowStatus("backoffice", common.Jumio))
+
+	// Testing the provider limit shared by the clients.
+	assert.NoError(l.AllowStatus("mobile", common.IDology))
+	assert.NoError(l.AllowStatus("mobile", common.IDology))
+	assert.NoError(l.AllowStatus("", common.IDology))
+
+	err = l.AllowStatus("", common.IDology)
+
+	if assert.Error(err) {
+		assert.Equal("rate limit of IDology exceeded", err.Error())
+		assert.Equal(20*time.Second, err.(Error).RetryAfter)
+	}
+
+	// Testing the rejected request doesn't consume the tokens of the client.
+	c.now = c.now.Add(time.Second)
+
+	err = l.AllowStatus("backoffice", common.IDology)
+
+	if assert.Error(err) {
+		assert.Equal("rate limit of IDology exceeded", err.Error())
+	}
+
+	assert.NoError(l.AllowStatus("backoffice", common.Jumio))
+	assert.Error(l.AllowStatus("backoffice", common.Jumio))
+}
+
+func TestQuotas(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &clock{now: time.Date(2026, 10, 31, 23, 0, 0, 0, time.UTC)}
+	l := newTestLimiter(Config{
+		Clients:   map[string]Limits{"backoffice": {DailyQuota: 2, MonthlyQuota: 3}},
+		Providers: map[common.KYCProvider]Limits{common.IDology: {DailyQuota: 3}},
+	}, c)
+
+	// Testing the daily quota of the client.
+	assert.NoError(l.AllowCheck("backoffice", common.IDology))
+	assert.NoError(l.AllowCheck("backoffice", common.Jumio))
+
+	err := l.AllowCheck("backoffice", common.Jumio)
+
+	assert.Equal(Error{Err: err.(Error).Err, RetryAfter: time.Hour}, err)
+	assert.Equal("daily quota of the client backoffice exhausted", err.Error())
+
+	// Testing the status checks aren't counted.
+	assert.NoError(l.AllowStatus("backoffice", common.Jumio))
+
+	// Testing the daily quota of the provider.
+	assert.NoError(l.AllowCheck("mobile", common.IDology))
+	assert.NoError(l.AllowCheck("mobile", common.IDology))
+
+	err = l.AllowCheck("mobile", common.IDology)
+
+	if assert.Error(err) {
+		assert.Equal("daily quota of IDology exhausted", err.Error())
+	}
+
+	assert.Equal([]Usage{
+		{Client: "backoffice", Provider: common.IDology, Day: "2026-10-31", Daily: 1, DailyQuota: 2, Month: "2026-10", Monthly: 1, MonthlyQuota: 3},
+		{Client: "backoffice", Provider: common.Jumio, Day: "2026-10-31", Daily: 1, DailyQuota: 2, Month: "2026-10", Monthly: 1, MonthlyQuota: 3},
+		{Client: "mobile", Provider: common.IDology, Day: "2026-10-31", Daily: 2, Month: "2026-10", Monthly: 2},
+	}, l.Usage("", ""))
+
+	assert.Equal([]Usage{
+		{Client: "mobile", Provider: common.IDology, Day: "2026-10-31", Daily: 2, Month: "2026-10", Monthly: 2},
+	}, l.Usage("mobile", ""))
+
+	assert.Len(l.Usage("", common.IDology), 2)
+	assert.Empty(l.Usage("billing", ""))
+
+	// Testing the renewal of the quotas in the next day and month.
+	c.now = c.now.Add(2 * time.Hour)
+
+	assert.NoError(l.AllowCheck("backoffice", common.Jumio))
+	assert.NoError(l.AllowCheck("backoffice", common.Jumio))
+
+	err = l.AllowCheck("backoffice", common.Jumio)
+
+	if assert.Error(err) {
+		assert.Equal("daily quota of the client backoffice exhausted", err.Error())
+	}
+
+	assert.Equal([]Usage{
+		{Client: "backoffice", Provider: common.IDology, Day: "2026-11-01", Daily: 0, DailyQuota: 2, Month: "2026-11", Monthly: 0, MonthlyQuota: 3},
+		{Client: "backoffice", Provider: common.Jumio, Day: "2026-11-01", Daily: 2, DailyQuota: 2, Month: "2026-11", Monthly: 2, MonthlyQuota: 3},
+	}, l.Usage("backoffice", ""))
+
+	// Testing the reloaded limits keep the usage.
+	l.SetConfig(Config{
+		Clients: map[string]Limits{"backoffice": {MonthlyQuota: 2}},
+	})
+
+	err = l.AllowCheck("backoffice", common.Jumio)
+
+	if assert.Error(err) {
+		assert.Equal("monthly quota of the client backoffice exhausted", err.Error())
+		assert.Equal(time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC).Sub(c.now), err.(Error).RetryAfter)
+	}
+}
+
+func TestRecordedUsage(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "limits")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err = store.Start(store.Config{Driver: store.BoltDriver, DSN: filepath.Join(dir, "kyc.db")}); err != nil {
+		t.Fatal(err)
+	}
+	defer store.Stop()
+
+	config := Config{
+		Clients: map[string]Limits{"backoffice": {DailyQuota: 3, MonthlyQuota: 5}},
+	}
+	c := &clock{now: time.Date(2026, 10, 31, 12, 0, 0, 0, time.UTC)}
+
+	l := newTestLimiter(config, c)
+	assert.NoError(l.AllowCheck("backoffice", common.IDology))
+	assert.NoError(l.AllowCheck("backoffice", common.IDology))
+	assert.NoError(l.AllowStatus("backoffice", common.IDology))
+
+	// Testing the usage is loaded by the restarted limiter.
+	l = newTestLimiter(config, c)
+	assert.NoError(l.Load())
+
+	assert.Equal([]Usage{
+		{Client: "backoffice", Provider: common.IDology, Day: "2026-10-31", Daily: 2, DailyQuota: 3, Month: "2026-10", Monthly: 2, MonthlyQuota: 5},
+	}, l.Usage("", ""))
+
+	assert.NoError(l.AllowCheck("backoffice", common.IDology))
+
+	err = l.AllowCheck("backoffice", common.IDology)
+
+	if assert.Error(err) {
+		assert.Equal("daily quota of the client backoffice exhausted", err.Error())
+	}
+
+	// Testing the usage of the passed day is loaded only into the monthly count.
+	c.now = c.now.Add(-24 * time.Hour)
+	assert.NoError(l.AllowCheck("backoffice", common.Jumio))
+
+	c.now = c.now.Add(24 * time.Hour)
+	l = newTestLimiter(config, c)
+	assert.NoError(l.Load())
+
+	assert.Equal([]Usage{
+		{Client: "backoffice", Provider: common.IDology, Day: "2026-10-31", Daily: 3, DailyQuota: 3, Month: "2026-10", Monthly: 3, MonthlyQuota: 5},
+		{Client: "backoffice", Provider: common.Jumio, Day: "2026-10-31", Daily: 0, DailyQuota: 3, Month: "2026-10", Monthly: 1, MonthlyQuota: 5},
+	}, l.Usage("", ""))
+}
+
+func TestService(t *testing.T) {
+	assert := assert.New(t)
+
+	defer func() {
+		mu.Lock()
+		current = nil
+		mu.Unlock()
+	}()
+
+	// Testing the limits aren't set up.
+	assert.NoError(AllowCheck("backoffice", common.IDology))
+	assert.Equal([]Usage{}, GetUsage("", ""))
+
+	Setup(Config{
+		Clients: map[string]Limits{"backoffice": {DailyQuota: 1}},
+	})
+
+	assert.NoError(AllowCheck("backoffice", common.IDology))
+	assert.Error(AllowCheck("backoffice", common.IDology))
+	assert.NoError(AllowStatus("backoffice", common.IDology))
+
+	// Testing the reload keeps the usage.
+	Setup(Config{})
+
+	assert.NoError(AllowCheck("backoffice", common.IDology))
+	if usage := GetUsage("backoffice", ""); assert.Len(usage, 1) {
+		assert.Equal(2, usage[0].Daily)
+	}
+}
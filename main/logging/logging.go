@@ -0,0 +1,113 @@
+// Package logging sets up the structured logger of the service.
+// The customer data is never logged as is: the document numbers are masked, the card and the bank account numbers
+// keep the last four digits, the emails and the phone numbers are hashed and the document files are dropped.
+// The messages of the standard logger are passed to the structured logger too.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// The names of the logging options in the service config section.
+const (
+	LevelOption  = "LogLevel"
+	FormatOption = "LogFormat"
+)
+
+// Supported log formats.
+const (
+	TextFormat = "text"
+	JSONFormat = "json"
+)
+
+// The default values of the logging options.
+const (
+	DefaultLevel  = slog.LevelInfo
+	DefaultFormat = TextFormat
+)
+
+// The keys of the common log attributes.
+const (
+	ProviderKey    = "provider"
+	StrategyKey    = "strategy"
+	ReferenceIDKey = "referenceID"
+	CustomerKey    = "customer"
+	ErrorKey       = "error"
+)
+
+// Config holds the settings of the logger.
+//
+// * Level is the minimal level of the logged records: debug, info, warn or error.
+// * Format is the format of the log records: text or json.
+type Config struct {
+	Level  slog.Level
+	Format string
+}
+
+// ConfigFromOptions parses the logging options from the service config section.
+// Absent options take their default values.
+func ConfigFromOptions(options map[string]string) (config Config, err error) {
+	config = Config{
+		Level:  DefaultLevel,
+		Format: strings.ToLower(options[FormatOption]),
+	}
+
+	if level, ok := options[LevelOption]; ok && len(level) > 0 {
+		if err = config.Level.UnmarshalText([]byte(level)); err != nil {
+			err = fmt.Errorf("invalid option '%s': unknown level %s", LevelOption, level)
+			return
+		}
+	}
+
+	switch config.Format {
+	case "":
+		config.Format = DefaultFormat
+	case TextFormat, JSONFormat:
+	default:
+		err = fmt.Errorf("invalid option '%s': unknown format %s", FormatOption, options[FormatOption])
+	}
+
+	return
+}
+
+var (
+	mu     sync.Mutex
+	level  = new(slog.LevelVar)
+	format string
+	output io.Writer
+)
+
+// Setup makes the logger with the config writing to the output the default one.
+// The repeated setup with the same output only changes the level and the format of the logger.
+func Setup(config Config, w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	level.Set(config.Level)
+
+	if config.Format == format && w == output {
+		return
+	}
+	format, output = config.Format, w
+
+	slog.SetDefault(slog.New(NewHandler(config.Format, w, level)))
+}
+
+// NewHandler constructs the log handler of the format writing to the output.
+// The handler redacts the customer data from the log records.
+func NewHandler(format string, w io.Writer, level slog.Leveler) slog.Handler {
+	options := &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redactAttr,
+	}
+
+	if format == JSONFormat {
+		return slog.NewJSONHandler(w, options)
+	}
+
+	return slog.NewTextHandler(w, options)
+}
@@ -0,0 +1,96 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigFromOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing the default config.
+	config, err := ConfigFromOptions(map[string]string{
+		"Port": "8080",
+	})
+
+	assert.NoError(err)
+	assert.Equal(Config{Level: slog.LevelInfo, Format: TextFormat}, config)
+
+	// Testing the valid options.
+	config, err = ConfigFromOptions(map[string]string{
+		"LogLevel":  "debug",
+		"LogFormat": "JSON",
+	})
+
+	assert.NoError(err)
+	assert.Equal(Config{Level: slog.LevelDebug, Format: JSONFormat}, config)
+
+	// Testing unknown level.
+	_, err = ConfigFromOptions(map[string]string{
+		"LogLevel": "verbose",
+	})
+
+	if assert.Error(err) {
+		assert.Equal("invalid option 'LogLevel': unknown level verbose", err.Error())
+	}
+
+	// Testing unknown format.
+	_, err = ConfigFromOptions(map[string]string{
+		"LogFormat": "xml",
+	})
+
+	if assert.Error(err) {
+		assert.Equal("invalid option 'LogFormat': unknown format xml", err.Error())
+	}
+}
+
+func TestSetup(t *testing.T) {
+	assert := assert.New(t)
+
+	defaultLogger := slog.Default()
+	defer slog.SetDefault(defaultLogger)
+	defer log.SetFlags(log.Flags())
+	defer log.SetOutput(log.Writer())
+
+	buf := &bytes.Buffer{}
+
+	Setup(Config{Level: slog.LevelWarn, Format: JSONFormat}, buf)
+
+	// Testing the level.
+	slog.Info("skipped")
+	slog.Warn("logged", ProviderKey, "Example")
+
+	record := map[string]interface{}{}
+
+	err := json.Unmarshal(buf.Bytes(), &record)
+
+	assert.NoError(err)
+	assert.Equal("WARN", record["level"])
+	assert.Equal("logged", record["msg"])
+	assert.Equal("Example", record["provider"])
+
+	// Testing the level change.
+	buf.Reset()
+
+	Setup(Config{Level: slog.LevelInfo, Format: JSONFormat}, buf)
+
+	slog.Info("logged")
+
+	assert.Contains(buf.String(), `"msg":"logged"`)
+
+	// Testing the standard logger passed to the structured logger.
+	buf.Reset()
+
+	Setup(Config{Level: slog.LevelInfo, Format: TextFormat}, buf)
+
+	log.Printf("Polling %s: rate limited", "Example")
+
+	assert.True(strings.Contains(buf.String(), "level=INFO"), buf.String())
+	assert.Contains(buf.String(), `msg="Polling Example: rate limited"`)
+}
@@ -0,0 +1,101 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// sensitiveKeys lists the parts of the names of the payload fields holding the personal data.
+var sensitiveKeys = []string{
+	"name", "birth", "dob", "address", "street", "city", "zip", "postal", "postcode",
+	"email", "phone", "mobile", "ssn", "passport", "document", "number", "iban", "account",
+	"card", "mrz", "ip", "latitude", "longitude", "image", "photo", "selfie",
+}
+
+// isSensitive reports whether the payload field holds the personal data.
+// The name is compared ignoring the case and the separators, e.g. first_name, firstName and First-Name are the same.
+func isSensitive(name string) bool {
+	name = strings.ToLower(strings.NewReplacer("_", "", "-", "", ".", "", ":", "").Replace(name))
+	for _, key := range sensitiveKeys {
+		if strings.Contains(name, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// xmlElement matches the XML elements holding the text only.
+var xmlElement = regexp.MustCompile(`<([A-Za-z_][\w.:-]*)([^<>]*)>([^<]*)</([A-Za-z_][\w.:-]*)>`)
+
+// RedactPayload returns the provider API response safe for exposing to the API clients as the JSON value.
+// The values of the JSON fields and the texts of the XML elements holding the personal data,
+// e.g. the names, the dates of birth, the addresses and the document numbers, are masked.
+// The JSON payload is returned as the JSON value and the XML payload is returned as the JSON string.
+// Any other payload, including the malformed JSON, is masked completely.
+func RedactPayload(body []byte) json.RawMessage {
+	trimmed := bytes.TrimSpace(body)
+
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		decoder.UseNumber()
+
+		var value interface{}
+		if err := decoder.Decode(&value); err == nil {
+			if redacted, err := json.Marshal(redactValue(value, false)); err == nil {
+				return redacted
+			}
+		}
+	}
+
+	text := string(trimmed)
+	if len(trimmed) > 0 && trimmed[0] == '<' {
+		text = xmlElement.ReplaceAllStringFunc(text, func(element string) string {
+			m := xmlElement.FindStringSubmatch(element)
+			if m[1] != m[4] || !isSensitive(m[1]) {
+				return element
+			}
+			return fmt.Sprintf("<%s%s>%s</%s>", m[1], m[2], Mask(m[3]), m[4])
+		})
+	} else {
+		text = Mask(text)
+	}
+
+	// The markup of the XML payload is kept readable.
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(text)
+
+	return bytes.TrimSpace(buf.Bytes())
+}
+
+// redactValue masks the values of the sensitive fields of the decoded JSON value.
+// All the scalar values nested in the sensitive field are masked.
+func redactValue(value interface{}, sensitive bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = redactValue(item, sensitive || isSensitive(key))
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item, sensitive)
+		}
+		return v
+	case string:
+		if sensitive {
+			return Mask(v)
+		}
+	case json.Number:
+		if sensitive {
+			return Mask(v.String())
+		}
+	}
+
+	return value
+}
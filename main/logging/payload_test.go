@@ -0,0 +1,39 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactPayload(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing the JSON payload.
+	payload := RedactPayload([]byte(`{
+		"id": 123,
+		"status": "success",
+		"total_hits": 1,
+		"hits": [{"doc": {"name": "John Doe", "types": ["pep"]}, "match_types": ["name_exact"]}],
+		"customer": {"firstName": "John", "dob": {"year": 1980}},
+		"Address": {"Street": "Main St", "Country": "US"}
+	}`))
+
+	assert.JSONEq(`{
+		"id": 123,
+		"status": "success",
+		"total_hits": 1,
+		"hits": [{"doc": {"name": "********", "types": ["pep"]}, "match_types": ["name_exact"]}],
+		"customer": {"firstName": "****", "dob": {"year": "****"}},
+		"Address": {"Street": "*******", "Country": "**"}
+	}`, string(payload))
+
+	// Testing the XML payload.
+	payload = RedactPayload([]byte(`<response><id-number>2073386264</id-number><summary-result><key>id.success</key></summary-result><firstName attr="1">John</firstName><dob>1980</dob></response>`))
+
+	assert.Equal(`"<response><id-number>**********</id-number><summary-result><key>id.success</key></summary-result><firstName attr=\"1\">****</firstName><dob>****</dob></response>"`, string(payload))
+
+	// Testing the malformed and the empty payloads.
+	assert.Equal(`"********"`, string(RedactPayload([]byte(`{"name": `))))
+	assert.Equal(`""`, string(RedactPayload(nil)))
+}
@@ -0,0 +1,260 @@
+package logging
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"modulus/kyc/common"
+)
+
+// maskRune replaces the hidden characters of the redacted values.
+const maskRune = '*'
+
+// hashPrefix marks the hashed values.
+const hashPrefix = "sha256:"
+
+// Mask hides every character of the value keeping its length.
+func Mask(value string) string {
+	return strings.Repeat(string(maskRune), utf8.RuneCountInString(value))
+}
+
+// LastFour hides every character of the value except the last four.
+// The value not longer than four characters is masked completely.
+func LastFour(value string) string {
+	runes := []rune(value)
+	if len(runes) <= 4 {
+		return Mask(value)
+	}
+
+	for i := 0; i < len(runes)-4; i++ {
+		runes[i] = maskRune
+	}
+
+	return string(runes)
+}
+
+// Hash replaces the value with the prefixed hex-encoded SHA256 hash truncated to 16 characters.
+// The same values produce the same hash, so they are still correlated in the logs.
+func Hash(value string) string {
+	if len(value) == 0 {
+		return value
+	}
+
+	hash := sha256.Sum256([]byte(value))
+
+	return hashPrefix + hex.EncodeToString(hash[:])[:16]
+}
+
+// HashEmail hashes the email ignoring the case and the surrounding spaces.
+func HashEmail(email string) string {
+	return Hash(strings.ToLower(strings.TrimSpace(email)))
+}
+
+// HashPhone hashes the phone number ignoring everything except the digits.
+func HashPhone(phone string) string {
+	digits := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, phone)
+	if len(digits) == 0 {
+		return Hash(phone)
+	}
+
+	return Hash(digits)
+}
+
+// Redact returns the copy of the customer data safe for logging.
+//
+// * The document numbers and the passport MRZ lines are masked.
+// * The card and the bank account numbers keep the last four digits only.
+// * The emails and the phone numbers are hashed.
+// * The contents of the document files are dropped. Their names and content types are kept.
+//
+// The customer data itself is left intact.
+func Redact(customer *common.UserData) *common.UserData {
+	if customer == nil {
+		return nil
+	}
+
+	c := *customer
+
+	c.Email = HashEmail(c.Email)
+	c.Phone = HashPhone(c.Phone)
+	c.MobilePhone = HashPhone(c.MobilePhone)
+	c.BankAccountNumber = LastFour(c.BankAccountNumber)
+
+	if c.Passport != nil {
+		v := *c.Passport
+		v.Number, v.Mrz1, v.Mrz2 = Mask(v.Number), Mask(v.Mrz1), Mask(v.Mrz2)
+		v.Image = redactFile(v.Image)
+		c.Passport = &v
+	}
+	if c.IDCard != nil {
+		v := *c.IDCard
+		v.Number = Mask(v.Number)
+		v.Image = redactFile(v.Image)
+		c.IDCard = &v
+	}
+	if c.SNILS != nil {
+		v := *c.SNILS
+		v.Number = Mask(v.Number)
+		v.Image = redactFile(v.Image)
+		c.SNILS = &v
+	}
+	if c.HealthID != nil {
+		v := *c.HealthID
+		v.Number = Mask(v.Number)
+		v.Image = redactFile(v.Image)
+		c.HealthID = &v
+	}
+	if c.SocialServiceID != nil {
+		v := *c.SocialServiceID
+		v.Number = Mask(v.Number)
+		v.Image = redactFile(v.Image)
+		c.SocialServiceID = &v
+	}
+	if c.TaxID != nil {
+		v := *c.TaxID
+		v.Number = Mask(v.Number)
+		v.Image = redactFile(v.Image)
+		c.TaxID = &v
+	}
+	if c.DriverLicense != nil {
+		v := *c.DriverLicense
+		v.Number = Mask(v.Number)
+		v.FrontImage, v.BackImage = redactFile(v.FrontImage), redactFile(v.BackImage)
+		c.DriverLicense = &v
+	}
+	if c.DriverLicenseTranslation != nil {
+		v := *c.DriverLicenseTranslation
+		v.Number = Mask(v.Number)
+		v.FrontImage, v.BackImage = redactFile(v.FrontImage), redactFile(v.BackImage)
+		c.DriverLicenseTranslation = &v
+	}
+	if c.CreditCard != nil {
+		v := *c.CreditCard
+		v.Number = LastFour(v.Number)
+		v.Image = redactFile(v.Image)
+		c.CreditCard = &v
+	}
+	if c.DebitCard != nil {
+		v := *c.DebitCard
+		v.Number = LastFour(v.Number)
+		v.Image = redactFile(v.Image)
+		c.DebitCard = &v
+	}
+	if c.Other != nil {
+		v := *c.Other
+		v.Number = Mask(v.Number)
+		v.Image = redactFile(v.Image)
+		c.Other = &v
+	}
+	if c.Document != nil {
+		v := *c.Document
+		switch v.Type {
+		case common.CreditCardType, common.DebitCardType:
+			v.Number = LastFour(v.Number)
+		default:
+			v.Number = Mask(v.Number)
+		}
+		v.Image = redactFile(v.Image)
+		c.Document = &v
+	}
+	if c.UtilityBill != nil {
+		v := *c.UtilityBill
+		v.Image = redactFile(v.Image)
+		c.UtilityBill = &v
+	}
+	if c.ResidencePermit != nil {
+		v := *c.ResidencePermit
+		v.Image = redactFile(v.Image)
+		c.ResidencePermit = &v
+	}
+	if c.Agreement != nil {
+		c.Agreement = &common.Agreement{Image: redactFile(c.Agreement.Image)}
+	}
+	if c.EmploymentCertificate != nil {
+		v := *c.EmploymentCertificate
+		v.Image = redactFile(v.Image)
+		c.EmploymentCertificate = &v
+	}
+	if c.Contract != nil {
+		c.Contract = &common.Contract{Image: redactFile(c.Contract.Image)}
+	}
+	if c.DocumentPhoto != nil {
+		c.DocumentPhoto = &common.DocumentPhoto{Image: redactFile(c.DocumentPhoto.Image)}
+	}
+	if c.Selfie != nil {
+		c.Selfie = &common.Selfie{Image: redactFile(c.Selfie.Image)}
+	}
+	if c.Avatar != nil {
+		c.Avatar = &common.Avatar{Image: redactFile(c.Avatar.Image)}
+	}
+	if c.VideoAuth != nil {
+		c.VideoAuth = (*common.VideoAuth)(redactFile((*common.DocumentFile)(c.VideoAuth)))
+	}
+	if c.CompanyBoard != nil {
+		c.CompanyBoard = (*common.CompanyBoard)(redactFile((*common.DocumentFile)(c.CompanyBoard)))
+	}
+	if c.CompanyRegistration != nil {
+		c.CompanyRegistration = (*common.CompanyRegistration)(redactFile((*common.DocumentFile)(c.CompanyRegistration)))
+	}
+
+	return &c
+}
+
+// redactFile returns the copy of the document file without its contents.
+func redactFile(file *common.DocumentFile) *common.DocumentFile {
+	if file == nil {
+		return nil
+	}
+
+	return &common.DocumentFile{
+		Filename:    file.Filename,
+		ContentType: file.ContentType,
+	}
+}
+
+// redactedCustomer represents the redacted customer data in the log records.
+// It's encoded as JSON by both the text and the JSON log formats.
+type redactedCustomer struct {
+	customer *common.UserData
+}
+
+// MarshalJSON implements json.Marshaler interface for the redactedCustomer.
+func (r redactedCustomer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.customer)
+}
+
+// MarshalText implements encoding.TextMarshaler interface for the redactedCustomer.
+func (r redactedCustomer) MarshalText() ([]byte, error) {
+	return json.Marshal(r.customer)
+}
+
+// Customer returns the log attribute holding the redacted customer data.
+func Customer(customer *common.UserData) slog.Attr {
+	return slog.Any(CustomerKey, redactedCustomer{customer: Redact(customer)})
+}
+
+// redactAttr redacts the customer data logged without the Customer helper.
+func redactAttr(groups []string, attr slog.Attr) slog.Attr {
+	if attr.Value.Kind() != slog.KindAny {
+		return attr
+	}
+
+	switch v := attr.Value.Any().(type) {
+	case *common.UserData:
+		attr.Value = slog.AnyValue(redactedCustomer{customer: Redact(v)})
+	case common.UserData:
+		attr.Value = slog.AnyValue(redactedCustomer{customer: Redact(&v)})
+	}
+
+	return attr
+}
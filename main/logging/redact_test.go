@@ -0,0 +1,139 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"modulus/kyc/common"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMask(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("", Mask(""))
+	assert.Equal("*********", Mask("AB1234567"))
+	assert.Equal("", LastFour(""))
+	assert.Equal("****", LastFour("1234"))
+	assert.Equal("************1111", LastFour("4111111111111111"))
+}
+
+func TestHash(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("", Hash(""))
+	assert.True(strings.HasPrefix(HashEmail("abby@example.com"), "sha256:"))
+	assert.Len(HashEmail("abby@example.com"), 23)
+	assert.Equal(HashEmail("abby@example.com"), HashEmail(" Abby@Example.com "))
+	assert.NotEqual(HashEmail("abby@example.com"), HashEmail("delilah@example.com"))
+	assert.Equal(HashPhone("+1 (555) 123-4567"), HashPhone("15551234567"))
+	assert.NotEqual("15551234567", HashPhone("15551234567"))
+}
+
+func TestRedact(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(Redact(nil))
+
+	customer := &common.UserData{
+		FirstName:         "Abby",
+		Email:             "abby@example.com",
+		Phone:             "+1 555 123 4567",
+		MobilePhone:       "+1 555 765 4321",
+		BankAccountNumber: "DE89370400440532013000",
+		Passport: &common.Passport{
+			Number:        "AB1234567",
+			Mrz1:          "P<UTOERIKSSON<<ANNA<MARIA<<<<<<<<<<<<<<<<<<<",
+			Mrz2:          "L898902C36UTO7408122F1204159ZE184226B<<<<<10",
+			CountryAlpha2: "US",
+			Image: &common.DocumentFile{
+				Filename:    "passport.jpg",
+				ContentType: "image/jpeg",
+				Data:        []byte("image"),
+			},
+		},
+		SocialServiceID: &common.SocialServiceID{
+			Number: "078-05-1120",
+		},
+		CreditCard: &common.CreditCard{
+			Number: "4111111111111111",
+		},
+		Document: &common.Document{
+			Type:   common.DebitCardType,
+			Number: "5500000000000004",
+		},
+		Selfie: &common.Selfie{
+			Image: &common.DocumentFile{
+				Filename: "selfie.png",
+				Data:     []byte("selfie"),
+			},
+		},
+		VideoAuth: &common.VideoAuth{
+			Filename: "video.mp4",
+			Data:     []byte("video"),
+		},
+	}
+
+	redacted := Redact(customer)
+
+	assert.Equal("Abby", redacted.FirstName)
+	assert.Equal(HashEmail("abby@example.com"), redacted.Email)
+	assert.Equal(HashPhone("+1 555 123 4567"), redacted.Phone)
+	assert.Equal(HashPhone("+1 555 765 4321"), redacted.MobilePhone)
+	assert.Equal("******************3000", redacted.BankAccountNumber)
+	assert.Equal("*********", redacted.Passport.Number)
+	assert.NotContains(redacted.Passport.Mrz1, "ERIKSSON")
+	assert.NotContains(redacted.Passport.Mrz2, "L898902C3")
+	assert.Equal("US", redacted.Passport.CountryAlpha2)
+	assert.Equal("passport.jpg", redacted.Passport.Image.Filename)
+	assert.Equal("image/jpeg", redacted.Passport.Image.ContentType)
+	assert.Nil(redacted.Passport.Image.Data)
+	assert.Equal("***********", redacted.SocialServiceID.Number)
+	assert.Equal("************1111", redacted.CreditCard.Number)
+	assert.Equal("************0004", redacted.Document.Number)
+	assert.Equal("selfie.png", redacted.Selfie.Image.Filename)
+	assert.Nil(redacted.Selfie.Image.Data)
+	assert.Equal("video.mp4", redacted.VideoAuth.Filename)
+	assert.Nil(redacted.VideoAuth.Data)
+
+	// Testing the customer data left intact.
+	assert.Equal("abby@example.com", customer.Email)
+	assert.Equal("AB1234567", customer.Passport.Number)
+	assert.Equal([]byte("image"), customer.Passport.Image.Data)
+	assert.Equal("4111111111111111", customer.CreditCard.Number)
+	assert.Equal([]byte("video"), customer.VideoAuth.Data)
+}
+
+func TestHandlerRedaction(t *testing.T) {
+	assert := assert.New(t)
+
+	customer := &common.UserData{
+		FirstName: "Abby",
+		Email:     "abby@example.com",
+		Passport: &common.Passport{
+			Number: "AB1234567",
+		},
+	}
+
+	for _, format := range []string{TextFormat, JSONFormat} {
+		buf := &bytes.Buffer{}
+		logger := slog.New(NewHandler(format, buf, slog.LevelDebug))
+
+		// Testing the customer data logged using the helper.
+		logger.Info("request", Customer(customer))
+
+		// Testing the customer data logged as is.
+		logger.Info("request", "customer", customer, "copy", *customer)
+
+		out := buf.String()
+
+		assert.Contains(out, "Abby", format)
+		assert.Contains(out, "*********", format)
+		assert.Contains(out, HashEmail("abby@example.com"), format)
+		assert.NotContains(out, "AB1234567", format)
+		assert.NotContains(out, "abby@example.com", format)
+	}
+}
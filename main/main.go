@@ -1,15 +1,37 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"github.com/fsnotify/fsnotify"
 	"io"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
 
+	"modulus/kyc/common"
+	"modulus/kyc/main/auth"
+	"modulus/kyc/main/batch"
 	"modulus/kyc/main/config"
+	"modulus/kyc/main/grpcapi"
+	grpcconfig "modulus/kyc/main/grpcapi/config"
 	"modulus/kyc/main/handlers"
+	"modulus/kyc/main/idempotency"
+	"modulus/kyc/main/jobs"
+	"modulus/kyc/main/limits"
+	"modulus/kyc/main/logging"
+	"modulus/kyc/main/metrics"
+	"modulus/kyc/main/notify"
+	"modulus/kyc/main/poller"
+	"modulus/kyc/main/rules"
+	"modulus/kyc/main/server"
+	"modulus/kyc/main/store"
+	"modulus/kyc/main/tracing"
 )
 
 const (
@@ -21,12 +43,19 @@ const (
 // For a production build, this flag value should be set to "false" upon compilation time using: [-ldflags "-X main.DevEnv=false"]
 var DevEnv = "true"
 
+// logOutput is where the service logs are written to.
+var logOutput io.Writer = os.Stdout
+
 var (
 	cfgFile = flag.String("config", "", "Load the service configuration from the file specified")
 	port    = flag.String("port", "", "Listen on the port specified")
 )
 
 func main() {
+	// Run the config subcommand instead of the service if it's requested, e.g. kyc config encrypt.
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		os.Exit(runConfig(os.Args[2:], os.Stdin, os.Stdout, os.Stderr))
+	}
 
 	// FIXME: temporarily turned off license check.
 	// Validate license in production environment.
@@ -38,8 +67,8 @@ func main() {
 	if err != nil {
 		log.Printf("error opening log file %s\n", err)
 	}
-	mw := io.MultiWriter(os.Stdout, file)
-	log.SetOutput(mw)
+	logOutput = io.MultiWriter(os.Stdout, file)
+	log.SetOutput(logOutput)
 
 	flag.Parse()
 
@@ -58,8 +87,98 @@ func main() {
 		log.Fatalf("Loading configuration from %s: %s\n", *cfgFile, err)
 	}
 
+	// Switch to the structured logger redacting the customer data.
+	if err := setupLogging(); err != nil {
+		log.Fatalf("Loading logging configuration: %s\n", err)
+	}
+
+	// Start the tracing if it's configured.
+	tracingConfig, err := tracing.ConfigFromOptions(config.Get()[config.ServiceSection])
+	if err != nil {
+		log.Fatalf("Loading tracing configuration: %s\n", err)
+	}
+	if err := tracing.Start(tracingConfig); err != nil {
+		log.Fatalf("Starting tracing: %s\n", err)
+	}
+	defer tracing.Stop(context.Background())
+
+	// Open the store of the verifications history.
+	storeConfig, err := store.ConfigFromOptions(config.Get()[config.ServiceSection])
+	if err != nil {
+		log.Fatalf("Loading store configuration: %s\n", err)
+	}
+	if err := store.Start(storeConfig); err != nil {
+		log.Fatalf("Opening %s store: %s\n", storeConfig.Driver, err)
+	}
+	defer store.Stop()
+
+	// Start the idempotency keys of the requests recorded in the store.
+	idempotencyConfig, err := idempotency.ConfigFromOptions(config.Get()[config.ServiceSection])
+	if err != nil {
+		log.Fatalf("Loading idempotency configuration: %s\n", err)
+	}
+	idempotency.Start(idempotencyConfig)
+	defer idempotency.Stop()
+
+	// Set up the authentication of the API clients if they're configured.
+	if err := setupAuth(); err != nil {
+		log.Fatalf("Loading authentication configuration: %s\n", err)
+	}
+	if !auth.Enabled() {
+		log.Println("Authentication is disabled: no clients are defined in the config")
+	}
+
+	// Set up the rate limits and the quotas of the clients and the providers.
+	if err := setupLimits(); err != nil {
+		log.Fatalf("Loading limits configuration: %s\n", err)
+	}
+
+	// Set up the decision rules applied to the verification results if they're configured.
+	if err := setupRules(); err != nil {
+		log.Fatalf("Loading decision rules: %s\n", err)
+	}
+
+	// Start the background polling of the pending verifications including the ones recorded before the restart.
+	poller.Start(handlers.CheckVerificationStatus, pollingSettings, pendingVerifications)
+	defer poller.Stop()
+
+	// Start the notifications about the verification results if they're configured.
+	notifyConfig, err := notify.ConfigFromOptions(config.Get()[config.ServiceSection])
+	if err != nil {
+		log.Fatalf("Loading notifications configuration: %s\n", err)
+	}
+	if err := notify.Start(notifyConfig, poller.Track); err != nil {
+		log.Fatalf("Starting notifications: %s\n", err)
+	}
+	defer notify.Stop()
+	if !notify.Enabled() {
+		log.Println("Result notifications are disabled: missing NotificationSecret in the config")
+	}
+
+	// Start the workers of the async CheckCustomer jobs.
+	jobsConfig, err := jobs.ConfigFromOptions(config.Get()[config.ServiceSection])
+	if err != nil {
+		log.Fatalf("Loading jobs configuration: %s\n", err)
+	}
+	if err := jobs.Start(jobsConfig, handlers.RunJob); err != nil {
+		log.Fatalf("Starting jobs: %s\n", err)
+	}
+	defer jobs.Stop()
+
+	// Start the batch verifications resuming the unfinished batches.
+	batchConfig, err := batch.ConfigFromOptions(config.Get()[config.ServiceSection])
+	if err != nil {
+		log.Fatalf("Loading batch configuration: %s\n", err)
+	}
+	if err := batch.Start(batchConfig, handlers.RunJob, batchConcurrency); err != nil {
+		log.Fatalf("Starting batch verifications: %s\n", err)
+	}
+	defer batch.Stop()
+
 	// watch config changes.
-	go watchConfigs()
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	go watchConfigs(watchCtx)
 
 	createHandlers()
 
@@ -67,14 +186,60 @@ func main() {
 	// If the command line flag is set its value will be used for the listening port
 	// otherwise the option from the service config will be used.
 	if len(*port) == 0 {
-		*port = config.Cfg.ServicePort()
+		*port = config.Get().ServicePort()
 	}
 
-	log.Printf("Listen on :%v", *port)
+	// Start the gRPC API next to the REST API if it's configured.
+	grpcConfig, err := grpcconfig.ConfigFromOptions(config.Get()[config.ServiceSection])
+	if err != nil {
+		log.Fatalf("Loading gRPC configuration: %s\n", err)
+	}
+	if err := grpcapi.Start(grpcConfig); err != nil {
+		log.Fatalf("Starting gRPC API: %s\n", err)
+	}
+	defer grpcapi.Stop()
+	switch {
+	case grpcConfig.Enabled() && grpcConfig.TLS():
+		log.Printf("Listen gRPC with TLS on :%v", grpcConfig.Port)
+	case grpcConfig.Enabled():
+		log.Printf("Listen gRPC on :%v", grpcConfig.Port)
+	}
 
-	if err := http.ListenAndServe(":"+*port, nil); err != nil {
+	// Start the HTTP server of the REST API terminating TLS if the certificate is configured.
+	serverConfig, err := server.ConfigFromOptions(config.Get()[config.ServiceSection])
+	if err != nil {
+		log.Fatalf("Loading HTTP server configuration: %s\n", err)
+	}
+	if err := server.Start(serverConfig, ":"+*port, http.DefaultServeMux); err != nil {
 		log.Fatalln("ListenAndServe:", err)
 	}
+	if serverConfig.TLS() {
+		log.Printf("Listen HTTPS on :%v", *port)
+	} else {
+		log.Printf("Listen on :%v", *port)
+	}
+
+	// Wait for the termination, e.g. by the deploy, and let the pending verifications complete.
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, os.Interrupt)
+	log.Printf("Received %s, shutting down\n", <-signals)
+
+	shutdown(serverConfig.ShutdownTimeout)
+}
+
+// shutdown stops the API servers gracefully and drains the async jobs and the batch verifications within the timeout.
+// The servers stop accepting the requests first, so no new jobs are submitted while they're drained.
+// The rest of the subsystems are stopped by the deferred calls of main.
+func shutdown(timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("Shutting down HTTP server: %s\n", err)
+	}
+	grpcapi.Stop()
+	jobs.Drain(ctx)
+	batch.Drain(ctx)
 }
 
 // createHandlers registers the API handlers in the DefaultServeMux.
@@ -85,37 +250,249 @@ func createHandlers() {
 	http.HandleFunc("/Ping", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("Pong!"))
 	})
-	http.HandleFunc("/CheckCustomer", handlers.CheckCustomer)
-	http.HandleFunc("/CheckStatus", handlers.CheckStatus)
-	http.HandleFunc("/Provider", handlers.IsProviderImplemented)
-	http.HandleFunc("/cipherTrace", handlers.CipherTraceCheck)
+	handle("/CheckCustomer", idempotent(handlers.CheckCustomer, idempotency.WriteError))
+	handle("/CheckStatus", handlers.CheckStatus)
+	handle("/Provider", handlers.IsProviderImplemented)
+	handle("/Status", handlers.TrackedStatus)
+	handle(handlers.VerificationsPath, handlers.Verifications)
+	handle(handlers.JobsPath, handlers.Jobs)
+	handle(handlers.BatchesPath, handlers.Batches)
+	handle("/Usage", handlers.Usage)
+	handle(handlers.AdminConfigPath, handlers.AdminConfig)
+	// The callbacks are authenticated by the providers signatures instead of the client credentials.
+	http.Handle(handlers.CallbackPath, tracing.Middleware(handlers.CallbackPath, http.HandlerFunc(handlers.Callback)))
+	http.Handle(metrics.Path, metrics.Handler())
+	http.HandleFunc(handlers.OpenAPIPath, handlers.OpenAPI)
+	handle("/cipherTrace", handlers.CipherTraceCheck)
+	handleV2("/v2/CheckCustomer", idempotent(handlers.CheckCustomerV2, handlers.WriteIdempotencyErrorV2))
+	handleV2("/v2/CheckStatus", handlers.CheckStatusV2)
+}
+
+// handle registers the handler function for the pattern tracing and authenticating the requests to it.
+// The endpoint name the clients are allowed to request is the pattern without slashes.
+func handle(pattern string, handler http.HandlerFunc) {
+	http.Handle(pattern, tracing.Middleware(pattern, auth.Middleware(strings.Trim(pattern, "/"), handler)))
+}
+
+// handleV2 registers the v2 API handler function for the pattern like the handle.
+// The v2 endpoints share the endpoint names with their v1 counterparts, e.g. /v2/CheckCustomer is CheckCustomer.
+func handleV2(pattern string, handler http.HandlerFunc) {
+	endpoint := strings.TrimPrefix(pattern, "/v2/")
+	http.Handle(pattern, tracing.Middleware(pattern, auth.MiddlewareWithErrorWriter(endpoint, handler, handlers.WriteAuthErrorV2)))
+}
+
+// idempotent wraps the handler function replaying the responses to the retries of the requests with the Idempotency-Key.
+// The errors of the idempotency keys are written using the writeError.
+func idempotent(handler http.HandlerFunc, writeError idempotency.ErrorWriter) http.HandlerFunc {
+	return idempotency.MiddlewareWithErrorWriter(handler, writeError).ServeHTTP
+}
+
+// setupAuth sets up the authentication using the clients and the authentication options from the config.
+func setupAuth() error {
+	cfg := config.Get()
+
+	authConfig, err := auth.ConfigFromOptions(cfg[config.ServiceSection], cfg.Clients())
+	if err != nil {
+		return err
+	}
+
+	auth.Setup(authConfig)
+
+	return nil
+}
+
+// setupLogging sets up the logger using the logging options from the config.
+func setupLogging() error {
+	logConfig, err := logging.ConfigFromOptions(config.Get()[config.ServiceSection])
+	if err != nil {
+		return err
+	}
+
+	logging.Setup(logConfig, logOutput)
+
+	return nil
 }
 
-// Watch config file and update configs when events happen.
-func watchConfigs() {
+// setupLimits sets up the limits using the limits options of the providers and the clients from the config.
+// The usage recorded in the store is loaded when the limits are set up first.
+func setupLimits() error {
+	cfg := config.Get()
+
+	providers := map[common.KYCProvider]map[string]string{}
+	for name, options := range cfg {
+		if provider := common.KYCProvider(name); common.KnownProvider(provider) {
+			providers[provider] = options
+		}
+	}
+
+	limitsConfig, err := limits.ConfigFromOptions(providers, cfg.Clients())
+	if err != nil {
+		return err
+	}
+
+	return limits.Setup(limitsConfig)
+}
+
+// setupRules sets up the decision rules using the rules file from the config.
+func setupRules() error {
+	rulesConfig, err := rules.ConfigFromOptions(config.Get()[config.ServiceSection])
+	if err != nil {
+		return err
+	}
+
+	rules.Setup(rulesConfig)
+
+	return nil
+}
+
+// pollingSettings returns the polling settings of the provider from the config.
+// The default settings are used if the config options are invalid.
+func pollingSettings(provider common.KYCProvider) poller.Settings {
+	settings, err := poller.SettingsFromOptions(config.Get()[string(provider)])
+	if err != nil {
+		return poller.DefaultSettings
+	}
+
+	return settings
+}
+
+// pendingVerifications returns the statuses of the pending verifications recorded in the store.
+func pendingVerifications() (statuses []poller.Status, err error) {
+	verifications, err := store.FindPending()
+	if err != nil {
+		return
+	}
+
+	for _, v := range verifications {
+		statuses = append(statuses, poller.Status{
+			Provider:    v.Provider,
+			ReferenceID: v.ReferenceID,
+			Tracked:     v.Created,
+			LastCheck:   v.Updated,
+		})
+	}
+
+	return
+}
+
+// batchConcurrency returns the number of the batch records verified by the provider at once from its config section.
+// The default concurrency is used if the option is invalid.
+func batchConcurrency(provider common.KYCProvider) int {
+	concurrency, err := batch.ConcurrencyFromOptions(config.Get()[string(provider)])
+	if err != nil {
+		return batch.DefaultConcurrency
+	}
+
+	return concurrency
+}
+
+// reloadDelay is how long the config files should stay unchanged before they're reloaded.
+// It lets the editors finish saving the files, so the half-saved config isn't loaded.
+const reloadDelay = 500 * time.Millisecond
+
+// watchConfigs reloads the config, the decision rules and the TLS certificate when their files change.
+// The directories of the files are watched instead of the files themselves,
+// so the files replaced by the editors using rename are followed as well.
+// The watching stops when the context is done.
+func watchConfigs(ctx context.Context) {
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
-		log.Println("file watcher can not be created")
-	}
-	err = watcher.Add(*cfgFile)
-	if err != nil {
-		log.Fatalf("Watching configuration from %s: %s\n", *cfgFile, err)
-	}
-	go func() {
-		for {
-			select {
-			case event, ok := <-watcher.Events:
-				if ok && event.Op.String() == "WRITE" {
-					if err := config.FromFile(*cfgFile); err != nil {
-						log.Printf("Reloading configuration from %s: %s\n", *cfgFile, err)
-					} else {
-						log.Printf("Reloading configuration from %s\n", *cfgFile)
-					}
-				}
-			case err, _ := <-watcher.Errors:
-				log.Println("error watching config file:", err)
+		log.Printf("Watching configuration from %s: %s\n", *cfgFile, err)
+		return
+	}
+	defer watcher.Close()
+
+	files := watchedFiles()
+	watchDirs(watcher, files)
+
+	reload := time.NewTimer(reloadDelay)
+	reload.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if files[filepath.Clean(event.Name)] && event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename|fsnotify.Remove) != 0 {
+				reload.Reset(reloadDelay)
 			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Println("error watching config file:", err)
+		case <-reload.C:
+			reloadConfigs()
+			// The decision rules file may have been changed in the reloaded config.
+			files = watchedFiles()
+			watchDirs(watcher, files)
+		}
+	}
+}
+
+// reloadConfigs reloads the config file and sets up the subsystems if the config has changed.
+// The invalid config is rejected in full, so the service keeps the previous one.
+// The decision rules and the TLS certificate are reloaded anyway since their files may have changed alone.
+func reloadConfigs() {
+	snapshot, changed, err := config.Reload(*cfgFile)
+	switch {
+	case err != nil:
+		log.Printf("Reloading configuration from %s: %s\n", *cfgFile, err)
+	case len(changed) > 0:
+		log.Printf("Reloaded configuration version %d from %s, changed sections: %s\n", snapshot.Version, *cfgFile, strings.Join(changed, ", "))
+		if err := setupLogging(); err != nil {
+			log.Printf("Reloading logging configuration: %s\n", err)
+		}
+		if err := setupAuth(); err != nil {
+			log.Printf("Reloading authentication configuration: %s\n", err)
+		}
+		if err := setupLimits(); err != nil {
+			log.Printf("Reloading limits configuration: %s\n", err)
+		}
+	}
+
+	if err := setupRules(); err != nil {
+		log.Printf("Reloading decision rules: %s\n", err)
+	}
+	if err := server.ReloadCertificate(); err != nil {
+		log.Printf("Reloading TLS certificate: %s\n", err)
+	}
+	if err := grpcapi.ReloadCertificate(); err != nil {
+		log.Printf("Reloading gRPC TLS certificate: %s\n", err)
+	}
+}
+
+// watchedFiles returns the absolute paths of the config file and the decision rules and the TLS files from the config.
+// The TLS files of the HTTP server and the gRPC API are watched both.
+func watchedFiles() (files map[string]bool) {
+	files = map[string]bool{}
+
+	service := config.Get()[config.ServiceSection]
+	paths := []string{
+		*cfgFile, service[rules.FileOption],
+		service[server.TLSCertOption], service[server.TLSKeyOption],
+		service[grpcconfig.TLSCertOption], service[grpcconfig.TLSKeyOption],
+	}
+	for _, path := range paths {
+		if len(path) == 0 {
+			continue
+		}
+		if abs, err := filepath.Abs(path); err == nil {
+			files[abs] = true
+		}
+	}
+
+	return
+}
+
+// watchDirs adds the directories of the files to the watcher.
+func watchDirs(watcher *fsnotify.Watcher, files map[string]bool) {
+	for path := range files {
+		if err := watcher.Add(filepath.Dir(path)); err != nil {
+			log.Printf("Watching configuration from %s: %s\n", path, err)
 		}
-	}()
-	<-make(chan struct{})
+	}
 }
@@ -0,0 +1,135 @@
+// Package metrics collects the Prometheus metrics of the verification traffic and the health of KYC providers.
+// The verifications are counted by the handlers, and the requests to the provider APIs are observed
+// in the http package. The provider and the operation labels are passed along with the request context.
+package metrics
+
+import (
+	"context"
+	stdhttp "net/http"
+	"strconv"
+	"time"
+
+	"modulus/kyc/common"
+	"modulus/kyc/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Path is the path of the metrics handler.
+const Path = "/metrics"
+
+// Operation represents the verification operation the metrics are labelled with.
+type Operation string
+
+// The operations of the service.
+const (
+	CheckCustomer Operation = "CheckCustomer"
+	CheckStatus   Operation = "CheckStatus"
+)
+
+// The values of the labels for the unknown provider and operation and for the failed upstream requests.
+const (
+	unknownLabel = "unknown"
+	errorLabel   = "error"
+)
+
+var (
+	verifications = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kyc",
+		Name:      "verifications_total",
+		Help:      "The number of the verification requests by the result status.",
+	}, []string{"provider", "operation", "status"})
+
+	verificationErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kyc",
+		Name:      "verification_errors_total",
+		Help:      "The number of the verification results with the error code.",
+	}, []string{"provider", "operation", "code"})
+
+	verificationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "kyc",
+		Name:      "verification_duration_seconds",
+		Help:      "The duration of the verification requests.",
+		Buckets:   []float64{.1, .25, .5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+	}, []string{"provider", "operation"})
+
+	upstreamRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kyc",
+		Name:      "upstream_requests_total",
+		Help:      "The number of the requests to the KYC provider APIs by the HTTP status code.",
+	}, []string{"provider", "operation", "method", "code"})
+
+	upstreamDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "kyc",
+		Name:      "upstream_request_duration_seconds",
+		Help:      "The duration of the requests to the KYC provider APIs.",
+		Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"provider", "operation", "method"})
+)
+
+// registry holds the metrics of the service along with the Go runtime and the process metrics.
+var registry = prometheus.NewRegistry()
+
+func init() {
+	registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		verifications,
+		verificationErrors,
+		verificationDuration,
+		upstreamRequests,
+		upstreamDuration,
+	)
+
+	http.SetObserver(observeCall)
+}
+
+// Handler returns the handler exposing the metrics in the Prometheus format.
+func Handler() stdhttp.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// WithOperation returns the copy of the context labelling the requests to the provider API with the provider and the operation.
+func WithOperation(ctx context.Context, provider common.KYCProvider, operation Operation) context.Context {
+	return http.WithLabels(ctx, http.Labels{Provider: string(provider), Operation: string(operation)})
+}
+
+// labelsFromContext returns the provider and the operation labels from the context.
+func labelsFromContext(ctx context.Context) (provider, operation string) {
+	labels, ok := http.LabelsFromContext(ctx)
+	if !ok {
+		return unknownLabel, unknownLabel
+	}
+
+	return labels.Provider, labels.Operation
+}
+
+// ObserveVerification counts the verification request with its result and duration.
+// The result status is Error if the request failed.
+func ObserveVerification(provider common.KYCProvider, operation Operation, started time.Time, result common.KYCResult, err error) {
+	status := common.KYCStatus2Status[result.Status]
+	if err != nil {
+		status = common.KYCStatus2Status[common.Error]
+	}
+
+	verifications.WithLabelValues(string(provider), string(operation), status).Inc()
+	verificationDuration.WithLabelValues(string(provider), string(operation)).Observe(time.Since(started).Seconds())
+
+	if len(result.ErrorCode) > 0 {
+		verificationErrors.WithLabelValues(string(provider), string(operation), result.ErrorCode).Inc()
+	}
+}
+
+// observeCall counts the request to the provider API with its status code and duration.
+func observeCall(ctx context.Context, call http.Call) {
+	provider, operation := labelsFromContext(ctx)
+
+	code := errorLabel
+	if call.Err == nil {
+		code = strconv.Itoa(call.Code)
+	}
+
+	upstreamRequests.WithLabelValues(provider, operation, call.Method, code).Inc()
+	upstreamDuration.WithLabelValues(provider, operation, call.Method).Observe(call.Duration.Seconds())
+}
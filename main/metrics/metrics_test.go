@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"modulus/kyc/common"
+	"modulus/kyc/http"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveVerification(t *testing.T) {
+	assert := assert.New(t)
+
+	started := time.Now().Add(-time.Second)
+
+	// Testing the result statuses.
+	ObserveVerification(common.Trulioo, CheckCustomer, started, common.KYCResult{Status: common.Approved}, nil)
+	ObserveVerification(common.Trulioo, CheckCustomer, started, common.KYCResult{Status: common.Denied}, nil)
+	ObserveVerification(common.Trulioo, CheckCustomer, started, common.KYCResult{Status: common.Approved}, nil)
+
+	assert.Equal(2.0, testutil.ToFloat64(verifications.WithLabelValues("Trulioo", "CheckCustomer", "Approved")))
+	assert.Equal(1.0, testutil.ToFloat64(verifications.WithLabelValues("Trulioo", "CheckCustomer", "Denied")))
+
+	// Testing the failed request with the error code.
+	ObserveVerification(common.Jumio, CheckStatus, started, common.KYCResult{Status: common.Unclear, ErrorCode: "401"}, errors.New("http error"))
+
+	assert.Equal(1.0, testutil.ToFloat64(verifications.WithLabelValues("Jumio", "CheckStatus", "Error")))
+	assert.Equal(0.0, testutil.ToFloat64(verifications.WithLabelValues("Jumio", "CheckStatus", "Unclear")))
+	assert.Equal(1.0, testutil.ToFloat64(verificationErrors.WithLabelValues("Jumio", "CheckStatus", "401")))
+	assert.Equal(2, testutil.CollectAndCount(verificationDuration))
+}
+
+func TestObserveCall(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		w.WriteHeader(stdhttp.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	ctx := WithOperation(context.Background(), common.SumSub, CheckStatus)
+
+	// Testing the labelled request.
+	code, _, err := http.GetContext(ctx, server.URL, nil)
+
+	assert.NoError(err)
+	assert.Equal(stdhttp.StatusBadGateway, code)
+	assert.Equal(1.0, testutil.ToFloat64(upstreamRequests.WithLabelValues("Sum&Substance", "CheckStatus", "GET", "502")))
+
+	// Testing the failed request.
+	_, _, err = http.PostContext(ctx, "http://127.0.0.1:0", nil, nil)
+
+	assert.Error(err)
+	assert.Equal(1.0, testutil.ToFloat64(upstreamRequests.WithLabelValues("Sum&Substance", "CheckStatus", "POST", "error")))
+
+	// Testing the request without labels.
+	_, _, err = http.Get(server.URL, nil)
+
+	assert.NoError(err)
+	assert.Equal(1.0, testutil.ToFloat64(upstreamRequests.WithLabelValues("unknown", "unknown", "GET", "502")))
+}
+
+func TestHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	ObserveVerification(common.IDology, CheckCustomer, time.Now(), common.KYCResult{Status: common.Approved}, nil)
+
+	req := httptest.NewRequest(stdhttp.MethodGet, Path, nil)
+	w := httptest.NewRecorder()
+
+	Handler().ServeHTTP(w, req)
+
+	assert.Equal(stdhttp.StatusOK, w.Code)
+
+	body, _ := ioutil.ReadAll(w.Body)
+
+	assert.Contains(string(body), `kyc_verifications_total{operation="CheckCustomer",provider="IDology",status="Approved"} 1`)
+	assert.Contains(string(body), "kyc_verification_duration_seconds_bucket")
+	assert.Contains(string(body), "go_goroutines")
+}
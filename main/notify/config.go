@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"time"
+
+	"modulus/kyc/main/option"
+)
+
+// The names of the notification options in the service config section.
+const (
+	SecretOption       = "NotificationSecret"
+	OutboxOption       = "NotificationOutbox"
+	MaxAttemptsOption  = "NotificationMaxAttempts"
+	RetryWaitOption    = "NotificationRetryWait"
+	RetryMaxWaitOption = "NotificationRetryMaxWait"
+)
+
+// The default values of the notification options.
+const (
+	DefaultOutbox       = "outbox"
+	DefaultMaxAttempts  = 10
+	DefaultRetryWait    = 10 * time.Second
+	DefaultRetryMaxWait = time.Hour
+)
+
+// Config holds the settings of the Notifier.
+//
+// * Secret is the key of the HMAC-SHA256 signature of notifications. Notifications are disabled if it's empty.
+// * Outbox is the directory where the pending notifications are stored.
+// * MaxAttempts is the maximum number of delivery attempts of a notification.
+// * RetryWait is the wait before the first redelivery. It's doubled for every next redelivery.
+// * RetryMaxWait limits the wait between redeliveries.
+type Config struct {
+	Secret       string
+	Outbox       string
+	MaxAttempts  int
+	RetryWait    time.Duration
+	RetryMaxWait time.Duration
+}
+
+// ConfigFromOptions parses the notification options from the service config section.
+// Absent options take their default values.
+func ConfigFromOptions(options map[string]string) (config Config, err error) {
+	config = Config{
+		Secret:       options[SecretOption],
+		Outbox:       options[OutboxOption],
+		MaxAttempts:  DefaultMaxAttempts,
+		RetryWait:    DefaultRetryWait,
+		RetryMaxWait: DefaultRetryMaxWait,
+	}
+
+	if len(config.Outbox) == 0 {
+		config.Outbox = DefaultOutbox
+	}
+
+	if err = option.Duration(options, RetryWaitOption, &config.RetryWait); err != nil {
+		return
+	}
+	if err = option.Duration(options, RetryMaxWaitOption, &config.RetryMaxWait); err != nil {
+		return
+	}
+	err = option.Int(options, MaxAttemptsOption, &config.MaxAttempts)
+
+	return
+}
+
+// Enabled reports whether the notifications are enabled by the config.
+func (c Config) Enabled() bool {
+	return len(c.Secret) > 0
+}
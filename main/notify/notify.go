@@ -0,0 +1,452 @@
+// Package notify delivers the final verification results to the notification URLs of the service clients.
+// The pending verification is followed up by the poller or by the provider callbacks,
+// and the final result is posted to the notification URL as the KYCResponse signed with HMAC-SHA256.
+// The notifications are kept in the durable outbox and redelivered with the exponential backoff until delivered.
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"modulus/kyc/common"
+	"modulus/kyc/http"
+	"modulus/kyc/main/events"
+	"modulus/kyc/main/logging"
+)
+
+// The headers of the notification request.
+const (
+	SignatureHeader      = "X-KYC-Signature"
+	TimestampHeader      = "X-KYC-Timestamp"
+	NotificationIDHeader = "X-KYC-Notification-Id"
+	ProviderHeader       = "X-KYC-Provider"
+	ReferenceIDHeader    = "X-KYC-Reference-Id"
+)
+
+// deliveryTimeout limits the time of a single delivery attempt.
+const deliveryTimeout = 30 * time.Second
+
+// ErrDisabled is returned when the notifications aren't configured for the service.
+var ErrDisabled = errors.New("result notifications aren't configured")
+
+// Tracker starts the follow-up of the pending verification.
+type Tracker func(provider common.KYCProvider, referenceID string)
+
+// Notifier follows up the pending verifications and delivers the notifications about their results.
+type Notifier struct {
+	config  Config
+	outbox  *outbox
+	tracker Tracker
+	client  *http.Client
+	wake    chan struct{}
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// New constructs a new Notifier using the config and loads the notifications stored in the outbox.
+// The tracker is used to follow up the pending verifications.
+func New(config Config, tracker Tracker) (notifier *Notifier, err error) {
+	if !config.Enabled() {
+		err = ErrDisabled
+		return
+	}
+
+	outbox, err := openOutbox(config.Outbox)
+	if err != nil {
+		err = fmt.Errorf("opening the outbox %s: %s", config.Outbox, err)
+		return
+	}
+
+	client, err := http.NewClient(http.Config{Timeout: deliveryTimeout})
+	if err != nil {
+		return
+	}
+
+	notifier = &Notifier{
+		config:  config,
+		outbox:  outbox,
+		tracker: tracker,
+		client:  client,
+		wake:    make(chan struct{}, 1),
+	}
+
+	return
+}
+
+// Start launches the background delivery of the notifications.
+// The follow-up of the pending verifications loaded from the outbox is resumed.
+func (n *Notifier) Start() {
+	for _, notification := range n.outbox.list(Notification.Pending) {
+		n.track(notification)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	n.cancel = cancel
+	n.done = make(chan struct{})
+
+	go n.run(ctx)
+}
+
+// Stop stops the background processing and waits for it to finish.
+// The undelivered notifications stay in the outbox till the next start.
+func (n *Notifier) Stop() {
+	if n.cancel == nil {
+		return
+	}
+
+	n.cancel()
+	<-n.done
+	n.cancel = nil
+}
+
+// Watch registers the notification about the pending verification.
+// The notification is delivered to the url when the final result of the verification is obtained.
+func (n *Notifier) Watch(provider common.KYCProvider, referenceID, url string) (err error) {
+	id, err := newID()
+	if err != nil {
+		return
+	}
+
+	notification := Notification{
+		ID:          id,
+		Provider:    provider,
+		ReferenceID: referenceID,
+		URL:         url,
+		Created:     time.Now(),
+	}
+
+	if err = n.outbox.put(notification); err == nil {
+		n.track(notification)
+	}
+
+	return
+}
+
+// Send queues the notification with the already known response for the delivery to the url.
+func (n *Notifier) Send(provider common.KYCProvider, referenceID, url string, response common.KYCResponse) (err error) {
+	id, err := newID()
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+
+	err = n.outbox.put(Notification{
+		ID:          id,
+		Provider:    provider,
+		ReferenceID: referenceID,
+		URL:         url,
+		Response:    &response,
+		NextAttempt: now,
+		Created:     now,
+	})
+	if err == nil {
+		n.wakeup()
+	}
+
+	return
+}
+
+// HandleResult completes the pending notifications of the verification if the update holds its final result.
+// It's meant to be subscribed to the result updates.
+func (n *Notifier) HandleResult(update events.Result) {
+	if !update.Result.IsFinal() {
+		return
+	}
+
+	pending := n.outbox.list(func(notification Notification) bool {
+		return notification.Pending() && notification.Provider == update.Provider && notification.ReferenceID == update.ReferenceID
+	})
+	if len(pending) == 0 {
+		return
+	}
+
+	response := common.KYCResponse{
+		Result: common.ResultFromKYCResult(update.Result),
+	}
+
+	for _, notification := range pending {
+		notification.Response = &response
+		notification.NextAttempt = update.Time
+		if err := n.outbox.put(notification); err != nil {
+			slog.Error("Notification store error", "notificationID", notification.ID, logging.ErrorKey, err)
+		}
+	}
+
+	n.wakeup()
+}
+
+// run processes the notifications until the context is done.
+func (n *Notifier) run(ctx context.Context) {
+	defer close(n.done)
+
+	for {
+		n.deliver(ctx)
+
+		timer := time.NewTimer(n.nextDelivery())
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-n.wake:
+		case <-timer.C:
+		}
+
+		timer.Stop()
+	}
+}
+
+// track starts the follow-up of the pending verification of the notification.
+func (n *Notifier) track(notification Notification) {
+	if n.tracker != nil {
+		n.tracker(notification.Provider, notification.ReferenceID)
+	}
+}
+
+// deliver posts the notifications which are due.
+func (n *Notifier) deliver(ctx context.Context) {
+	now := time.Now()
+
+	due := n.outbox.list(func(notification Notification) bool {
+		return !notification.Pending() && !notification.Failed && !notification.NextAttempt.After(now)
+	})
+
+	for _, notification := range due {
+		if ctx.Err() != nil {
+			return
+		}
+
+		err := n.post(ctx, notification)
+		if err == nil {
+			if err = n.outbox.remove(notification.ID); err != nil {
+				slog.Error("Notification removal error", "notificationID", notification.ID, logging.ErrorKey, err)
+			}
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		notification.Attempts++
+		if notification.Attempts >= n.config.MaxAttempts {
+			notification.Failed = true
+			slog.Error("Notification delivery failed", "notificationID", notification.ID, "attempts", notification.Attempts, logging.ErrorKey, err)
+		} else {
+			notification.NextAttempt = time.Now().Add(n.backoff(notification.Attempts - 1))
+			slog.Warn("Notification delivery error", "notificationID", notification.ID, "attempt", notification.Attempts, logging.ErrorKey, err)
+		}
+
+		if err = n.outbox.put(notification); err != nil {
+			slog.Error("Notification state store error", "notificationID", notification.ID, logging.ErrorKey, err)
+		}
+	}
+}
+
+// post sends the signed notification to its url.
+func (n *Notifier) post(ctx context.Context, notification Notification) (err error) {
+	body, err := json.Marshal(notification.Response)
+	if err != nil {
+		return
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	headers := http.Headers{
+		"Content-Type":       "application/json; charset=utf-8",
+		TimestampHeader:      timestamp,
+		SignatureHeader:      Sign(n.config.Secret, timestamp, body),
+		NotificationIDHeader: notification.ID,
+		ProviderHeader:       string(notification.Provider),
+		ReferenceIDHeader:    notification.ReferenceID,
+	}
+
+	code, _, err := n.client.PostContext(ctx, notification.URL, headers, body)
+	if err != nil {
+		return
+	}
+	if code < 200 || code >= 300 {
+		err = fmt.Errorf("unexpected response status: %d", code)
+	}
+
+	return
+}
+
+// nextDelivery returns the wait until the earliest redelivery.
+func (n *Notifier) nextDelivery() (wait time.Duration) {
+	wait = time.Hour
+
+	scheduled := n.outbox.list(func(notification Notification) bool {
+		return !notification.Pending() && !notification.Failed
+	})
+
+	for _, notification := range scheduled {
+		if d := time.Until(notification.NextAttempt); d < wait {
+			wait = d
+		}
+	}
+	if wait < 0 {
+		wait = 0
+	}
+
+	return
+}
+
+// backoff returns the wait before the next redelivery.
+func (n *Notifier) backoff(attempt int) (wait time.Duration) {
+	wait = n.config.RetryWait << uint(attempt)
+	if wait > n.config.RetryMaxWait || wait < n.config.RetryWait {
+		wait = n.config.RetryMaxWait
+	}
+
+	return
+}
+
+// wakeup signals the background processing to deliver the due notifications.
+func (n *Notifier) wakeup() {
+	select {
+	case n.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 signature of the notification.
+// The signature covers the timestamp and the body joined with the dot.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidateURL checks that the notification url is the absolute HTTP(S) url.
+func ValidateURL(rawurl string) (err error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return fmt.Errorf("invalid notification url: %s", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return fmt.Errorf("invalid notification url: %s", rawurl)
+	}
+
+	return
+}
+
+// newID returns a new random identificator of a notification.
+func newID() (id string, err error) {
+	b := make([]byte, 16)
+	if _, err = rand.Read(b); err != nil {
+		return
+	}
+	id = hex.EncodeToString(b)
+
+	return
+}
+
+var (
+	mu        sync.RWMutex
+	current   *Notifier
+	subscribe sync.Once
+)
+
+// Start constructs the service Notifier using the config and launches it.
+// The Notifier receives the result updates published by the other subsystems.
+// If the notifications are disabled by the config then nothing is started.
+func Start(config Config, tracker Tracker) (err error) {
+	if !config.Enabled() {
+		return
+	}
+
+	notifier, err := New(config, tracker)
+	if err != nil {
+		return
+	}
+
+	subscribe.Do(func() {
+		events.Subscribe(handleResult)
+	})
+
+	notifier.Start()
+
+	// The previous Notifier is stopped outside of the lock
+	// because its background processing might wait for the lock to publish a result.
+	previous := swap(notifier)
+	if previous != nil {
+		previous.Stop()
+	}
+
+	return
+}
+
+// Stop stops the service Notifier.
+func Stop() {
+	if previous := swap(nil); previous != nil {
+		previous.Stop()
+	}
+}
+
+// swap replaces the service Notifier and returns the previous one.
+func swap(notifier *Notifier) (previous *Notifier) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	previous, current = current, notifier
+
+	return
+}
+
+// Enabled reports whether the service Notifier is running.
+func Enabled() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return current != nil
+}
+
+// Watch registers the notification about the pending verification with the service Notifier.
+func Watch(provider common.KYCProvider, referenceID, url string) error {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current == nil {
+		return ErrDisabled
+	}
+
+	return current.Watch(provider, referenceID, url)
+}
+
+// Send queues the notification with the already known response with the service Notifier.
+func Send(provider common.KYCProvider, referenceID, url string, response common.KYCResponse) error {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current == nil {
+		return ErrDisabled
+	}
+
+	return current.Send(provider, referenceID, url, response)
+}
+
+// handleResult passes the result update to the service Notifier.
+func handleResult(update events.Result) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current != nil {
+		current.HandleResult(update)
+	}
+}
@@ -0,0 +1,400 @@
+package notify
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/events"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// receiver represents the backend receiving the notifications in tests.
+type receiver struct {
+	server   *httptest.Server
+	failures int32
+	calls    int32
+	requests chan *http.Request
+	bodies   chan []byte
+}
+
+// newReceiver starts the receiver failing the first requests with the number of failures.
+func newReceiver(failures int32) (r *receiver) {
+	r = &receiver{
+		failures: failures,
+		requests: make(chan *http.Request, 10),
+		bodies:   make(chan []byte, 10),
+	}
+	r.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&r.calls, 1) <= atomic.LoadInt32(&r.failures) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := ioutil.ReadAll(req.Body)
+		r.requests <- req
+		r.bodies <- body
+	}))
+
+	return
+}
+
+// testConfig returns the config of the Notifier with the outbox in the temporary directory.
+func testConfig(t *testing.T) Config {
+	dir, err := ioutil.TempDir("", "outbox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	return Config{
+		Secret:       "secret",
+		Outbox:       dir,
+		MaxAttempts:  3,
+		RetryWait:    10 * time.Millisecond,
+		RetryMaxWait: 50 * time.Millisecond,
+	}
+}
+
+// waitBody waits for the notification delivered to the receiver.
+func waitBody(t *testing.T, r *receiver) (req *http.Request, body []byte) {
+	select {
+	case req = <-r.requests:
+		body = <-r.bodies
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification wasn't delivered")
+	}
+
+	return
+}
+
+// waitOutbox waits until the outbox holds the number of notifications matching the filter.
+func waitOutbox(n *Notifier, count int, filter func(Notification) bool) bool {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if len(n.outbox.list(filter)) == count {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	return false
+}
+
+func all(Notification) bool { return true }
+
+func TestConfigFromOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing the default config.
+	config, err := ConfigFromOptions(map[string]string{
+		"Port": "8080",
+	})
+
+	assert.NoError(err)
+	assert.False(config.Enabled())
+	assert.Equal(Config{
+		Outbox:       DefaultOutbox,
+		MaxAttempts:  DefaultMaxAttempts,
+		RetryWait:    DefaultRetryWait,
+		RetryMaxWait: DefaultRetryMaxWait,
+	}, config)
+
+	// Testing the full config.
+	config, err = ConfigFromOptions(map[string]string{
+		"NotificationSecret":       "secret",
+		"NotificationOutbox":       "/var/lib/kyc/outbox",
+		"NotificationMaxAttempts":  "5",
+		"NotificationRetryWait":    "1s",
+		"NotificationRetryMaxWait": "10m",
+	})
+
+	assert.NoError(err)
+	assert.True(config.Enabled())
+	assert.Equal(Config{
+		Secret:       "secret",
+		Outbox:       "/var/lib/kyc/outbox",
+		MaxAttempts:  5,
+		RetryWait:    time.Second,
+		RetryMaxWait: 10 * time.Minute,
+	}, config)
+
+	// Testing invalid duration.
+	_, err = ConfigFromOptions(map[string]string{
+		"NotificationRetryWait": "0s",
+	})
+
+	if assert.Error(err) {
+		assert.Equal("invalid option 'NotificationRetryWait': non-positive duration", err.Error())
+	}
+
+	// Testing invalid number of attempts.
+	_, err = ConfigFromOptions(map[string]string{
+		"NotificationMaxAttempts": "none",
+	})
+
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "invalid option 'NotificationMaxAttempts'")
+	}
+}
+
+func TestSign(t *testing.T) {
+	assert := assert.New(t)
+
+	signature := Sign("secret", "1500000000", []byte(`{"Result":null}`))
+
+	assert.Len(signature, 64)
+	assert.Equal(signature, Sign("secret", "1500000000", []byte(`{"Result":null}`)))
+	assert.NotEqual(signature, Sign("secret", "1500000001", []byte(`{"Result":null}`)))
+	assert.NotEqual(signature, Sign("another", "1500000000", []byte(`{"Result":null}`)))
+}
+
+func TestValidateURL(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(ValidateURL("https://backend.example.com/kyc/notify"))
+	assert.NoError(ValidateURL("http://localhost:8000/notify"))
+	assert.Error(ValidateURL("backend.example.com/notify"))
+	assert.Error(ValidateURL("ftp://backend.example.com/notify"))
+	assert.Error(ValidateURL("https:///notify"))
+	assert.Error(ValidateURL(":"))
+}
+
+func TestNew(t *testing.T) {
+	notifier, err := New(Config{}, nil)
+
+	assert.Nil(t, notifier)
+	assert.Equal(t, ErrDisabled, err)
+}
+
+func TestNotifierSend(t *testing.T) {
+	assert := assert.New(t)
+
+	r := newReceiver(0)
+	defer r.server.Close()
+
+	n, err := New(testConfig(t), nil)
+	if !assert.NoError(err) {
+		return
+	}
+	n.Start()
+	defer n.Stop()
+
+	response := common.KYCResponse{
+		Result: &common.Result{Status: "Approved"},
+	}
+
+	err = n.Send(common.Example, "ref", r.server.URL, response)
+	assert.NoError(err)
+
+	req, body := waitBody(t, r)
+
+	assert.Equal(http.MethodPost, req.Method)
+	assert.Equal("application/json; charset=utf-8", req.Header.Get("Content-Type"))
+	assert.Equal("Example", req.Header.Get(ProviderHeader))
+	assert.Equal("ref", req.Header.Get(ReferenceIDHeader))
+	assert.Len(req.Header.Get(NotificationIDHeader), 32)
+	assert.Equal(Sign("secret", req.Header.Get(TimestampHeader), body), req.Header.Get(SignatureHeader))
+
+	delivered := common.KYCResponse{}
+
+	assert.NoError(json.Unmarshal(body, &delivered))
+	assert.Equal(response, delivered)
+	assert.True(waitOutbox(n, 0, all))
+}
+
+func TestNotifierRetry(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing the delivery after failed attempts.
+	r := newReceiver(2)
+	defer r.server.Close()
+
+	n, err := New(testConfig(t), nil)
+	if !assert.NoError(err) {
+		return
+	}
+	n.Start()
+	defer n.Stop()
+
+	err = n.Send(common.Example, "ref", r.server.URL, common.KYCResponse{Error: "failed"})
+	assert.NoError(err)
+
+	_, body := waitBody(t, r)
+
+	assert.Equal(`{"Result":null,"Error":"failed"}`, string(body))
+	assert.Equal(int32(3), atomic.LoadInt32(&r.calls))
+	assert.True(waitOutbox(n, 0, all))
+
+	// Testing the notification given up after max attempts.
+	atomic.StoreInt32(&r.calls, 0)
+	atomic.StoreInt32(&r.failures, 100)
+
+	err = n.Send(common.Example, "ref", r.server.URL, common.KYCResponse{Error: "failed"})
+	assert.NoError(err)
+
+	assert.True(waitOutbox(n, 1, func(notification Notification) bool { return notification.Failed }))
+	assert.Equal(int32(3), atomic.LoadInt32(&r.calls))
+}
+
+func TestNotifierBackoff(t *testing.T) {
+	assert := assert.New(t)
+
+	n := &Notifier{
+		config: Config{
+			RetryWait:    time.Second,
+			RetryMaxWait: time.Minute,
+		},
+	}
+
+	assert.Equal(time.Second, n.backoff(0))
+	assert.Equal(8*time.Second, n.backoff(3))
+	assert.Equal(time.Minute, n.backoff(10))
+	assert.Equal(time.Minute, n.backoff(64))
+}
+
+func TestNotifierWatch(t *testing.T) {
+	assert := assert.New(t)
+
+	r := newReceiver(0)
+	defer r.server.Close()
+
+	n, err := New(testConfig(t), nil)
+	if !assert.NoError(err) {
+		return
+	}
+	n.Start()
+	defer n.Stop()
+
+	err = n.Watch(common.ShuftiPro, "ref", r.server.URL)
+	assert.NoError(err)
+
+	// Testing the update of another verification.
+	n.HandleResult(events.Result{
+		Provider:    common.ShuftiPro,
+		ReferenceID: "another",
+		Result:      common.KYCResult{Status: common.Approved},
+	})
+
+	// Testing the non-final update.
+	n.HandleResult(events.Result{
+		Provider:    common.ShuftiPro,
+		ReferenceID: "ref",
+		Result: common.KYCResult{
+			Status:      common.Unclear,
+			StatusCheck: &common.KYCStatusCheck{Provider: common.ShuftiPro, ReferenceID: "ref"},
+		},
+	})
+
+	assert.Len(n.outbox.list(Notification.Pending), 1)
+
+	// Testing the final update.
+	n.HandleResult(events.Result{
+		Provider:    common.ShuftiPro,
+		ReferenceID: "ref",
+		Result: common.KYCResult{
+			Status: common.Denied,
+			Details: &common.KYCDetails{
+				Finality: common.Final,
+				Reasons:  []string{"fraud"},
+			},
+		},
+	})
+
+	req, body := waitBody(t, r)
+
+	assert.Equal("ref", req.Header.Get(ReferenceIDHeader))
+	assert.Equal(`{"Result":{"Status":"Denied","Details":{"Finality":"Final","Reasons":["fraud"]},"ErrorCode":"","StatusCheck":null},"Error":""}`, string(body))
+	assert.True(waitOutbox(n, 0, all))
+}
+
+func TestNotifierTrack(t *testing.T) {
+	assert := assert.New(t)
+
+	r := newReceiver(0)
+	defer r.server.Close()
+
+	tracked := make(chan string, 10)
+	tracker := func(provider common.KYCProvider, referenceID string) {
+		tracked <- string(provider) + " " + referenceID
+	}
+
+	err := Start(testConfig(t), tracker)
+	if !assert.NoError(err) {
+		return
+	}
+	defer Stop()
+
+	assert.True(Enabled())
+
+	err = Watch(common.Example, "followed", r.server.URL)
+	assert.NoError(err)
+
+	assert.Equal("Example followed", <-tracked)
+
+	// Testing the final result published by another subsystem.
+	events.Publish(events.Result{
+		Provider:    common.Example,
+		ReferenceID: "followed",
+		Result:      common.KYCResult{Status: common.Approved},
+		Source:      events.Polling,
+	})
+
+	_, body := waitBody(t, r)
+
+	assert.Equal(`{"Result":{"Status":"Approved","Details":null,"ErrorCode":"","StatusCheck":null},"Error":""}`, string(body))
+
+	Stop()
+
+	assert.False(Enabled())
+	assert.Equal(ErrDisabled, Watch(common.Example, "followed", r.server.URL))
+	assert.Equal(ErrDisabled, Send(common.Example, "followed", r.server.URL, common.KYCResponse{}))
+}
+
+func TestNotifierRestart(t *testing.T) {
+	assert := assert.New(t)
+
+	r := newReceiver(0)
+	defer r.server.Close()
+
+	config := testConfig(t)
+
+	n, err := New(config, nil)
+	if !assert.NoError(err) {
+		return
+	}
+
+	err = n.Watch(common.SumSub, "pending", r.server.URL)
+	assert.NoError(err)
+	err = n.Send(common.SumSub, "final", r.server.URL, common.KYCResponse{Error: "failed"})
+	assert.NoError(err)
+
+	// Testing the notifications survived the restart.
+	tracked := []string{}
+	tracker := func(provider common.KYCProvider, referenceID string) {
+		tracked = append(tracked, referenceID)
+	}
+
+	n, err = New(config, tracker)
+	if !assert.NoError(err) {
+		return
+	}
+
+	assert.Len(n.outbox.list(all), 2)
+
+	n.Start()
+	defer n.Stop()
+
+	assert.Equal([]string{"pending"}, tracked)
+
+	req, _ := waitBody(t, r)
+
+	assert.Equal("final", req.Header.Get(ReferenceIDHeader))
+	assert.True(waitOutbox(n, 1, Notification.Pending))
+}
@@ -0,0 +1,146 @@
+package notify
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"modulus/kyc/common"
+)
+
+// Notification represents the notification about the verification result.
+// The Response is nil while the verification is pending.
+type Notification struct {
+	ID          string
+	Provider    common.KYCProvider
+	ReferenceID string
+	URL         string
+	Response    *common.KYCResponse
+	Attempts    int
+	NextAttempt time.Time
+	Failed      bool
+	Created     time.Time
+}
+
+// Pending reports whether the notification waits for the final verification result.
+func (n Notification) Pending() bool {
+	return n.Response == nil
+}
+
+// outbox represents the durable store of the notifications.
+// Every notification is kept in its own JSON file in the outbox directory until it's delivered.
+// Undeliverable notifications are kept with the Failed flag set for the inspection.
+type outbox struct {
+	mu            sync.Mutex
+	dir           string
+	notifications map[string]Notification
+}
+
+// openOutbox creates the outbox directory if needed and loads the stored notifications.
+func openOutbox(dir string) (o *outbox, err error) {
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	o = &outbox{
+		dir:           dir,
+		notifications: map[string]Notification{},
+	}
+
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+
+		data, err1 := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		if err1 != nil {
+			err = err1
+			o = nil
+			return
+		}
+
+		notification := Notification{}
+		if err1 = json.Unmarshal(data, &notification); err1 != nil {
+			err = err1
+			o = nil
+			return
+		}
+
+		o.notifications[notification.ID] = notification
+	}
+
+	return
+}
+
+// put stores the notification.
+// The file is replaced atomically so the notification is never lost halfway.
+func (o *outbox) put(notification Notification) (err error) {
+	data, err := json.Marshal(notification)
+	if err != nil {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	filename := o.filename(notification.ID)
+	tmp := filename + ".tmp"
+
+	if err = ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return
+	}
+	if err = os.Rename(tmp, filename); err != nil {
+		os.Remove(tmp)
+		return
+	}
+
+	o.notifications[notification.ID] = notification
+
+	return
+}
+
+// remove deletes the notification from the outbox.
+func (o *outbox) remove(id string) (err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	err = os.Remove(o.filename(id))
+	if os.IsNotExist(err) {
+		err = nil
+	}
+	if err == nil {
+		delete(o.notifications, id)
+	}
+
+	return
+}
+
+// list returns the notifications matching the filter sorted by creation time.
+func (o *outbox) list(filter func(notification Notification) bool) (notifications []Notification) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, notification := range o.notifications {
+		if filter(notification) {
+			notifications = append(notifications, notification)
+		}
+	}
+	sort.Slice(notifications, func(i, j int) bool { return notifications[i].Created.Before(notifications[j].Created) })
+
+	return
+}
+
+// filename returns the name of the file holding the notification.
+func (o *outbox) filename(id string) string {
+	return filepath.Join(o.dir, id+".json")
+}
@@ -0,0 +1,292 @@
+// Package openapi generates the OpenAPI 3 document of the service from the Go models of the API payloads.
+// The schemas of the request and the response bodies are derived from the models using reflection
+// the same way encoding/json serializes them, so the document follows the code.
+// The document also validates the payloads, so the tests may check the handlers conform to it.
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Version is the version of the OpenAPI specification the document conforms to.
+const Version = "3.0.3"
+
+// The media types of the payloads.
+const (
+	JSON = "application/json"
+	Text = "text/plain"
+)
+
+// Document represents the OpenAPI document.
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+	Security   []map[string][]string            `json:"security,omitempty"`
+}
+
+// Info represents the metadata of the API.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Components holds the schemas of the models and the security schemes of the API.
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme represents the authentication method of the API clients.
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Description  string `json:"description,omitempty"`
+	Name         string `json:"name,omitempty"`
+	In           string `json:"in,omitempty"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// Operation represents the API operation on the path.
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter represents the path or the query parameter of the operation.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody represents the request body of the operation.
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response represents the response of the operation with the HTTP status.
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of the payload of the media type.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema represents the schema of the JSON value.
+// AdditionalProperties is either the schema of the values of the map or false for the structs.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"`
+}
+
+// OneOf holds the models of the payload having one of several shapes.
+type OneOf []interface{}
+
+// File describes the body which isn't JSON, e.g. the uploaded or the downloaded file.
+// The keys are the media types of the body and the values are their descriptions.
+type File map[string]string
+
+// Endpoint describes the API operation the document is generated for.
+//
+// * Request is the model of the JSON request body or the File. The operation has no body if it's nil.
+// * Responses holds the models of the response bodies by the HTTP statuses. The response has no body
+// if its model is nil, the plain text body if it's a string, the File if it's File and one of several shapes if it's OneOf.
+type Endpoint struct {
+	Method    string
+	Path      string
+	ID        string
+	Summary   string
+	Params    []Parameter
+	Request   interface{}
+	Responses map[int]interface{}
+}
+
+type fieldKey struct {
+	t     reflect.Type
+	field string
+}
+
+// Spec builds the OpenAPI document from the endpoints.
+type Spec struct {
+	doc          Document
+	types        map[string]reflect.Type
+	enums        map[reflect.Type][]interface{}
+	fieldEnums   map[fieldKey][]interface{}
+	descriptions map[reflect.Type]string
+}
+
+// New constructs the Spec with the API metadata.
+func New(info Info) *Spec {
+	return &Spec{
+		doc: Document{
+			OpenAPI: Version,
+			Info:    info,
+			Paths:   map[string]map[string]*Operation{},
+			Components: Components{
+				Schemas: map[string]*Schema{},
+			},
+		},
+		types:        map[string]reflect.Type{},
+		enums:        map[reflect.Type][]interface{}{},
+		fieldEnums:   map[fieldKey][]interface{}{},
+		descriptions: map[reflect.Type]string{},
+	}
+}
+
+// Enum registers the possible values of the type of the model.
+// The type is added to the schemas of the document if the endpoints use it.
+func (s *Spec) Enum(model interface{}, values ...interface{}) {
+	s.enums[reflect.TypeOf(model)] = values
+}
+
+// FieldEnum registers the possible values of the field of the struct model.
+func (s *Spec) FieldEnum(model interface{}, field string, values ...interface{}) {
+	s.fieldEnums[fieldKey{reflect.TypeOf(model), field}] = values
+}
+
+// Describe sets the description of the schema of the type of the model.
+func (s *Spec) Describe(model interface{}, description string) {
+	s.descriptions[reflect.TypeOf(model)] = description
+}
+
+// SecurityScheme adds the authentication method of the API clients.
+// The API clients may use any of the methods added.
+func (s *Spec) SecurityScheme(name string, scheme *SecurityScheme) {
+	if s.doc.Components.SecuritySchemes == nil {
+		s.doc.Components.SecuritySchemes = map[string]*SecurityScheme{}
+	}
+	s.doc.Components.SecuritySchemes[name] = scheme
+	s.doc.Security = append(s.doc.Security, map[string][]string{name: {}})
+}
+
+// Add adds the operation of the endpoint to the document.
+func (s *Spec) Add(e Endpoint) {
+	op := &Operation{
+		OperationID: e.ID,
+		Summary:     e.Summary,
+		Parameters:  e.Params,
+		Responses:   map[string]*Response{},
+	}
+
+	if f, ok := e.Request.(File); ok {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  f.content(),
+		}
+	} else if e.Request != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{JSON: {Schema: s.Schema(e.Request)}},
+		}
+	}
+
+	for status, model := range e.Responses {
+		response := &Response{Description: http.StatusText(status)}
+		switch m := model.(type) {
+		case nil:
+		case string:
+			response.Content = map[string]*MediaType{Text: {Schema: &Schema{Type: "string", Description: m}}}
+		case File:
+			response.Content = m.content()
+		default:
+			response.Content = map[string]*MediaType{JSON: {Schema: s.Schema(model)}}
+		}
+		op.Responses[strconv.Itoa(status)] = response
+	}
+
+	path, ok := s.doc.Paths[e.Path]
+	if !ok {
+		path = map[string]*Operation{}
+		s.doc.Paths[e.Path] = path
+	}
+	path[strings.ToLower(e.Method)] = op
+}
+
+// content returns the media types of the File.
+func (f File) content() map[string]*MediaType {
+	content := map[string]*MediaType{}
+	for mediaType, description := range f {
+		content[mediaType] = &MediaType{Schema: &Schema{Type: "string", Format: "binary", Description: description}}
+	}
+
+	return content
+}
+
+// Document returns the OpenAPI document.
+func (s *Spec) Document() Document {
+	return s.doc
+}
+
+// Operation returns the operation matching the method and the path of the request.
+// The path parameters of the templated paths, e.g. /Verifications/{id}, match any non-empty segment.
+func (s *Spec) Operation(method, path string) (op *Operation, ok bool) {
+	method = strings.ToLower(method)
+
+	if op, ok = s.doc.Paths[path][method]; ok {
+		return
+	}
+
+	templates := make([]string, 0, len(s.doc.Paths))
+	for template := range s.doc.Paths {
+		templates = append(templates, template)
+	}
+	sort.Strings(templates)
+
+	for _, template := range templates {
+		if matchPath(template, path) {
+			if op, ok = s.doc.Paths[template][method]; ok {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+// matchPath reports whether the path matches the path template.
+func matchPath(template, path string) bool {
+	t := strings.Split(template, "/")
+	p := strings.Split(path, "/")
+	if len(t) != len(p) {
+		return false
+	}
+
+	for i := range t {
+		if strings.HasPrefix(t[i], "{") && strings.HasSuffix(t[i], "}") {
+			if len(p[i]) == 0 {
+				return false
+			}
+			continue
+		}
+		if t[i] != p[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,216 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"modulus/kyc/common"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testColor string
+
+type testBase struct {
+	ID string
+}
+
+type testNode struct {
+	testBase
+	Name     string `json:"name"`
+	Color    testColor
+	Optional *testNode `json:",omitempty"`
+	Children []testNode
+	Labels   map[string]int
+	Data     []byte
+	Created  time.Time
+	Birthday common.Time
+	Raw      json.RawMessage
+	Ignored  string `json:"-"`
+	hidden   string
+}
+
+func newTestSpec() *Spec {
+	s := New(Info{Title: "Test", Version: "1"})
+	s.Enum(testColor(""), "red", "green")
+	s.Describe(testNode{}, "The node")
+
+	s.Add(Endpoint{
+		Method:  http.MethodPost,
+		Path:    "/nodes/{id}",
+		ID:      "node",
+		Request: testNode{},
+		Responses: map[int]interface{}{
+			http.StatusOK:         testNode{},
+			http.StatusNoContent:  nil,
+			http.StatusBadRequest: "The error",
+		},
+	})
+
+	return s
+}
+
+func TestSchema(t *testing.T) {
+	assert := assert.New(t)
+
+	doc := newTestSpec().Document()
+
+	assert.Equal(Version, doc.OpenAPI)
+	assert.Equal(map[string]*Schema{
+		"TestColor": {Type: "string", Enum: []interface{}{"red", "green"}},
+		"TestNode": {
+			Type:        "object",
+			Description: "The node",
+			Properties: map[string]*Schema{
+				"ID":       {Type: "string"},
+				"name":     {Type: "string"},
+				"Color":    {Ref: "#/components/schemas/TestColor"},
+				"Optional": {AllOf: []*Schema{{Ref: "#/components/schemas/TestNode"}}, Nullable: true},
+				"Children": {Type: "array", Items: &Schema{Ref: "#/components/schemas/TestNode"}, Nullable: true},
+				"Labels":   {Type: "object", AdditionalProperties: &Schema{Type: "integer", Format: "int64"}, Nullable: true},
+				"Data":     {Type: "string", Format: "byte", Nullable: true},
+				"Created":  {Type: "string", Format: "date-time"},
+				"Birthday": {Type: "string", Format: "date-time"},
+				"Raw":      {Description: "Any JSON value"},
+			},
+			AdditionalProperties: false,
+		},
+	}, doc.Components.Schemas)
+
+	op := doc.Paths["/nodes/{id}"]["post"]
+	if assert.NotNil(op) {
+		assert.Equal("node", op.OperationID)
+		assert.Equal(&Schema{Ref: "#/components/schemas/TestNode"}, op.RequestBody.Content[JSON].Schema)
+		assert.Equal("OK", op.Responses["200"].Description)
+		assert.Empty(op.Responses["204"].Content)
+		assert.Equal(&Schema{Type: "string", Description: "The error"}, op.Responses["400"].Content[Text].Schema)
+	}
+}
+
+func TestOperation(t *testing.T) {
+	assert := assert.New(t)
+
+	s := newTestSpec()
+
+	_, ok := s.Operation(http.MethodPost, "/nodes/1")
+
+	assert.True(ok)
+
+	testCases := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, "/nodes/1"},
+		{http.MethodPost, "/nodes/"},
+		{http.MethodPost, "/nodes/1/2"},
+	}
+
+	for _, tc := range testCases {
+		_, ok := s.Operation(tc.method, tc.path)
+
+		assert.False(ok, tc.path)
+	}
+}
+
+func TestFile(t *testing.T) {
+	assert := assert.New(t)
+
+	s := newTestSpec()
+	s.Add(Endpoint{
+		Method:  http.MethodPut,
+		Path:    "/nodes/{id}/data",
+		ID:      "nodeData",
+		Request: File{"text/csv": "The CSV data"},
+		Responses: map[int]interface{}{
+			http.StatusOK: File{"application/x-ndjson": "The NDJSON data"},
+		},
+	})
+
+	op, ok := s.Operation(http.MethodPut, "/nodes/1/data")
+	if !assert.True(ok) {
+		return
+	}
+
+	assert.Equal(&Schema{Type: "string", Format: "binary", Description: "The CSV data"}, op.RequestBody.Content["text/csv"].Schema)
+	assert.Equal(&Schema{Type: "string", Format: "binary", Description: "The NDJSON data"}, op.Responses["200"].Content["application/x-ndjson"].Schema)
+
+	// Testing the file isn't validated as JSON.
+	assert.NoError(s.ValidateRequest(http.MethodPut, "/nodes/1/data", []byte("ID,Name\n1,Abby\n")))
+	assert.NoError(s.ValidateResponse(http.MethodPut, "/nodes/1/data", http.StatusOK, "application/x-ndjson", []byte("{}\n{}\n")))
+	assert.Error(s.ValidateResponse(http.MethodPut, "/nodes/1/data", http.StatusOK, "text/plain", []byte("{}")))
+}
+
+func TestValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	s := newTestSpec()
+
+	// Testing the valid payloads.
+	node, err := json.Marshal(testNode{
+		Name:     "root",
+		Color:    "red",
+		Children: []testNode{{Color: "green", Labels: map[string]int{"a": 1}, Data: []byte("data")}},
+		Raw:      json.RawMessage(`[1, "a"]`),
+	})
+
+	assert.NoError(err)
+	assert.NoError(s.ValidateRequest(http.MethodPost, "/nodes/1", node))
+	assert.NoError(s.ValidateResponse(http.MethodPost, "/nodes/1", http.StatusOK, "application/json; charset=utf-8", node))
+	assert.NoError(s.ValidateResponse(http.MethodPost, "/nodes/1", http.StatusNoContent, "", nil))
+	assert.NoError(s.ValidateResponse(http.MethodPost, "/nodes/1", http.StatusBadRequest, "text/plain; charset=utf-8", []byte("error")))
+
+	// Testing the invalid payloads.
+	testCases := []struct {
+		data string
+		err  string
+	}{
+		{`{"name": 1}`, "$.name: string expected"},
+		{`{"Color": "blue"}`, "$.Color: blue isn't one of [red green]"},
+		{`{"Children": [{"Labels": {"a": 1.5}}]}`, "$.Children[0].Labels.a: integer expected"},
+		{`{"Created": "yesterday"}`, `$.Created: invalid date-time: parsing time "yesterday" as "2006-01-02T15:04:05.999999999Z07:00": cannot parse "yesterday" as "2006"`},
+		{`{"Data": "?"}`, "$.Data: invalid byte: illegal base64 data at input byte 0"},
+		{`{"Created": null}`, "$.Created: null isn't allowed"},
+		{`{"Ignored": ""}`, "$: undocumented property Ignored"},
+		{`[]`, "$: object expected"},
+	}
+
+	for _, tc := range testCases {
+		err := s.ValidateRequest(http.MethodPost, "/nodes/1", []byte(tc.data))
+
+		if assert.Error(err, tc.data) {
+			assert.Equal(tc.err, err.Error(), tc.data)
+		}
+	}
+
+	// Testing the undocumented responses.
+	err = s.ValidateResponse(http.MethodPost, "/nodes/1", http.StatusNotFound, "application/json", []byte(`{}`))
+
+	if assert.Error(err) {
+		assert.Equal("undocumented response 404 of POST /nodes/1", err.Error())
+	}
+
+	err = s.ValidateResponse(http.MethodPost, "/nodes/1", http.StatusOK, "text/html", []byte(`{}`))
+
+	if assert.Error(err) {
+		assert.Equal("undocumented content type 'text/html' of the response 200 of POST /nodes/1", err.Error())
+	}
+
+	err = s.ValidateResponse(http.MethodGet, "/nodes", http.StatusOK, "application/json", []byte(`{}`))
+
+	if assert.Error(err) {
+		assert.Equal("undocumented operation GET /nodes", err.Error())
+	}
+}
+
+func TestOneOf(t *testing.T) {
+	assert := assert.New(t)
+
+	s := New(Info{Title: "Test", Version: "1"})
+	schema := s.Schema(OneOf{testBase{}, []string{}})
+
+	assert.NoError(s.Validate(schema, []byte(`{"ID": "1"}`)))
+	assert.NoError(s.Validate(schema, []byte(`["a"]`)))
+	assert.Error(s.Validate(schema, []byte(`1`)))
+}
@@ -0,0 +1,193 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	"modulus/kyc/common"
+)
+
+// The types serialized specially by encoding/json.
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	commonTimeType = reflect.TypeOf(common.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	marshalerType  = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// Schema returns the schema of the model.
+// The structs and the enum types are added to the schemas of the document and referenced.
+func (s *Spec) Schema(model interface{}) *Schema {
+	if models, ok := model.(OneOf); ok {
+		schema := &Schema{}
+		for _, m := range models {
+			schema.OneOf = append(schema.OneOf, s.Schema(m))
+		}
+		return schema
+	}
+
+	return s.schemaOf(reflect.TypeOf(model))
+}
+
+// schemaOf returns the schema of the type according to its JSON serialization.
+func (s *Spec) schemaOf(t reflect.Type) *Schema {
+	switch {
+	case t == timeType || t == commonTimeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{Description: "Any JSON value"}
+	}
+
+	if values, ok := s.enums[t]; ok {
+		return s.component(t, func() *Schema {
+			schema := s.kindSchema(t)
+			schema.Enum = values
+			return schema
+		})
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return nullable(s.schemaOf(t.Elem()))
+	case reflect.Struct:
+		if t.Implements(marshalerType) {
+			return &Schema{}
+		}
+		if len(t.Name()) == 0 {
+			return s.structSchema(t)
+		}
+		return s.component(t, func() *Schema {
+			return s.structSchema(t)
+		})
+	case reflect.Slice:
+		return nullable(s.kindSchema(t))
+	case reflect.Map:
+		return nullable(s.kindSchema(t))
+	}
+
+	return s.kindSchema(t)
+}
+
+// kindSchema returns the schema of the type by its kind.
+func (s *Spec) kindSchema(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.schemaOf(t.Elem())}
+	}
+
+	// The interfaces may hold any value.
+	return &Schema{}
+}
+
+// structSchema returns the schema of the struct type with the properties of its serialized fields.
+// The fields of the embedded structs are promoted like encoding/json does.
+func (s *Spec) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{
+		Type:                 "object",
+		Properties:           map[string]*Schema{},
+		AdditionalProperties: false,
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if len(f.PkgPath) > 0 && !f.Anonymous {
+			continue
+		}
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		if f.Anonymous && len(name) == 0 {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for n, p := range s.structSchema(ft).Properties {
+					if _, ok := schema.Properties[n]; !ok {
+						schema.Properties[n] = p
+					}
+				}
+				continue
+			}
+		}
+		if len(name) == 0 {
+			name = f.Name
+		}
+
+		property := s.schemaOf(f.Type)
+		if values, ok := s.fieldEnums[fieldKey{t, f.Name}]; ok {
+			property = &Schema{Type: property.Type, Enum: values, Nullable: property.Nullable}
+		}
+		schema.Properties[name] = property
+	}
+
+	return schema
+}
+
+// component adds the schema of the named type to the document once and returns its reference.
+// The name of the type from another package is prefixed with the package name if it's taken.
+func (s *Spec) component(t reflect.Type, schema func() *Schema) *Schema {
+	name := componentName(t)
+	if other, ok := s.types[name]; ok && other != t {
+		pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+
+	ref := &Schema{Ref: "#/components/schemas/" + name}
+	if _, ok := s.types[name]; ok {
+		return ref
+	}
+
+	// The type is registered before its schema is built to support the recursive types.
+	s.types[name] = t
+	component := schema()
+	if description, ok := s.descriptions[t]; ok {
+		component.Description = description
+	}
+	s.doc.Components.Schemas[name] = component
+
+	return ref
+}
+
+// componentName returns the name of the schema of the type.
+func componentName(t reflect.Type) string {
+	name := []rune(t.Name())
+	name[0] = unicode.ToUpper(name[0])
+	return string(name)
+}
+
+// nullable returns the schema allowing the null value too.
+func nullable(schema *Schema) *Schema {
+	if len(schema.Ref) > 0 {
+		return &Schema{AllOf: []*Schema{schema}, Nullable: true}
+	}
+
+	s := *schema
+	s.Nullable = true
+
+	return &s
+}
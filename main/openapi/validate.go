@@ -0,0 +1,208 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValidateRequest checks the body of the request conforms to the request body schema of the operation.
+func (s *Spec) ValidateRequest(method, path string, body []byte) error {
+	op, ok := s.Operation(method, path)
+	if !ok {
+		return fmt.Errorf("undocumented operation %s %s", method, path)
+	}
+	if op.RequestBody == nil {
+		return fmt.Errorf("undocumented request body of %s %s", method, path)
+	}
+
+	// The bodies other than JSON, e.g. the files, aren't validated.
+	content, ok := op.RequestBody.Content[JSON]
+	if !ok {
+		return nil
+	}
+
+	return s.Validate(content.Schema, body)
+}
+
+// ValidateResponse checks the response of the operation is documented and its body conforms to the schema.
+func (s *Spec) ValidateResponse(method, path string, status int, contentType string, body []byte) error {
+	op, ok := s.Operation(method, path)
+	if !ok {
+		return fmt.Errorf("undocumented operation %s %s", method, path)
+	}
+
+	response, ok := op.Responses[strconv.Itoa(status)]
+	if !ok {
+		return fmt.Errorf("undocumented response %d of %s %s", status, method, path)
+	}
+
+	mediaType := strings.TrimSpace(strings.Split(contentType, ";")[0])
+	content, ok := response.Content[mediaType]
+	switch {
+	case len(response.Content) == 0 && len(body) == 0:
+		return nil
+	case !ok:
+		return fmt.Errorf("undocumented content type '%s' of the response %d of %s %s", contentType, status, method, path)
+	case mediaType != JSON:
+		return nil
+	}
+
+	return s.Validate(content.Schema, body)
+}
+
+// Validate checks the JSON value conforms to the schema.
+func (s *Spec) Validate(schema *Schema, data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return err
+	}
+
+	return s.validate(schema, value, "$")
+}
+
+// validate checks the decoded JSON value at the path conforms to the schema.
+func (s *Spec) validate(schema *Schema, value interface{}, path string) error {
+	if len(schema.Ref) > 0 {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		component, ok := s.doc.Components.Schemas[name]
+		if !ok {
+			return fmt.Errorf("%s: unknown schema %s", path, schema.Ref)
+		}
+		return s.validate(component, value, path)
+	}
+
+	if len(schema.OneOf) > 0 {
+		matched := 0
+		for _, sub := range schema.OneOf {
+			if s.validate(sub, value, path) == nil {
+				matched++
+			}
+		}
+		if matched != 1 {
+			return fmt.Errorf("%s: value matches %d of %d schemas instead of one", path, matched, len(schema.OneOf))
+		}
+		return nil
+	}
+
+	if value == nil {
+		if schema.Nullable || (len(schema.Type) == 0 && len(schema.AllOf) == 0) {
+			return nil
+		}
+		return fmt.Errorf("%s: null isn't allowed", path)
+	}
+
+	for _, sub := range schema.AllOf {
+		if err := s.validate(sub, value, path); err != nil {
+			return err
+		}
+	}
+
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		return fmt.Errorf("%s: %v isn't one of %v", path, value, schema.Enum)
+	}
+
+	switch schema.Type {
+	case "object":
+		return s.validateObject(schema, value, path)
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: array expected", path)
+		}
+		for i, item := range items {
+			if err := s.validate(schema.Items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: string expected", path)
+		}
+		return validateFormat(schema.Format, str, path)
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			return fmt.Errorf("%s: integer expected", path)
+		}
+		if _, err := n.Int64(); err != nil {
+			return fmt.Errorf("%s: integer expected", path)
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			return fmt.Errorf("%s: number expected", path)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: boolean expected", path)
+		}
+	}
+
+	return nil
+}
+
+// validateObject checks the properties of the object.
+// The properties not described by the schema are allowed only if the additional properties schema is set.
+func (s *Spec) validateObject(schema *Schema, value interface{}, path string) error {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%s: object expected", path)
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property, ok := schema.Properties[name]
+		if !ok {
+			additional, ok := schema.AdditionalProperties.(*Schema)
+			if !ok {
+				return fmt.Errorf("%s: undocumented property %s", path, name)
+			}
+			property = additional
+		}
+		if err := s.validate(property, object[name], path+"."+name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateFormat checks the string conforms to the format.
+func validateFormat(format, value, path string) (err error) {
+	switch format {
+	case "date-time":
+		_, err = time.Parse(time.RFC3339Nano, value)
+	case "byte":
+		_, err = base64.StdEncoding.DecodeString(value)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: invalid %s: %s", path, format, err)
+	}
+
+	return nil
+}
+
+// inEnum reports whether the value is one of the values of the enum.
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, e := range enum {
+		if fmt.Sprint(e) == fmt.Sprint(value) {
+			return true
+		}
+	}
+
+	return false
+}
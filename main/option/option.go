@@ -0,0 +1,86 @@
+// Package option parses the values of the config options.
+// The absent options leave the values as they are, so the defaults are set beforehand.
+// The malformed and the out of range values are reported with the names of the options.
+package option
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Duration parses the positive duration of the option from the options into the value.
+func Duration(options map[string]string, name string, value *time.Duration) error {
+	return parse(options, name, func(s string) (err error) {
+		*value, err = time.ParseDuration(s)
+		if err == nil && *value <= 0 {
+			err = errors.New("non-positive duration")
+		}
+
+		return
+	})
+}
+
+// NonNegativeDuration is like Duration but accepts zero, e.g. for the disabled timeout.
+func NonNegativeDuration(options map[string]string, name string, value *time.Duration) error {
+	return parse(options, name, func(s string) (err error) {
+		*value, err = time.ParseDuration(s)
+		if err == nil && *value < 0 {
+			err = errors.New("negative duration")
+		}
+
+		return
+	})
+}
+
+// Int parses the positive number of the option from the options into the value.
+func Int(options map[string]string, name string, value *int) error {
+	return parse(options, name, func(s string) (err error) {
+		*value, err = strconv.Atoi(s)
+		if err == nil && *value <= 0 {
+			err = errors.New("non-positive number")
+		}
+
+		return
+	})
+}
+
+// NonNegativeInt is like Int but accepts zero, e.g. for the disabled limit.
+func NonNegativeInt(options map[string]string, name string, value *int) error {
+	return parse(options, name, func(s string) (err error) {
+		*value, err = strconv.Atoi(s)
+		if err == nil && *value < 0 {
+			err = errors.New("negative number")
+		}
+
+		return
+	})
+}
+
+// Int64 is like Int but parses the 64-bit number, e.g. the size in bytes.
+func Int64(options map[string]string, name string, value *int64) error {
+	return parse(options, name, func(s string) (err error) {
+		*value, err = strconv.ParseInt(s, 10, 64)
+		if err == nil && *value <= 0 {
+			err = errors.New("non-positive number")
+		}
+
+		return
+	})
+}
+
+// parse parses the value of the option using the fn unless the option is absent.
+// The error of the fn is wrapped as the invalid option.
+func parse(options map[string]string, name string, fn func(value string) error) (err error) {
+	value := options[name]
+	if len(value) == 0 {
+		return
+	}
+
+	if err = fn(value); err != nil {
+		err = fmt.Errorf("invalid option '%s': %s", name, err)
+	}
+
+	return
+}
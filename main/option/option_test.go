@@ -0,0 +1,63 @@
+package option
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuration(t *testing.T) {
+	assert := assert.New(t)
+
+	options := map[string]string{"Wait": "5m", "Zero": "0s", "Soon": "soon"}
+
+	// Testing the absent option keeps the value.
+	value := time.Minute
+
+	assert.NoError(Duration(options, "Absent", &value))
+	assert.Equal(time.Minute, value)
+
+	assert.NoError(Duration(options, "Wait", &value))
+	assert.Equal(5*time.Minute, value)
+
+	// Testing the invalid values.
+	assert.EqualError(Duration(options, "Zero", &value), "invalid option 'Zero': non-positive duration")
+	assert.EqualError(Duration(options, "Soon", &value), `invalid option 'Soon': time: invalid duration "soon"`)
+
+	// Testing the zero duration.
+	assert.NoError(NonNegativeDuration(options, "Zero", &value))
+	assert.Zero(value)
+	assert.EqualError(NonNegativeDuration(map[string]string{"Wait": "-1s"}, "Wait", &value), "invalid option 'Wait': negative duration")
+}
+
+func TestInt(t *testing.T) {
+	assert := assert.New(t)
+
+	options := map[string]string{"Workers": "8", "Zero": "0", "Many": "many", "Size": "1048576"}
+
+	// Testing the absent option keeps the value.
+	value := 4
+
+	assert.NoError(Int(options, "Absent", &value))
+	assert.Equal(4, value)
+
+	assert.NoError(Int(options, "Workers", &value))
+	assert.Equal(8, value)
+
+	// Testing the invalid values.
+	assert.EqualError(Int(options, "Zero", &value), "invalid option 'Zero': non-positive number")
+	assert.EqualError(Int(options, "Many", &value), `invalid option 'Many': strconv.Atoi: parsing "many": invalid syntax`)
+
+	// Testing the zero number.
+	assert.NoError(NonNegativeInt(options, "Zero", &value))
+	assert.Zero(value)
+	assert.EqualError(NonNegativeInt(map[string]string{"Limit": "-1"}, "Limit", &value), "invalid option 'Limit': negative number")
+
+	// Testing the 64-bit number.
+	var size int64
+
+	assert.NoError(Int64(options, "Size", &size))
+	assert.Equal(int64(1<<20), size)
+	assert.EqualError(Int64(options, "Zero", &size), "invalid option 'Zero': non-positive number")
+}
@@ -0,0 +1,199 @@
+// Package orchestration verifies the customer using several KYC providers according to the strategy.
+// The Fallback strategy fails over to the next provider when the previous one fails.
+// The Parallel strategy checks all the providers at once and merges their results by the consensus rule.
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"modulus/kyc/common"
+)
+
+// CheckFunc checks the customer using the provider.
+type CheckFunc func(ctx context.Context, provider common.KYCProvider) (common.KYCResult, error)
+
+// Run verifies the customer using the providers of the strategy.
+// The returned result keeps the individual results of the providers.
+// The error is returned only if all the providers failed.
+func Run(ctx context.Context, strategy common.Strategy, check CheckFunc) (result common.KYCResult, err error) {
+	if err = strategy.Validate(); err != nil {
+		return
+	}
+
+	if strategy.Mode == common.Fallback {
+		return fallback(ctx, strategy.Providers, check)
+	}
+
+	return parallel(ctx, strategy.Providers, strategy.Consensus, check)
+}
+
+// failed reports whether the provider failed to verify the customer so the next provider should be tried.
+func failed(result common.KYCResult, err error) bool {
+	return err != nil || result.Status == common.Error
+}
+
+// providerResult returns the individual result of the provider.
+func providerResult(provider common.KYCProvider, result common.KYCResult, err error) (r common.KYCProviderResult) {
+	r = common.KYCProviderResult{
+		Provider: provider,
+		Result:   result,
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+
+	return
+}
+
+// fallback tries the providers in order until one of them doesn't fail.
+// The result of the provider succeeded is returned along with the results of all the tried providers.
+func fallback(ctx context.Context, providers []common.KYCProvider, check CheckFunc) (result common.KYCResult, err error) {
+	results := make([]common.KYCProviderResult, 0, len(providers))
+
+	for _, provider := range providers {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
+		r, err1 := check(ctx, provider)
+		results = append(results, providerResult(provider, r, err1))
+		if !failed(r, err1) {
+			result, err = r, nil
+			result.Providers = results
+			return
+		}
+	}
+
+	result = common.KYCResult{
+		Status:    common.Error,
+		Providers: results,
+	}
+	if len(results) > 0 {
+		last := results[len(results)-1].Result
+		result.Details, result.ErrorCode = last.Details, last.ErrorCode
+	}
+	if err == nil {
+		err = allFailed(results)
+	}
+
+	return
+}
+
+// parallel checks all the providers at once and merges their results by the consensus rule.
+func parallel(ctx context.Context, providers []common.KYCProvider, consensus common.Consensus, check CheckFunc) (result common.KYCResult, err error) {
+	results := make([]common.KYCProviderResult, len(providers))
+
+	wg := sync.WaitGroup{}
+	for i, provider := range providers {
+		wg.Add(1)
+		go func(i int, provider common.KYCProvider) {
+			defer wg.Done()
+
+			r, err := check(ctx, provider)
+			results[i] = providerResult(provider, r, err)
+		}(i, provider)
+	}
+	wg.Wait()
+
+	result = Merge(consensus, results)
+	if result.Status == common.Error {
+		err = allFailed(results)
+	}
+
+	return
+}
+
+// Merge merges the results of the providers by the consensus rule.
+// The failed providers aren't taken into account. The merged status is Error only if all the providers failed.
+// The merged result is final if the results of all the providers answered are final.
+func Merge(consensus common.Consensus, results []common.KYCProviderResult) (result common.KYCResult) {
+	counts := map[common.KYCStatus]int{}
+	answered := 0
+	final := true
+	reasons := common.KYCDetails{}
+
+	for _, r := range results {
+		if len(r.Error) > 0 || r.Result.Status == common.Error {
+			continue
+		}
+		answered++
+		counts[r.Result.Status]++
+		if !r.Result.IsFinal() || r.Result.Details != nil && r.Result.Details.Finality == common.NonFinal {
+			final = false
+		}
+		if r.Result.Details != nil {
+			for i, reason := range r.Result.Details.Reasons {
+				reasons.AddReason(r.Result.Details.ReasonCode(i), string(r.Provider)+": "+reason)
+			}
+		}
+	}
+
+	result.Providers = results
+
+	if answered == 0 {
+		result.Status = common.Error
+		return
+	}
+
+	switch consensus {
+	case common.AnyDenial:
+		switch {
+		case counts[common.Denied] > 0:
+			result.Status = common.Denied
+		case counts[common.Approved] > 0:
+			result.Status = common.Approved
+		default:
+			result.Status = common.Unclear
+		}
+	case common.Majority:
+		switch {
+		case counts[common.Approved]*2 > answered:
+			result.Status = common.Approved
+		case counts[common.Denied]*2 > answered:
+			result.Status = common.Denied
+		default:
+			result.Status = common.Unclear
+		}
+	default:
+		switch {
+		case counts[common.Denied] > 0:
+			result.Status = common.Denied
+		case counts[common.Approved] == len(results):
+			result.Status = common.Approved
+		default:
+			result.Status = common.Unclear
+		}
+	}
+
+	result.Details = &common.KYCDetails{
+		Finality: common.Final,
+	}
+	if !final {
+		result.Details.Finality = common.NonFinal
+	}
+	if len(reasons.Reasons) > 0 {
+		result.Details.Reasons, result.Details.ReasonCodes = reasons.Reasons, reasons.ReasonCodes
+	}
+
+	return
+}
+
+// allFailed returns the error listing the failures of all the providers.
+func allFailed(results []common.KYCProviderResult) error {
+	failures := make([]string, 0, len(results))
+	for _, r := range results {
+		reason := r.Error
+		if len(reason) == 0 {
+			reason = "verification error"
+			if len(r.Result.ErrorCode) > 0 {
+				reason += " " + r.Result.ErrorCode
+			}
+		}
+		failures = append(failures, fmt.Sprintf("%s: %s", r.Provider, reason))
+	}
+
+	return fmt.Errorf("all KYC providers failed: %s", strings.Join(failures, "; "))
+}
@@ -0,0 +1,206 @@
+package orchestration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"modulus/kyc/common"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// outcome is the simulated outcome of the provider check.
+type outcome struct {
+	result common.KYCResult
+	err    error
+}
+
+// checker returns the CheckFunc simulating the outcomes of the providers and the list of the providers checked.
+func checker(outcomes map[common.KYCProvider]outcome) (CheckFunc, *[]common.KYCProvider) {
+	checked := []common.KYCProvider{}
+
+	return func(ctx context.Context, provider common.KYCProvider) (common.KYCResult, error) {
+		checked = append(checked, provider)
+		o := outcomes[provider]
+		return o.result, o.err
+	}, &checked
+}
+
+func TestFallback(t *testing.T) {
+	assert := assert.New(t)
+
+	strategy := common.Strategy{
+		Mode:      common.Fallback,
+		Providers: []common.KYCProvider{common.Trulioo, common.IDology, common.Jumio},
+	}
+
+	// Testing the failover to the next provider.
+	check, checked := checker(map[common.KYCProvider]outcome{
+		common.Trulioo: {err: errors.New("http error 503")},
+		common.IDology: {result: common.KYCResult{Status: common.Approved}},
+	})
+
+	result, err := Run(context.Background(), strategy, check)
+
+	assert.NoError(err)
+	assert.Equal([]common.KYCProvider{common.Trulioo, common.IDology}, *checked)
+	assert.Equal(common.Approved, result.Status)
+	assert.Equal([]common.KYCProviderResult{
+		{Provider: common.Trulioo, Error: "http error 503"},
+		{Provider: common.IDology, Result: common.KYCResult{Status: common.Approved}},
+	}, result.Providers)
+
+	// Testing the failover on the Error status.
+	check, checked = checker(map[common.KYCProvider]outcome{
+		common.Trulioo: {result: common.KYCResult{Status: common.Error, ErrorCode: "500"}},
+		common.IDology: {result: common.KYCResult{Status: common.Denied}},
+	})
+
+	result, err = Run(context.Background(), strategy, check)
+
+	assert.NoError(err)
+	assert.Len(*checked, 2)
+	assert.Equal(common.Denied, result.Status)
+
+	// Testing the first provider answered.
+	check, checked = checker(map[common.KYCProvider]outcome{
+		common.Trulioo: {result: common.KYCResult{Status: common.Unclear}},
+	})
+
+	result, err = Run(context.Background(), strategy, check)
+
+	assert.NoError(err)
+	assert.Equal([]common.KYCProvider{common.Trulioo}, *checked)
+	assert.Equal(common.Unclear, result.Status)
+	assert.Len(result.Providers, 1)
+
+	// Testing all the providers failed.
+	check, checked = checker(map[common.KYCProvider]outcome{
+		common.Trulioo: {err: errors.New("timeout")},
+		common.IDology: {result: common.KYCResult{Status: common.Error, ErrorCode: "502"}},
+		common.Jumio:   {err: errors.New("http error 500"), result: common.KYCResult{ErrorCode: "500"}},
+	})
+
+	result, err = Run(context.Background(), strategy, check)
+
+	assert.Len(*checked, 3)
+	if assert.Error(err) {
+		assert.Equal("all KYC providers failed: Trulioo: timeout; IDology: verification error 502; Jumio: http error 500", err.Error())
+	}
+	assert.Equal(common.Error, result.Status)
+	assert.Equal("500", result.ErrorCode)
+	assert.Len(result.Providers, 3)
+
+	// Testing the canceled context.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	check, checked = checker(nil)
+
+	_, err = Run(ctx, strategy, check)
+
+	assert.Equal(context.Canceled, err)
+	assert.Empty(*checked)
+
+	// Testing the invalid strategy.
+	_, err = Run(context.Background(), common.Strategy{Mode: "Random", Providers: strategy.Providers}, check)
+
+	if assert.Error(err) {
+		assert.Equal("unknown strategy mode: Random", err.Error())
+	}
+}
+
+func TestParallel(t *testing.T) {
+	assert := assert.New(t)
+
+	outcomes := map[common.KYCProvider]outcome{
+		common.Trulioo: {result: common.KYCResult{Status: common.Approved}},
+		common.IDology: {result: common.KYCResult{
+			Status:  common.Denied,
+			Details: &common.KYCDetails{Finality: common.Final, Reasons: []string{"sanctions match"}},
+		}},
+		common.Jumio: {err: errors.New("http error 503")},
+	}
+
+	check := func(ctx context.Context, provider common.KYCProvider) (common.KYCResult, error) {
+		o := outcomes[provider]
+		return o.result, o.err
+	}
+
+	strategy := common.Strategy{
+		Mode:      common.Parallel,
+		Providers: []common.KYCProvider{common.Trulioo, common.IDology, common.Jumio},
+		Consensus: common.AnyDenial,
+	}
+
+	result, err := Run(context.Background(), strategy, check)
+
+	assert.NoError(err)
+	assert.Equal(common.Denied, result.Status)
+	assert.Equal(&common.KYCDetails{Finality: common.Final, Reasons: []string{"IDology: sanctions match"}}, result.Details)
+	assert.Nil(result.StatusCheck)
+	if assert.Len(result.Providers, 3) {
+		assert.Equal(common.Trulioo, result.Providers[0].Provider)
+		assert.Equal(common.IDology, result.Providers[1].Provider)
+		assert.Equal(common.Jumio, result.Providers[2].Provider)
+		assert.Equal("http error 503", result.Providers[2].Error)
+	}
+
+	// Testing all the providers failed.
+	strategy.Providers = []common.KYCProvider{common.Jumio}
+
+	result, err = Run(context.Background(), strategy, check)
+
+	if assert.Error(err) {
+		assert.Equal("all KYC providers failed: Jumio: http error 503", err.Error())
+	}
+	assert.Equal(common.Error, result.Status)
+}
+
+func TestMerge(t *testing.T) {
+	assert := assert.New(t)
+
+	approved := common.KYCProviderResult{Provider: common.Trulioo, Result: common.KYCResult{Status: common.Approved}}
+	denied := common.KYCProviderResult{Provider: common.IDology, Result: common.KYCResult{Status: common.Denied}}
+	pending := common.KYCProviderResult{Provider: common.SumSub, Result: common.KYCResult{
+		Status:      common.Unclear,
+		StatusCheck: &common.KYCStatusCheck{Provider: common.SumSub, ReferenceID: "ref"},
+	}}
+	failed := common.KYCProviderResult{Provider: common.Jumio, Error: "http error 503"}
+
+	testCases := []struct {
+		name      string
+		consensus common.Consensus
+		results   []common.KYCProviderResult
+		status    common.KYCStatus
+		finality  common.KYCFinality
+	}{
+		{"all approved", common.AllApprove, []common.KYCProviderResult{approved, approved}, common.Approved, common.Final},
+		{"all approve with denial", common.AllApprove, []common.KYCProviderResult{approved, denied}, common.Denied, common.Final},
+		{"all approve with failure", common.AllApprove, []common.KYCProviderResult{approved, failed}, common.Unclear, common.Final},
+		{"all approve with pending", "", []common.KYCProviderResult{approved, pending}, common.Unclear, common.NonFinal},
+		{"any denial approved", common.AnyDenial, []common.KYCProviderResult{approved, failed}, common.Approved, common.Final},
+		{"any denial denied", common.AnyDenial, []common.KYCProviderResult{approved, denied, pending}, common.Denied, common.NonFinal},
+		{"any denial pending", common.AnyDenial, []common.KYCProviderResult{pending, failed}, common.Unclear, common.NonFinal},
+		{"majority approved", common.Majority, []common.KYCProviderResult{approved, approved, denied}, common.Approved, common.Final},
+		{"majority denied", common.Majority, []common.KYCProviderResult{denied, denied, approved, failed}, common.Denied, common.Final},
+		{"majority tie", common.Majority, []common.KYCProviderResult{approved, denied}, common.Unclear, common.Final},
+	}
+
+	for _, tc := range testCases {
+		result := Merge(tc.consensus, tc.results)
+
+		assert.Equal(tc.status, result.Status, tc.name)
+		if assert.NotNil(result.Details, tc.name) {
+			assert.Equal(tc.finality, result.Details.Finality, tc.name)
+		}
+		assert.Equal(tc.results, result.Providers, tc.name)
+	}
+
+	// Testing all the providers failed.
+	result := Merge(common.AllApprove, []common.KYCProviderResult{failed})
+
+	assert.Equal(common.Error, result.Status)
+	assert.Nil(result.Details)
+}
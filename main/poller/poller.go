@@ -0,0 +1,566 @@
+// Package poller tracks the pending verifications and polls their status in the background.
+// The status checks of a provider follow its cadence with the jitter, respect its rate limit and back off after failures.
+// The polling of a verification stops when its final result is obtained by any means.
+// Every provider is polled by its own worker, so the provider not responding doesn't hold up the checks of the others.
+// The latest known status of a tracked verification is kept for the queries.
+package poller
+
+import (
+	"context"
+	"log/slog"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/events"
+	"modulus/kyc/main/logging"
+)
+
+// jitter is the fraction of the interval by which the status checks are randomly shifted.
+const jitter = 0.1
+
+// Retention is the time during which the status of a finished verification is kept for the queries.
+var Retention = 24 * time.Hour
+
+// StatusChecker checks the current status of the verification.
+type StatusChecker func(ctx context.Context, provider common.KYCProvider, referenceID string) (common.KYCResult, error)
+
+// SettingsFunc returns the polling settings of the provider.
+type SettingsFunc func(provider common.KYCProvider) Settings
+
+// PendingFunc returns the pending verifications to resume tracking on the start, e.g. recorded in the store.
+// Provider, ReferenceID, Tracked and LastCheck of the returned statuses are used.
+type PendingFunc func() ([]Status, error)
+
+// Status represents the latest known status of the tracked verification.
+//
+// * Result is the latest verification result. It's nil until the result is obtained.
+// * Error is the error of the latest failed status check.
+// * Final indicates that the final result is obtained and the polling is stopped.
+// * Expired indicates that the verification is pending for too long and the polling is stopped.
+// * Polling indicates whether the verification is polled. Otherwise, the result is expected from the callbacks.
+type Status struct {
+	Provider    common.KYCProvider
+	ReferenceID string
+	Result      *common.KYCResult
+	Error       string
+	Final       bool
+	Expired     bool
+	Polling     bool
+	Checks      int
+	LastCheck   time.Time
+	NextCheck   time.Time
+	Tracked     time.Time
+	Updated     time.Time
+}
+
+// finished reports whether the verification isn't polled anymore.
+func (s Status) finished() bool {
+	return s.Final || s.Expired
+}
+
+// key identifies the tracked verification.
+type key struct {
+	provider    common.KYCProvider
+	referenceID string
+}
+
+// verification represents the tracked verification.
+type verification struct {
+	Status
+	failures int
+}
+
+// providerState holds the state of the status checks of a provider.
+type providerState struct {
+	lastCheck   time.Time
+	pausedUntil time.Time
+	failures    int
+}
+
+// Scheduler tracks the pending verifications and polls their status.
+// The busy providers are being checked by their workers.
+type Scheduler struct {
+	mu            sync.Mutex
+	checker       StatusChecker
+	settings      SettingsFunc
+	verifications map[key]*verification
+	providers     map[common.KYCProvider]*providerState
+	busy          map[common.KYCProvider]bool
+	workers       sync.WaitGroup
+	wake          chan struct{}
+	cancel        context.CancelFunc
+	done          chan struct{}
+}
+
+// New constructs a new Scheduler.
+// The checker is used to check the status of the verifications,
+// the settings of the providers are obtained using the settings func.
+func New(checker StatusChecker, settings SettingsFunc) *Scheduler {
+	return &Scheduler{
+		checker:       checker,
+		settings:      settings,
+		verifications: map[key]*verification{},
+		providers:     map[common.KYCProvider]*providerState{},
+		busy:          map[common.KYCProvider]bool{},
+		wake:          make(chan struct{}, 1),
+	}
+}
+
+// Start launches the background polling.
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go s.run(ctx)
+}
+
+// Stop stops the background polling and waits for it to finish.
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+
+	s.cancel()
+	<-s.done
+	s.cancel = nil
+}
+
+// Track starts tracking the pending verification.
+// The first status check is scheduled after the provider interval.
+// The verifications of the providers not supporting the status polling wait for the callbacks.
+func (s *Scheduler) Track(provider common.KYCProvider, referenceID string) {
+	s.track(provider, referenceID, time.Now())
+}
+
+// Restore resumes tracking the pending verifications, e.g. recorded before the restart.
+// The age of a verification is counted from the time it's tracked originally, so it still expires in time.
+// The next status check is scheduled after the provider interval since the last check.
+func (s *Scheduler) Restore(statuses []Status) {
+	for _, status := range statuses {
+		s.restore(status.Provider, status.ReferenceID, status.Tracked, status.LastCheck)
+	}
+}
+
+// track starts tracking the verification with the last check at the specified time.
+func (s *Scheduler) track(provider common.KYCProvider, referenceID string, lastCheck time.Time) {
+	s.restore(provider, referenceID, time.Now(), lastCheck)
+}
+
+// restore starts tracking the verification tracked since the time with the last check at the specified time.
+func (s *Scheduler) restore(provider common.KYCProvider, referenceID string, tracked, lastCheck time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := key{provider, referenceID}
+	if _, ok := s.verifications[k]; ok {
+		return
+	}
+
+	now := time.Now()
+	polling := true
+	if spec, ok := common.LookupProvider(provider); ok && !spec.Capabilities.StatusPolling {
+		polling = false
+	}
+
+	s.verifications[k] = &verification{
+		Status: Status{
+			Provider:    provider,
+			ReferenceID: referenceID,
+			Polling:     polling,
+			NextCheck:   lastCheck.Add(withJitter(s.settings(provider).Interval)),
+			Tracked:     tracked,
+			Updated:     now,
+		},
+	}
+
+	s.wakeup()
+}
+
+// Lookup returns the latest known status of the tracked verification.
+// The ok result indicates whether the verification is tracked.
+func (s *Scheduler) Lookup(provider common.KYCProvider, referenceID string) (status Status, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.verifications[key{provider, referenceID}]
+	if ok {
+		status = v.Status
+	}
+
+	return
+}
+
+// HandleResult updates the status of the verification with the result update.
+// The pending verification is tracked if it isn't yet, and the polling is stopped if the result is final.
+// It's meant to be subscribed to the result updates.
+func (s *Scheduler) HandleResult(update events.Result) {
+	if !update.Result.IsFinal() {
+		lastCheck := update.Time
+		if !update.Result.StatusCheck.LastCheck.IsZero() {
+			lastCheck = update.Result.StatusCheck.LastCheck
+		}
+		s.track(update.Provider, update.ReferenceID, lastCheck)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.verifications[key{update.Provider, update.ReferenceID}]
+	if !ok || v.Final {
+		return
+	}
+
+	result := update.Result
+	v.Result = &result
+	v.Error = ""
+	v.Final = result.IsFinal()
+	v.Updated = update.Time
+}
+
+// run polls the verifications until the context is done.
+// It waits for the workers to return before finishing.
+func (s *Scheduler) run(ctx context.Context) {
+	defer close(s.done)
+	defer s.workers.Wait()
+
+	for {
+		s.poll(ctx)
+		s.cleanup()
+
+		timer := time.NewTimer(s.nextCheck())
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-s.wake:
+		case <-timer.C:
+		}
+
+		timer.Stop()
+	}
+}
+
+// poll launches the workers checking the status of the verifications which are due.
+// The provider already being checked is skipped, its worker reschedules the polling when it returns.
+func (s *Scheduler) poll(ctx context.Context) {
+	for provider, keys := range s.due() {
+		s.workers.Add(1)
+		go func(provider common.KYCProvider, keys []key) {
+			defer s.workers.Done()
+
+			s.check(ctx, keys)
+			s.release(provider)
+		}(provider, keys)
+	}
+}
+
+// check checks the status of the verifications of a provider one by one.
+// The checks are postponed if the provider rate limit is reached or the provider asked to slow down.
+func (s *Scheduler) check(ctx context.Context, keys []key) {
+	for _, k := range keys {
+		if ctx.Err() != nil {
+			return
+		}
+		if !s.acquire(k) {
+			continue
+		}
+
+		result, err := s.checker(ctx, k.provider, k.referenceID)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if s.complete(k, result, err) {
+			events.Publish(events.Result{
+				Provider:    k.provider,
+				ReferenceID: k.referenceID,
+				Result:      result,
+				Source:      events.Polling,
+			})
+		}
+	}
+}
+
+// due returns the verifications to check by the providers which aren't busy
+// sorted by the time of the next check and marks the providers busy.
+func (s *Scheduler) due() (keys map[common.KYCProvider][]key) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	var due []*verification
+	for _, v := range s.verifications {
+		if v.Polling && !v.finished() && !s.busy[v.Provider] && !v.NextCheck.After(now) {
+			due = append(due, v)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].NextCheck.Before(due[j].NextCheck) })
+
+	keys = map[common.KYCProvider][]key{}
+	for _, v := range due {
+		keys[v.Provider] = append(keys[v.Provider], key{v.Provider, v.ReferenceID})
+		s.busy[v.Provider] = true
+	}
+
+	return
+}
+
+// release marks the provider not busy and reschedules the polling.
+func (s *Scheduler) release(provider common.KYCProvider) {
+	s.mu.Lock()
+	delete(s.busy, provider)
+	s.mu.Unlock()
+
+	s.wakeup()
+}
+
+// acquire reserves the status check of the verification according to the provider settings.
+// It returns false if the check should be postponed or the verification doesn't need it anymore.
+func (s *Scheduler) acquire(k key) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.verifications[k]
+	if !ok || v.finished() {
+		return false
+	}
+
+	now := time.Now()
+	settings := s.settings(k.provider)
+
+	if now.Sub(v.Tracked) > settings.MaxAge {
+		v.Expired = true
+		v.Updated = now
+		slog.Warn("Polling expired", logging.ProviderKey, k.provider, logging.ReferenceIDKey, k.referenceID, "maxAge", settings.MaxAge)
+		return false
+	}
+
+	p := s.state(k.provider)
+
+	allowed := p.lastCheck.Add(settings.gap())
+	if p.pausedUntil.After(allowed) {
+		allowed = p.pausedUntil
+	}
+	if allowed.After(now) {
+		v.NextCheck = allowed
+		return false
+	}
+
+	p.lastCheck = now
+
+	return true
+}
+
+// complete updates the verification with the result of the status check.
+// It returns true if the result should be published.
+func (s *Scheduler) complete(k key, result common.KYCResult, err error) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.verifications[k]
+	if !ok {
+		return false
+	}
+
+	now := time.Now()
+	settings := s.settings(k.provider)
+	p := s.state(k.provider)
+
+	v.Checks++
+	v.LastCheck = now
+
+	if result.ErrorCode == common.TooManyRequests {
+		p.failures++
+		p.pausedUntil = now.Add(backoff(settings.Interval, p.failures, settings.MaxInterval))
+		v.NextCheck = p.pausedUntil
+		v.Error = "too many requests"
+		if err != nil {
+			v.Error = err.Error()
+		}
+		slog.Warn("Polling rate limited", logging.ProviderKey, k.provider, "pausedUntil", p.pausedUntil.Format(time.RFC3339))
+		return false
+	}
+	p.failures = 0
+
+	if err != nil {
+		v.failures++
+		v.Error = err.Error()
+		v.NextCheck = now.Add(withJitter(backoff(settings.Interval, v.failures, settings.MaxInterval)))
+		slog.Warn("Polling error", logging.ProviderKey, k.provider, logging.ReferenceIDKey, k.referenceID, logging.ErrorKey, err)
+		return false
+	}
+
+	if v.Final {
+		return false
+	}
+
+	if result.StatusCheck != nil {
+		result.StatusCheck.LastCheck = now
+	}
+
+	v.failures = 0
+	v.Error = ""
+	v.Result = &result
+	v.Final = result.IsFinal()
+	v.Updated = now
+	v.NextCheck = now.Add(withJitter(settings.Interval))
+
+	return true
+}
+
+// cleanup forgets the verifications finished longer than the retention time ago.
+func (s *Scheduler) cleanup() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for k, v := range s.verifications {
+		if v.finished() && time.Since(v.Updated) > Retention {
+			delete(s.verifications, k)
+		}
+	}
+}
+
+// nextCheck returns the wait until the earliest status check.
+// The verifications of the busy providers are rescheduled once their workers return.
+func (s *Scheduler) nextCheck() (wait time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wait = time.Hour
+
+	for _, v := range s.verifications {
+		if !v.Polling || v.finished() || s.busy[v.Provider] {
+			continue
+		}
+		if d := time.Until(v.NextCheck); d < wait {
+			wait = d
+		}
+	}
+	if wait < 0 {
+		wait = 0
+	}
+
+	return
+}
+
+// state returns the state of the status checks of the provider.
+// The caller must hold the lock.
+func (s *Scheduler) state(name common.KYCProvider) *providerState {
+	p, ok := s.providers[name]
+	if !ok {
+		p = &providerState{}
+		s.providers[name] = p
+	}
+
+	return p
+}
+
+// wakeup signals the background polling to reschedule the status checks.
+func (s *Scheduler) wakeup() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// backoff returns the interval doubled for every failure and limited by the max interval.
+func backoff(interval time.Duration, failures int, maxInterval time.Duration) (wait time.Duration) {
+	wait = interval << uint(failures)
+	if wait > maxInterval || wait < interval {
+		wait = maxInterval
+	}
+
+	return
+}
+
+// withJitter randomly shifts the interval by the jitter fraction.
+func withJitter(interval time.Duration) time.Duration {
+	return interval + time.Duration((rand.Float64()*2-1)*jitter*float64(interval))
+}
+
+var (
+	mu        sync.RWMutex
+	current   *Scheduler
+	subscribe sync.Once
+)
+
+// Start constructs the service Scheduler and launches it.
+// The Scheduler receives the result updates published by the other subsystems.
+// The pending verifications returned by the pending func are tracked again if it's set.
+func Start(checker StatusChecker, settings SettingsFunc, pending PendingFunc) {
+	scheduler := New(checker, settings)
+	if pending != nil {
+		statuses, err := pending()
+		if err != nil {
+			slog.Error("Polling restore error", logging.ErrorKey, err)
+		}
+		scheduler.Restore(statuses)
+	}
+	scheduler.Start()
+
+	subscribe.Do(func() {
+		events.Subscribe(handleResult)
+	})
+
+	// The previous Scheduler is stopped outside of the lock
+	// because its background polling might wait for the lock to publish a result.
+	if previous := swap(scheduler); previous != nil {
+		previous.Stop()
+	}
+}
+
+// Stop stops the service Scheduler.
+func Stop() {
+	if previous := swap(nil); previous != nil {
+		previous.Stop()
+	}
+}
+
+// swap replaces the service Scheduler and returns the previous one.
+func swap(scheduler *Scheduler) (previous *Scheduler) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	previous, current = current, scheduler
+
+	return
+}
+
+// Track starts tracking the pending verification with the service Scheduler.
+func Track(provider common.KYCProvider, referenceID string) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current != nil {
+		current.Track(provider, referenceID)
+	}
+}
+
+// Lookup returns the latest known status of the verification tracked by the service Scheduler.
+func Lookup(provider common.KYCProvider, referenceID string) (status Status, ok bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current != nil {
+		status, ok = current.Lookup(provider, referenceID)
+	}
+
+	return
+}
+
+// handleResult passes the result update to the service Scheduler.
+func handleResult(update events.Result) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current != nil {
+		current.HandleResult(update)
+	}
+}
@@ -0,0 +1,583 @@
+package poller
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/events"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// checks records the status checks done by the scheduler in tests.
+type checks struct {
+	mu      sync.Mutex
+	times   map[string][]time.Time
+	results func(referenceID string, count int) (common.KYCResult, error)
+}
+
+// checker returns the StatusChecker recording the checks.
+func (c *checks) checker() StatusChecker {
+	c.times = map[string][]time.Time{}
+
+	return func(ctx context.Context, provider common.KYCProvider, referenceID string) (common.KYCResult, error) {
+		c.mu.Lock()
+		c.times[referenceID] = append(c.times[referenceID], time.Now())
+		count := len(c.times[referenceID])
+		c.mu.Unlock()
+
+		return c.results(referenceID, count)
+	}
+}
+
+// count returns the number of checks of the verification.
+func (c *checks) count(referenceID string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.times[referenceID])
+}
+
+// pending returns the non-final result of the verification.
+func pending(referenceID string) common.KYCResult {
+	return common.KYCResult{
+		Status: common.Unclear,
+		StatusCheck: &common.KYCStatusCheck{
+			Provider:    common.Example,
+			ReferenceID: referenceID,
+		},
+	}
+}
+
+// fixed returns the SettingsFunc returning the settings.
+func fixed(settings Settings) SettingsFunc {
+	return func(common.KYCProvider) Settings { return settings }
+}
+
+// waitStatus waits until the status of the verification satisfies the condition.
+func waitStatus(s *Scheduler, referenceID string, condition func(Status) bool) (status Status, ok bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		status, _ = s.Lookup(common.Example, referenceID)
+		if condition(status) {
+			return status, true
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	return
+}
+
+func TestSettingsFromOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing the default settings.
+	settings, err := SettingsFromOptions(map[string]string{
+		"Host": "https://example.com",
+	})
+
+	assert.NoError(err)
+	assert.Equal(DefaultSettings, settings)
+	assert.Equal(time.Second, settings.gap())
+
+	// Testing the full settings.
+	settings, err = SettingsFromOptions(map[string]string{
+		"PollInterval":    "30s",
+		"PollMaxInterval": "10m",
+		"PollRateLimit":   "0",
+		"PollMaxAge":      "24h",
+	})
+
+	assert.NoError(err)
+	assert.Equal(Settings{
+		Interval:    30 * time.Second,
+		MaxInterval: 10 * time.Minute,
+		RateLimit:   0,
+		MaxAge:      24 * time.Hour,
+	}, settings)
+	assert.Equal(time.Duration(0), settings.gap())
+
+	// Testing invalid duration.
+	_, err = SettingsFromOptions(map[string]string{
+		"PollMaxAge": "-1h",
+	})
+
+	if assert.Error(err) {
+		assert.Equal("invalid option 'PollMaxAge': non-positive duration", err.Error())
+	}
+
+	// Testing the max interval less than the interval.
+	_, err = SettingsFromOptions(map[string]string{
+		"PollInterval": "1h",
+	})
+
+	if assert.Error(err) {
+		assert.Equal("option 'PollMaxInterval' must not be less than 'PollInterval'", err.Error())
+	}
+
+	// Testing invalid rate limit.
+	_, err = SettingsFromOptions(map[string]string{
+		"PollRateLimit": "-5",
+	})
+
+	if assert.Error(err) {
+		assert.Equal("invalid option 'PollRateLimit': negative number", err.Error())
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(2*time.Second, backoff(time.Second, 1, time.Minute))
+	assert.Equal(16*time.Second, backoff(time.Second, 4, time.Minute))
+	assert.Equal(time.Minute, backoff(time.Second, 6, time.Minute))
+	assert.Equal(time.Minute, backoff(time.Second, 64, time.Minute))
+}
+
+func TestWithJitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		interval := withJitter(time.Minute)
+
+		assert.True(t, interval >= 54*time.Second && interval <= 66*time.Second, interval)
+	}
+}
+
+func TestSchedulerPoll(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &checks{
+		results: func(referenceID string, count int) (common.KYCResult, error) {
+			if count < 3 {
+				return pending(referenceID), nil
+			}
+			return common.KYCResult{Status: common.Approved}, nil
+		},
+	}
+
+	s := New(c.checker(), fixed(Settings{
+		Interval:    10 * time.Millisecond,
+		MaxInterval: time.Second,
+		MaxAge:      time.Hour,
+	}))
+	s.Start()
+	defer s.Stop()
+
+	s.Track(common.Example, "ref")
+
+	status, ok := s.Lookup(common.Example, "ref")
+
+	assert.True(ok)
+	assert.True(status.Polling)
+	assert.False(status.Final)
+	assert.Nil(status.Result)
+
+	status, ok = waitStatus(s, "ref", func(status Status) bool { return status.Final })
+
+	assert.True(ok)
+	assert.Equal(3, status.Checks)
+	assert.Equal(common.Approved, status.Result.Status)
+	assert.False(status.LastCheck.IsZero())
+
+	// Testing the polling stopped after the final result.
+	time.Sleep(50 * time.Millisecond)
+
+	assert.Equal(3, c.count("ref"))
+
+	_, ok = s.Lookup(common.Example, "unknown")
+
+	assert.False(ok)
+}
+
+func TestSchedulerRateLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &checks{
+		results: func(referenceID string, count int) (common.KYCResult, error) {
+			return common.KYCResult{Status: common.Approved}, nil
+		},
+	}
+
+	s := New(c.checker(), fixed(Settings{
+		Interval:    time.Millisecond,
+		MaxInterval: time.Second,
+		RateLimit:   1200,
+		MaxAge:      time.Hour,
+	}))
+	s.Start()
+	defer s.Stop()
+
+	for _, ref := range []string{"first", "second", "third"} {
+		s.Track(common.Example, ref)
+	}
+	for _, ref := range []string{"first", "second", "third"} {
+		_, ok := waitStatus(s, ref, func(status Status) bool { return status.Final })
+
+		assert.True(ok)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var times []time.Time
+	for _, ref := range []string{"first", "second", "third"} {
+		times = append(times, c.times[ref]...)
+	}
+
+	assert.Len(times, 3)
+	for i := range times {
+		for j := range times {
+			if i == j {
+				continue
+			}
+			d := times[i].Sub(times[j])
+			if d < 0 {
+				d = -d
+			}
+			assert.True(d >= 45*time.Millisecond, d)
+		}
+	}
+}
+
+func TestSchedulerTooManyRequests(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &checks{
+		results: func(referenceID string, count int) (result common.KYCResult, err error) {
+			if count == 1 {
+				result.ErrorCode = common.TooManyRequests
+				err = errors.New("slow down")
+				return
+			}
+			result.Status = common.Denied
+			return
+		},
+	}
+
+	s := New(c.checker(), fixed(Settings{
+		Interval:    20 * time.Millisecond,
+		MaxInterval: time.Second,
+		MaxAge:      time.Hour,
+	}))
+	s.Start()
+	defer s.Stop()
+
+	s.Track(common.Example, "ref")
+
+	status, ok := waitStatus(s, "ref", func(status Status) bool { return status.Checks == 1 })
+
+	assert.True(ok)
+	assert.Equal("slow down", status.Error)
+	assert.False(status.Final)
+
+	// Testing the provider paused for the doubled interval.
+	s.mu.Lock()
+	paused := s.providers[common.Example].pausedUntil.Sub(status.LastCheck)
+	s.mu.Unlock()
+
+	assert.True(paused >= 40*time.Millisecond, paused)
+
+	status, ok = waitStatus(s, "ref", func(status Status) bool { return status.Final })
+
+	assert.True(ok)
+	assert.Empty(status.Error)
+	assert.Equal(common.Denied, status.Result.Status)
+
+	times := c.times["ref"]
+
+	assert.True(times[1].Sub(times[0]) >= 40*time.Millisecond)
+}
+
+func TestSchedulerErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &checks{
+		results: func(referenceID string, count int) (common.KYCResult, error) {
+			return common.KYCResult{}, errors.New("connection refused")
+		},
+	}
+
+	s := New(c.checker(), fixed(Settings{
+		Interval:    5 * time.Millisecond,
+		MaxInterval: 40 * time.Millisecond,
+		MaxAge:      time.Hour,
+	}))
+	s.Start()
+	defer s.Stop()
+
+	s.Track(common.Example, "ref")
+
+	status, ok := waitStatus(s, "ref", func(status Status) bool { return status.Checks == 5 })
+
+	assert.True(ok)
+	assert.Equal("connection refused", status.Error)
+	assert.Nil(status.Result)
+
+	// Testing the backoff of the failed checks.
+	times := c.times["ref"]
+
+	assert.True(times[4].Sub(times[3]) > times[1].Sub(times[0]))
+}
+
+func TestSchedulerExpired(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &checks{
+		results: func(referenceID string, count int) (common.KYCResult, error) {
+			return pending(referenceID), nil
+		},
+	}
+
+	s := New(c.checker(), fixed(Settings{
+		Interval:    5 * time.Millisecond,
+		MaxInterval: time.Second,
+		MaxAge:      30 * time.Millisecond,
+	}))
+	s.Start()
+	defer s.Stop()
+
+	s.Track(common.Example, "ref")
+
+	status, ok := waitStatus(s, "ref", func(status Status) bool { return status.Expired })
+
+	assert.True(ok)
+	assert.False(status.Final)
+
+	checks := c.count("ref")
+	time.Sleep(30 * time.Millisecond)
+
+	assert.Equal(checks, c.count("ref"))
+}
+
+func TestSchedulerHandleResult(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &checks{
+		results: func(referenceID string, count int) (common.KYCResult, error) {
+			return pending(referenceID), nil
+		},
+	}
+
+	s := New(c.checker(), fixed(Settings{
+		Interval:    time.Hour,
+		MaxInterval: time.Hour,
+		MaxAge:      time.Hour,
+	}))
+
+	// Testing the final result of the untracked verification.
+	s.HandleResult(events.Result{
+		Provider:    common.Example,
+		ReferenceID: "ref",
+		Result:      common.KYCResult{Status: common.Approved},
+		Source:      events.Check,
+		Time:        time.Now(),
+	})
+
+	_, ok := s.Lookup(common.Example, "ref")
+
+	assert.False(ok)
+
+	// Testing the pending verification tracked from the update.
+	lastCheck := time.Now().Add(-time.Minute)
+	result := pending("ref")
+	result.StatusCheck.LastCheck = lastCheck
+
+	s.HandleResult(events.Result{
+		Provider:    common.Example,
+		ReferenceID: "ref",
+		Result:      result,
+		Source:      events.Check,
+		Time:        time.Now(),
+	})
+
+	status, ok := s.Lookup(common.Example, "ref")
+
+	assert.True(ok)
+	assert.False(status.Final)
+	assert.Equal(common.Unclear, status.Result.Status)
+	assert.True(status.NextCheck.Before(lastCheck.Add(time.Hour + 7*time.Minute)))
+	assert.True(status.NextCheck.After(lastCheck.Add(time.Hour - 7*time.Minute)))
+
+	// Testing the final result from the callback.
+	s.HandleResult(events.Result{
+		Provider:    common.Example,
+		ReferenceID: "ref",
+		Result:      common.KYCResult{Status: common.Denied},
+		Source:      events.Callback,
+		Time:        time.Now(),
+	})
+
+	status, _ = s.Lookup(common.Example, "ref")
+
+	assert.True(status.Final)
+	assert.Equal(common.Denied, status.Result.Status)
+
+	// Testing the final result isn't overwritten.
+	s.HandleResult(events.Result{
+		Provider:    common.Example,
+		ReferenceID: "ref",
+		Result:      common.KYCResult{Status: common.Approved},
+		Source:      events.Callback,
+		Time:        time.Now(),
+	})
+
+	status, _ = s.Lookup(common.Example, "ref")
+
+	assert.Equal(common.Denied, status.Result.Status)
+
+	// Testing the cleanup of the finished verification.
+	retention := Retention
+	Retention = 0
+	defer func() { Retention = retention }()
+
+	s.cleanup()
+
+	_, ok = s.Lookup(common.Example, "ref")
+
+	assert.False(ok)
+}
+
+func TestSchedulerProviders(t *testing.T) {
+	assert := assert.New(t)
+
+	hanging := common.KYCProvider("Hanging")
+	release := make(chan struct{})
+
+	c := &checks{
+		results: func(referenceID string, count int) (common.KYCResult, error) {
+			if referenceID == "hanging" {
+				<-release
+				return pending(referenceID), nil
+			}
+			return common.KYCResult{Status: common.Approved}, nil
+		},
+	}
+
+	s := New(c.checker(), fixed(Settings{
+		Interval:    5 * time.Millisecond,
+		MaxInterval: time.Second,
+		MaxAge:      time.Hour,
+	}))
+	s.Start()
+	defer s.Stop()
+
+	s.Track(hanging, "hanging")
+
+	waitStatus(s, "", func(Status) bool { return c.count("hanging") > 0 })
+
+	// Testing the provider not responding doesn't hold up the others.
+	s.Track(common.Example, "ref")
+
+	status, ok := waitStatus(s, "ref", func(status Status) bool { return status.Final })
+
+	assert.True(ok)
+	assert.Equal(common.Approved, status.Result.Status)
+	assert.Equal(1, c.count("hanging"))
+
+	// Testing the provider is polled again once its worker returns.
+	close(release)
+
+	waitStatus(s, "", func(Status) bool { return c.count("hanging") > 1 })
+
+	assert.True(c.count("hanging") > 1)
+}
+
+func TestSchedulerRestore(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &checks{
+		results: func(referenceID string, count int) (common.KYCResult, error) {
+			return common.KYCResult{Status: common.Approved}, nil
+		},
+	}
+
+	s := New(c.checker(), fixed(Settings{
+		Interval:    time.Hour,
+		MaxInterval: time.Hour,
+		MaxAge:      24 * time.Hour,
+	}))
+
+	tracked := time.Now().Add(-2 * time.Hour)
+	lastCheck := time.Now().Add(-30 * time.Minute)
+
+	s.Restore([]Status{
+		{Provider: common.Example, ReferenceID: "ref", Tracked: tracked, LastCheck: lastCheck},
+	})
+
+	// Testing the restored verification keeps its age and the time of the last check.
+	status, ok := s.Lookup(common.Example, "ref")
+
+	assert.True(ok)
+	assert.True(status.Polling)
+	assert.True(tracked.Equal(status.Tracked))
+	assert.True(status.NextCheck.Before(lastCheck.Add(time.Hour + 7*time.Minute)))
+	assert.True(status.NextCheck.After(lastCheck.Add(time.Hour - 7*time.Minute)))
+
+	// Testing the verification tracked already isn't restored again.
+	s.Restore([]Status{
+		{Provider: common.Example, ReferenceID: "ref", Tracked: time.Now(), LastCheck: time.Now()},
+	})
+
+	status, _ = s.Lookup(common.Example, "ref")
+
+	assert.True(tracked.Equal(status.Tracked))
+}
+
+func TestStart(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &checks{
+		results: func(referenceID string, count int) (common.KYCResult, error) {
+			return common.KYCResult{Status: common.Approved}, nil
+		},
+	}
+
+	Start(c.checker(), fixed(Settings{
+		Interval:    10 * time.Millisecond,
+		MaxInterval: time.Second,
+		MaxAge:      time.Hour,
+	}), func() ([]Status, error) {
+		return []Status{{Provider: common.Example, ReferenceID: "restored", Tracked: time.Now(), LastCheck: time.Now()}}, nil
+	})
+	defer Stop()
+
+	// Testing the pending verification restored on the start.
+	_, ok := Lookup(common.Example, "restored")
+
+	assert.True(ok)
+
+	updates := make(chan events.Result, 10)
+	events.Subscribe(func(update events.Result) {
+		if update.ReferenceID == "started" && update.Source == events.Polling {
+			updates <- update
+		}
+	})
+
+	// Testing the verification tracked from the published update.
+	events.Publish(events.Result{
+		Provider:    common.Example,
+		ReferenceID: "started",
+		Result:      pending("started"),
+		Source:      events.Check,
+	})
+
+	_, ok = Lookup(common.Example, "started")
+
+	assert.True(ok)
+
+	select {
+	case update := <-updates:
+		assert.Equal(common.Approved, update.Result.Status)
+	case <-time.After(5 * time.Second):
+		assert.Fail("the result wasn't published")
+	}
+
+	Stop()
+
+	_, ok = Lookup(common.Example, "started")
+
+	assert.False(ok)
+}
@@ -0,0 +1,79 @@
+package poller
+
+import (
+	"fmt"
+	"time"
+
+	"modulus/kyc/main/option"
+)
+
+// The names of the polling options in the config section of a KYC provider.
+const (
+	IntervalOption    = "PollInterval"
+	MaxIntervalOption = "PollMaxInterval"
+	RateLimitOption   = "PollRateLimit"
+	MaxAgeOption      = "PollMaxAge"
+)
+
+// The default values of the polling options.
+const (
+	DefaultInterval    = time.Minute
+	DefaultMaxInterval = 30 * time.Minute
+	DefaultRateLimit   = 60
+	DefaultMaxAge      = 72 * time.Hour
+)
+
+// Settings holds the polling settings of a KYC provider.
+//
+// * Interval is the cadence of the status checks of a pending verification.
+// * MaxInterval limits the backoff of the status checks after failures.
+// * RateLimit is the maximum number of the status checks per minute. The rate isn't limited if it's zero.
+// * MaxAge is the time after which a pending verification isn't polled anymore.
+type Settings struct {
+	Interval    time.Duration
+	MaxInterval time.Duration
+	RateLimit   int
+	MaxAge      time.Duration
+}
+
+// DefaultSettings holds the default polling settings.
+var DefaultSettings = Settings{
+	Interval:    DefaultInterval,
+	MaxInterval: DefaultMaxInterval,
+	RateLimit:   DefaultRateLimit,
+	MaxAge:      DefaultMaxAge,
+}
+
+// SettingsFromOptions parses the polling options from the config section of a KYC provider.
+// Absent options take their default values.
+func SettingsFromOptions(options map[string]string) (settings Settings, err error) {
+	settings = DefaultSettings
+
+	if err = option.Duration(options, IntervalOption, &settings.Interval); err != nil {
+		return
+	}
+	if err = option.Duration(options, MaxIntervalOption, &settings.MaxInterval); err != nil {
+		return
+	}
+	if err = option.Duration(options, MaxAgeOption, &settings.MaxAge); err != nil {
+		return
+	}
+
+	if settings.MaxInterval < settings.Interval {
+		err = fmt.Errorf("option '%s' must not be less than '%s'", MaxIntervalOption, IntervalOption)
+		return
+	}
+
+	err = option.NonNegativeInt(options, RateLimitOption, &settings.RateLimit)
+
+	return
+}
+
+// gap returns the minimal time between the status checks allowed by the rate limit.
+func (s Settings) gap() time.Duration {
+	if s.RateLimit == 0 {
+		return 0
+	}
+
+	return time.Minute / time.Duration(s.RateLimit)
+}
@@ -0,0 +1,514 @@
+package rules
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expr represents the compiled condition of a decision rule.
+//
+// The conditions use a small expression language:
+//
+// * literals: numbers, "strings" or 'strings', true, false, null and lists like ["a", "b"];
+// * variables: dotted paths like signals.hitTypes. The missing variables are null;
+// * operators: || && ! == != < <= > >= and in, e.g. status in ["Denied", "Unclear"];
+// * functions: len(x), contains(list, value), only(list, value).
+//
+// The comparisons of the values of different types are false.
+type Expr interface {
+	eval(env map[string]interface{}) interface{}
+}
+
+// Compile compiles the expression source.
+func Compile(source string) (expr Expr, err error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return
+	}
+
+	p := &parser{tokens: tokens}
+
+	expr, err = p.parseOr()
+	if err == nil && p.peek().kind != tokenEOF {
+		err = fmt.Errorf("unexpected %s at position %d", p.peek(), p.peek().pos)
+	}
+	if err != nil {
+		expr = nil
+	}
+
+	return
+}
+
+// Match reports whether the expression is true in the environment.
+func Match(expr Expr, env map[string]interface{}) bool {
+	value, ok := expr.eval(env).(bool)
+	return ok && value
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenOperator
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+// String implements fmt.Stringer interface for the token.
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+
+	return fmt.Sprintf("'%s'", t.value)
+}
+
+// operators lists the operators of the language, the longest first.
+var operators = []string{"||", "&&", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")", "[", "]", ","}
+
+// tokenize splits the expression source into the tokens.
+func tokenize(source string) (tokens []token, err error) {
+	runes := []rune(source)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, value: string(runes[start:i]), pos: start})
+		case r == '"' || r == '\'':
+			start := i
+			i++
+			for i < len(runes) && runes[i] != r {
+				i++
+			}
+			if i == len(runes) {
+				err = fmt.Errorf("unterminated string at position %d", start)
+				return
+			}
+			tokens = append(tokens, token{kind: tokenString, value: string(runes[start+1 : i]), pos: start})
+			i++
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, value: string(runes[start:i]), pos: start})
+		default:
+			op := ""
+			for _, o := range operators {
+				if strings.HasPrefix(string(runes[i:]), o) {
+					op = o
+					break
+				}
+			}
+			if len(op) == 0 {
+				err = fmt.Errorf("unexpected character '%c' at position %d", r, i)
+				return
+			}
+			tokens = append(tokens, token{kind: tokenOperator, value: op, pos: i})
+			i += len(op)
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(runes)})
+
+	return
+}
+
+// parser is the recursive descent parser of the expressions.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+
+	return t
+}
+
+// accept consumes the next token if it's the operator or the keyword.
+func (p *parser) accept(value string) bool {
+	t := p.peek()
+	if (t.kind == tokenOperator || t.kind == tokenIdent) && t.value == value {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+// expect consumes the operator or fails.
+func (p *parser) expect(value string) error {
+	if !p.accept(value) {
+		return fmt.Errorf("expected '%s' instead of %s at position %d", value, p.peek(), p.peek().pos)
+	}
+
+	return nil
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	for err == nil && p.accept("||") {
+		var right Expr
+		if right, err = p.parseAnd(); err == nil {
+			left = logical{or: true, left: left, right: right}
+		}
+	}
+
+	return left, err
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseNot()
+	for err == nil && p.accept("&&") {
+		var right Expr
+		if right, err = p.parseNot(); err == nil {
+			left = logical{left: left, right: right}
+		}
+	}
+
+	return left, err
+}
+
+func (p *parser) parseNot() (Expr, error) {
+	if p.accept("!") {
+		operand, err := p.parseNot()
+		return not{operand: operand}, err
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">", "in"} {
+		if p.accept(op) {
+			right, err := p.parsePrimary()
+			return comparison{op: op, left: left, right: right}, err
+		}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	t := p.next()
+
+	switch t.kind {
+	case tokenNumber:
+		value, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s at position %d", t, t.pos)
+		}
+		return literal{value: value}, nil
+	case tokenString:
+		return literal{value: t.value}, nil
+	case tokenIdent:
+		switch t.value {
+		case "true":
+			return literal{value: true}, nil
+		case "false":
+			return literal{value: false}, nil
+		case "null":
+			return literal{}, nil
+		}
+		if p.accept("(") {
+			return p.parseCall(t)
+		}
+		return variable{path: strings.Split(t.value, ".")}, nil
+	case tokenOperator:
+		switch t.value {
+		case "(":
+			expr, err := p.parseOr()
+			if err == nil {
+				err = p.expect(")")
+			}
+			return expr, err
+		case "[":
+			items := list{}
+			if p.accept("]") {
+				return items, nil
+			}
+			for {
+				item, err := p.parseOr()
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+				if !p.accept(",") {
+					return items, p.expect("]")
+				}
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("unexpected %s at position %d", t, t.pos)
+}
+
+// parseCall parses the arguments of the function call.
+func (p *parser) parseCall(name token) (Expr, error) {
+	f, ok := functions[name.value]
+	if !ok {
+		return nil, fmt.Errorf("unknown function '%s' at position %d", name.value, name.pos)
+	}
+
+	c := call{name: name.value, f: f.f}
+	if !p.accept(")") {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			c.args = append(c.args, arg)
+			if !p.accept(",") {
+				if err = p.expect(")"); err != nil {
+					return nil, err
+				}
+				break
+			}
+		}
+	}
+
+	if len(c.args) != f.arity {
+		return nil, fmt.Errorf("function '%s' expects %d arguments at position %d", name.value, f.arity, name.pos)
+	}
+
+	return c, nil
+}
+
+type literal struct {
+	value interface{}
+}
+
+func (e literal) eval(env map[string]interface{}) interface{} {
+	return e.value
+}
+
+type variable struct {
+	path []string
+}
+
+func (e variable) eval(env map[string]interface{}) interface{} {
+	var value interface{} = env
+	for _, name := range e.path {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = m[name]
+	}
+
+	return normalize(value)
+}
+
+type list []Expr
+
+func (e list) eval(env map[string]interface{}) interface{} {
+	values := make([]interface{}, len(e))
+	for i, item := range e {
+		values[i] = item.eval(env)
+	}
+
+	return values
+}
+
+type logical struct {
+	or          bool
+	left, right Expr
+}
+
+func (e logical) eval(env map[string]interface{}) interface{} {
+	left := Match(e.left, env)
+	if left == e.or {
+		return left
+	}
+
+	return Match(e.right, env)
+}
+
+type not struct {
+	operand Expr
+}
+
+func (e not) eval(env map[string]interface{}) interface{} {
+	return !Match(e.operand, env)
+}
+
+type comparison struct {
+	op          string
+	left, right Expr
+}
+
+func (e comparison) eval(env map[string]interface{}) interface{} {
+	left, right := e.left.eval(env), e.right.eval(env)
+
+	switch e.op {
+	case "==":
+		return equal(left, right)
+	case "!=":
+		return !equal(left, right)
+	case "in":
+		return contains(right, left)
+	}
+
+	if l, ok := left.(float64); ok {
+		if r, ok := right.(float64); ok {
+			return compare(e.op, l < r, l == r)
+		}
+	}
+	if l, ok := left.(string); ok {
+		if r, ok := right.(string); ok {
+			return compare(e.op, l < r, l == r)
+		}
+	}
+
+	return false
+}
+
+// compare returns the result of the ordering operator.
+func compare(op string, less, equal bool) bool {
+	switch op {
+	case "<":
+		return less
+	case "<=":
+		return less || equal
+	case ">":
+		return !less && !equal
+	}
+
+	return !less
+}
+
+type call struct {
+	name string
+	f    func(args []interface{}) interface{}
+	args []Expr
+}
+
+func (e call) eval(env map[string]interface{}) interface{} {
+	args := make([]interface{}, len(e.args))
+	for i, arg := range e.args {
+		args[i] = arg.eval(env)
+	}
+
+	return e.f(args)
+}
+
+// functions lists the functions of the language with their arities.
+var functions = map[string]struct {
+	arity int
+	f     func(args []interface{}) interface{}
+}{
+	"len": {1, func(args []interface{}) interface{} {
+		switch v := args[0].(type) {
+		case []interface{}:
+			return float64(len(v))
+		case string:
+			return float64(len(v))
+		}
+		return float64(0)
+	}},
+	"contains": {2, func(args []interface{}) interface{} {
+		return contains(args[0], args[1])
+	}},
+	"only": {2, func(args []interface{}) interface{} {
+		values, ok := args[0].([]interface{})
+		if !ok || len(values) == 0 {
+			return false
+		}
+		for _, v := range values {
+			if !equal(v, args[1]) {
+				return false
+			}
+		}
+		return true
+	}},
+}
+
+// contains reports whether the list contains the value.
+func contains(list, value interface{}) bool {
+	values, ok := list.([]interface{})
+	if !ok {
+		return false
+	}
+	for _, v := range values {
+		if equal(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// equal reports whether the values are equal.
+func equal(a, b interface{}) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+// normalize converts the value of the environment to the types of the language:
+// float64, string, bool, []interface{}, map[string]interface{} or nil.
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil, bool, float64, string, []interface{}, map[string]interface{}:
+		return v
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	case reflect.Float32:
+		return rv.Float()
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.Slice, reflect.Array:
+		values := make([]interface{}, rv.Len())
+		for i := range values {
+			values[i] = normalize(rv.Index(i).Interface())
+		}
+		return values
+	case reflect.Map:
+		if rv.Type().Key().Kind() == reflect.String {
+			values := map[string]interface{}{}
+			for _, key := range rv.MapKeys() {
+				values[key.String()] = rv.MapIndex(key).Interface()
+			}
+			return values
+		}
+	}
+
+	return nil
+}
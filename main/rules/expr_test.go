@@ -0,0 +1,84 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompile(t *testing.T) {
+	assert := assert.New(t)
+
+	env := map[string]interface{}{
+		"status": "Denied",
+		"signals": map[string]interface{}{
+			"hits":           2,
+			"hitTypes":       []string{"adverse-media"},
+			"sanctionsMatch": false,
+			"riskLevel":      "medium",
+		},
+		"document": map[string]interface{}{
+			"expiresInDays": float64(12),
+		},
+	}
+
+	testCases := []struct {
+		source string
+		match  bool
+	}{
+		{`status == "Denied"`, true},
+		{`status != 'Denied'`, false},
+		{`signals.hits > 1 && signals.hits <= 2`, true},
+		{`signals.hits >= 3 || signals.riskLevel == "medium"`, true},
+		{`!signals.sanctionsMatch`, true},
+		{`signals.sanctionsMatch`, false},
+		{`only(signals.hitTypes, "adverse-media")`, true},
+		{`contains(signals.hitTypes, "sanction")`, false},
+		{`len(signals.hitTypes) == 1`, true},
+		{`signals.riskLevel in ["medium", "high"]`, true},
+		{`status in []`, false},
+		{`document.expiresInDays < 30`, true},
+		{`(status == "Approved" || status == "Denied") && !(signals.hits == 0)`, true},
+		{`customer.age < 18`, false},
+		{`customer.age == null`, true},
+		{`signals.hits == "2"`, false},
+		{`signals.riskLevel`, false},
+	}
+
+	for _, tc := range testCases {
+		expr, err := Compile(tc.source)
+
+		if assert.NoError(err, tc.source) {
+			assert.Equal(tc.match, Match(expr, env), tc.source)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	testCases := []struct {
+		source string
+		err    string
+	}{
+		{``, "unexpected end of expression at position 0"},
+		{`status == `, "unexpected end of expression at position 10"},
+		{`status = "Denied"`, "unexpected character '=' at position 7"},
+		{`status == "Denied`, "unterminated string at position 10"},
+		{`(status == "Denied"`, "expected ')' instead of end of expression at position 19"},
+		{`status == "Denied" status`, "unexpected 'status' at position 19"},
+		{`size(reasons) > 0`, "unknown function 'size' at position 0"},
+		{`contains(reasons)`, "function 'contains' expects 2 arguments at position 0"},
+		{`signals.hits > 1.2.3`, "invalid number '1.2.3' at position 15"},
+		{`status in ["Denied",]`, "unexpected ']' at position 20"},
+	}
+
+	for _, tc := range testCases {
+		expr, err := Compile(tc.source)
+
+		assert.Nil(expr, tc.source)
+		if assert.Error(err, tc.source) {
+			assert.Equal(tc.err, err.Error(), tc.source)
+		}
+	}
+}
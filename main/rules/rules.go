@@ -0,0 +1,271 @@
+// Package rules applies the declarative decision rules to the verification results of the KYC providers.
+// The rules are loaded from the YAML file so the compliance policy may be changed without a redeploy.
+// The rules are evaluated in order on the final results and the first matched rule overrides the status.
+package rules
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/logging"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileOption is the name of the option in the service config section holding the path of the rules file.
+const FileOption = "RulesFile"
+
+// Rule represents the decision rule.
+//
+// * Name identifies the rule in the reasons of the results and in the logs.
+// * Provider restricts the rule to the results of the provider if specified.
+// * When is the condition of the rule, e.g. signals.sanctionsMatch && signals.dobMatch.
+// * Then is the status set by the rule: Approved, Denied or Unclear.
+// * Reason is appended to the reasons of the result along with the rule name.
+type Rule struct {
+	Name     string             `yaml:"name"`
+	Provider common.KYCProvider `yaml:"provider"`
+	When     string             `yaml:"when"`
+	Then     string             `yaml:"then"`
+	Reason   string             `yaml:"reason"`
+	status   common.KYCStatus
+	expr     Expr
+}
+
+// compile validates the rule and compiles its condition.
+func (r *Rule) compile() (err error) {
+	if len(r.Name) == 0 {
+		return errors.New("missing rule name")
+	}
+	if len(r.Provider) > 0 && !common.KnownProvider(r.Provider) && r.Provider != common.Example {
+		return fmt.Errorf("rule %s: unknown provider %s", r.Name, r.Provider)
+	}
+	switch r.Then {
+	case common.KYCStatus2Status[common.Approved]:
+		r.status = common.Approved
+	case common.KYCStatus2Status[common.Denied]:
+		r.status = common.Denied
+	case common.KYCStatus2Status[common.Unclear]:
+		r.status = common.Unclear
+	default:
+		return fmt.Errorf("rule %s: invalid status '%s'", r.Name, r.Then)
+	}
+	if len(r.When) == 0 {
+		return fmt.Errorf("rule %s: missing condition", r.Name)
+	}
+	if r.expr, err = Compile(r.When); err != nil {
+		return fmt.Errorf("rule %s: %s", r.Name, err)
+	}
+
+	return
+}
+
+// Config holds the decision rules in the order of their evaluation.
+type Config struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Parse parses and compiles the rules from the YAML data.
+func Parse(data []byte) (config Config, err error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	if err = decoder.Decode(&config); err != nil {
+		config = Config{}
+		return
+	}
+
+	names := map[string]bool{}
+	for i := range config.Rules {
+		if err = config.Rules[i].compile(); err != nil {
+			config = Config{}
+			return
+		}
+		if names[config.Rules[i].Name] {
+			err = fmt.Errorf("duplicate rule %s", config.Rules[i].Name)
+			config = Config{}
+			return
+		}
+		names[config.Rules[i].Name] = true
+	}
+
+	return
+}
+
+// ConfigFromOptions loads the rules from the file specified in the service config section.
+// No rules are applied if the file isn't specified.
+func ConfigFromOptions(options map[string]string) (config Config, err error) {
+	path := options[FileOption]
+	if len(path) == 0 {
+		return
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err == nil {
+		config, err = Parse(data)
+	}
+	if err != nil {
+		err = fmt.Errorf("invalid option '%s': %s", FileOption, err)
+	}
+
+	return
+}
+
+// Environment returns the variables the conditions of the rules are evaluated with.
+//
+// * provider, status, finality, reasons and errorCode describe the result of the provider;
+// * signals holds the raw provider data, e.g. signals.hitTypes;
+// * customer.country, customer.nationality and customer.age (in years) describe the customer;
+// * document.expiresInDays is the number of days until the earliest expiration of the customer's documents.
+//
+// The customer and the document variables are null for the results of the status checks.
+func Environment(provider common.KYCProvider, customer *common.UserData, result common.KYCResult, now time.Time) map[string]interface{} {
+	env := map[string]interface{}{
+		"provider":  string(provider),
+		"status":    common.KYCStatus2Status[result.Status],
+		"finality":  "",
+		"reasons":   []interface{}{},
+		"errorCode": result.ErrorCode,
+		"signals":   map[string]interface{}(result.Signals),
+	}
+	if result.Details != nil {
+		env["finality"] = common.KYCFinality2Finality[result.Details.Finality]
+		env["reasons"] = normalize(result.Details.Reasons)
+	}
+
+	if customer == nil {
+		return env
+	}
+
+	c := map[string]interface{}{
+		"country":     customer.CountryAlpha2,
+		"nationality": customer.Nationality,
+	}
+	if dob := time.Time(customer.DateOfBirth); !dob.IsZero() {
+		age := now.Year() - dob.Year()
+		if now.Month() < dob.Month() || now.Month() == dob.Month() && now.Day() < dob.Day() {
+			age--
+		}
+		c["age"] = float64(age)
+	}
+	env["customer"] = c
+
+	d := map[string]interface{}{}
+	expires := []common.Time{}
+	if customer.Passport != nil {
+		expires = append(expires, customer.Passport.ValidUntil)
+	}
+	if customer.IDCard != nil {
+		expires = append(expires, customer.IDCard.ValidUntil)
+	}
+	if customer.DriverLicense != nil {
+		expires = append(expires, customer.DriverLicense.ValidUntil)
+	}
+	if customer.ResidencePermit != nil {
+		expires = append(expires, customer.ResidencePermit.ValidUntil)
+	}
+	days := math.Inf(1)
+	for _, e := range expires {
+		if t := time.Time(e); !t.IsZero() {
+			days = math.Min(days, math.Floor(t.Sub(now).Hours()/24))
+		}
+	}
+	if !math.IsInf(days, 1) {
+		d["expiresInDays"] = days
+	}
+	env["document"] = d
+
+	return env
+}
+
+// Engine applies the decision rules to the verification results.
+type Engine struct {
+	rules []Rule
+	now   func() time.Time
+}
+
+// New returns the engine applying the rules of the config.
+func New(config Config) *Engine {
+	return &Engine{
+		rules: config.Rules,
+		now:   time.Now,
+	}
+}
+
+// Apply applies the first rule matching the final result of the provider.
+// The status of the result is overridden and the reason of the rule is appended to the reasons.
+// The failed and the pending results are returned unchanged.
+func (e *Engine) Apply(provider common.KYCProvider, customer *common.UserData, result common.KYCResult) common.KYCResult {
+	if len(e.rules) == 0 || result.Status == common.Error || !result.IsFinal() {
+		return result
+	}
+
+	env := Environment(provider, customer, result, e.now())
+
+	for _, rule := range e.rules {
+		if len(rule.Provider) > 0 && rule.Provider != provider || !Match(rule.expr, env) {
+			continue
+		}
+
+		slog.Info("Decision rule applied", "rule", rule.Name, logging.ProviderKey, provider, "status", common.KYCStatus2Status[result.Status], "decision", rule.Then)
+
+		reason := "Rule " + rule.Name
+		if len(rule.Reason) > 0 {
+			reason += ": " + rule.Reason
+		}
+
+		details := common.KYCDetails{}
+		if result.Details != nil {
+			details = *result.Details
+			details.Reasons = append([]string{}, details.Reasons...)
+			details.ReasonCodes = append([]string(nil), details.ReasonCodes...)
+		}
+		details.AddReason("rule."+rule.Name, reason)
+
+		result.Status = rule.status
+		result.Details = &details
+
+		break
+	}
+
+	return result
+}
+
+var (
+	mu      sync.RWMutex
+	current *Engine
+)
+
+// Setup sets up the decision rules of the service using the config.
+func Setup(config Config) {
+	var e *Engine
+	if len(config.Rules) > 0 {
+		e = New(config)
+	}
+
+	mu.Lock()
+	current = e
+	mu.Unlock()
+}
+
+// Apply applies the decision rules of the service to the result of the provider.
+// The customer is nil for the results of the status checks.
+// The result is returned unchanged if the rules aren't set up.
+func Apply(provider common.KYCProvider, customer *common.UserData, result common.KYCResult) common.KYCResult {
+	mu.RLock()
+	e := current
+	mu.RUnlock()
+
+	if e == nil {
+		return result
+	}
+
+	return e.Apply(provider, customer, result)
+}
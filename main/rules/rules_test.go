@@ -0,0 +1,269 @@
+package rules
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"modulus/kyc/common"
+	// Make the registered KYC providers known to the tests.
+	_ "modulus/kyc/integrations"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testRules = `
+rules:
+  - name: sanctions-dob
+    provider: ComplyAdvantage
+    when: signals.sanctionsMatch && signals.dobMatch
+    then: Denied
+    reason: sanctions match with the date of birth
+  - name: adverse-media-only
+    provider: ComplyAdvantage
+    when: status == "Denied" && only(signals.hitTypes, "adverse-media")
+    then: Approved
+  - name: expiring-document
+    when: status == "Approved" && document.expiresInDays < 30
+    then: Unclear
+    reason: the document expires in less than 30 days
+`
+
+// date returns the time of the date in UTC.
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParse(t *testing.T) {
+	assert := assert.New(t)
+
+	config, err := Parse([]byte(testRules))
+
+	assert.NoError(err)
+	if assert.Len(config.Rules, 3) {
+		assert.Equal("sanctions-dob", config.Rules[0].Name)
+		assert.Equal(common.ComplyAdvantage, config.Rules[0].Provider)
+		assert.Equal(common.Denied, config.Rules[0].status)
+		assert.Equal(common.Approved, config.Rules[1].status)
+		assert.Equal(common.Unclear, config.Rules[2].status)
+		assert.Empty(config.Rules[2].Provider)
+	}
+
+	testCases := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{"missing name", "rules:\n  - when: true\n    then: Denied\n", "missing rule name"},
+		{"unknown provider", "rules:\n  - name: r\n    provider: Acme\n    when: true\n    then: Denied\n", "rule r: unknown provider Acme"},
+		{"invalid status", "rules:\n  - name: r\n    when: true\n    then: Review\n", "rule r: invalid status 'Review'"},
+		{"missing condition", "rules:\n  - name: r\n    then: Denied\n", "rule r: missing condition"},
+		{"invalid condition", "rules:\n  - name: r\n    when: status ==\n    then: Denied\n", "rule r: unexpected end of expression at position 9"},
+		{"duplicate rule", "rules:\n  - name: r\n    when: true\n    then: Denied\n  - name: r\n    when: false\n    then: Approved\n", "duplicate rule r"},
+		{"unknown field", "rules:\n  - name: r\n    if: true\n    then: Denied\n", "yaml: unmarshal errors:\n  line 3: field if not found in type rules.Rule"},
+	}
+
+	for _, tc := range testCases {
+		config, err := Parse([]byte(tc.data))
+
+		assert.Empty(config.Rules, tc.name)
+		if assert.Error(err, tc.name) {
+			assert.Equal(tc.err, err.Error(), tc.name)
+		}
+	}
+}
+
+func TestConfigFromOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "rules")
+	if !assert.NoError(err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "rules.yml")
+	if !assert.NoError(ioutil.WriteFile(path, []byte(testRules), 0600)) {
+		return
+	}
+
+	// Testing the rules aren't configured.
+	config, err := ConfigFromOptions(map[string]string{})
+
+	assert.NoError(err)
+	assert.Empty(config.Rules)
+
+	// Testing the rules file.
+	config, err = ConfigFromOptions(map[string]string{FileOption: path})
+
+	assert.NoError(err)
+	assert.Len(config.Rules, 3)
+
+	// Testing the missing file.
+	_, err = ConfigFromOptions(map[string]string{FileOption: filepath.Join(dir, "missing.yml")})
+
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "invalid option 'RulesFile': open ")
+	}
+
+	// Testing the invalid rules.
+	if !assert.NoError(ioutil.WriteFile(path, []byte("rules:\n  - name: r\n    when: true\n    then: Maybe\n"), 0600)) {
+		return
+	}
+
+	_, err = ConfigFromOptions(map[string]string{FileOption: path})
+
+	if assert.Error(err) {
+		assert.Equal("invalid option 'RulesFile': rule r: invalid status 'Maybe'", err.Error())
+	}
+}
+
+func TestEnvironment(t *testing.T) {
+	assert := assert.New(t)
+
+	now := date(2026, 10, 18)
+	customer := &common.UserData{
+		CountryAlpha2: "GB",
+		Nationality:   "GB",
+		DateOfBirth:   common.Time(date(2000, 10, 19)),
+		Passport:      &common.Passport{ValidUntil: common.Time(date(2027, 10, 18))},
+		IDCard:        &common.IDCard{ValidUntil: common.Time(date(2026, 11, 7))},
+		DriverLicense: &common.DriverLicense{},
+	}
+	result := common.KYCResult{
+		Status:  common.Denied,
+		Details: &common.KYCDetails{Finality: common.Final, Reasons: []string{"hit"}},
+		Signals: common.Signals{"hits": 1},
+	}
+
+	env := Environment(common.ComplyAdvantage, customer, result, now)
+
+	assert.Equal(map[string]interface{}{
+		"provider":  "ComplyAdvantage",
+		"status":    "Denied",
+		"finality":  "Final",
+		"reasons":   []interface{}{"hit"},
+		"errorCode": "",
+		"signals":   map[string]interface{}{"hits": 1},
+		"customer":  map[string]interface{}{"country": "GB", "nationality": "GB", "age": float64(25)},
+		"document":  map[string]interface{}{"expiresInDays": float64(20)},
+	}, env)
+
+	// Testing the result of the status check.
+	env = Environment(common.IDology, nil, common.KYCResult{Status: common.Approved}, now)
+
+	assert.Equal(map[string]interface{}{
+		"provider":  "IDology",
+		"status":    "Approved",
+		"finality":  "",
+		"reasons":   []interface{}{},
+		"errorCode": "",
+		"signals":   map[string]interface{}(nil),
+	}, env)
+}
+
+func TestApply(t *testing.T) {
+	assert := assert.New(t)
+
+	config, err := Parse([]byte(testRules))
+	if !assert.NoError(err) {
+		return
+	}
+
+	e := New(config)
+	e.now = func() time.Time {
+		return date(2026, 10, 18)
+	}
+
+	// Testing the sanctions match with the date of birth.
+	result := e.Apply(common.ComplyAdvantage, nil, common.KYCResult{
+		Status:  common.Approved,
+		Signals: common.Signals{"sanctionsMatch": true, "dobMatch": true, "hitTypes": []string{"sanction"}},
+	})
+
+	assert.Equal(common.Denied, result.Status)
+	assert.Equal(&common.KYCDetails{
+		Reasons:     []string{"Rule sanctions-dob: sanctions match with the date of birth"},
+		ReasonCodes: []string{"rule.sanctions-dob"},
+	}, result.Details)
+
+	// Testing the adverse media hits are approved.
+	details := &common.KYCDetails{Reasons: []string{"Search ID: 1"}}
+	result = e.Apply(common.ComplyAdvantage, nil, common.KYCResult{
+		Status:  common.Denied,
+		Details: details,
+		Signals: common.Signals{"sanctionsMatch": false, "hitTypes": []string{"adverse-media"}},
+	})
+
+	assert.Equal(common.Approved, result.Status)
+	assert.Equal([]string{"Search ID: 1", "Rule adverse-media-only"}, result.Details.Reasons)
+	assert.Equal([]string{"", "rule.adverse-media-only"}, result.Details.ReasonCodes)
+	assert.Equal([]string{"Search ID: 1"}, details.Reasons)
+
+	// Testing the rule of the other provider isn't applied.
+	result = e.Apply(common.IDology, nil, common.KYCResult{
+		Status:  common.Denied,
+		Signals: common.Signals{"hitTypes": []string{"adverse-media"}},
+	})
+
+	assert.Equal(common.Denied, result.Status)
+	assert.Nil(result.Details)
+
+	// Testing the expiring document.
+	customer := &common.UserData{Passport: &common.Passport{ValidUntil: common.Time(date(2026, 11, 1))}}
+
+	result = e.Apply(common.IDology, customer, common.KYCResult{Status: common.Approved})
+
+	assert.Equal(common.Unclear, result.Status)
+	assert.Equal([]string{"Rule expiring-document: the document expires in less than 30 days"}, result.Details.Reasons)
+
+	customer.Passport.ValidUntil = common.Time(date(2027, 11, 1))
+
+	result = e.Apply(common.IDology, customer, common.KYCResult{Status: common.Approved})
+
+	assert.Equal(common.Approved, result.Status)
+
+	// Testing the failed and the pending results are left unchanged.
+	customer.Passport.ValidUntil = common.Time(date(2026, 11, 1))
+
+	result = e.Apply(common.IDology, customer, common.KYCResult{Status: common.Error})
+
+	assert.Equal(common.Error, result.Status)
+
+	pending := common.KYCResult{
+		Status:      common.Unclear,
+		StatusCheck: &common.KYCStatusCheck{Provider: common.IDology, ReferenceID: "ref"},
+	}
+
+	assert.Equal(pending, e.Apply(common.IDology, customer, pending))
+}
+
+func TestService(t *testing.T) {
+	assert := assert.New(t)
+
+	defer Setup(Config{})
+
+	result := common.KYCResult{
+		Status:  common.Denied,
+		Signals: common.Signals{"hitTypes": []string{"adverse-media"}},
+	}
+
+	// Testing the rules aren't set up.
+	assert.Equal(result, Apply(common.ComplyAdvantage, nil, result))
+
+	config, err := Parse([]byte(testRules))
+	if !assert.NoError(err) {
+		return
+	}
+
+	Setup(config)
+
+	assert.Equal(common.Approved, Apply(common.ComplyAdvantage, nil, result).Status)
+
+	// Testing the reload removing the rules.
+	Setup(Config{})
+
+	assert.Equal(result, Apply(common.ComplyAdvantage, nil, result))
+}
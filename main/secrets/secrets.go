@@ -0,0 +1,157 @@
+// Package secrets encrypts the option values kept in the service config.
+// The encrypted value is the Prefix followed by the base64-encoded nonce and the AES-256-GCM sealed value.
+// The key is taken from the KeyProvider: the local key file or the environment variable are supported,
+// and the KMS or Vault backed providers may implement the same interface.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// Prefix marks the encrypted option values.
+const Prefix = "enc:"
+
+// KeySize is the size of the AES-256 key in bytes.
+const KeySize = 32
+
+// The names of the environment variables holding the key or the path to the key file.
+const (
+	KeyEnv     = "KYC_ENCRYPTION_KEY"
+	KeyFileEnv = "KYC_ENCRYPTION_KEY_FILE"
+)
+
+// ErrNoKey is returned when no key is configured to decrypt the values.
+var ErrNoKey = fmt.Errorf("no encryption key: set %s or %s", KeyEnv, KeyFileEnv)
+
+// ErrMalformed is returned when the encrypted value isn't properly encoded.
+var ErrMalformed = errors.New("malformed encrypted value")
+
+// KeyProvider defines the source of the key encrypting the option values.
+type KeyProvider interface {
+	// Key returns the AES-256 key.
+	Key() ([]byte, error)
+}
+
+// StaticKey is the KeyProvider of the key in the base64 encoding.
+type StaticKey string
+
+// Key implements KeyProvider interface for the StaticKey.
+func (k StaticKey) Key() ([]byte, error) {
+	return decodeKey([]byte(k))
+}
+
+// KeyFile is the KeyProvider of the key kept in the file in the base64 encoding.
+type KeyFile string
+
+// Key implements KeyProvider interface for the KeyFile.
+func (f KeyFile) Key() ([]byte, error) {
+	data, err := ioutil.ReadFile(string(f))
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeKey(data)
+}
+
+// EnvKey is the KeyProvider of the key from the KeyEnv environment variable
+// or from the file specified by the KeyFileEnv environment variable.
+type EnvKey struct{}
+
+// Key implements KeyProvider interface for the EnvKey.
+func (EnvKey) Key() ([]byte, error) {
+	if key := os.Getenv(KeyEnv); len(key) > 0 {
+		return StaticKey(key).Key()
+	}
+	if path := os.Getenv(KeyFileEnv); len(path) > 0 {
+		return KeyFile(path).Key()
+	}
+
+	return nil, ErrNoKey
+}
+
+// decodeKey decodes the base64-encoded key and checks its size.
+func decodeKey(data []byte) (key []byte, err error) {
+	key, err = base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		err = fmt.Errorf("invalid encryption key: %s", err)
+		return
+	}
+	if len(key) != KeySize {
+		err = fmt.Errorf("invalid encryption key: %d bytes instead of %d", len(key), KeySize)
+	}
+
+	return
+}
+
+// IsEncrypted reports whether the option value is encrypted.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+// Encrypt encrypts the value using the key.
+func Encrypt(key []byte, value string) (encrypted string, err error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return
+	}
+
+	encrypted = Prefix + base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(value), nil))
+
+	return
+}
+
+// Decrypt decrypts the value encrypted by Encrypt using the key.
+func Decrypt(key []byte, encrypted string) (value string, err error) {
+	if !IsEncrypted(encrypted) {
+		err = ErrMalformed
+		return
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(encrypted, Prefix))
+	if err != nil {
+		err = ErrMalformed
+		return
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return
+	}
+	if len(sealed) < gcm.NonceSize()+gcm.Overhead() {
+		err = ErrMalformed
+		return
+	}
+
+	data, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		err = errors.New("wrong key or corrupted value")
+		return
+	}
+
+	value = string(data)
+
+	return
+}
+
+// newGCM constructs the AES-GCM cipher using the key.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,122 @@
+package secrets
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncrypt(t *testing.T) {
+	assert := assert.New(t)
+
+	key := make([]byte, KeySize)
+
+	encrypted, err := Encrypt(key, "secret")
+
+	assert.NoError(err)
+	assert.True(IsEncrypted(encrypted))
+	assert.NotContains(encrypted, "secret")
+
+	// Testing the values are encrypted with the random nonces.
+	again, err := Encrypt(key, "secret")
+
+	assert.NoError(err)
+	assert.NotEqual(encrypted, again)
+
+	value, err := Decrypt(key, encrypted)
+
+	assert.NoError(err)
+	assert.Equal("secret", value)
+
+	// Testing the wrong key.
+	key[0] = 1
+
+	_, err = Decrypt(key, encrypted)
+
+	assert.EqualError(err, "wrong key or corrupted value")
+
+	// Testing the malformed values.
+	_, err = Decrypt(key, "secret")
+
+	assert.Equal(ErrMalformed, err)
+
+	_, err = Decrypt(key, Prefix+"!!!")
+
+	assert.Equal(ErrMalformed, err)
+
+	_, err = Decrypt(key, Prefix+base64.StdEncoding.EncodeToString([]byte("short")))
+
+	assert.Equal(ErrMalformed, err)
+
+	// Testing the key of the wrong size.
+	_, err = Encrypt(key[:10], "secret")
+
+	assert.Error(err)
+}
+
+func TestKeyProviders(t *testing.T) {
+	assert := assert.New(t)
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", KeySize)))
+
+	// Testing the static key.
+	key, err := StaticKey(encoded).Key()
+
+	assert.NoError(err)
+	assert.Len(key, KeySize)
+
+	_, err = StaticKey("a2V5").Key()
+
+	assert.EqualError(err, "invalid encryption key: 3 bytes instead of 32")
+
+	_, err = StaticKey("key!").Key()
+
+	assert.Error(err)
+
+	// Testing the key file.
+	dir, err := ioutil.TempDir("", "secrets")
+	if !assert.NoError(err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "kyc.key")
+	ioutil.WriteFile(path, []byte(encoded+"\n"), 0600)
+
+	key, err = KeyFile(path).Key()
+
+	assert.NoError(err)
+	assert.Len(key, KeySize)
+
+	_, err = KeyFile(filepath.Join(dir, "missing.key")).Key()
+
+	assert.Error(err)
+
+	// Testing the environment variables.
+	t.Setenv(KeyEnv, "")
+	t.Setenv(KeyFileEnv, "")
+
+	_, err = EnvKey{}.Key()
+
+	assert.Equal(ErrNoKey, err)
+
+	t.Setenv(KeyFileEnv, path)
+
+	key, err = EnvKey{}.Key()
+
+	assert.NoError(err)
+	assert.Len(key, KeySize)
+
+	t.Setenv(KeyEnv, encoded)
+	t.Setenv(KeyFileEnv, filepath.Join(dir, "missing.key"))
+
+	key, err = EnvKey{}.Key()
+
+	assert.NoError(err)
+	assert.Len(key, KeySize)
+}
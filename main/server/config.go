@@ -0,0 +1,90 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"modulus/kyc/main/option"
+)
+
+// The names of the HTTP server options in the service config section.
+const (
+	ReadTimeoutOption     = "HTTPReadTimeout"
+	WriteTimeoutOption    = "HTTPWriteTimeout"
+	IdleTimeoutOption     = "HTTPIdleTimeout"
+	MaxBodySizeOption     = "HTTPMaxBodySize"
+	ShutdownTimeoutOption = "ShutdownTimeout"
+	TLSCertOption         = "TLSCert"
+	TLSKeyOption          = "TLSKey"
+)
+
+// The default values of the HTTP server options.
+// The write timeout is disabled by default. The synchronous verification may chain several provider calls,
+// e.g. the strategy or the document uploads, and each of them may take up to the 5-minute timeout of the provider
+// HTTP client, so the write timeout has to exceed the provider timeout times the number of the chained calls.
+const (
+	DefaultReadTimeout     = 30 * time.Second
+	DefaultWriteTimeout    = 0
+	DefaultIdleTimeout     = 2 * time.Minute
+	DefaultMaxBodySize     = 32 << 20
+	DefaultShutdownTimeout = 30 * time.Second
+)
+
+// Config holds the settings of the HTTP server.
+//
+// * ReadTimeout limits the time of reading the request including its body.
+// * WriteTimeout limits the time from the end of reading the request headers to the end of writing the response. Zero means no limit.
+// * IdleTimeout limits the time the keep-alive connection waits for the next request.
+// * MaxBodySize limits the size of the request body in bytes.
+// * ShutdownTimeout limits the wait for the pending requests and the background jobs on the shutdown.
+// * TLSCert and TLSKey are the paths to the PEM-encoded certificate and key. The server uses plain HTTP if they're empty.
+type Config struct {
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	IdleTimeout     time.Duration
+	MaxBodySize     int64
+	ShutdownTimeout time.Duration
+	TLSCert         string
+	TLSKey          string
+}
+
+// ConfigFromOptions parses the HTTP server options from the service config section.
+// Absent options take their default values.
+func ConfigFromOptions(options map[string]string) (config Config, err error) {
+	config = Config{
+		ReadTimeout:     DefaultReadTimeout,
+		WriteTimeout:    DefaultWriteTimeout,
+		IdleTimeout:     DefaultIdleTimeout,
+		MaxBodySize:     DefaultMaxBodySize,
+		ShutdownTimeout: DefaultShutdownTimeout,
+		TLSCert:         options[TLSCertOption],
+		TLSKey:          options[TLSKeyOption],
+	}
+
+	if err = option.Duration(options, ReadTimeoutOption, &config.ReadTimeout); err != nil {
+		return
+	}
+	if err = option.NonNegativeDuration(options, WriteTimeoutOption, &config.WriteTimeout); err != nil {
+		return
+	}
+	if err = option.Duration(options, IdleTimeoutOption, &config.IdleTimeout); err != nil {
+		return
+	}
+	if err = option.Duration(options, ShutdownTimeoutOption, &config.ShutdownTimeout); err != nil {
+		return
+	}
+	if err = option.Int64(options, MaxBodySizeOption, &config.MaxBodySize); err != nil {
+		return
+	}
+
+	if (len(config.TLSCert) == 0) != (len(config.TLSKey) == 0) {
+		err = fmt.Errorf("options '%s' and '%s' must be set together", TLSCertOption, TLSKeyOption)
+	}
+
+	return
+}
+
+// TLS reports whether the server terminates TLS.
+func (c Config) TLS() bool {
+	return len(c.TLSCert) > 0
+}
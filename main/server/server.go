@@ -0,0 +1,180 @@
+// Package server runs the HTTP server of the REST API.
+// The server limits the time of reading the requests, writing the responses and keeping the idle connections,
+// and the size of the request bodies. It terminates TLS itself if the certificate is configured,
+// and the certificate is reloaded from its files without the restart.
+// On the shutdown the server stops accepting the connections and waits for the pending requests to complete,
+// so the verifications aren't cut off in the middle, e.g. between creating the applicant and uploading its documents.
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"modulus/kyc/main/logging"
+)
+
+// readHeaderTimeout limits the time of reading the request headers, so the slow clients can't hold the connections.
+const readHeaderTimeout = 10 * time.Second
+
+// ErrNoTLS is returned when the certificate is reloaded by the server not terminating TLS.
+var ErrNoTLS = errors.New("TLS isn't configured")
+
+// Server serves the HTTP requests using the settings from the config.
+type Server struct {
+	config Config
+	http   *http.Server
+	cert   atomic.Pointer[tls.Certificate]
+}
+
+// New constructs a new Server of the handler using the config.
+// The certificate is loaded if the server terminates TLS.
+func New(config Config, handler http.Handler) (s *Server, err error) {
+	s = &Server{config: config}
+
+	s.http = &http.Server{
+		Handler:           LimitBody(handler, config.MaxBodySize),
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       config.ReadTimeout,
+		WriteTimeout:      config.WriteTimeout,
+		IdleTimeout:       config.IdleTimeout,
+	}
+
+	if config.TLS() {
+		if err = s.ReloadCertificate(); err != nil {
+			s = nil
+			return
+		}
+		s.http.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: s.certificate,
+		}
+	}
+
+	return
+}
+
+// Serve accepts the connections on the listener until the server is shut down.
+// It returns nil once the server is shut down.
+func (s *Server) Serve(listener net.Listener) (err error) {
+	if s.config.TLS() {
+		err = s.http.ServeTLS(listener, "", "")
+	} else {
+		err = s.http.Serve(listener)
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		err = nil
+	}
+
+	return
+}
+
+// Shutdown stops accepting the connections and waits for the pending requests to complete until the context is done.
+// The connections still open then are closed.
+func (s *Server) Shutdown(ctx context.Context) (err error) {
+	if err = s.http.Shutdown(ctx); err != nil {
+		s.http.Close()
+	}
+
+	return
+}
+
+// ReloadCertificate loads the certificate and the key from their files.
+// The previous certificate is kept if the files can't be loaded.
+func (s *Server) ReloadCertificate() error {
+	if !s.config.TLS() {
+		return ErrNoTLS
+	}
+
+	cert, err := tls.LoadX509KeyPair(s.config.TLSCert, s.config.TLSKey)
+	if err != nil {
+		return err
+	}
+
+	s.cert.Store(&cert)
+
+	return nil
+}
+
+// certificate returns the current certificate for the TLS handshakes.
+func (s *Server) certificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return s.cert.Load(), nil
+}
+
+// LimitBody wraps the handler limiting the size of the request bodies.
+// The request declaring the larger body is rejected at once, and reading beyond the limit fails otherwise.
+func LimitBody(handler http.Handler, size int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > size {
+			http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, size)
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+var (
+	mu      sync.Mutex
+	current *Server
+)
+
+// Start starts serving the handler on the address in the background using the config.
+// The error is returned if the certificate can't be loaded or the address can't be listened on.
+func Start(config Config, addr string, handler http.Handler) error {
+	s, err := New(config, handler)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	current = s
+	mu.Unlock()
+
+	go func() {
+		if err := s.Serve(listener); err != nil {
+			slog.Error("HTTP server error", logging.ErrorKey, err)
+		}
+	}()
+
+	return nil
+}
+
+// Shutdown shuts down the service Server gracefully, see Server.Shutdown.
+func Shutdown(ctx context.Context) error {
+	mu.Lock()
+	s := current
+	current = nil
+	mu.Unlock()
+
+	if s == nil {
+		return nil
+	}
+
+	return s.Shutdown(ctx)
+}
+
+// ReloadCertificate reloads the certificate of the service Server if it terminates TLS.
+func ReloadCertificate() error {
+	mu.Lock()
+	s := current
+	mu.Unlock()
+
+	if s == nil || !s.config.TLS() {
+		return nil
+	}
+
+	return s.ReloadCertificate()
+}
@@ -0,0 +1,307 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeCertificate writes the self-signed certificate for the common name and its key to the files.
+func writeCertificate(t *testing.T, certFile, keyFile, commonName string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+}
+
+// serve starts serving the handler on the local port and returns the address.
+func serve(t *testing.T, s *Server) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go s.Serve(listener)
+	t.Cleanup(func() { s.Shutdown(context.Background()) })
+
+	return listener.Addr().String()
+}
+
+func TestConfigFromOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing the default config.
+	config, err := ConfigFromOptions(map[string]string{})
+
+	assert.NoError(err)
+	assert.Equal(Config{
+		ReadTimeout:     DefaultReadTimeout,
+		WriteTimeout:    DefaultWriteTimeout,
+		IdleTimeout:     DefaultIdleTimeout,
+		MaxBodySize:     DefaultMaxBodySize,
+		ShutdownTimeout: DefaultShutdownTimeout,
+	}, config)
+	assert.False(config.TLS())
+
+	// Testing the disabled write timeout.
+	config, err = ConfigFromOptions(map[string]string{WriteTimeoutOption: "0s"})
+
+	assert.NoError(err)
+	assert.Zero(config.WriteTimeout)
+
+	// Testing the custom config.
+	config, err = ConfigFromOptions(map[string]string{
+		ReadTimeoutOption:     "10s",
+		WriteTimeoutOption:    "5m",
+		IdleTimeoutOption:     "1m",
+		MaxBodySizeOption:     "1048576",
+		ShutdownTimeoutOption: "1m",
+		TLSCertOption:         "/etc/kyc/tls.crt",
+		TLSKeyOption:          "/etc/kyc/tls.key",
+	})
+
+	assert.NoError(err)
+	assert.Equal(Config{
+		ReadTimeout:     10 * time.Second,
+		WriteTimeout:    5 * time.Minute,
+		IdleTimeout:     time.Minute,
+		MaxBodySize:     1 << 20,
+		ShutdownTimeout: time.Minute,
+		TLSCert:         "/etc/kyc/tls.crt",
+		TLSKey:          "/etc/kyc/tls.key",
+	}, config)
+	assert.True(config.TLS())
+
+	// Testing the invalid options.
+	_, err = ConfigFromOptions(map[string]string{WriteTimeoutOption: "5"})
+
+	assert.EqualError(err, `invalid option 'HTTPWriteTimeout': time: missing unit in duration "5"`)
+
+	_, err = ConfigFromOptions(map[string]string{WriteTimeoutOption: "-1s"})
+
+	assert.EqualError(err, "invalid option 'HTTPWriteTimeout': negative duration")
+
+	_, err = ConfigFromOptions(map[string]string{ShutdownTimeoutOption: "0s"})
+
+	assert.EqualError(err, "invalid option 'ShutdownTimeout': non-positive duration")
+
+	_, err = ConfigFromOptions(map[string]string{MaxBodySizeOption: "-1"})
+
+	assert.EqualError(err, "invalid option 'HTTPMaxBodySize': non-positive number")
+
+	_, err = ConfigFromOptions(map[string]string{TLSCertOption: "/etc/kyc/tls.crt"})
+
+	assert.EqualError(err, "options 'TLSCert' and 'TLSKey' must be set together")
+}
+
+func TestLimitBody(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := LimitBody(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := ioutil.ReadAll(r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}), 4)
+
+	// Testing the body within the limit.
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/CheckCustomer", strings.NewReader("1234")))
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	// Testing the body declared larger than the limit.
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/CheckCustomer", strings.NewReader("12345")))
+
+	assert.Equal(http.StatusRequestEntityTooLarge, w.Code)
+
+	// Testing the body of unknown size larger than the limit.
+	r := httptest.NewRequest(http.MethodPost, "/CheckCustomer", strings.NewReader("12345"))
+	r.ContentLength = -1
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(http.StatusBadRequest, w.Code)
+	assert.Contains(w.Body.String(), "http: request body too large")
+}
+
+func TestTLS(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "tls")
+	if !assert.NoError(err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+
+	config, _ := ConfigFromOptions(map[string]string{TLSCertOption: certFile, TLSKeyOption: keyFile})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Pong!"))
+	})
+
+	// Testing the missing certificate.
+	_, err = New(config, handler)
+
+	assert.Error(err)
+
+	// Testing the server terminating TLS.
+	writeCertificate(t, certFile, keyFile, "kyc-1")
+
+	s, err := New(config, handler)
+	if !assert.NoError(err) {
+		return
+	}
+	addr := serve(t, s)
+
+	commonName := func() string {
+		conn, err := tls.Dial("tcp", addr, &tls.Config{InsecureSkipVerify: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+
+		return conn.ConnectionState().PeerCertificates[0].Subject.CommonName
+	}
+
+	assert.Equal("kyc-1", commonName())
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	resp, err := client.Get("https://" + addr + "/Ping")
+	if assert.NoError(err) {
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Equal("Pong!", string(body))
+	}
+
+	// Testing the reloaded certificate.
+	writeCertificate(t, certFile, keyFile, "kyc-2")
+
+	assert.NoError(s.ReloadCertificate())
+	assert.Equal("kyc-2", commonName())
+
+	// Testing the previous certificate is kept if the files are broken.
+	ioutil.WriteFile(keyFile, []byte("broken"), 0600)
+
+	assert.Error(s.ReloadCertificate())
+	assert.Equal("kyc-2", commonName())
+
+	// Testing the plain HTTP server doesn't reload the certificate.
+	s, err = New(Config{MaxBodySize: DefaultMaxBodySize}, handler)
+	assert.NoError(err)
+	assert.Equal(ErrNoTLS, s.ReloadCertificate())
+}
+
+func TestShutdown(t *testing.T) {
+	assert := assert.New(t)
+
+	started, release := make(chan struct{}), make(chan struct{})
+
+	config, _ := ConfigFromOptions(map[string]string{})
+	s, err := New(config, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.Write([]byte("done"))
+	}))
+	if !assert.NoError(err) {
+		return
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(err) {
+		return
+	}
+	served := make(chan error, 1)
+	go func() {
+		served <- s.Serve(listener)
+	}()
+
+	// Testing the pending request is completed on the shutdown.
+	responded := make(chan string, 1)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String() + "/CheckCustomer")
+		if err != nil {
+			responded <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+		responded <- string(body)
+	}()
+
+	<-started
+
+	shut := make(chan error, 1)
+	go func() {
+		shut <- s.Shutdown(context.Background())
+	}()
+
+	assert.NoError(<-served)
+
+	// The new connections are refused once the shutdown has begun.
+	_, err = net.Dial("tcp", listener.Addr().String())
+	assert.Error(err)
+
+	close(release)
+
+	assert.Equal("done", <-responded)
+	assert.NoError(<-shut)
+
+	// Testing the request still pending after the shutdown timeout is cut off.
+	started, release = make(chan struct{}), make(chan struct{})
+	defer close(release)
+
+	s, err = New(config, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	}))
+	if !assert.NoError(err) {
+		return
+	}
+	addr := serve(t, s)
+
+	go http.Get("http://" + addr + "/CheckCustomer")
+
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	assert.Equal(context.DeadlineExceeded, s.Shutdown(ctx))
+}
@@ -0,0 +1,362 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"modulus/kyc/common"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// The names of the BoltDB buckets.
+var (
+	verificationsBucket = []byte("verifications")
+	referencesBucket    = []byte("references")
+	fingerprintsBucket  = []byte("fingerprints")
+	keysBucket          = []byte("idempotency_keys")
+	usageBucket         = []byte("usage")
+)
+
+// keySeparator separates the parts of the composite keys.
+const keySeparator = 0
+
+// boltStore represents the Store embedded into the service using BoltDB.
+//
+// * The verifications bucket maps the verification id to its JSON.
+// * The references bucket maps the provider and the reference id to the latest verification id.
+// * The fingerprints bucket indexes the verification ids by the fingerprint.
+// * The idempotency keys bucket maps the client id and the idempotency key to the JSON of the key.
+// * The usage bucket maps the client id, the provider and the period to the count of the verification checks.
+type boltStore struct {
+	db *bolt.DB
+}
+
+// OpenBolt opens the BoltDB store in the file creating it if needed.
+func OpenBolt(path string) (s Store, err error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{verificationsBucket, referencesBucket, fingerprintsBucket, keysBucket, usageBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return
+	}
+
+	s = &boltStore{db: db}
+
+	return
+}
+
+// Create implements Store interface for the boltStore.
+func (s *boltStore) Create(verification Verification) error {
+	data, err := json.Marshal(verification)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) (err error) {
+		id := []byte(verification.ID)
+
+		if err = tx.Bucket(verificationsBucket).Put(id, data); err != nil {
+			return
+		}
+		if len(verification.ReferenceID) > 0 {
+			err = tx.Bucket(referencesBucket).Put(compositeKey(string(verification.Provider), verification.ReferenceID), id)
+			if err != nil {
+				return
+			}
+		}
+		err = tx.Bucket(fingerprintsBucket).Put(compositeKey(verification.Fingerprint, verification.ID), nil)
+
+		return
+	})
+}
+
+// AddTransition implements Store interface for the boltStore.
+func (s *boltStore) AddTransition(id string, transition Transition, final bool) error {
+	return s.db.Update(func(tx *bolt.Tx) (err error) {
+		bucket := tx.Bucket(verificationsBucket)
+
+		verification, err := getVerification(bucket, id)
+		if err != nil {
+			return
+		}
+
+		verification.Transitions = append(verification.Transitions, transition)
+		verification.Result = transition.Result
+		verification.Error = transition.Error
+		verification.Final = final
+		verification.Updated = transition.Time
+
+		data, err := json.Marshal(verification)
+		if err != nil {
+			return
+		}
+
+		err = bucket.Put([]byte(id), data)
+
+		return
+	})
+}
+
+// Get implements Store interface for the boltStore.
+func (s *boltStore) Get(id string) (verification Verification, err error) {
+	err = s.db.View(func(tx *bolt.Tx) (err error) {
+		verification, err = getVerification(tx.Bucket(verificationsBucket), id)
+		return
+	})
+
+	return
+}
+
+// FindByReference implements Store interface for the boltStore.
+func (s *boltStore) FindByReference(provider common.KYCProvider, referenceID string) (verification Verification, err error) {
+	err = s.db.View(func(tx *bolt.Tx) (err error) {
+		id := tx.Bucket(referencesBucket).Get(compositeKey(string(provider), referenceID))
+		if id == nil {
+			return ErrNotFound
+		}
+		verification, err = getVerification(tx.Bucket(verificationsBucket), string(id))
+		return
+	})
+
+	return
+}
+
+// FindByFingerprint implements Store interface for the boltStore.
+func (s *boltStore) FindByFingerprint(fingerprint string) (verifications []Verification, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(verificationsBucket)
+		prefix := compositeKey(fingerprint, "")
+		cursor := tx.Bucket(fingerprintsBucket).Cursor()
+
+		for k, _ := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = cursor.Next() {
+			verification, err := getVerification(bucket, string(k[len(prefix):]))
+			if err != nil {
+				return err
+			}
+			verifications = append(verifications, verification)
+		}
+
+		return nil
+	})
+	if err != nil {
+		verifications = nil
+		return
+	}
+
+	sort.SliceStable(verifications, func(i, j int) bool { return verifications[i].Created.Before(verifications[j].Created) })
+
+	return
+}
+
+// FindPending implements Store interface for the boltStore.
+func (s *boltStore) FindPending() (verifications []Verification, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(verificationsBucket)
+
+		return tx.Bucket(referencesBucket).ForEach(func(k, id []byte) error {
+			verification, err := getVerification(bucket, string(id))
+			if err != nil {
+				return err
+			}
+			if !verification.Final {
+				verifications = append(verifications, verification)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		verifications = nil
+		return
+	}
+
+	sort.SliceStable(verifications, func(i, j int) bool { return verifications[i].Created.Before(verifications[j].Created) })
+
+	return
+}
+
+// ReserveKey implements Store interface for the boltStore.
+func (s *boltStore) ReserveKey(key IdempotencyKey) (existing IdempotencyKey, reserved bool, err error) {
+	data, err := json.Marshal(key)
+	if err != nil {
+		return
+	}
+
+	err = s.db.Update(func(tx *bolt.Tx) (err error) {
+		bucket := tx.Bucket(keysBucket)
+		id := compositeKey(key.ClientID, key.Key)
+
+		if recorded := bucket.Get(id); recorded != nil {
+			if err = json.Unmarshal(recorded, &existing); err != nil {
+				return
+			}
+			if existing.Expires.After(key.Created) {
+				return
+			}
+			existing = IdempotencyKey{}
+		}
+
+		if err = bucket.Put(id, data); err != nil {
+			return
+		}
+		reserved = true
+
+		return
+	})
+
+	return
+}
+
+// CompleteKey implements Store interface for the boltStore.
+func (s *boltStore) CompleteKey(key IdempotencyKey) error {
+	data, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(keysBucket).Put(compositeKey(key.ClientID, key.Key), data)
+	})
+}
+
+// ReleaseKey implements Store interface for the boltStore.
+func (s *boltStore) ReleaseKey(clientID, key string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(keysBucket).Delete(compositeKey(clientID, key))
+	})
+}
+
+// PurgeKeys implements Store interface for the boltStore.
+// The expired keys are collected first since deleting them while iterating the cursor skips the keys.
+func (s *boltStore) PurgeKeys(now time.Time) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(keysBucket)
+
+		var expired [][]byte
+		err := bucket.ForEach(func(k, v []byte) error {
+			key := IdempotencyKey{}
+			if err := json.Unmarshal(v, &key); err != nil {
+				return err
+			}
+			if !key.Expires.After(now) {
+				expired = append(expired, append([]byte{}, k...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, k := range expired {
+			if err = bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// AddUsage implements Store interface for the boltStore.
+func (s *boltStore) AddUsage(client string, provider common.KYCProvider, day, month string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(usageBucket)
+
+		for _, period := range []string{day, month} {
+			k := usageKey(client, provider, period)
+
+			count := 0
+			if data := bucket.Get(k); data != nil {
+				var err error
+				if count, err = strconv.Atoi(string(data)); err != nil {
+					return err
+				}
+			}
+			if err := bucket.Put(k, []byte(strconv.Itoa(count+1))); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// FindUsage implements Store interface for the boltStore.
+func (s *boltStore) FindUsage(day, month string) (usage []Usage, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(usageBucket).ForEach(func(k, v []byte) error {
+			parts := bytes.SplitN(k, []byte{keySeparator}, 3)
+			if len(parts) != 3 {
+				return nil
+			}
+			if period := string(parts[2]); period != day && period != month {
+				return nil
+			}
+
+			count, err := strconv.Atoi(string(v))
+			if err != nil {
+				return err
+			}
+			usage = append(usage, Usage{
+				Client:   string(parts[0]),
+				Provider: common.KYCProvider(parts[1]),
+				Period:   string(parts[2]),
+				Count:    count,
+			})
+
+			return nil
+		})
+	})
+	if err != nil {
+		usage = nil
+	}
+
+	return
+}
+
+// Close implements Store interface for the boltStore.
+func (s *boltStore) Close() error {
+	return s.db.Close()
+}
+
+// getVerification reads the verification from the bucket.
+func getVerification(bucket *bolt.Bucket, id string) (verification Verification, err error) {
+	data := bucket.Get([]byte(id))
+	if data == nil {
+		err = ErrNotFound
+		return
+	}
+
+	err = json.Unmarshal(data, &verification)
+
+	return
+}
+
+// usageKey returns the key of the usage of the client using the provider in the period.
+func usageKey(client string, provider common.KYCProvider, period string) []byte {
+	return compositeKey(string(compositeKey(client, string(provider))), period)
+}
+
+// compositeKey joins the parts of the key with the separator.
+func compositeKey(first, second string) []byte {
+	key := make([]byte, 0, len(first)+len(second)+1)
+	key = append(key, first...)
+	key = append(key, keySeparator)
+	key = append(key, second...)
+
+	return key
+}
@@ -0,0 +1,240 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/events"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoltStore(t *testing.T) {
+	assert := assert.New(t)
+
+	s, path := openTestBolt(t)
+
+	first := NewVerification("first", common.Example, &common.UserData{FirstName: "Urbi"}, common.KYCResult{
+		Status: common.Unclear,
+		StatusCheck: &common.KYCStatusCheck{
+			Provider:    common.Example,
+			ReferenceID: "ref",
+		},
+	}, nil)
+	second := NewVerification("second", common.Example, &common.UserData{FirstName: "Urbi"}, common.KYCResult{
+		Status: common.Approved,
+	}, nil)
+	second.Created = first.Created.Add(time.Second)
+
+	assert.NoError(s.Create(second))
+	assert.NoError(s.Create(first))
+
+	// Testing the lookup by id.
+	verification, err := s.Get("first")
+
+	assert.NoError(err)
+	assert.Equal(first.ID, verification.ID)
+	assert.Equal(first.Result, verification.Result)
+	assert.True(first.Created.Equal(verification.Created))
+
+	_, err = s.Get("unknown")
+
+	assert.Equal(ErrNotFound, err)
+
+	// Testing the pending verifications.
+	verifications, err := s.FindPending()
+
+	assert.NoError(err)
+	if assert.Len(verifications, 1) {
+		assert.Equal("first", verifications[0].ID)
+	}
+
+	// Testing the transition.
+	now := time.Now()
+
+	err = s.AddTransition("first", Transition{
+		Result: &common.Result{Status: "Approved"},
+		Source: events.Callback,
+		Time:   now,
+	}, true)
+	assert.NoError(err)
+
+	err = s.AddTransition("unknown", Transition{Time: now}, true)
+
+	assert.Equal(ErrNotFound, err)
+
+	// Testing the lookup by reference id.
+	verification, err = s.FindByReference(common.Example, "ref")
+
+	assert.NoError(err)
+	assert.Equal("first", verification.ID)
+	assert.True(verification.Final)
+	assert.Equal("Approved", verification.Result.Status)
+	assert.True(now.Equal(verification.Updated))
+	assert.Len(verification.Transitions, 2)
+
+	_, err = s.FindByReference(common.IDology, "ref")
+
+	assert.Equal(ErrNotFound, err)
+
+	// Testing the lookup by fingerprint.
+	verifications, err = s.FindByFingerprint(first.Fingerprint)
+
+	assert.NoError(err)
+	if assert.Len(verifications, 2) {
+		assert.Equal("first", verifications[0].ID)
+		assert.Equal("second", verifications[1].ID)
+	}
+
+	verifications, err = s.FindByFingerprint(Fingerprint(&common.UserData{FirstName: "Abby"}))
+
+	assert.NoError(err)
+	assert.Empty(verifications)
+
+	// Testing the final verification isn't pending.
+	verifications, err = s.FindPending()
+
+	assert.NoError(err)
+	assert.Empty(verifications)
+
+	// Testing the verifications survived reopening.
+	assert.NoError(s.Close())
+
+	s, err = OpenBolt(path)
+	if !assert.NoError(err) {
+		return
+	}
+	defer s.Close()
+
+	verification, err = s.Get("second")
+
+	assert.NoError(err)
+	assert.Equal("Approved", verification.Result.Status)
+}
+
+func TestBoltIdempotencyKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	s, _ := openTestBolt(t)
+	defer s.Close()
+
+	now := time.Now()
+	key := IdempotencyKey{
+		Key:      "key",
+		ClientID: "backoffice",
+		Hash:     "hash",
+		Created:  now,
+		Expires:  now.Add(time.Minute),
+	}
+
+	// Testing the reservation of the new key.
+	_, reserved, err := s.ReserveKey(key)
+
+	assert.NoError(err)
+	assert.True(reserved)
+
+	// Testing the reservation of the key in progress.
+	existing, reserved, err := s.ReserveKey(key)
+
+	assert.NoError(err)
+	assert.False(reserved)
+	assert.Equal("hash", existing.Hash)
+	assert.False(existing.Completed)
+
+	// Testing the same key of another client.
+	other := key
+	other.ClientID = "another"
+
+	_, reserved, err = s.ReserveKey(other)
+
+	assert.NoError(err)
+	assert.True(reserved)
+
+	// Testing the completion.
+	key.Completed = true
+	key.Status = 200
+	key.Header = map[string][]string{"Content-Type": {"application/json"}}
+	key.Body = []byte(`{"Result":{"Status":"Approved"}}`)
+	key.Expires = now.Add(time.Hour)
+
+	assert.NoError(s.CompleteKey(key))
+
+	existing, reserved, err = s.ReserveKey(IdempotencyKey{Key: "key", ClientID: "backoffice", Hash: "other", Created: now})
+
+	assert.NoError(err)
+	assert.False(reserved)
+	assert.Equal(key.Body, existing.Body)
+	assert.Equal("application/json", existing.Header.Get("Content-Type"))
+	assert.Equal(200, existing.Status)
+	assert.True(existing.Completed)
+
+	// Testing the reservation of the expired key.
+	later := key
+	later.Created = now.Add(2 * time.Hour)
+	later.Completed = false
+
+	_, reserved, err = s.ReserveKey(later)
+
+	assert.NoError(err)
+	assert.True(reserved)
+
+	// Testing the release.
+	assert.NoError(s.ReleaseKey("backoffice", "key"))
+
+	_, reserved, err = s.ReserveKey(key)
+
+	assert.NoError(err)
+	assert.True(reserved)
+
+	// Testing the purge of the expired keys.
+	assert.NoError(s.PurgeKeys(now.Add(30 * time.Minute)))
+
+	_, reserved, err = s.ReserveKey(other)
+
+	assert.NoError(err)
+	assert.True(reserved)
+
+	_, reserved, err = s.ReserveKey(key)
+
+	assert.NoError(err)
+	assert.False(reserved)
+}
+
+func TestBoltUsage(t *testing.T) {
+	assert := assert.New(t)
+
+	s, path := openTestBolt(t)
+
+	// Testing the counting of the checks.
+	assert.NoError(s.AddUsage("backoffice", common.IDology, "2026-10-31", "2026-10"))
+	assert.NoError(s.AddUsage("backoffice", common.IDology, "2026-10-31", "2026-10"))
+	assert.NoError(s.AddUsage("", common.Jumio, "2026-10-30", "2026-10"))
+	assert.NoError(s.AddUsage("backoffice", common.IDology, "2026-09-30", "2026-09"))
+
+	usage, err := s.FindUsage("2026-10-31", "2026-10")
+
+	assert.NoError(err)
+	assert.ElementsMatch([]Usage{
+		{Client: "", Provider: common.Jumio, Period: "2026-10", Count: 1},
+		{Client: "backoffice", Provider: common.IDology, Period: "2026-10-31", Count: 2},
+		{Client: "backoffice", Provider: common.IDology, Period: "2026-10", Count: 2},
+	}, usage)
+
+	// Testing the usage survived reopening.
+	assert.NoError(s.Close())
+
+	s, err = OpenBolt(path)
+	if !assert.NoError(err) {
+		return
+	}
+	defer s.Close()
+
+	usage, err = s.FindUsage("2026-09-30", "2026-09")
+
+	assert.NoError(err)
+	assert.Equal([]Usage{
+		{Client: "backoffice", Provider: common.IDology, Period: "2026-09", Count: 1},
+		{Client: "backoffice", Provider: common.IDology, Period: "2026-09-30", Count: 1},
+	}, usage)
+}
@@ -0,0 +1,75 @@
+package store
+
+import (
+	"net/http"
+	"time"
+)
+
+// IdempotencyKey represents the recorded idempotency key of the request and the response to it.
+//
+// * Key is the value of the Idempotency-Key header. The keys of different API clients don't clash.
+// * ClientID is the identificator of the API client sent the request if the authentication is enabled.
+// * Hash is the SHA256 hash of the request. The replays of the key with another request are rejected.
+// * Completed is set once the response is saved. Until then the request is in progress.
+// * Status, Header and Body make up the saved response.
+// * Expires is the time after which the key is free to use again.
+type IdempotencyKey struct {
+	Key       string
+	ClientID  string
+	Hash      string
+	Completed bool
+	Status    int
+	Header    http.Header
+	Body      []byte
+	Created   time.Time
+	Expires   time.Time
+}
+
+// ReserveKey records the idempotency key in the service store unless the key of the client is recorded already.
+func ReserveKey(key IdempotencyKey) (existing IdempotencyKey, reserved bool, err error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current == nil {
+		err = ErrDisabled
+		return
+	}
+
+	return current.ReserveKey(key)
+}
+
+// CompleteKey saves the response to the request with the idempotency key in the service store.
+func CompleteKey(key IdempotencyKey) error {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current == nil {
+		return ErrDisabled
+	}
+
+	return current.CompleteKey(key)
+}
+
+// ReleaseKey removes the idempotency key of the client from the service store.
+func ReleaseKey(clientID, key string) error {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current == nil {
+		return ErrDisabled
+	}
+
+	return current.ReleaseKey(clientID, key)
+}
+
+// PurgeKeys removes the idempotency keys expired by the time from the service store.
+func PurgeKeys(now time.Time) error {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current == nil {
+		return ErrDisabled
+	}
+
+	return current.PurgeKeys(now)
+}
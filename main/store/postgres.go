@@ -0,0 +1,501 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/events"
+
+	// Register the Postgres driver for database/sql.
+	_ "github.com/lib/pq"
+)
+
+// schema creates the tables of the Postgres store if they don't exist.
+const schema = `
+CREATE TABLE IF NOT EXISTS kyc_verifications (
+	id           TEXT PRIMARY KEY,
+	fingerprint  TEXT NOT NULL,
+	provider     TEXT NOT NULL,
+	reference_id TEXT NOT NULL DEFAULT '',
+	client_id    TEXT NOT NULL DEFAULT '',
+	final        BOOLEAN NOT NULL DEFAULT FALSE,
+	result       JSONB,
+	error        TEXT NOT NULL DEFAULT '',
+	created_at   TIMESTAMPTZ NOT NULL,
+	updated_at   TIMESTAMPTZ NOT NULL
+);
+ALTER TABLE kyc_verifications ADD COLUMN IF NOT EXISTS client_id TEXT NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS kyc_verifications_reference_idx ON kyc_verifications (provider, reference_id);
+CREATE INDEX IF NOT EXISTS kyc_verifications_fingerprint_idx ON kyc_verifications (fingerprint);
+CREATE TABLE IF NOT EXISTS kyc_transitions (
+	id              BIGSERIAL PRIMARY KEY,
+	verification_id TEXT NOT NULL REFERENCES kyc_verifications (id) ON DELETE CASCADE,
+	result          JSONB,
+	error           TEXT NOT NULL DEFAULT '',
+	source          TEXT NOT NULL,
+	created_at      TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS kyc_transitions_verification_idx ON kyc_transitions (verification_id, id);
+CREATE TABLE IF NOT EXISTS kyc_idempotency_keys (
+	client_id       TEXT NOT NULL,
+	idempotency_key TEXT NOT NULL,
+	hash            TEXT NOT NULL,
+	completed       BOOLEAN NOT NULL DEFAULT FALSE,
+	status          INTEGER NOT NULL DEFAULT 0,
+	header          JSONB,
+	body            BYTEA,
+	created_at      TIMESTAMPTZ NOT NULL,
+	expires_at      TIMESTAMPTZ NOT NULL,
+	PRIMARY KEY (client_id, idempotency_key)
+);
+CREATE INDEX IF NOT EXISTS kyc_idempotency_keys_expires_idx ON kyc_idempotency_keys (expires_at);
+CREATE TABLE IF NOT EXISTS kyc_usage (
+	client_id TEXT NOT NULL,
+	provider  TEXT NOT NULL,
+	period    TEXT NOT NULL,
+	count     INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY (client_id, provider, period)
+);
+`
+
+// The queries of the Postgres store.
+const (
+	insertVerification = `INSERT INTO kyc_verifications (id, fingerprint, provider, reference_id, client_id, final, result, error, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	insertTransition   = `INSERT INTO kyc_transitions (verification_id, result, error, source, created_at) VALUES ($1, $2, $3, $4, $5)`
+	updateVerification = `UPDATE kyc_verifications SET final = $2, result = $3, error = $4, updated_at = $5 WHERE id = $1`
+	selectVerification = `SELECT id, fingerprint, provider, reference_id, client_id, final, result, error, created_at, updated_at FROM kyc_verifications WHERE id = $1`
+	selectTransitions  = `SELECT result, error, source, created_at FROM kyc_transitions WHERE verification_id = $1 ORDER BY id`
+	selectByReference  = `SELECT id FROM kyc_verifications WHERE provider = $1 AND reference_id = $2 ORDER BY created_at DESC LIMIT 1`
+	selectByFinger     = `SELECT id FROM kyc_verifications WHERE fingerprint = $1 ORDER BY created_at`
+	selectPending      = `SELECT id FROM (SELECT DISTINCT ON (provider, reference_id) id, final, created_at FROM kyc_verifications WHERE reference_id <> '' ORDER BY provider, reference_id, created_at DESC) latest WHERE NOT final ORDER BY created_at`
+	// The expired key is replaced by the reserved one.
+	insertKey  = `INSERT INTO kyc_idempotency_keys AS k (client_id, idempotency_key, hash, completed, status, header, body, created_at, expires_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) ON CONFLICT (client_id, idempotency_key) DO UPDATE SET hash = EXCLUDED.hash, completed = EXCLUDED.completed, status = EXCLUDED.status, header = EXCLUDED.header, body = EXCLUDED.body, created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at WHERE k.expires_at <= EXCLUDED.created_at`
+	updateKey  = `UPDATE kyc_idempotency_keys SET completed = $3, status = $4, header = $5, body = $6, expires_at = $7 WHERE client_id = $1 AND idempotency_key = $2`
+	selectKey  = `SELECT hash, completed, status, header, body, created_at, expires_at FROM kyc_idempotency_keys WHERE client_id = $1 AND idempotency_key = $2`
+	deleteKey  = `DELETE FROM kyc_idempotency_keys WHERE client_id = $1 AND idempotency_key = $2`
+	deleteKeys = `DELETE FROM kyc_idempotency_keys WHERE expires_at <= $1`
+	// The usage is counted by the concurrent service instances without losing the checks.
+	upsertUsage = `INSERT INTO kyc_usage AS u (client_id, provider, period, count) VALUES ($1, $2, $3, 1) ON CONFLICT (client_id, provider, period) DO UPDATE SET count = u.count + 1`
+	selectUsage = `SELECT client_id, provider, period, count FROM kyc_usage WHERE period IN ($1, $2)`
+)
+
+// postgresStore represents the Store keeping the verifications in Postgres.
+type postgresStore struct {
+	db *sql.DB
+}
+
+// OpenPostgres connects to the Postgres database using the connection string and creates the tables if needed.
+func OpenPostgres(dsn string) (s Store, err error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return
+	}
+
+	store, err := newPostgresStore(db)
+	if err != nil {
+		db.Close()
+		return
+	}
+
+	s = store
+
+	return
+}
+
+// newPostgresStore constructs the Postgres store using the database.
+func newPostgresStore(db *sql.DB) (s *postgresStore, err error) {
+	if _, err = db.Exec(schema); err != nil {
+		return
+	}
+
+	s = &postgresStore{db: db}
+
+	return
+}
+
+// Create implements Store interface for the postgresStore.
+func (s *postgresStore) Create(verification Verification) (err error) {
+	result, err := marshalResult(verification.Result)
+	if err != nil {
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	_, err = tx.Exec(insertVerification,
+		verification.ID,
+		verification.Fingerprint,
+		string(verification.Provider),
+		verification.ReferenceID,
+		verification.ClientID,
+		verification.Final,
+		result,
+		verification.Error,
+		verification.Created,
+		verification.Updated,
+	)
+	if err != nil {
+		return
+	}
+
+	for _, transition := range verification.Transitions {
+		if err = insertTransitionTx(tx, verification.ID, transition); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// AddTransition implements Store interface for the postgresStore.
+func (s *postgresStore) AddTransition(id string, transition Transition, final bool) (err error) {
+	result, err := marshalResult(transition.Result)
+	if err != nil {
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	res, err := tx.Exec(updateVerification, id, final, result, transition.Error, transition.Time)
+	if err != nil {
+		return
+	}
+	if n, err1 := res.RowsAffected(); err1 == nil && n == 0 {
+		err = ErrNotFound
+		return
+	}
+
+	err = insertTransitionTx(tx, id, transition)
+
+	return
+}
+
+// Get implements Store interface for the postgresStore.
+func (s *postgresStore) Get(id string) (verification Verification, err error) {
+	var (
+		provider string
+		result   []byte
+	)
+
+	err = s.db.QueryRow(selectVerification, id).Scan(
+		&verification.ID,
+		&verification.Fingerprint,
+		&provider,
+		&verification.ReferenceID,
+		&verification.ClientID,
+		&verification.Final,
+		&result,
+		&verification.Error,
+		&verification.Created,
+		&verification.Updated,
+	)
+	if err == sql.ErrNoRows {
+		err = ErrNotFound
+	}
+	if err != nil {
+		return
+	}
+
+	verification.Provider = common.KYCProvider(provider)
+	if verification.Result, err = unmarshalResult(result); err != nil {
+		return
+	}
+
+	rows, err := s.db.Query(selectTransitions, id)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		transition := Transition{}
+		source := ""
+
+		if err = rows.Scan(&result, &transition.Error, &source, &transition.Time); err != nil {
+			return
+		}
+		if transition.Result, err = unmarshalResult(result); err != nil {
+			return
+		}
+		transition.Source = events.Source(source)
+
+		verification.Transitions = append(verification.Transitions, transition)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// FindByReference implements Store interface for the postgresStore.
+func (s *postgresStore) FindByReference(provider common.KYCProvider, referenceID string) (verification Verification, err error) {
+	id := ""
+
+	err = s.db.QueryRow(selectByReference, string(provider), referenceID).Scan(&id)
+	if err == sql.ErrNoRows {
+		err = ErrNotFound
+	}
+	if err != nil {
+		return
+	}
+
+	return s.Get(id)
+}
+
+// FindByFingerprint implements Store interface for the postgresStore.
+func (s *postgresStore) FindByFingerprint(fingerprint string) (verifications []Verification, err error) {
+	return s.find(selectByFinger, fingerprint)
+}
+
+// FindPending implements Store interface for the postgresStore.
+func (s *postgresStore) FindPending() (verifications []Verification, err error) {
+	return s.find(selectPending)
+}
+
+// find returns the verifications whose ids are selected by the query.
+func (s *postgresStore) find(query string, args ...interface{}) (verifications []Verification, err error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return
+	}
+
+	var ids []string
+	for rows.Next() {
+		id := ""
+		if err = rows.Scan(&id); err != nil {
+			rows.Close()
+			return
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return
+	}
+
+	for _, id := range ids {
+		verification, err1 := s.Get(id)
+		if err1 != nil {
+			err = err1
+			verifications = nil
+			return
+		}
+		verifications = append(verifications, verification)
+	}
+
+	return
+}
+
+// ReserveKey implements Store interface for the postgresStore.
+func (s *postgresStore) ReserveKey(key IdempotencyKey) (existing IdempotencyKey, reserved bool, err error) {
+	header, err := marshalHeader(key.Header)
+	if err != nil {
+		return
+	}
+
+	res, err := s.db.Exec(insertKey, key.ClientID, key.Key, key.Hash, key.Completed, key.Status, header, key.Body, key.Created, key.Expires)
+	if err != nil {
+		return
+	}
+	if n, err1 := res.RowsAffected(); err1 == nil && n > 0 {
+		reserved = true
+		return
+	}
+
+	existing = IdempotencyKey{Key: key.Key, ClientID: key.ClientID}
+	data := []byte{}
+
+	err = s.db.QueryRow(selectKey, key.ClientID, key.Key).Scan(
+		&existing.Hash,
+		&existing.Completed,
+		&existing.Status,
+		&data,
+		&existing.Body,
+		&existing.Created,
+		&existing.Expires,
+	)
+	if err == sql.ErrNoRows {
+		err = ErrNotFound
+	}
+	if err != nil {
+		existing = IdempotencyKey{}
+		return
+	}
+
+	existing.Header, err = unmarshalHeader(data)
+
+	return
+}
+
+// CompleteKey implements Store interface for the postgresStore.
+func (s *postgresStore) CompleteKey(key IdempotencyKey) (err error) {
+	header, err := marshalHeader(key.Header)
+	if err != nil {
+		return
+	}
+
+	res, err := s.db.Exec(updateKey, key.ClientID, key.Key, key.Completed, key.Status, header, key.Body, key.Expires)
+	if err != nil {
+		return
+	}
+	if n, err1 := res.RowsAffected(); err1 == nil && n == 0 {
+		err = ErrNotFound
+	}
+
+	return
+}
+
+// ReleaseKey implements Store interface for the postgresStore.
+func (s *postgresStore) ReleaseKey(clientID, key string) (err error) {
+	_, err = s.db.Exec(deleteKey, clientID, key)
+	return
+}
+
+// PurgeKeys implements Store interface for the postgresStore.
+func (s *postgresStore) PurgeKeys(now time.Time) (err error) {
+	_, err = s.db.Exec(deleteKeys, now)
+	return
+}
+
+// AddUsage implements Store interface for the postgresStore.
+func (s *postgresStore) AddUsage(client string, provider common.KYCProvider, day, month string) (err error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	for _, period := range []string{day, month} {
+		if _, err = tx.Exec(upsertUsage, client, string(provider), period); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// FindUsage implements Store interface for the postgresStore.
+func (s *postgresStore) FindUsage(day, month string) (usage []Usage, err error) {
+	rows, err := s.db.Query(selectUsage, day, month)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		u, provider := Usage{}, ""
+		if err = rows.Scan(&u.Client, &provider, &u.Period, &u.Count); err != nil {
+			usage = nil
+			return
+		}
+		u.Provider = common.KYCProvider(provider)
+		usage = append(usage, u)
+	}
+
+	if err = rows.Err(); err != nil {
+		usage = nil
+	}
+
+	return
+}
+
+// Close implements Store interface for the postgresStore.
+func (s *postgresStore) Close() error {
+	return s.db.Close()
+}
+
+// insertTransitionTx inserts the transition of the verification within the transaction.
+func insertTransitionTx(tx *sql.Tx, id string, transition Transition) (err error) {
+	result, err := marshalResult(transition.Result)
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec(insertTransition, id, result, transition.Error, string(transition.Source), transition.Time)
+
+	return
+}
+
+// marshalResult encodes the result for the JSONB column.
+// The nil result is stored as NULL.
+func marshalResult(result *common.Result) (value interface{}, err error) {
+	if result == nil {
+		return
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return
+	}
+	value = string(data)
+
+	return
+}
+
+// marshalHeader encodes the response header for the JSONB column.
+// The nil header is stored as NULL.
+func marshalHeader(header http.Header) (value interface{}, err error) {
+	if header == nil {
+		return
+	}
+
+	data, err := json.Marshal(header)
+	if err != nil {
+		return
+	}
+	value = string(data)
+
+	return
+}
+
+// unmarshalHeader decodes the response header from the JSONB column.
+func unmarshalHeader(data []byte) (header http.Header, err error) {
+	if data == nil {
+		return
+	}
+
+	err = json.Unmarshal(data, &header)
+
+	return
+}
+
+// unmarshalResult decodes the result from the JSONB column.
+func unmarshalResult(data []byte) (result *common.Result, err error) {
+	if data == nil {
+		return
+	}
+
+	result = &common.Result{}
+	err = json.Unmarshal(data, result)
+
+	return
+}
@@ -0,0 +1,270 @@
+package store
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/events"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresStore(t *testing.T) {
+	assert := assert.New(t)
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(schema)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	s, err := newPostgresStore(db)
+	if !assert.NoError(err) {
+		return
+	}
+
+	now := time.Now()
+	verification := NewVerification("id", common.Example, &common.UserData{FirstName: "Urbi"}, common.KYCResult{
+		Status: common.Unclear,
+		StatusCheck: &common.KYCStatusCheck{
+			Provider:    common.Example,
+			ReferenceID: "ref",
+		},
+	}, nil)
+	result, _ := marshalResult(verification.Result)
+
+	// Testing the creation.
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(insertVerification)).
+		WithArgs("id", verification.Fingerprint, "Example", "ref", "", false, result, "", verification.Created, verification.Updated).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertTransition)).
+		WithArgs("id", result, "", "check", verification.Created).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(s.Create(verification))
+
+	// Testing the transition of the unknown verification.
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(updateVerification)).
+		WithArgs("unknown", true, nil, "failed", now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err = s.AddTransition("unknown", Transition{Error: "failed", Source: events.Polling, Time: now}, true)
+
+	assert.Equal(ErrNotFound, err)
+
+	// Testing the lookup by reference id.
+	mock.ExpectQuery(regexp.QuoteMeta(selectByReference)).
+		WithArgs("Example", "ref").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("id"))
+	mock.ExpectQuery(regexp.QuoteMeta(selectVerification)).
+		WithArgs("id").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "fingerprint", "provider", "reference_id", "client_id", "final", "result", "error", "created_at", "updated_at"}).
+			AddRow("id", verification.Fingerprint, "Example", "ref", "backoffice", true, []byte(`{"Status":"Approved"}`), "", verification.Created, now))
+	mock.ExpectQuery(regexp.QuoteMeta(selectTransitions)).
+		WithArgs("id").
+		WillReturnRows(sqlmock.NewRows([]string{"result", "error", "source", "created_at"}).
+			AddRow([]byte(result.(string)), "", "check", verification.Created).
+			AddRow([]byte(`{"Status":"Approved"}`), "", "callback", now))
+
+	found, err := s.FindByReference(common.Example, "ref")
+
+	assert.NoError(err)
+	assert.Equal("id", found.ID)
+	assert.Equal(common.Example, found.Provider)
+	assert.Equal("backoffice", found.ClientID)
+	assert.True(found.Final)
+	assert.Equal("Approved", found.Result.Status)
+	if assert.Len(found.Transitions, 2) {
+		assert.Equal(events.Check, found.Transitions[0].Source)
+		assert.Equal("Unclear", found.Transitions[0].Result.Status)
+		assert.Equal(events.Callback, found.Transitions[1].Source)
+	}
+
+	// Testing the lookup of the unknown reference id.
+	mock.ExpectQuery(regexp.QuoteMeta(selectByReference)).
+		WithArgs("Example", "unknown").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err = s.FindByReference(common.Example, "unknown")
+
+	assert.Equal(ErrNotFound, err)
+
+	// Testing the lookup by fingerprint without verifications.
+	mock.ExpectQuery(regexp.QuoteMeta(selectByFinger)).
+		WithArgs("fingerprint").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	verifications, err := s.FindByFingerprint("fingerprint")
+
+	assert.NoError(err)
+	assert.Empty(verifications)
+
+	// Testing the pending verifications.
+	mock.ExpectQuery(regexp.QuoteMeta(selectPending)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("id"))
+	mock.ExpectQuery(regexp.QuoteMeta(selectVerification)).
+		WithArgs("id").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "fingerprint", "provider", "reference_id", "client_id", "final", "result", "error", "created_at", "updated_at"}).
+			AddRow("id", verification.Fingerprint, "Example", "ref", "", false, []byte(result.(string)), "", verification.Created, verification.Updated))
+	mock.ExpectQuery(regexp.QuoteMeta(selectTransitions)).
+		WithArgs("id").
+		WillReturnRows(sqlmock.NewRows([]string{"result", "error", "source", "created_at"}).
+			AddRow([]byte(result.(string)), "", "check", verification.Created))
+
+	verifications, err = s.FindPending()
+
+	assert.NoError(err)
+	if assert.Len(verifications, 1) {
+		assert.Equal("ref", verifications[0].ReferenceID)
+		assert.False(verifications[0].Final)
+	}
+
+	assert.NoError(mock.ExpectationsWereMet())
+}
+
+func TestPostgresIdempotencyKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(schema)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	s, err := newPostgresStore(db)
+	if !assert.NoError(err) {
+		return
+	}
+
+	now := time.Now()
+	key := IdempotencyKey{
+		Key:      "key",
+		ClientID: "backoffice",
+		Hash:     "hash",
+		Created:  now,
+		Expires:  now.Add(time.Minute),
+	}
+
+	// Testing the reservation of the new key.
+	mock.ExpectExec(regexp.QuoteMeta(insertKey)).
+		WithArgs("backoffice", "key", "hash", false, 0, nil, []byte(nil), now, key.Expires).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	_, reserved, err := s.ReserveKey(key)
+
+	assert.NoError(err)
+	assert.True(reserved)
+
+	// Testing the reservation of the recorded key.
+	mock.ExpectExec(regexp.QuoteMeta(insertKey)).
+		WithArgs("backoffice", "key", "hash", false, 0, nil, []byte(nil), now, key.Expires).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(selectKey)).
+		WithArgs("backoffice", "key").
+		WillReturnRows(sqlmock.NewRows([]string{"hash", "completed", "status", "header", "body", "created_at", "expires_at"}).
+			AddRow("hash", true, 200, []byte(`{"Content-Type":["application/json"]}`), []byte(`{}`), now, now.Add(time.Hour)))
+
+	existing, reserved, err := s.ReserveKey(key)
+
+	assert.NoError(err)
+	assert.False(reserved)
+	assert.True(existing.Completed)
+	assert.Equal(200, existing.Status)
+	assert.Equal("application/json", existing.Header.Get("Content-Type"))
+	assert.Equal([]byte(`{}`), existing.Body)
+
+	// Testing the completion of the released key.
+	key.Completed = true
+	key.Status = 200
+	key.Header = map[string][]string{"Content-Type": {"application/json"}}
+	key.Body = []byte(`{}`)
+
+	mock.ExpectExec(regexp.QuoteMeta(updateKey)).
+		WithArgs("backoffice", "key", true, 200, `{"Content-Type":["application/json"]}`, []byte(`{}`), key.Expires).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.Equal(ErrNotFound, s.CompleteKey(key))
+
+	// Testing the release and the purge.
+	mock.ExpectExec(regexp.QuoteMeta(deleteKey)).
+		WithArgs("backoffice", "key").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(deleteKeys)).
+		WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	assert.NoError(s.ReleaseKey("backoffice", "key"))
+	assert.NoError(s.PurgeKeys(now))
+
+	assert.NoError(mock.ExpectationsWereMet())
+}
+
+func TestPostgresUsage(t *testing.T) {
+	assert := assert.New(t)
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(schema)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	s, err := newPostgresStore(db)
+	if !assert.NoError(err) {
+		return
+	}
+
+	// Testing the day and the month are counted in the transaction.
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(upsertUsage)).
+		WithArgs("backoffice", "IDology", "2026-10-31").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(upsertUsage)).
+		WithArgs("backoffice", "IDology", "2026-10").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(s.AddUsage("backoffice", common.IDology, "2026-10-31", "2026-10"))
+
+	// Testing the failed count is rolled back.
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(upsertUsage)).
+		WithArgs("backoffice", "IDology", "2026-10-31").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(upsertUsage)).
+		WithArgs("backoffice", "IDology", "2026-10").
+		WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	assert.EqualError(s.AddUsage("backoffice", common.IDology, "2026-10-31", "2026-10"), "connection reset")
+
+	// Testing the lookup of the usage.
+	mock.ExpectQuery(regexp.QuoteMeta(selectUsage)).
+		WithArgs("2026-10-31", "2026-10").
+		WillReturnRows(sqlmock.NewRows([]string{"client_id", "provider", "period", "count"}).
+			AddRow("backoffice", "IDology", "2026-10-31", 1).
+			AddRow("backoffice", "IDology", "2026-10", 5))
+
+	usage, err := s.FindUsage("2026-10-31", "2026-10")
+
+	assert.NoError(err)
+	assert.Equal([]Usage{
+		{Client: "backoffice", Provider: common.IDology, Period: "2026-10-31", Count: 1},
+		{Client: "backoffice", Provider: common.IDology, Period: "2026-10", Count: 5},
+	}, usage)
+
+	assert.NoError(mock.ExpectationsWereMet())
+}
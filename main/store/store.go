@@ -0,0 +1,349 @@
+// Package store keeps the history of verifications.
+// Every verification is recorded with the fingerprint of the customer data, the provider, the reference id,
+// every transition of its status and the final result.
+// The store keeps the idempotency keys of the requests along with the responses to them as well.
+// The embedded BoltDB and the Postgres implementations of the Store are provided.
+package store
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sync"
+	"time"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/events"
+	"modulus/kyc/main/logging"
+)
+
+// The names of the store options in the service config section.
+const (
+	DriverOption = "StoreDriver"
+	DSNOption    = "StoreDSN"
+)
+
+// Supported store drivers.
+const (
+	BoltDriver     = "bolt"
+	PostgresDriver = "postgres"
+)
+
+// The default values of the store options.
+const (
+	DefaultDriver = BoltDriver
+	DefaultDSN    = "kyc.db"
+)
+
+// ErrNotFound is returned when the requested verification isn't found in the store.
+var ErrNotFound = errors.New("verification not found")
+
+// ErrDisabled is returned when the store isn't opened for the service.
+var ErrDisabled = errors.New("verification store isn't opened")
+
+// Config holds the settings of the store.
+//
+// * Driver is the store implementation: bolt or postgres.
+// * DSN is the path to the database file for bolt or the connection string for postgres.
+type Config struct {
+	Driver string
+	DSN    string
+}
+
+// ConfigFromOptions parses the store options from the service config section.
+// Absent options take their default values.
+func ConfigFromOptions(options map[string]string) (config Config, err error) {
+	config = Config{
+		Driver: options[DriverOption],
+		DSN:    options[DSNOption],
+	}
+
+	if len(config.Driver) == 0 {
+		config.Driver = DefaultDriver
+	}
+
+	switch config.Driver {
+	case BoltDriver:
+		if len(config.DSN) == 0 {
+			config.DSN = DefaultDSN
+		}
+	case PostgresDriver:
+		if len(config.DSN) == 0 {
+			err = fmt.Errorf("missing option '%s' for the %s store", DSNOption, PostgresDriver)
+		}
+	default:
+		err = fmt.Errorf("invalid option '%s': unknown driver %s", DriverOption, config.Driver)
+	}
+
+	return
+}
+
+// Transition represents the change of the verification status.
+type Transition struct {
+	Result *common.Result
+	Error  string
+	Source events.Source
+	Time   time.Time
+}
+
+// Verification represents the recorded verification.
+//
+// * ID is the identificator of the verification in the service.
+// * Fingerprint is the SHA256 hash of the customer data. The verifications of the same customer data share it.
+// * ReferenceID is the identificator of the verification in the provider if provided.
+// * ClientID is the identificator of the API client requested the verification if the authentication is enabled.
+// * Result is the latest result of the verification. It's the final result if Final is set.
+// * Transitions lists the results of the verification in the order of their arrival.
+type Verification struct {
+	ID          string
+	Fingerprint string
+	Provider    common.KYCProvider
+	ReferenceID string
+	ClientID    string
+	Final       bool
+	Result      *common.Result
+	Error       string
+	Created     time.Time
+	Updated     time.Time
+	Transitions []Transition
+}
+
+// Store defines the storage of the verifications.
+type Store interface {
+	// Create records the new verification.
+	Create(verification Verification) error
+	// AddTransition appends the transition to the verification and updates its latest result.
+	AddTransition(id string, transition Transition, final bool) error
+	// Get returns the verification by its id.
+	Get(id string) (Verification, error)
+	// FindByReference returns the latest verification with the provider reference id.
+	FindByReference(provider common.KYCProvider, referenceID string) (Verification, error)
+	// FindByFingerprint returns the verifications of the same customer data sorted by creation time.
+	FindByFingerprint(fingerprint string) ([]Verification, error)
+	// FindPending returns the latest verifications by the provider reference ids which aren't final
+	// sorted by creation time.
+	FindPending() ([]Verification, error)
+	// ReserveKey records the idempotency key unless the unexpired key of the same client is recorded already.
+	// Otherwise, the recorded key is returned and reserved is false.
+	ReserveKey(key IdempotencyKey) (existing IdempotencyKey, reserved bool, err error)
+	// CompleteKey saves the response to the request with the reserved idempotency key.
+	CompleteKey(key IdempotencyKey) error
+	// ReleaseKey removes the idempotency key of the client, so the request can be retried with it.
+	ReleaseKey(clientID, key string) error
+	// PurgeKeys removes the idempotency keys expired by the time.
+	PurgeKeys(now time.Time) error
+	// AddUsage increments the counts of the verification checks of the client using the provider
+	// in the day and the month at once.
+	AddUsage(client string, provider common.KYCProvider, day, month string) error
+	// FindUsage returns the counts of the verification checks in the day and the month.
+	FindUsage(day, month string) ([]Usage, error)
+	// Close releases the resources of the store.
+	Close() error
+}
+
+// Open opens the store using the config.
+func Open(config Config) (Store, error) {
+	switch config.Driver {
+	case BoltDriver:
+		return OpenBolt(config.DSN)
+	case PostgresDriver:
+		return OpenPostgres(config.DSN)
+	}
+
+	return nil, fmt.Errorf("unknown store driver: %s", config.Driver)
+}
+
+// Fingerprint returns the hex-encoded SHA256 hash of the customer data.
+func Fingerprint(customer *common.UserData) string {
+	data, _ := json.Marshal(customer)
+	hash := sha256.Sum256(data)
+
+	return hex.EncodeToString(hash[:])
+}
+
+// NewVerification constructs the verification from the result of the verification request.
+func NewVerification(id string, provider common.KYCProvider, customer *common.UserData, result common.KYCResult, err error) (verification Verification) {
+	now := time.Now()
+
+	verification = Verification{
+		ID:          id,
+		Fingerprint: Fingerprint(customer),
+		Provider:    provider,
+		Final:       result.IsFinal(),
+		Result:      common.ResultFromKYCResult(result),
+		Created:     now,
+		Updated:     now,
+	}
+	if result.StatusCheck != nil {
+		verification.ReferenceID = result.StatusCheck.ReferenceID
+	}
+	if err != nil {
+		verification.Error = err.Error()
+	}
+
+	verification.Transitions = []Transition{
+		{
+			Result: verification.Result,
+			Error:  verification.Error,
+			Source: events.Check,
+			Time:   now,
+		},
+	}
+
+	return
+}
+
+// record appends the result update to the recorded verification if it changes the verification status.
+func record(s Store, update events.Result) (err error) {
+	verification, err := s.FindByReference(update.Provider, update.ReferenceID)
+	if err == ErrNotFound {
+		return nil
+	}
+	if err != nil || verification.Final {
+		return
+	}
+
+	result := common.ResultFromKYCResult(update.Result)
+	if sameStatus(verification.Result, result) {
+		return
+	}
+
+	err = s.AddTransition(verification.ID, Transition{
+		Result: result,
+		Source: update.Source,
+		Time:   update.Time,
+	}, update.Result.IsFinal())
+
+	return
+}
+
+// sameStatus reports whether the results represent the same verification status.
+// The time of the last status check doesn't matter.
+func sameStatus(a, b *common.Result) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	x, y := *a, *b
+	x.StatusCheck, y.StatusCheck = nil, nil
+
+	return reflect.DeepEqual(x, y)
+}
+
+var (
+	mu        sync.RWMutex
+	current   Store
+	subscribe sync.Once
+)
+
+// Start opens the service store using the config.
+// The store receives the result updates published by the other subsystems.
+func Start(config Config) (err error) {
+	s, err := Open(config)
+	if err != nil {
+		return
+	}
+
+	subscribe.Do(func() {
+		events.Subscribe(handleResult)
+	})
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if current != nil {
+		current.Close()
+	}
+	current = s
+
+	return
+}
+
+// Stop closes the service store.
+func Stop() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if current != nil {
+		current.Close()
+		current = nil
+	}
+}
+
+// Create records the new verification in the service store.
+func Create(verification Verification) error {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current == nil {
+		return ErrDisabled
+	}
+
+	return current.Create(verification)
+}
+
+// Get returns the verification by its id from the service store.
+func Get(id string) (Verification, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current == nil {
+		return Verification{}, ErrDisabled
+	}
+
+	return current.Get(id)
+}
+
+// FindByReference returns the latest verification with the provider reference id from the service store.
+func FindByReference(provider common.KYCProvider, referenceID string) (Verification, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current == nil {
+		return Verification{}, ErrDisabled
+	}
+
+	return current.FindByReference(provider, referenceID)
+}
+
+// FindByFingerprint returns the verifications of the same customer data from the service store.
+func FindByFingerprint(fingerprint string) ([]Verification, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current == nil {
+		return nil, ErrDisabled
+	}
+
+	return current.FindByFingerprint(fingerprint)
+}
+
+// FindPending returns the pending verifications from the service store.
+func FindPending() ([]Verification, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current == nil {
+		return nil, ErrDisabled
+	}
+
+	return current.FindPending()
+}
+
+// handleResult records the result update in the service store.
+func handleResult(update events.Result) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current == nil {
+		return
+	}
+
+	if err := record(current, update); err != nil {
+		slog.Error("Store error", logging.ProviderKey, update.Provider, logging.ReferenceIDKey, update.ReferenceID, logging.ErrorKey, err)
+	}
+}
@@ -0,0 +1,268 @@
+package store
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"modulus/kyc/common"
+	"modulus/kyc/main/events"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// openTestBolt opens the BoltDB store in the temporary directory.
+func openTestBolt(t *testing.T) (s Store, path string) {
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	path = filepath.Join(dir, "kyc.db")
+
+	s, err = OpenBolt(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return
+}
+
+func TestConfigFromOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing the default config.
+	config, err := ConfigFromOptions(map[string]string{
+		"Port": "8080",
+	})
+
+	assert.NoError(err)
+	assert.Equal(Config{Driver: "bolt", DSN: "kyc.db"}, config)
+
+	// Testing the Postgres config.
+	config, err = ConfigFromOptions(map[string]string{
+		"StoreDriver": "postgres",
+		"StoreDSN":    "postgres://kyc@localhost/kyc?sslmode=disable",
+	})
+
+	assert.NoError(err)
+	assert.Equal(Config{Driver: "postgres", DSN: "postgres://kyc@localhost/kyc?sslmode=disable"}, config)
+
+	// Testing the Postgres config without the connection string.
+	_, err = ConfigFromOptions(map[string]string{
+		"StoreDriver": "postgres",
+	})
+
+	if assert.Error(err) {
+		assert.Equal("missing option 'StoreDSN' for the postgres store", err.Error())
+	}
+
+	// Testing unknown driver.
+	_, err = ConfigFromOptions(map[string]string{
+		"StoreDriver": "mysql",
+	})
+
+	if assert.Error(err) {
+		assert.Equal("invalid option 'StoreDriver': unknown driver mysql", err.Error())
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	assert := assert.New(t)
+
+	abby := &common.UserData{FirstName: "Abby", LastName: "Smith"}
+
+	assert.Len(Fingerprint(abby), 64)
+	assert.Equal(Fingerprint(abby), Fingerprint(&common.UserData{FirstName: "Abby", LastName: "Smith"}))
+	assert.NotEqual(Fingerprint(abby), Fingerprint(&common.UserData{FirstName: "Abby", LastName: "Jones"}))
+}
+
+func TestNewVerification(t *testing.T) {
+	assert := assert.New(t)
+
+	customer := &common.UserData{FirstName: "Urbi"}
+
+	// Testing the pending verification.
+	verification := NewVerification("id", common.Example, customer, common.KYCResult{
+		Status: common.Unclear,
+		StatusCheck: &common.KYCStatusCheck{
+			Provider:    common.Example,
+			ReferenceID: "ref",
+		},
+	}, nil)
+
+	assert.Equal("id", verification.ID)
+	assert.Equal(Fingerprint(customer), verification.Fingerprint)
+	assert.Equal(common.Example, verification.Provider)
+	assert.Equal("ref", verification.ReferenceID)
+	assert.False(verification.Final)
+	assert.Equal("Unclear", verification.Result.Status)
+	assert.Empty(verification.Error)
+	if assert.Len(verification.Transitions, 1) {
+		assert.Equal(events.Check, verification.Transitions[0].Source)
+		assert.Equal(verification.Result, verification.Transitions[0].Result)
+	}
+
+	// Testing the failed verification.
+	verification = NewVerification("id", common.Example, customer, common.KYCResult{}, errors.New("failed"))
+
+	assert.Empty(verification.ReferenceID)
+	assert.True(verification.Final)
+	assert.Equal("failed", verification.Error)
+	assert.Equal("failed", verification.Transitions[0].Error)
+}
+
+func TestSameStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	unclear := &common.Result{
+		Status:      "Unclear",
+		StatusCheck: &common.KYCStatusCheck{ReferenceID: "ref", LastCheck: time.Now()},
+	}
+
+	assert.True(sameStatus(nil, nil))
+	assert.False(sameStatus(unclear, nil))
+	assert.True(sameStatus(unclear, &common.Result{
+		Status:      "Unclear",
+		StatusCheck: &common.KYCStatusCheck{ReferenceID: "ref", LastCheck: time.Now().Add(time.Minute)},
+	}))
+	assert.False(sameStatus(unclear, &common.Result{Status: "Approved"}))
+	assert.False(sameStatus(&common.Result{Status: "Error", ErrorCode: "1"}, &common.Result{Status: "Error", ErrorCode: "2"}))
+}
+
+func TestRecord(t *testing.T) {
+	assert := assert.New(t)
+
+	s, _ := openTestBolt(t)
+	defer s.Close()
+
+	pending := common.KYCResult{
+		Status: common.Unclear,
+		StatusCheck: &common.KYCStatusCheck{
+			Provider:    common.Example,
+			ReferenceID: "ref",
+		},
+	}
+
+	err := s.Create(NewVerification("id", common.Example, &common.UserData{FirstName: "Urbi"}, pending, nil))
+	assert.NoError(err)
+
+	// Testing the update of the unknown verification.
+	err = record(s, events.Result{
+		Provider:    common.Example,
+		ReferenceID: "unknown",
+		Result:      common.KYCResult{Status: common.Approved},
+		Time:        time.Now(),
+	})
+	assert.NoError(err)
+
+	// Testing the update without the status change.
+	pending.StatusCheck.LastCheck = time.Now()
+
+	err = record(s, events.Result{
+		Provider:    common.Example,
+		ReferenceID: "ref",
+		Result:      pending,
+		Source:      events.Polling,
+		Time:        time.Now(),
+	})
+	assert.NoError(err)
+
+	verification, err := s.Get("id")
+
+	assert.NoError(err)
+	assert.Len(verification.Transitions, 1)
+
+	// Testing the final result.
+	err = record(s, events.Result{
+		Provider:    common.Example,
+		ReferenceID: "ref",
+		Result:      common.KYCResult{Status: common.Denied},
+		Source:      events.Callback,
+		Time:        time.Now(),
+	})
+	assert.NoError(err)
+
+	verification, err = s.Get("id")
+
+	assert.NoError(err)
+	assert.True(verification.Final)
+	assert.Equal("Denied", verification.Result.Status)
+	if assert.Len(verification.Transitions, 2) {
+		assert.Equal(events.Callback, verification.Transitions[1].Source)
+	}
+
+	// Testing the update after the final result.
+	err = record(s, events.Result{
+		Provider:    common.Example,
+		ReferenceID: "ref",
+		Result:      common.KYCResult{Status: common.Approved},
+		Source:      events.Check,
+		Time:        time.Now(),
+	})
+	assert.NoError(err)
+
+	verification, err = s.Get("id")
+
+	assert.NoError(err)
+	assert.Equal("Denied", verification.Result.Status)
+	assert.Len(verification.Transitions, 2)
+}
+
+func TestStart(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := Get("id")
+
+	assert.Equal(ErrDisabled, err)
+
+	dir, err := ioutil.TempDir("", "store")
+	if !assert.NoError(err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	err = Start(Config{Driver: BoltDriver, DSN: filepath.Join(dir, "kyc.db")})
+	if !assert.NoError(err) {
+		return
+	}
+	defer Stop()
+
+	err = Create(NewVerification("started", common.Example, &common.UserData{FirstName: "Urbi"}, common.KYCResult{
+		Status: common.Unclear,
+		StatusCheck: &common.KYCStatusCheck{
+			Provider:    common.Example,
+			ReferenceID: "started",
+		},
+	}, nil))
+	assert.NoError(err)
+
+	// Testing the transition recorded from the published update.
+	events.Publish(events.Result{
+		Provider:    common.Example,
+		ReferenceID: "started",
+		Result:      common.KYCResult{Status: common.Approved},
+		Source:      events.Polling,
+	})
+
+	verification, err := FindByReference(common.Example, "started")
+
+	assert.NoError(err)
+	assert.True(verification.Final)
+	assert.Len(verification.Transitions, 2)
+
+	verifications, err := FindByFingerprint(verification.Fingerprint)
+
+	assert.NoError(err)
+	assert.Len(verifications, 1)
+
+	Stop()
+
+	_, err = FindByReference(common.Example, "started")
+
+	assert.Equal(ErrDisabled, err)
+}
@@ -0,0 +1,40 @@
+package store
+
+import (
+	"modulus/kyc/common"
+)
+
+// Usage represents the number of the verification checks of an API client using a KYC provider in a period.
+//
+// * Client is empty for the checks of the unauthenticated clients.
+// * Period is the day formatted as 2006-01-02 or the month formatted as 2006-01.
+type Usage struct {
+	Client   string
+	Provider common.KYCProvider
+	Period   string
+	Count    int
+}
+
+// AddUsage counts the verification check of the client using the provider in the day and the month of the service store.
+func AddUsage(client string, provider common.KYCProvider, day, month string) error {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current == nil {
+		return ErrDisabled
+	}
+
+	return current.AddUsage(client, provider, day, month)
+}
+
+// FindUsage returns the usage counted in the day and the month from the service store.
+func FindUsage(day, month string) ([]Usage, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current == nil {
+		return nil, ErrDisabled
+	}
+
+	return current.FindUsage(day, month)
+}
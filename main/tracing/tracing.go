@@ -0,0 +1,232 @@
+// Package tracing sets up the OpenTelemetry tracing of the service.
+// The inbound requests are traced with the server spans continuing the trace context from the request headers,
+// and every request to the provider APIs is traced with the client span by the http package.
+// The spans are exported to the OTLP collector.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"modulus/kyc/common"
+	kychttp "modulus/kyc/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// The names of the tracing options in the service config section.
+const (
+	EndpointOption    = "TracingEndpoint"
+	SampleRatioOption = "TracingSampleRatio"
+	ServiceNameOption = "TracingServiceName"
+)
+
+// The default values of the tracing options.
+const (
+	DefaultSampleRatio = 1.0
+	DefaultServiceName = "kyc"
+)
+
+// tracerName is the instrumentation name of the service spans.
+const tracerName = "modulus/kyc"
+
+// The attributes of the service spans.
+const (
+	ProviderAttribute    = kychttp.ProviderAttribute
+	OperationAttribute   = kychttp.OperationAttribute
+	ReferenceIDAttribute = attribute.Key("kyc.reference_id")
+	routeAttribute       = attribute.Key("http.route")
+	methodAttribute      = attribute.Key("http.request.method")
+	statusCodeAttribute  = attribute.Key("http.response.status_code")
+)
+
+// Config holds the settings of the tracing.
+//
+// * Endpoint is the url of the OTLP/HTTP collector, e.g. http://localhost:4318. The tracing is disabled if it's empty.
+// * SampleRatio is the ratio of the traces started by the service to sample, from 0 to 1.
+// The traces continued from the request headers are sampled if the caller sampled them.
+// * ServiceName is the name of the service in the traces.
+type Config struct {
+	Endpoint    string
+	SampleRatio float64
+	ServiceName string
+}
+
+// Enabled reports whether the spans are exported.
+func (c Config) Enabled() bool {
+	return len(c.Endpoint) > 0
+}
+
+// ConfigFromOptions parses the tracing options from the service config section.
+// Absent options take their default values.
+func ConfigFromOptions(options map[string]string) (config Config, err error) {
+	config = Config{
+		Endpoint:    options[EndpointOption],
+		SampleRatio: DefaultSampleRatio,
+		ServiceName: options[ServiceNameOption],
+	}
+
+	if len(config.ServiceName) == 0 {
+		config.ServiceName = DefaultServiceName
+	}
+
+	if value := options[SampleRatioOption]; len(value) > 0 {
+		config.SampleRatio, err = strconv.ParseFloat(value, 64)
+		if err == nil && (config.SampleRatio < 0 || config.SampleRatio > 1) {
+			err = errors.New("must be between 0 and 1")
+		}
+		if err != nil {
+			err = fmt.Errorf("invalid option '%s': %s", SampleRatioOption, err)
+			return
+		}
+	}
+
+	return
+}
+
+var (
+	mu       sync.Mutex
+	provider *sdktrace.TracerProvider
+)
+
+// Start sets up the global tracer provider exporting the spans to the OTLP collector from the config.
+// Nothing is traced if the tracing isn't configured.
+func Start(config Config) (err error) {
+	if !config.Enabled() {
+		return
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(config.Endpoint))
+	if err != nil {
+		return
+	}
+
+	start(config, sdktrace.WithBatcher(exporter))
+
+	return
+}
+
+// StartLocal sets up the global tracer provider exporting every span to the exporter as soon as it ends.
+// It's meant for tests and local debugging, e.g. with the in-memory exporter from the tracetest package.
+func StartLocal(exporter sdktrace.SpanExporter) {
+	start(Config{SampleRatio: 1, ServiceName: DefaultServiceName}, sdktrace.WithSyncer(exporter))
+}
+
+// start replaces the global tracer provider with the one using the config and the span processor option.
+func start(config Config, processor sdktrace.TracerProviderOption) {
+	tp := sdktrace.NewTracerProvider(
+		processor,
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", config.ServiceName))),
+	)
+
+	mu.Lock()
+	previous := provider
+	provider = tp
+	mu.Unlock()
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if previous != nil {
+		previous.Shutdown(context.Background())
+	}
+}
+
+// Stop flushes the pending spans and disables the tracing.
+func Stop(ctx context.Context) (err error) {
+	mu.Lock()
+	tp := provider
+	provider = nil
+	mu.Unlock()
+
+	if tp == nil {
+		return
+	}
+
+	otel.SetTracerProvider(noop.NewTracerProvider())
+	err = tp.Shutdown(ctx)
+
+	return
+}
+
+// Middleware traces the requests to the handler with the server spans named after the route.
+// The trace context is extracted from the request headers.
+func Middleware(route string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := otel.Tracer(tracerName).Start(ctx, route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(routeAttribute.String(route), methodAttribute.String(r.Method)),
+		)
+		defer span.End()
+
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(sw, r.WithContext(ctx))
+
+		span.SetAttributes(statusCodeAttribute.Int(sw.status))
+		if sw.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(sw.status))
+		}
+	})
+}
+
+// Annotate adds the provider and the reference id to the span from the context.
+// The empty values are skipped.
+func Annotate(ctx context.Context, provider common.KYCProvider, referenceID string) {
+	span := trace.SpanFromContext(ctx)
+	if len(provider) > 0 {
+		span.SetAttributes(ProviderAttribute.String(string(provider)))
+	}
+	if len(referenceID) > 0 {
+		span.SetAttributes(ReferenceIDAttribute.String(referenceID))
+	}
+}
+
+// StartOperation starts the span of the verification operation done on behalf of the background subsystems.
+func StartOperation(ctx context.Context, provider common.KYCProvider, operation string, referenceID string) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		ProviderAttribute.String(string(provider)),
+		OperationAttribute.String(operation),
+	}
+	if len(referenceID) > 0 {
+		attrs = append(attrs, ReferenceIDAttribute.String(referenceID))
+	}
+
+	return otel.Tracer(tracerName).Start(ctx, operation, trace.WithAttributes(attrs...))
+}
+
+// EndOperation records the error of the operation if any and ends the span.
+func EndOperation(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// statusWriter captures the status code of the response.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader implements http.ResponseWriter interface for the statusWriter.
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,173 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"modulus/kyc/common"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanAttributes returns the attributes of the span as a map.
+func spanAttributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, attr := range span.Attributes {
+		attrs[attr.Key] = attr.Value
+	}
+
+	return attrs
+}
+
+func TestConfigFromOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing the default config.
+	config, err := ConfigFromOptions(map[string]string{
+		"Port": "8080",
+	})
+
+	assert.NoError(err)
+	assert.Equal(Config{SampleRatio: 1, ServiceName: "kyc"}, config)
+	assert.False(config.Enabled())
+
+	// Testing the full config.
+	config, err = ConfigFromOptions(map[string]string{
+		"TracingEndpoint":    "http://localhost:4318",
+		"TracingSampleRatio": "0.25",
+		"TracingServiceName": "kyc-eu",
+	})
+
+	assert.NoError(err)
+	assert.Equal(Config{Endpoint: "http://localhost:4318", SampleRatio: 0.25, ServiceName: "kyc-eu"}, config)
+	assert.True(config.Enabled())
+
+	// Testing invalid sample ratio.
+	_, err = ConfigFromOptions(map[string]string{
+		"TracingSampleRatio": "2",
+	})
+
+	if assert.Error(err) {
+		assert.Equal("invalid option 'TracingSampleRatio': must be between 0 and 1", err.Error())
+	}
+
+	_, err = ConfigFromOptions(map[string]string{
+		"TracingSampleRatio": "all",
+	})
+
+	assert.Error(err)
+}
+
+func TestStart(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing the disabled tracing.
+	assert.NoError(Start(Config{}))
+	assert.Nil(provider)
+
+	// Testing the OTLP exporter.
+	err := Start(Config{Endpoint: "http://localhost:4318", SampleRatio: 1, ServiceName: "kyc"})
+
+	assert.NoError(err)
+	assert.NotNil(provider)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	Stop(ctx)
+
+	assert.Nil(provider)
+}
+
+func TestMiddleware(t *testing.T) {
+	assert := assert.New(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+
+	StartLocal(exporter)
+	defer Stop(context.Background())
+
+	handler := Middleware("/CheckStatus", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Annotate(r.Context(), common.Jumio, "ref")
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+
+	// Testing the trace continued from the request headers.
+	req := httptest.NewRequest(http.MethodPost, "/CheckStatus", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(http.StatusBadGateway, w.Code)
+
+	spans := exporter.GetSpans()
+	if assert.Len(spans, 1) {
+		span := spans[0]
+		attrs := spanAttributes(span)
+
+		assert.Equal("/CheckStatus", span.Name)
+		assert.Equal(trace.SpanKindServer, span.SpanKind)
+		assert.Equal("4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext.TraceID().String())
+		assert.Equal("00f067aa0ba902b7", span.Parent.SpanID().String())
+		assert.True(span.Parent.IsRemote())
+		assert.Equal("Jumio", attrs["kyc.provider"].AsString())
+		assert.Equal("ref", attrs["kyc.reference_id"].AsString())
+		assert.Equal("POST", attrs["http.request.method"].AsString())
+		assert.Equal(int64(http.StatusBadGateway), attrs["http.response.status_code"].AsInt64())
+		assert.Equal(codes.Error, span.Status.Code)
+	}
+
+	// Testing the new trace.
+	exporter.Reset()
+
+	req = httptest.NewRequest(http.MethodPost, "/CheckStatus", nil)
+	w = httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	spans = exporter.GetSpans()
+	if assert.Len(spans, 1) {
+		assert.False(spans[0].Parent.IsValid())
+	}
+}
+
+func TestStartOperation(t *testing.T) {
+	assert := assert.New(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+
+	StartLocal(exporter)
+	defer Stop(context.Background())
+
+	_, span := StartOperation(context.Background(), common.SumSub, "CheckStatus", "ref")
+	EndOperation(span, errors.New("http error"))
+
+	spans := exporter.GetSpans()
+	if assert.Len(spans, 1) {
+		attrs := spanAttributes(spans[0])
+
+		assert.Equal("CheckStatus", spans[0].Name)
+		assert.Equal("Sum&Substance", attrs["kyc.provider"].AsString())
+		assert.Equal("CheckStatus", attrs["kyc.operation"].AsString())
+		assert.Equal("ref", attrs["kyc.reference_id"].AsString())
+		assert.Equal(codes.Error, spans[0].Status.Code)
+		assert.Equal("http error", spans[0].Status.Description)
+	}
+
+	// Testing the spans aren't exported after stop.
+	Stop(context.Background())
+	exporter.Reset()
+
+	_, span = StartOperation(context.Background(), common.SumSub, "CheckStatus", "ref")
+	EndOperation(span, nil)
+
+	assert.Empty(exporter.GetSpans())
+}
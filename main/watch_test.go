@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"modulus/kyc/main/config"
+	"modulus/kyc/main/grpcapi"
+	grpcconfig "modulus/kyc/main/grpcapi/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchGRPCCertificate(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "kyc")
+	if !assert.NoError(err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile := filepath.Join(dir, "grpc.crt"), filepath.Join(dir, "grpc.key")
+	writeCertificate(t, certFile, keyFile, "kyc-1")
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(err) {
+		return
+	}
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	listener.Close()
+
+	cfg := filepath.Join(dir, "kyc.cfg")
+	ioutil.WriteFile(cfg, []byte("[Config]\nPort=8080\nGRPCPort="+port+"\nTLSCert="+certFile+"\nTLSKey="+keyFile+"\n"), 0600)
+
+	previous := *cfgFile
+	defer func() { *cfgFile = previous }()
+	*cfgFile = cfg
+
+	if !assert.NoError(config.FromFile(cfg)) {
+		return
+	}
+
+	grpcConfig, err := grpcconfig.ConfigFromOptions(config.Get()[config.ServiceSection])
+	if !assert.NoError(err) {
+		return
+	}
+	if !assert.NoError(grpcapi.Start(grpcConfig)) {
+		return
+	}
+	defer grpcapi.Stop()
+
+	commonName := func() string {
+		conn, err := tls.Dial("tcp", "127.0.0.1:"+port, &tls.Config{InsecureSkipVerify: true, NextProtos: []string{"h2"}})
+		if err != nil {
+			return ""
+		}
+		defer conn.Close()
+
+		return conn.ConnectionState().PeerCertificates[0].Subject.CommonName
+	}
+
+	// Testing the certificate files of the gRPC API are watched.
+	files := watchedFiles()
+
+	assert.True(files[certFile])
+	assert.True(files[keyFile])
+	assert.Equal("kyc-1", commonName())
+
+	// Testing the rotated certificate is picked up.
+	ctx, cancel := context.WithCancel(context.Background())
+	watched := make(chan struct{})
+	go func() {
+		watchConfigs(ctx)
+		close(watched)
+	}()
+	defer func() {
+		cancel()
+		<-watched
+	}()
+
+	// Let the watcher add the directories before the files change.
+	time.Sleep(100 * time.Millisecond)
+	writeCertificate(t, certFile, keyFile, "kyc-2")
+
+	assert.Eventually(func() bool { return commonName() == "kyc-2" }, 5*time.Second, 50*time.Millisecond)
+}
+
+// writeCertificate writes the self-signed certificate for the common name and its key to the files.
+func writeCertificate(t *testing.T, certFile, keyFile, commonName string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+}